@@ -20,24 +20,187 @@ const (
 	Default    = Local
 )
 
+// CursorSigningKeyEnv is the environment variable the cursor signing key is read from
+const CursorSigningKeyEnv = "PAGINATION_CURSOR_SIGNING_KEY"
+
+var ErrCursorSigningKeyMissing = errors.New("the cursor signing key is not set, it is read from " + CursorSigningKeyEnv)
+
+var ErrWebhookLocalTargetsNotAllowed = errors.New("webhookConfig.allowLocalTargets can only be set in the local environment")
+
 type ServiceConfig struct {
-	AppConfig           AppConfig           `yaml:"appConfig"`
-	DBConfig            DBConfig            `yaml:"dBConfig"`
-	HTTPClientConfig    HTTPClientConfig    `yaml:"httpClientConfig"`
-	WebServerConfig     WebServerConfig     `yaml:"webServerConfig"`
-	OpenTelemetryConfig OpenTelemetryConfig `yaml:"openTelemetryConfig"`
-	KafkaConfig         KafkaConfig         `yaml:"kafkaConfig"`
+	AppConfig            AppConfig            `yaml:"appConfig"`
+	DBConfig             DBConfig             `yaml:"dBConfig"`
+	HTTPClientConfig     HTTPClientConfig     `yaml:"httpClientConfig"`
+	WebServerConfig      WebServerConfig      `yaml:"webServerConfig"`
+	OpenTelemetryConfig  OpenTelemetryConfig  `yaml:"openTelemetryConfig"`
+	KafkaConfig          KafkaConfig          `yaml:"kafkaConfig"`
+	OutboxConfig         OutboxConfig         `yaml:"outboxConfig"`
+	ReferenceDataConfig  ReferenceDataConfig  `yaml:"referenceDataConfig"`
+	PaginationConfig     PaginationConfig     `yaml:"paginationConfig"`
+	IdempotencyConfig    IdempotencyConfig    `yaml:"idempotencyConfig"`
+	AuthConfig           AuthConfig           `yaml:"authConfig"`
+	APIKeyConfig         APIKeyConfig         `yaml:"apiKeyConfig"`
+	LocationStreamConfig LocationStreamConfig `yaml:"locationStreamConfig"`
+	WebhookConfig        WebhookConfig        `yaml:"webhookConfig"`
+	HealthConfig         HealthConfig         `yaml:"healthConfig"`
 }
 
 type WebServerConfig struct {
 	Address           string `yaml:"address"`
 	ReadHeaderTimeout string `yaml:"readHeaderTimeout"`
+	GRPCAddress       string `yaml:"grpcAddress"`
 }
 
 type KafkaConfig struct {
 	Brokers       []string `yaml:"brokers"`
 	ConsumerGroup string   `yaml:"consumerGroup"`
 	MaxRetries    int      `yaml:"maxRetries"`
+	// CloudEventsMode is how the CloudEvents are published, 'binary' (the default) or 'structured'
+	CloudEventsMode string `yaml:"cloudEventsMode"`
+	// HandlerDefaults applies to every event handler, Handlers overrides its values for the handlers with the name
+	HandlerDefaults HandlerConfig            `yaml:"handlerDefaults"`
+	Handlers        map[string]HandlerConfig `yaml:"handlers"`
+	// TopicFormats sets the serialization of the data of the topics, the ones not listed are published as JSON
+	TopicFormats   []TopicFormat        `yaml:"topicFormats"`
+	SchemaRegistry SchemaRegistryConfig `yaml:"schemaRegistry"`
+}
+
+// TopicFormat sets how the data of the events published to the topic is serialized, 'json' or 'protobuf'
+type TopicFormat struct {
+	Topic  string `yaml:"topic"`
+	Format string `yaml:"format"`
+}
+
+// SchemaRegistryConfig points to the Confluent compatible schema registry the Protobuf schemas are registered in. When
+// the URL is empty the schemas are kept in the file instead, which stands in for the registry locally and in tests.
+type SchemaRegistryConfig struct {
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	FilePath string `yaml:"filePath"`
+}
+
+// HandlerConfig sets how many times an event handler retries a message that failed, how long it waits between the
+// attempts, doubling the interval up to the max, and the poison topic the message is sent to once the retries run out.
+type HandlerConfig struct {
+	MaxRetries        int    `yaml:"maxRetries"`
+	InitialIntervalMs int    `yaml:"initialIntervalMs"`
+	MaxIntervalMs     int    `yaml:"maxIntervalMs"`
+	PoisonTopic       string `yaml:"poisonTopic"`
+}
+
+// GetHandlerConfig returns the settings of the handler, the values it does not set are taken from the defaults
+func (c KafkaConfig) GetHandlerConfig(name string) HandlerConfig {
+	handlerCfg := c.Handlers[name]
+
+	return HandlerConfig{
+		MaxRetries:        GetIntValueOrDefault(handlerCfg.MaxRetries, c.HandlerDefaults.MaxRetries),
+		InitialIntervalMs: GetIntValueOrDefault(handlerCfg.InitialIntervalMs, c.HandlerDefaults.InitialIntervalMs),
+		MaxIntervalMs:     GetIntValueOrDefault(handlerCfg.MaxIntervalMs, c.HandlerDefaults.MaxIntervalMs),
+		PoisonTopic:       handlerCfg.PoisonTopic,
+	}
+}
+
+// GetTopicFormat returns the format set for the topic, or an empty string when the topic is not listed
+func (c KafkaConfig) GetTopicFormat(topic string) string {
+	for _, topicFormat := range c.TopicFormats {
+		if topicFormat.Topic == topic {
+			return topicFormat.Format
+		}
+	}
+
+	return ""
+}
+
+// WithConsumerGroupSuffix returns a copy of the config for consumers that need their own consumer group, e.g. to
+// receive every message of a topic that is also consumed by other handlers of the service
+func (c KafkaConfig) WithConsumerGroupSuffix(suffix string) KafkaConfig {
+	c.ConsumerGroup = c.ConsumerGroup + "-" + suffix
+
+	return c
+}
+
+type OutboxConfig struct {
+	PollIntervalMs         int `yaml:"pollIntervalMs"`
+	BatchSize              int `yaml:"batchSize"`
+	CleanupIntervalMinutes int `yaml:"cleanupIntervalMinutes"`
+	RetentionHours         int `yaml:"retentionHours"`
+	// MaxAttempts is the number of failed publishes after which a message is parked, the retries wait twice as long
+	// after each failure, from RetryIntervalMs up to MaxRetryIntervalMs
+	MaxAttempts        int `yaml:"maxAttempts"`
+	RetryIntervalMs    int `yaml:"retryIntervalMs"`
+	MaxRetryIntervalMs int `yaml:"maxRetryIntervalMs"`
+	// ClaimTimeoutMs is how long the messages of a batch are kept from other relays while they are published, it
+	// must be longer than the publish timeout of the broker
+	ClaimTimeoutMs int `yaml:"claimTimeoutMs"`
+}
+
+type ReferenceDataConfig struct {
+	CacheTTLSeconds int `yaml:"cacheTTLSeconds"`
+}
+
+// PaginationConfig holds the HMAC key the pagination cursors are signed with. It is a secret, only the local config
+// sets it, the other environments read it from the CursorSigningKeyEnv environment variable.
+type PaginationConfig struct {
+	CursorSigningKey string `yaml:"cursorSigningKey"`
+}
+
+type IdempotencyConfig struct {
+	KeyTTLHours            int `yaml:"keyTTLHours"`
+	CleanupIntervalMinutes int `yaml:"cleanupIntervalMinutes"`
+}
+
+// AuthConfig holds the keys used to verify the bearer tokens. Keys are read from the JWKS file and from StaticKeys,
+// tokens are matched with a key by their kid header.
+type AuthConfig struct {
+	Issuer     string            `yaml:"issuer"`
+	Audience   string            `yaml:"audience"`
+	JWKSFile   string            `yaml:"jwksFile"`
+	StaticKeys []StaticKeyConfig `yaml:"staticKeys"`
+}
+
+// StaticKeyConfig is an HS256 shared secret or an RS256 PEM encoded public key
+type StaticKeyConfig struct {
+	KeyID     string `yaml:"keyId"`
+	Algorithm string `yaml:"algorithm"`
+	Secret    string `yaml:"secret"`
+	PublicKey string `yaml:"publicKey"`
+}
+
+// APIKeyConfig sets how long authenticated API keys are cached, a revoked key can be accepted by other instances
+// until its cache entry expires. Last used timestamps are written to the DB in batches every flush interval.
+type APIKeyConfig struct {
+	CacheTTLSeconds              int `yaml:"cacheTTLSeconds"`
+	LastUsedFlushIntervalSeconds int `yaml:"lastUsedFlushIntervalSeconds"`
+}
+
+// LocationStreamConfig sets how many location changes are kept to resume the Server-Sent Events streams, how many
+// changes a client can fall behind before it is disconnected and how often idle streams get a heartbeat.
+type LocationStreamConfig struct {
+	BufferSize               int `yaml:"bufferSize"`
+	ClientBufferSize         int `yaml:"clientBufferSize"`
+	HeartbeatIntervalSeconds int `yaml:"heartbeatIntervalSeconds"`
+}
+
+// WebhookConfig sets how many times a webhook delivery is attempted before it is marked failed in the delivery log, the
+// failed deliveries are retried in the background every RetryIntervalMs and the wait between their attempts starts
+// at the initial backoff and doubles up to the max backoff. Webhooks are only sent to
+// https URLs of public addresses, AllowLocalTargets lifts both restrictions to test with local receivers and can only
+// be set in the local environment. Only the first MaxResponseBytes of the responses are read.
+type WebhookConfig struct {
+	MaxAttempts       int  `yaml:"maxAttempts"`
+	InitialBackoffMs  int  `yaml:"initialBackoffMs"`
+	MaxBackoffMs      int  `yaml:"maxBackoffMs"`
+	RetryIntervalMs   int  `yaml:"retryIntervalMs"`
+	MaxResponseBytes  int  `yaml:"maxResponseBytes"`
+	AllowLocalTargets bool `yaml:"allowLocalTargets"`
+}
+
+// HealthConfig sets how long a dependency check of the health endpoints can take before it is reported as down and
+// how long its result is reused, so frequent probes do not hit the dependencies on every request.
+type HealthConfig struct {
+	CheckTimeoutMs int `yaml:"checkTimeoutMs"`
+	CacheTTLMs     int `yaml:"cacheTTLMs"`
 }
 
 type OpenTelemetryConfig struct {
@@ -72,6 +235,9 @@ func LoadConfig() (*ServiceConfig, error) {
 
 	viperInstance := viper.New()
 	viperInstance.AutomaticEnv()
+	if err := viperInstance.BindEnv("paginationConfig.cursorSigningKey", CursorSigningKeyEnv); err != nil {
+		return nil, errors.Wrapf(err, "Failed to bind environment variables")
+	}
 	viperInstance.AddConfigPath("config")
 	viperInstance.AddConfigPath("../config")
 	viperInstance.AddConfigPath("../../config")
@@ -87,6 +253,14 @@ func LoadConfig() (*ServiceConfig, error) {
 		return nil, errors.Wrapf(err, "Failed to parse configuration")
 	}
 
+	if ServiceConf.PaginationConfig.CursorSigningKey == "" {
+		return nil, ErrCursorSigningKeyMissing
+	}
+
+	if ServiceConf.WebhookConfig.AllowLocalTargets && env != Local {
+		return nil, ErrWebhookLocalTargetsNotAllowed
+	}
+
 	return ServiceConf, nil
 }
 
@@ -17,80 +17,207 @@ const docTemplate = `{
     "paths": {
         "/health": {
             "get": {
-                "description": "Simple healthcheck endpoint",
+                "description": "Same as the liveness check, kept for the probes configured before it existed",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "summary": "Check health",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
                     }
                 }
             }
         },
-        "/v1/locations": {
+        "/health/live": {
             "get": {
-                "description": "Get paginated locations",
+                "description": "Answers 503 when the service cannot recover by itself and has to be restarted, along with the result of each check",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Retrieve paginated locations",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Optional location name section. Service will filter locations that include this string",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Pagination limit, default to 10000",
-                        "name": "limit",
-                        "in": "query"
-                    },
+                "summary": "Check liveness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Answers 503 when a dependency is down or the service is shutting down, along with the result of each check. Results are cached for a few seconds",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Check readiness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api-keys": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Cursor value, default to empty string",
-                        "name": "cursor",
-                        "in": "query"
+                        "BearerAuth": []
                     },
                     {
-                        "type": "string",
-                        "description": "Indicates the cursor direction. Accepted values: 'next' or 'prev'",
-                        "name": "direction",
-                        "in": "query",
-                        "required": true
+                        "APIKeyAuth": []
                     }
                 ],
+                "description": "Get all the API keys, including the revoked ones. Keys are never returned, only their prefix. Callers restricted to a supplier only get the keys of their supplier",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.ExampleCursorPage"
+                                "$ref": "#/definitions/domain.APIKey"
                             }
                         }
                     }
                 }
             },
             "post": {
-                "description": "Create a new location and a default sub location",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create an API key for a machine client, sent in the X-API-Key header. The key is only returned in this response. A key restricted to a supplier can only act on the locations of that supplier. The key can only be granted scopes the caller has, and the keys created by a caller restricted to a supplier are restricted to the same supplier",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create location",
+                "summary": "Create API key",
                 "parameters": [
                     {
-                        "description": "Location attributes",
+                        "description": "API key attributes",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateLocationRequest"
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.IssuedAPIKey"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/api-keys/{apiKeyID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Revoke an active API key, revoked keys are still listed",
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "apiKeyID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/api-keys/{apiKeyID}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Replace an active API key with a new one keeping its name, scopes and supplier. The new key is only returned in this response, the previous one stops working",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Rotate API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "apiKeyID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.IssuedAPIKey"
                         }
                     }
+                }
+            }
+        },
+        "/v1/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the newest messages the event handlers could not process after every retry, along with the error of the last attempt",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List dead letters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Optional filter by the name of the handler that failed",
+                        "name": "handler",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Optional filter, true to get only the redriven dead letters and false to get only the pending ones",
+                        "name": "redriven",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -98,25 +225,33 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Location"
+                                "$ref": "#/definitions/domain.DeadLetter"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/locations/{locationID}": {
+        "/v1/dead-letters/{deadLetterID}": {
             "get": {
-                "description": "Get location details",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get a dead letter by its ID, including the payload and metadata of the original message",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get location details",
+                "summary": "Get dead letter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Location ID",
-                        "name": "locationID",
+                        "description": "Dead letter ID",
+                        "name": "deadLetterID",
                         "in": "path",
                         "required": true
                     }
@@ -125,32 +260,117 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Location"
+                            "$ref": "#/definitions/domain.DeadLetter"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing location",
+            }
+        },
+        "/v1/dead-letters/{deadLetterID}/redrive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Publish the message of a dead letter to its original topic again, with the same ID, key and metadata. Only the handler that failed it processes it again. A dead letter can only be redriven once, a message that fails again produces a new one",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update existing location",
+                "summary": "Redrive dead letter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Location ID",
-                        "name": "locationID",
+                        "description": "Dead letter ID",
+                        "name": "deadLetterID",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DeadLetter"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/location-mock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     },
                     {
-                        "description": "Location attributes",
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Receives a request and mocks a location creation",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create location mock",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/v1/location-types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get all the location types",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List location types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LocationType"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create a new location type",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create location type",
+                "parameters": [
+                    {
+                        "description": "Location type attributes",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateLocationRequest"
+                            "$ref": "#/definitions/dto.LocationTypeRequest"
                         }
                     }
                 ],
@@ -158,55 +378,2126 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Location"
-                            }
+                            "$ref": "#/definitions/domain.LocationType"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/location-types/{locationTypeID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get location type details",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get location type details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location type ID",
+                        "name": "locationTypeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LocationType"
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing location type",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update location type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location type ID",
+                        "name": "locationTypeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location type attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LocationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LocationType"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete a location type that is not in use",
+                "summary": "Delete location type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location type ID",
+                        "name": "locationTypeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get paginated locations",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieve paginated locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Optional location name section. Service will filter locations that include this string",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by city, case insensitive",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by state, case insensitive",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by zipcode",
+                        "name": "zipcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by location type IDs, repeat the param or send a comma separated list",
+                        "name": "location_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by supplier IDs, repeat the param or send a comma separated list",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Optional filter by active status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations created at or after this RFC 3339 timestamp or YYYY-MM-DD date",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations created before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations updated at or after this RFC 3339 timestamp or YYYY-MM-DD date, the creation counts as an update",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations updated before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day, the creation counts as an update",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft deleted locations, default to false",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, prefix a field with '-' to sort it descending. Accepted fields: name, created_at, city, state, zipcode. Default to 'name'",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination limit, default to 10000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned by a previous page, it must be used with the same sort. Default to empty string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Indicates the cursor direction. Accepted values: 'next' or 'prev'",
+                        "name": "direction",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ExampleCursorPage"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create a new location and a default sub location. Requests sent with an Idempotency-Key can be safely retried, the same key with a different body is rejected with 422 and a retry sent while the first request is still running with 409",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request. Retries with the same key and body get the original response instead of creating the location again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Location attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Location"
+                            }
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "Set to true when the response is the stored response of a previous request with the same Idempotency-Key"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Stream every location matching the filters as a file download. Unlike the paginated list the whole result is sent in one response, rows are written as they are read from the database. When the export fails after the first row the connection is closed before the end of the file, so a partial file is never received as a complete one",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/geo+json"
+                ],
+                "summary": "Export locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export format. Accepted values: 'csv', 'ndjson' or 'geojson'. Default to 'csv'",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional location name section. Service will filter locations that include this string",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by zipcode",
+                        "name": "zipcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by location type IDs, repeat the param or send a comma separated list",
+                        "name": "location_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by supplier IDs, repeat the param or send a comma separated list",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Optional filter by active status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations created at or after this RFC 3339 timestamp or YYYY-MM-DD date",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations created before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations updated at or after this RFC 3339 timestamp or YYYY-MM-DD date, the creation counts as an update",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only locations updated before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day, the creation counts as an update",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft deleted locations, default to false",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, prefix a field with '-' to sort it descending. Accepted fields: name, created_at, city, state, zipcode. Default to 'name'",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "attachment; filename=locations-\u003ctimestamp\u003e.\u003cformat\u003e"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create locations in bulk from a CSV file with a header row or from NDJSON, one location per line. Columns and fields are the ones of the create location request. The response reports the result of every row, rows are numbered from 1 without counting the CSV header",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Import locations",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Validate and geocode the rows without creating them, default to false",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "CSV or NDJSON file, up to 5000 rows",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LocationImportReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/nearby": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the locations within a radius of a point, ordered by great-circle distance",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Search nearby locations",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude of the search center",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the search center",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in meters, up to 100000",
+                        "name": "radius_m",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results, default to 100 and up to 1000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional location name section. Service will filter locations that include this string",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by city, case insensitive",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by state, case insensitive",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by zipcode",
+                        "name": "zipcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by location type IDs",
+                        "name": "location_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by supplier IDs",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Optional filter by active status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft deleted locations, default to false",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.NearbyLocation"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Push the created, updated, deleted and restored locations as Server-Sent Events. Each event is named after the change (location.created, location.updated, location.deleted or location.restored) and its data is the change with the location after it. Reconnecting clients send the Last-Event-ID header to receive the changes they missed, when that change is not buffered anymore a location.reset event is sent first and the locations should be reloaded. Idle streams receive a heartbeat comment periodically",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Stream location changes",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by supplier IDs, repeat the param or send a comma separated list",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Optional filter by location type IDs, repeat the param or send a comma separated list",
+                        "name": "location_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, the stream resumes after it",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LocationChange"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/{locationID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get location details",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get location details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the location even if it was soft deleted, default to false",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Location"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the location, send it back as If-Match when updating it"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing location",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update existing location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the location being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Location attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Location"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated location"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Soft delete a location. Deleted locations can be brought back with the restore endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch (RFC 7396) to an existing location. Only the fields present in the document are updated",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Partially update existing location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the location being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Location attributes to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Location"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated location"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/{locationID}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the paginated list of changes made to a location, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieve location history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination limit, default to 10000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor value, default to empty string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Indicates the cursor direction. Accepted values: 'next' or 'prev'",
+                        "name": "direction",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ExampleCursorPage"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/{locationID}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Restore a soft deleted location",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Restore location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Location"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/{locationID}/sub-locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get paginated sub locations of a location",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieve paginated sub locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pagination limit, default to 10000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor value, default to empty string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Indicates the cursor direction. Accepted values: 'next' or 'prev'",
+                        "name": "direction",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ExampleCursorPage"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create a new sub location in an existing location",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create sub location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sub location attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubLocation"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/{locationID}/sub-locations/{subLocationID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get sub location details",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get sub location details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub location ID",
+                        "name": "subLocationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubLocation"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Rename an existing sub location. The default sub location cannot be renamed",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Rename sub location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub location ID",
+                        "name": "subLocationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sub location name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RenameSubLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubLocation"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/locations/{locationID}/sub-locations/{subLocationID}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Deactivate an existing sub location. The default sub location cannot be deactivated",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deactivate sub location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub location ID",
+                        "name": "subLocationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubLocation"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/outbox/parked": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the oldest outbox messages that failed every publish attempt, along with the error of the last one. Each of them holds back the later messages of its aggregate until it is redriven or discarded",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List parked outbox messages",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.OutboxMessage"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/outbox/parked/{messageID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete a parked outbox message without publishing it, the messages of its aggregate it held back are published",
+                "summary": "Discard parked outbox message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Outbox message ID",
+                        "name": "messageID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/outbox/parked/{messageID}/redrive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Make a parked outbox message pending again with its attempts reset, it is published before the messages of its aggregate it held back",
+                "summary": "Redrive parked outbox message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Outbox message ID",
+                        "name": "messageID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/sub-location-types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get all the sub location types",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List sub location types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.SubLocationType"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create a new sub location type",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create sub location type",
+                "parameters": [
+                    {
+                        "description": "Sub location type attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubLocationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubLocationType"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sub-location-types/{subLocationTypeID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get sub location type details",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get sub location type details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sub location type ID",
+                        "name": "subLocationTypeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubLocationType"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing sub location type",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update sub location type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sub location type ID",
+                        "name": "subLocationTypeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sub location type attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubLocationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SubLocationType"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete a sub location type that is not in use",
+                "summary": "Delete sub location type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sub location type ID",
+                        "name": "subLocationTypeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get all the suppliers",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List suppliers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Supplier"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Create a new supplier",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Supplier"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/suppliers/{supplierID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get supplier details",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get supplier details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplierID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Supplier"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing supplier",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplierID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Supplier"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete a supplier that is not in use",
+                "summary": "Delete supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplierID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the webhook subscriptions, callers restricted to a supplier only get the ones of that supplier. Secrets are never returned",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.WebhookSubscription"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to location events. Each delivery is a POST signed in the X-Webhook-Signature header as 'sha256=\u003chex HMAC-SHA256 of \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\" with the secret\u003e'. A subscription restricted to a supplier only receives the events of its locations. The URL must be an https URL of a public address, redirects are not followed",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscription"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get a webhook subscription by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscription"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Replace a webhook subscription, send the status 'paused' to stop its deliveries. The secret is only changed when it is sent",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookSubscription"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Delete a webhook subscription along with its delivery log",
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookID}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Get the newest entries of the delivery log of a webhook, the events that could not be delivered at once. They are retried in the background until they succeed or run out of attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional filter by delivery status. Accepted values: 'retrying', 'failed' or 'succeeded'",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.WebhookDelivery"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Send a logged event again, once and with a new signature. The delivery is returned with the outcome of the attempt",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Redeliver webhook event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WebhookDelivery"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "supplier_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ContactInformation": {
+            "type": "object",
+            "properties": {
+                "contact_person": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DeadLetter": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "correlation_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "handler": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "original_topic": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "poison_topic": {
+                    "type": "string"
+                },
+                "redriven_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExampleCursorPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {}
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_page": {
+                    "type": "string"
+                },
+                "previous_page": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HealthCheckResult": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.HealthCheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.IssuedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "supplier_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Location": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "information": {
+                    "$ref": "#/definitions/domain.LocationInformation"
+                },
+                "location_type": {
+                    "$ref": "#/definitions/domain.LocationType"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "supplier": {
+                    "$ref": "#/definitions/domain.Supplier"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LocationChange": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/domain.Location"
+                },
+                "operation": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LocationImportReport": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LocationImportRowResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LocationImportRowResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
             }
-        }
-    },
-    "definitions": {
-        "domain.ContactInformation": {
+        },
+        "domain.LocationInformation": {
             "type": "object",
             "properties": {
-                "contact_person": {
+                "address": {
                     "type": "string"
                 },
-                "email": {
+                "city": {
                     "type": "string"
                 },
-                "phone_number": {
+                "contact_information": {
+                    "$ref": "#/definitions/domain.ContactInformation"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "zipcode": {
                     "type": "string"
                 }
             }
         },
-        "domain.ExampleCursorPage": {
+        "domain.LocationType": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {}
-                },
-                "limit": {
+                "id": {
                     "type": "integer"
                 },
-                "next_page": {
-                    "type": "string"
-                },
-                "previous_page": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.Location": {
+        "domain.NearbyLocation": {
             "type": "object",
             "properties": {
                 "active": {
                     "type": "boolean"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "distance_m": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -221,36 +2512,80 @@ const docTemplate = `{
                 },
                 "supplier": {
                     "$ref": "#/definitions/domain.Supplier"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.LocationInformation": {
+        "domain.OutboxMessage": {
             "type": "object",
             "properties": {
-                "address": {
+                "aggregate_id": {
                     "type": "string"
                 },
-                "city": {
+                "attempts": {
+                    "description": "Attempts is the number of failed publishes, the next one is not made before NextAttemptAt",
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "contact_information": {
-                    "$ref": "#/definitions/domain.ContactInformation"
+                "failed_at": {
+                    "description": "FailedAt is set when the message is parked, it holds back the later messages of its aggregate until it is\nredriven or discarded",
+                    "type": "string"
                 },
-                "latitude": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "longitude": {
-                    "type": "number"
+                "last_error": {
+                    "type": "string"
                 },
-                "state": {
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "next_attempt_at": {
                     "type": "string"
                 },
-                "zipcode": {
+                "payload": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "topic": {
                     "type": "string"
                 }
             }
         },
-        "domain.LocationType": {
+        "domain.SubLocation": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sub_location_type": {
+                    "$ref": "#/definitions/domain.SubLocationType"
+                }
+            }
+        },
+        "domain.SubLocationType": {
             "type": "object",
             "properties": {
                 "id": {
@@ -272,9 +2607,211 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "supplier_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.CreateLocationRequest": {
             "type": "object",
+            "required": [
+                "address",
+                "city",
+                "location_type_id",
+                "name",
+                "state",
+                "supplier_id",
+                "zipcode"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "contact_person": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "location_type_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "zipcode": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateSubLocationRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "sub_location_type_id"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "sub_location_type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "paused"
+                    ]
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LocationTypeRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
             "properties": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PatchLocationRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
                 "address": {
                     "type": "string"
                 },
@@ -307,8 +2844,51 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.RenameSubLocationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SubLocationTypeRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SupplierRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UpdateLocationRequest": {
             "type": "object",
+            "required": [
+                "address",
+                "city",
+                "id",
+                "location_type_id",
+                "name",
+                "state",
+                "supplier_id",
+                "zipcode"
+            ],
             "properties": {
                 "active": {
                     "type": "boolean"
@@ -347,6 +2927,54 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "dto.UpdateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "status",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "paused"
+                    ]
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "APIKeyAuth": {
+            "description": "API key of a machine client",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT bearer token, sent as 'Bearer \u003ctoken\u003e'",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     },
     "tags": [
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// APIKey is a credential of a machine client. Only the SHA-256 hash of the key is stored, the key itself is shown once
+// when it is created or rotated. The prefix is the public part of the key, used to find it without the secret.
+// Requests authenticated with a key restricted to a supplier can only act on the locations of that supplier.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Hash       string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	SupplierID *int       `json:"supplier_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IssuedAPIKey is a created or rotated API key along with the key to send in the requests
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"go-service-template/utils"
+	"strings"
+	"testing"
+)
+
+func Test_CursorCodec_DecodeReturnsTheEncodedCursor(t *testing.T) {
+	order := SortOrder{{Field: SortByCreatedAt}, {Field: SortByName, Descending: true}}
+	token, err := testCodec.Encode(Cursor{Sort: order.String(), Values: []any{"2024-01-01T00:00:00Z", 10}, ID: "id"})
+	assert.Nil(t, err)
+
+	cursor, err := testCodec.Decode(token, order, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Cursor{Sort: "created_at,-name", Values: []any{"2024-01-01T00:00:00Z", json.Number("10")}, ID: "id"}, cursor)
+}
+
+func Test_CursorCodec_DecodeRejectsTamperedTokens(t *testing.T) {
+	token, _ := testCodec.Encode(Cursor{Sort: testSortOrder.String(), Values: []any{NameA}, ID: "id"})
+
+	for _, invalidToken := range []string{
+		"not-a-cursor",
+		token + "x",
+		base64.RawURLEncoding.EncodeToString([]byte(`{"s":"name","v":["Z"],"id":"id"}`)) + token[strings.Index(token, "."):],
+	} {
+		_, err := testCodec.Decode(invalidToken, testSortOrder, "")
+		assert.ErrorIs(t, err, ErrInvalidCursor, invalidToken)
+	}
+
+	_, err := NewCursorCodec("other-key").Decode(token, testSortOrder, "")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func Test_CursorCodec_DecodeRejectsCursorsOfAnotherSortOrder(t *testing.T) {
+	token, _ := testCodec.Encode(Cursor{Sort: testSortOrder.String(), Values: []any{NameA}, ID: "id"})
+
+	_, err := testCodec.Decode(token, SortOrder{{Field: SortByName, Descending: true}}, "")
+
+	assert.ErrorAs(t, err, &BusinessErr{})
+}
+
+func Test_CursorCodec_DecodeRejectsCursorsOfOtherFilters(t *testing.T) {
+	token, _ := testCodec.Encode(Cursor{Sort: testSortOrder.String(), Scope: testScope, Values: []any{NameA}, ID: "id"})
+
+	for _, scope := range []string{
+		LocationsFilters{Name: utils.ToPointer(NameB)}.CursorScope(),
+		SubLocationsFilters{LocationID: "other-location"}.CursorScope(),
+	} {
+		_, err := testCodec.Decode(token, testSortOrder, scope)
+		assert.ErrorAs(t, err, &BusinessErr{})
+	}
+}
+
+func Test_LocationsFilters_CursorScopeIgnoresPaginationFilters(t *testing.T) {
+	filters := LocationsFilters{Name: utils.ToPointer(NameA)}
+	nextPage := filters
+	nextPage.CursorPaginationFilters = CursorPaginationFilters{Cursor: CursorValue, Direction: PreviousPage, Limit: Limit}
+
+	assert.Equal(t, filters.CursorScope(), nextPage.CursorScope())
+}
+
+func Test_ParseSortOrder_ParsesDirections(t *testing.T) {
+	order, err := ParseSortOrder("created_at, -name", LocationSortFields)
+
+	assert.Nil(t, err)
+	assert.Equal(t, SortOrder{{Field: SortByCreatedAt}, {Field: SortByName, Descending: true}}, order)
+	assert.Equal(t, "created_at,-name", order.String())
+}
+
+func Test_ParseSortOrder_RejectsUnknownAndRepeatedFields(t *testing.T) {
+	for _, raw := range []string{"id", "name,-name", "name,"} {
+		_, err := ParseSortOrder(raw, LocationSortFields)
+		assert.NotNil(t, err, raw)
+	}
+}
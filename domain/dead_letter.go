@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// DeadLetter is the envelope of a message an event handler could not process after every retry. It is published to
+// the poison topic of the handler and kept so the message can be inspected and redriven to its original topic.
+type DeadLetter struct {
+	ID            string            `json:"id"`
+	MessageID     string            `json:"message_id"`
+	Handler       string            `json:"handler"`
+	OriginalTopic string            `json:"original_topic"`
+	PoisonTopic   string            `json:"poison_topic"`
+	Error         string            `json:"error"`
+	Attempts      int               `json:"attempts"`
+	CorrelationID string            `json:"correlation_id"`
+	Payload       []byte            `json:"payload" swaggertype:"string" format:"base64"`
+	Metadata      map[string]string `json:"metadata"`
+	FailedAt      time.Time         `json:"failed_at"`
+	RedrivenAt    *time.Time        `json:"redriven_at,omitempty"`
+}
+
+// DeadLetterFilters selects the dead letters listed, nil values match every dead letter
+type DeadLetterFilters struct {
+	Handler  *string
+	Redriven *bool
+	Limit    int
+}
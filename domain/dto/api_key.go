@@ -0,0 +1,7 @@
+package dto
+
+type CreateAPIKeyRequest struct {
+	Name       string   `json:"name" validate:"required"`
+	Scopes     []string `json:"scopes" validate:"required,min=1,dive,required"`
+	SupplierID *int     `json:"supplier_id,omitempty"`
+}
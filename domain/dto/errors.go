@@ -0,0 +1,11 @@
+package dto
+
+// BodyFieldErr is an invalid value of a request body field, Field is its JSON name
+type BodyFieldErr struct {
+	Field string
+	Msg   string
+}
+
+func (e BodyFieldErr) Error() string {
+	return e.Msg
+}
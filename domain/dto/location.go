@@ -1,5 +1,10 @@
 package dto
 
+import (
+	"errors"
+	"fmt"
+)
+
 type CreateLocationRequest struct {
 	SupplierID     int     `json:"supplier_id" validate:"required"`
 	Name           string  `json:"name" validate:"required"`
@@ -27,3 +32,60 @@ type UpdateLocationRequest struct {
 	Email          *string `json:"email"`
 	Active         bool    `json:"active"`
 }
+
+// PatchLocationRequest is a JSON merge patch document for a location. Fields that are not sent are left untouched and
+// contact fields sent as null are cleared.
+type PatchLocationRequest struct {
+	SupplierID     Optional[int]    `json:"supplier_id" swaggertype:"integer"`
+	Name           Optional[string] `json:"name" swaggertype:"string"`
+	Address        Optional[string] `json:"address" swaggertype:"string"`
+	City           Optional[string] `json:"city" swaggertype:"string"`
+	State          Optional[string] `json:"state" swaggertype:"string"`
+	Zipcode        Optional[string] `json:"zipcode" swaggertype:"string"`
+	LocationTypeID Optional[int]    `json:"location_type_id" swaggertype:"integer"`
+	ContactPerson  Optional[string] `json:"contact_person" swaggertype:"string"`
+	PhoneNumber    Optional[string] `json:"phone_number" swaggertype:"string"`
+	Email          Optional[string] `json:"email" swaggertype:"string"`
+	Active         Optional[bool]   `json:"active" swaggertype:"boolean"`
+}
+
+// Validate checks the fields present in the patch. Only contact fields can be removed with null.
+func (r PatchLocationRequest) Validate() error {
+	var errs []error
+
+	requiredStrings := []struct {
+		name  string
+		value Optional[string]
+	}{
+		{"name", r.Name}, {"address", r.Address}, {"city", r.City}, {"state", r.State}, {"zipcode", r.Zipcode},
+	}
+	for _, field := range requiredStrings {
+		if field.value.Set && (field.value.Null || field.value.Value == "") {
+			errs = append(errs, BodyFieldErr{Field: field.name, Msg: fmt.Sprintf("field '%v' cannot be null or empty", field.name)})
+		}
+	}
+
+	requiredInts := []struct {
+		name  string
+		value Optional[int]
+	}{
+		{"supplier_id", r.SupplierID}, {"location_type_id", r.LocationTypeID},
+	}
+	for _, field := range requiredInts {
+		if field.value.Set && (field.value.Null || field.value.Value == 0) {
+			errs = append(errs, BodyFieldErr{Field: field.name, Msg: fmt.Sprintf("field '%v' cannot be null or zero", field.name)})
+		}
+	}
+
+	if r.Active.Set && r.Active.Null {
+		errs = append(errs, BodyFieldErr{Field: "active", Msg: "field 'active' cannot be null"})
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsEmpty reports whether the patch does not change any field
+func (r PatchLocationRequest) IsEmpty() bool {
+	return !r.SupplierID.Set && !r.Name.Set && !r.Address.Set && !r.City.Set && !r.State.Set && !r.Zipcode.Set &&
+		!r.LocationTypeID.Set && !r.ContactPerson.Set && !r.PhoneNumber.Set && !r.Email.Set && !r.Active.Set
+}
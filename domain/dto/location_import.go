@@ -0,0 +1,9 @@
+package dto
+
+// ImportLocationRow is a location read from an import file. Err is set when the row could not be parsed or is not
+// valid, in that case Request may be incomplete.
+type ImportLocationRow struct {
+	Row     int
+	Request CreateLocationRequest
+	Err     error
+}
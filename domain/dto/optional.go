@@ -0,0 +1,27 @@
+package dto
+
+import "encoding/json"
+
+// Optional is a field of a JSON merge patch document (RFC 7396). It tells apart a field that was not sent, a field
+// explicitly set to null and a field set to a value.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+
+	if string(data) == "null" {
+		o.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &o.Value)
+}
+
+// HasValue reports whether the field was sent with a non-null value
+func (o Optional[T]) HasValue() bool {
+	return o.Set && !o.Null
+}
@@ -0,0 +1,13 @@
+package dto
+
+type SupplierRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type LocationTypeRequest struct {
+	Type string `json:"type" validate:"required"`
+}
+
+type SubLocationTypeRequest struct {
+	Type string `json:"type" validate:"required"`
+}
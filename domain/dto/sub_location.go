@@ -0,0 +1,10 @@
+package dto
+
+type CreateSubLocationRequest struct {
+	Name              string `json:"name" validate:"required"`
+	SubLocationTypeID int    `json:"sub_location_type_id" validate:"required"`
+}
+
+type RenameSubLocationRequest struct {
+	Name string `json:"name" validate:"required"`
+}
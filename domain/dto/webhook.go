@@ -0,0 +1,19 @@
+package dto
+
+// CreateWebhookRequest registers a callback, the secret signs the deliveries and is never returned
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=location.created location.updated"`
+	Secret     string   `json:"secret" validate:"required,min=16"`
+	SupplierID *int     `json:"supplier_id,omitempty"`
+	Status     string   `json:"status,omitempty" validate:"omitempty,oneof=active paused"`
+}
+
+// UpdateWebhookRequest replaces the subscription, the secret is only changed when it is sent
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=location.created location.updated"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16"`
+	SupplierID *int     `json:"supplier_id,omitempty"`
+	Status     string   `json:"status" validate:"required,oneof=active paused"`
+}
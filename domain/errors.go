@@ -1,21 +1,80 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Resources reported in the error codes, which are built as "<resource>.<reason>"
+const (
+	ResourceLocation        = "location"
+	ResourceSubLocation     = "sub_location"
+	ResourceSupplier        = "supplier"
+	ResourceLocationType    = "location_type"
+	ResourceSubLocationType = "sub_location_type"
+	ResourceAPIKey          = "api_key"
+	ResourceIdempotencyKey  = "idempotency_key"
+	ResourceWebhook         = "webhook"
+	ResourceWebhookDelivery = "webhook_delivery"
+	ResourceDeadLetter      = "dead_letter"
+	ResourceOutboxMessage   = "outbox_message"
+	ResourceRequest         = "request"
+)
+
+// Error codes that do not depend on the resource
+const (
+	CodeInvalidAddress        = ResourceLocation + ".invalid_address"
+	CodeIdempotencyInProgress = ResourceIdempotencyKey + ".in_progress"
+	CodeIdempotencyMismatch   = ResourceIdempotencyKey + ".mismatch"
+	CodeDependencyUnavailable = "dependency.unavailable"
+	CodeDependencyTimeout     = "dependency.timeout"
+)
+
+// CodedErr is implemented by the errors that carry a stable, machine-readable code
+type CodedErr interface {
+	error
+	Code() string
+}
+
+// DescribeResource returns the resource as it reads in the error messages
+func DescribeResource(resource string) string {
+	return strings.ReplaceAll(resource, "_", " ")
+}
+
+func buildCode(resource, reason string) string {
+	if resource == "" {
+		resource = ResourceRequest
+	}
+
+	return resource + "." + reason
+}
+
 type BusinessErr struct {
-	Msg string
+	Msg      string
+	Resource string
 }
 
 func (e BusinessErr) Error() string {
 	return e.Msg
 }
 
+func (e BusinessErr) Code() string {
+	return buildCode(e.Resource, "rule_violation")
+}
+
 type NameAlreadyInUseErr struct {
-	Msg string
+	Msg      string
+	Resource string
 }
 
 func (e NameAlreadyInUseErr) Error() string {
 	return e.Msg
 }
 
+func (e NameAlreadyInUseErr) Code() string {
+	return buildCode(e.Resource, "name_conflict")
+}
+
 type AddressNotValidErr struct {
 	Msg string
 }
@@ -23,3 +82,119 @@ type AddressNotValidErr struct {
 func (e AddressNotValidErr) Error() string {
 	return e.Msg
 }
+
+func (e AddressNotValidErr) Code() string {
+	return CodeInvalidAddress
+}
+
+// UnknownReferenceErr is returned when a request refers to a resource that does not exist. Resource is the referenced
+// resource, not the one being modified.
+type UnknownReferenceErr struct {
+	Msg      string
+	Resource string
+}
+
+func (e UnknownReferenceErr) Error() string {
+	return e.Msg
+}
+
+func (e UnknownReferenceErr) Code() string {
+	return buildCode(e.Resource, "unknown_reference")
+}
+
+type NotFoundErr struct {
+	Msg      string
+	Resource string
+}
+
+func (e NotFoundErr) Error() string {
+	return e.Msg
+}
+
+func (e NotFoundErr) Code() string {
+	return buildCode(e.Resource, "not_found")
+}
+
+type PreconditionFailedErr struct {
+	Msg      string
+	Resource string
+}
+
+func (e PreconditionFailedErr) Error() string {
+	return e.Msg
+}
+
+func (e PreconditionFailedErr) Code() string {
+	return buildCode(e.Resource, "version_mismatch")
+}
+
+// ConflictErr is returned when a change could not be applied because of a concurrent one, such as a serialization
+// failure of the transaction. The operation can be retried.
+type ConflictErr struct {
+	Msg      string
+	Resource string
+}
+
+func (e ConflictErr) Error() string {
+	return e.Msg
+}
+
+func (e ConflictErr) Code() string {
+	return buildCode(e.Resource, "conflict")
+}
+
+// TimeoutErr is returned when a dependency, such as the database, took too long and the operation was cancelled
+type TimeoutErr struct {
+	Msg string
+}
+
+func (e TimeoutErr) Error() string {
+	return e.Msg
+}
+
+func (e TimeoutErr) Code() string {
+	return CodeDependencyTimeout
+}
+
+type IdempotencyKeyMismatchErr struct {
+	Msg string
+}
+
+func (e IdempotencyKeyMismatchErr) Error() string {
+	return e.Msg
+}
+
+func (e IdempotencyKeyMismatchErr) Code() string {
+	return CodeIdempotencyMismatch
+}
+
+type IdempotencyKeyInProgressErr struct {
+	Msg string
+}
+
+func (e IdempotencyKeyInProgressErr) Error() string {
+	return e.Msg
+}
+
+func (e IdempotencyKeyInProgressErr) Code() string {
+	return CodeIdempotencyInProgress
+}
+
+// DependencyErr is returned when a service the operation depends on, such as the address validation API, fails or
+// cannot be reached. The operation can be retried later.
+type DependencyErr struct {
+	Dependency string
+	Err        error
+}
+
+func (e DependencyErr) Error() string {
+	return fmt.Sprintf("%v is unavailable: %v", e.Dependency, e.Err)
+}
+
+func (e DependencyErr) Unwrap() error {
+	return e.Err
+}
+
+func (e DependencyErr) Code() string {
+	return CodeDependencyUnavailable
+}
@@ -1,17 +1,74 @@
 package domain
 
+import "time"
+
 const (
 	NextPage     = "next"
 	PreviousPage = "prev"
 )
 
 type CursorPaginationFilters struct {
-	Cursor    string `json:"cursor"`
-	Direction string `json:"direction"` // next or prev
-	Limit     int    `json:"limit"`
+	Cursor    string    `json:"cursor"`
+	Direction string    `json:"direction"` // next or prev
+	Limit     int       `json:"limit"`
+	Sort      SortOrder `json:"sort"`
 }
 
+// LocationsFilters narrows a location search. Nil or empty fields are not applied, date ranges include the lower
+// bound and exclude the upper one.
 type LocationsFilters struct {
 	CursorPaginationFilters
-	Name *string `json:"name"`
+	Name            *string    `json:"name"`
+	City            *string    `json:"city"`
+	State           *string    `json:"state"`
+	Zipcode         *string    `json:"zipcode"`
+	LocationTypeIDs []int      `json:"location_type_ids"`
+	SupplierIDs     []int      `json:"supplier_ids"`
+	Active          *bool      `json:"active"`
+	CreatedFrom     *time.Time `json:"created_from"`
+	CreatedTo       *time.Time `json:"created_to"`
+	UpdatedFrom     *time.Time `json:"updated_from"`
+	UpdatedTo       *time.Time `json:"updated_to"`
+	IncludeDeleted  bool       `json:"include_deleted"`
+}
+
+// CursorScope is bound into the cursors of the search, so they cannot be used with other filters. The pagination
+// filters change between pages and are left out.
+func (f LocationsFilters) CursorScope() string {
+	f.CursorPaginationFilters = CursorPaginationFilters{}
+
+	return hashCursorScope(f)
+}
+
+// NearbyLocationsFilters searches locations within RadiusMeters of a point. Results are ordered by distance, so only
+// the limit of the embedded pagination filters is used.
+type NearbyLocationsFilters struct {
+	LocationsFilters
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	RadiusMeters float64 `json:"radius_m"`
+}
+
+type SubLocationsFilters struct {
+	CursorPaginationFilters
+	LocationID string `json:"location_id"`
+}
+
+// CursorScope binds the cursors to the parent location, so they cannot be used to page another location
+func (f SubLocationsFilters) CursorScope() string {
+	f.CursorPaginationFilters = CursorPaginationFilters{}
+
+	return hashCursorScope(f)
+}
+
+type LocationHistoryFilters struct {
+	CursorPaginationFilters
+	LocationID string `json:"location_id"`
+}
+
+// CursorScope binds the cursors to the location, so they cannot be used to page the history of another location
+func (f LocationHistoryFilters) CursorScope() string {
+	f.CursorPaginationFilters = CursorPaginationFilters{}
+
+	return hashCursorScope(f)
 }
@@ -0,0 +1,58 @@
+package domain
+
+import "math"
+
+const (
+	EarthRadiusMeters = 6371000.0
+	// metersPerLatitudeDegree is the length of one degree of latitude on the sphere used to compute distances
+	metersPerLatitudeDegree = EarthRadiusMeters * math.Pi / 180
+)
+
+// BoundingBox is a latitude/longitude rectangle that contains every point within a radius of its center. It is used
+// as a cheap index friendly prefilter before computing exact distances.
+type BoundingBox struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+	// AllLongitudes is set when the box reaches a pole or crosses the antimeridian, longitude can't be used to filter
+	AllLongitudes bool
+}
+
+func NewBoundingBox(latitude, longitude, radiusMeters float64) BoundingBox {
+	latitudeDelta := radiusMeters / metersPerLatitudeDegree
+	box := BoundingBox{
+		MinLatitude: math.Max(latitude-latitudeDelta, -90),
+		MaxLatitude: math.Min(latitude+latitudeDelta, 90),
+	}
+
+	// The widest part of the circle in longitude degrees is at the latitude closest to a pole
+	widestLatitude := math.Max(math.Abs(box.MinLatitude), math.Abs(box.MaxLatitude))
+	if widestLatitude >= 90 {
+		box.AllLongitudes = true
+		return box
+	}
+
+	longitudeDelta := radiusMeters / (metersPerLatitudeDegree * math.Cos(widestLatitude*math.Pi/180))
+	box.MinLongitude = longitude - longitudeDelta
+	box.MaxLongitude = longitude + longitudeDelta
+
+	if box.MinLongitude < -180 || box.MaxLongitude > 180 {
+		box.AllLongitudes = true
+	}
+
+	return box
+}
+
+// HaversineDistanceMeters returns the great-circle distance between two points
+func HaversineDistanceMeters(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	latitudeDelta := toRadians(latitude2 - latitude1)
+	longitudeDelta := toRadians(longitude2 - longitude1)
+
+	a := math.Pow(math.Sin(latitudeDelta/2), 2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Pow(math.Sin(longitudeDelta/2), 2)
+
+	return 2 * EarthRadiusMeters * math.Asin(math.Sqrt(a))
+}
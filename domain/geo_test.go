@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func Test_HaversineDistanceMeters_OneDegreeAlongAMeridian(t *testing.T) {
+	distance := HaversineDistanceMeters(10, 20, 11, 20)
+
+	assert.InDelta(t, EarthRadiusMeters*math.Pi/180, distance, 0.001)
+}
+
+func Test_NewBoundingBox_ContainsTheWholeRadius(t *testing.T) {
+	latitude, longitude, radius := -34.6037, -58.3816, 10000.0
+
+	box := NewBoundingBox(latitude, longitude, radius)
+
+	assert.False(t, box.AllLongitudes)
+	assert.GreaterOrEqual(t, HaversineDistanceMeters(latitude, longitude, box.MaxLatitude, longitude), radius-1)
+	assert.GreaterOrEqual(t, HaversineDistanceMeters(latitude, longitude, latitude, box.MaxLongitude), radius-1)
+}
+
+func Test_NewBoundingBox_DoesNotFilterLongitudeAcrossTheAntimeridian(t *testing.T) {
+	box := NewBoundingBox(0, 179.99, 5000)
+
+	assert.True(t, box.AllLongitudes)
+}
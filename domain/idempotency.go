@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// IdempotencyKey is a key sent by a client to make a request safe to retry. Keys are scoped to the method and path of
+// the request, and the hash of the request they were first used with is kept so the key cannot be reused for another
+// request. Response is nil while the first request is being processed.
+type IdempotencyKey struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	Response    *IdempotentResponse
+	ExpiresAt   time.Time
+}
+
+// IdempotentResponse is the stored response replayed to the retries of a request
+type IdempotentResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers"`
+	Body       []byte            `json:"body"`
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 const (
 	ReconLocationTypeID       = 1
 	WholesaleLocationTypeID   = 2
@@ -17,10 +19,39 @@ type Location struct {
 	LocationType LocationType        `json:"location_type"`
 	Supplier     Supplier            `json:"supplier"`
 	Active       bool                `json:"active"`
+	DeletedAt    *time.Time          `json:"deleted_at,omitempty"`
+	Version      int                 `json:"version"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+type NearbyLocation struct {
+	Location
+	DistanceMeters float64 `json:"distance_m"`
+}
+
+func (l Location) IsDeleted() bool {
+	return l.DeletedAt != nil
 }
 
 func (l Location) GetUniqueOrderedIdentifier() string {
-	return l.Name
+	return l.ID
+}
+
+func (l Location) GetSortValue(field string) any {
+	switch field {
+	case SortByName:
+		return l.Name
+	case SortByCreatedAt:
+		return l.CreatedAt
+	case SortByCity:
+		return l.Information.City
+	case SortByState:
+		return l.Information.State
+	case SortByZipcode:
+		return l.Information.Zipcode
+	default:
+		return nil
+	}
 }
 
 type LocationInformation struct {
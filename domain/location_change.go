@@ -0,0 +1,25 @@
+package domain
+
+import "slices"
+
+// LocationChange is a change of a location pushed to the clients of the location changes stream. Its ID is the one of
+// the broker message that carried it, which is the same on every instance, so clients can resume from any of them.
+type LocationChange struct {
+	ID        string   `json:"id"`
+	Operation string   `json:"operation"`
+	Location  Location `json:"location"`
+}
+
+// LocationChangeFilters selects the changes sent to a stream client, an empty list matches every value
+type LocationChangeFilters struct {
+	SupplierIDs     []int
+	LocationTypeIDs []int
+}
+
+func (f LocationChangeFilters) Matches(change LocationChange) bool {
+	if len(f.SupplierIDs) > 0 && !slices.Contains(f.SupplierIDs, change.Location.Supplier.ID) {
+		return false
+	}
+
+	return len(f.LocationTypeIDs) == 0 || slices.Contains(f.LocationTypeIDs, change.Location.LocationType.ID)
+}
@@ -0,0 +1,30 @@
+package domain
+
+// Types of the CloudEvents published to the location topics
+const (
+	LocationCreatedEventType  = "go-service-template.location.created"
+	LocationUpdatedEventType  = "go-service-template.location.updated"
+	LocationDeletedEventType  = "go-service-template.location.deleted"
+	LocationRestoredEventType = "go-service-template.location.restored"
+)
+
+// LocationEventTypes are the types of the events published to each location topic
+var LocationEventTypes = map[string]string{
+	LocationsNewTopic:      LocationCreatedEventType,
+	LocationsUpdatedTopic:  LocationUpdatedEventType,
+	LocationsDeletedTopic:  LocationDeletedEventType,
+	LocationsRestoredTopic: LocationRestoredEventType,
+}
+
+// LocationEventSchemaVersion is the schema version of the location events being published, LocationEventV2
+const LocationEventSchemaVersion = 2
+
+// LocationEventV1 is the data of the location events published before they were wrapped in CloudEvents, the bare
+// location
+type LocationEventV1 = Location
+
+// LocationEventV2 is the data of the location events, the location along with who changed it
+type LocationEventV2 struct {
+	Location Location `json:"location"`
+	Actor    string   `json:"actor,omitempty"`
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	LocationCreatedOperation  = "created"
+	LocationUpdatedOperation  = "updated"
+	LocationDeletedOperation  = "deleted"
+	LocationRestoredOperation = "restored"
+)
+
+type LocationHistoryEntry struct {
+	ID            int64     `json:"id"`
+	LocationID    string    `json:"location_id"`
+	Operation     string    `json:"operation"`
+	Before        *Location `json:"before"`
+	After         *Location `json:"after"`
+	ChangedFields []string  `json:"changed_fields"`
+	CorrelationID string    `json:"correlation_id"`
+	Actor         string    `json:"actor"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+func (e LocationHistoryEntry) GetUniqueOrderedIdentifier() string {
+	return strconv.FormatInt(e.ID, 10)
+}
+
+// GetSortValue returns nil since history entries are always sorted by their ID
+func (e LocationHistoryEntry) GetSortValue(string) any {
+	return nil
+}
+
+// LocationChangedFields lists the fields that differ between two snapshots of a location, named as in its JSON
+// representation. The version is left out since it changes on every write.
+func LocationChangedFields(before, after *Location) []string {
+	if before == nil {
+		before = &Location{}
+	}
+	if after == nil {
+		after = &Location{}
+	}
+
+	candidates := []struct {
+		name          string
+		before, after any
+	}{
+		{"name", before.Name, after.Name},
+		{"active", before.Active, after.Active},
+		{"supplier_id", before.Supplier.ID, after.Supplier.ID},
+		{"location_type_id", before.LocationType.ID, after.LocationType.ID},
+		{"address", before.Information.Address, after.Information.Address},
+		{"city", before.Information.City, after.Information.City},
+		{"state", before.Information.State, after.Information.State},
+		{"zipcode", before.Information.Zipcode, after.Information.Zipcode},
+		{"latitude", before.Information.Latitude, after.Information.Latitude},
+		{"longitude", before.Information.Longitude, after.Information.Longitude},
+		{"contact_person", before.Information.ContactInformation.ContactPerson, after.Information.ContactInformation.ContactPerson},
+		{"phone_number", before.Information.ContactInformation.PhoneNumber, after.Information.ContactInformation.PhoneNumber},
+		{"email", before.Information.ContactInformation.Email, after.Information.ContactInformation.Email},
+		{"deleted_at", unixNanoOrNil(before.DeletedAt), unixNanoOrNil(after.DeletedAt)},
+	}
+
+	changedFields := make([]string, 0)
+	for _, candidate := range candidates {
+		if !reflect.DeepEqual(candidate.before, candidate.after) {
+			changedFields = append(changedFields, candidate.name)
+		}
+	}
+
+	return changedFields
+}
+
+// unixNanoOrNil makes timestamps comparable regardless of their location or monotonic clock reading
+func unixNanoOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return t.UnixNano()
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go-service-template/utils"
+	"testing"
+	"time"
+)
+
+func Test_LocationChangedFields_ListsOnlyChangedFields(t *testing.T) {
+	before := Location{Name: NameA, Active: true, Version: 1}
+	before.Information.City = "City"
+	before.Information.ContactInformation.Email = utils.ToPointer("old@mail.com")
+
+	after := before
+	after.Version = 2
+	after.Information.City = "Other city"
+	after.Information.ContactInformation.Email = utils.ToPointer("new@mail.com")
+
+	assert.Equal(t, []string{"city", "email"}, LocationChangedFields(&before, &after))
+}
+
+func Test_LocationChangedFields_IgnoresTimestampRepresentation(t *testing.T) {
+	deletedAt := time.Now()
+	before := Location{DeletedAt: &deletedAt}
+	after := Location{DeletedAt: utils.ToPointer(deletedAt.UTC().Round(0))}
+
+	assert.Empty(t, LocationChangedFields(&before, &after))
+}
+
+func Test_LocationHistoryEntry_IdentifierIsTheEntryID(t *testing.T) {
+	assert.Equal(t, "10", LocationHistoryEntry{ID: 10}.GetUniqueOrderedIdentifier())
+}
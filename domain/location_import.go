@@ -0,0 +1,25 @@
+package domain
+
+const (
+	ImportRowCreated = "created"
+	ImportRowValid   = "valid"
+	ImportRowFailed  = "failed"
+)
+
+// LocationImportReport has one result per imported row, in the order of the file. On dry runs the rows that would have
+// been created are reported as valid.
+type LocationImportReport struct {
+	DryRun    bool                      `json:"dry_run"`
+	Total     int                       `json:"total"`
+	Succeeded int                       `json:"succeeded"`
+	Failed    int                       `json:"failed"`
+	Rows      []LocationImportRowResult `json:"rows"`
+}
+
+type LocationImportRowResult struct {
+	Row        int      `json:"row"`
+	Name       string   `json:"name"`
+	Status     string   `json:"status"`
+	LocationID string   `json:"location_id,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+}
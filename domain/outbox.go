@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// OutboxMessage is an event stored in the same transaction as the change that produced it. The outbox relay
+// is the only component that moves these rows to the message broker.
+type OutboxMessage struct {
+	ID          string            `json:"id"`
+	Sequence    int64             `json:"sequence"`
+	AggregateID string            `json:"aggregate_id"`
+	Topic       string            `json:"topic"`
+	Payload     []byte            `json:"payload" swaggertype:"string" format:"base64"`
+	Metadata    map[string]string `json:"metadata"`
+	CreatedAt   time.Time         `json:"created_at"`
+	PublishedAt *time.Time        `json:"published_at,omitempty"`
+	// Attempts is the number of failed publishes, the next one is not made before NextAttemptAt
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastError     *string    `json:"last_error,omitempty"`
+	// FailedAt is set when the message is parked, it holds back the later messages of its aggregate until it is
+	// redriven or discarded
+	FailedAt *time.Time `json:"failed_at,omitempty"`
+}
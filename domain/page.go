@@ -2,11 +2,14 @@ package domain
 
 import (
 	"go-service-template/utils"
-	"sort"
+	"slices"
 )
 
 type Pageable interface {
+	// GetUniqueOrderedIdentifier is the last tie-breaker of every sort order
 	GetUniqueOrderedIdentifier() string
+	// GetSortValue returns the value of one of the fields the element can be sorted by
+	GetSortValue(field string) any
 }
 
 // ExampleCursorPage is only for swagger usages (swaggo/swag still does not support generics)
@@ -24,43 +27,69 @@ type CursorPage[T Pageable] struct {
 	PreviousPage *string `json:"previous_page"`
 }
 
-func BuildCursorPage[T Pageable](data []T, filters CursorPaginationFilters) CursorPage[T] {
+// BuildCursorPage expects the data in the order the query returned it: sorted by filters.Sort when moving to the next
+// page and in reverse order when moving to the previous one. The query must read one element more than the limit.
+// The cursors of the page are bound to the scope of the search filters.
+func BuildCursorPage[T Pageable](data []T, filters CursorPaginationFilters, scope string, codec CursorCodec) (CursorPage[T], error) {
 	if len(data) == 0 {
-		return CursorPage[T]{Data: []T{}, Limit: 0, NextPage: nil, PreviousPage: nil}
+		return CursorPage[T]{Data: []T{}, Limit: 0, NextPage: nil, PreviousPage: nil}, nil
 	}
 
 	page := CursorPage[T]{Limit: filters.Limit}
 
-	// Sort elements ASC
-	sort.Slice(data, func(i, j int) bool {
-		return data[i].GetUniqueOrderedIdentifier() < data[j].GetUniqueOrderedIdentifier()
-	})
+	if filters.Direction != NextPage {
+		slices.Reverse(data)
+	}
+
+	var firstCursor, lastCursor *T
 
 	if filters.Direction == NextPage {
 		if len(data) > filters.Limit {
-			page.NextPage = utils.ToPointer[string]((data[len(data)-2]).GetUniqueOrderedIdentifier())
 			page.Data = data[0 : len(data)-1] // Remove last element
+			lastCursor = &page.Data[len(page.Data)-1]
 		} else {
-			page.NextPage = nil
 			page.Data = data
 		}
 
-		if filters.Cursor == "" {
-			page.PreviousPage = nil
-		}else{
-			page.PreviousPage = utils.ToPointer[string]((data[0]).GetUniqueOrderedIdentifier())
+		if filters.Cursor != "" {
+			firstCursor = &page.Data[0]
 		}
 	} else {
-		page.NextPage = utils.ToPointer[string]((data[len(data)-1]).GetUniqueOrderedIdentifier())
-
 		if len(data) > filters.Limit {
-			page.PreviousPage = utils.ToPointer[string](data[1].GetUniqueOrderedIdentifier())
 			page.Data = data[1:] // Remove first element
+			firstCursor = &page.Data[0]
 		} else {
-			page.PreviousPage = nil
 			page.Data = data
 		}
+
+		lastCursor = &page.Data[len(page.Data)-1]
+	}
+
+	var err error
+	if page.PreviousPage, err = encodeCursor(firstCursor, filters.Sort, scope, codec); err != nil {
+		return page, err
+	}
+	if page.NextPage, err = encodeCursor(lastCursor, filters.Sort, scope, codec); err != nil {
+		return page, err
+	}
+
+	return page, nil
+}
+
+func encodeCursor[T Pageable](element *T, order SortOrder, scope string, codec CursorCodec) (*string, error) {
+	if element == nil {
+		return nil, nil
+	}
+
+	cursor := Cursor{Sort: order.String(), Scope: scope, ID: (*element).GetUniqueOrderedIdentifier()}
+	for _, field := range order {
+		cursor.Values = append(cursor.Values, (*element).GetSortValue(field.Field))
+	}
+
+	token, err := codec.Encode(cursor)
+	if err != nil {
+		return nil, err
 	}
 
-	return page
+	return utils.ToPointer[string](token), nil
 }
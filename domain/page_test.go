@@ -2,6 +2,7 @@ package domain
 
 import (
 	"github.com/stretchr/testify/assert"
+	"go-service-template/utils"
 	"testing"
 )
 
@@ -13,89 +14,118 @@ const (
 	NameC       = "C"
 )
 
+var (
+	testCodec     = NewCursorCodec("test-key")
+	testSortOrder = SortOrder{{Field: SortByName}}
+	testScope     = LocationsFilters{Name: utils.ToPointer(NameA)}.CursorScope()
+	locationA     = Location{ID: "idA", Name: NameA}
+	locationB     = Location{ID: "idB", Name: NameB}
+	locationC     = Location{ID: "idC", Name: NameC}
+)
+
+func assertCursorPointsAt(t *testing.T, location Location, token *string) {
+	if assert.NotNil(t, token) {
+		cursor, err := testCodec.Decode(*token, testSortOrder, testScope)
+		assert.Nil(t, err)
+		assert.Equal(t, location.ID, cursor.ID)
+		assert.Equal(t, []any{location.Name}, cursor.Values)
+	}
+}
+
 func Test_BuildCursorPage_NextDirectionWithMoreDataThanLimitOnEmptyCursor(t *testing.T) {
-	data := []Location{{Name: NameA}, {Name: NameB}, {Name: NameC}}
+	data := []Location{locationA, locationB, locationC}
 	filters := CursorPaginationFilters{
 		Cursor:    "",
 		Direction: NextPage,
 		Limit:     Limit,
+		Sort:      testSortOrder,
 	}
 
-	page := BuildCursorPage(data, filters)
+	page, err := BuildCursorPage(data, filters, testScope, testCodec)
 
-	assert.Equal(t, NameB, *page.NextPage)
+	assert.Nil(t, err)
+	assertCursorPointsAt(t, locationB, page.NextPage)
 	assert.Nil(t, page.PreviousPage)
-	assert.Equal(t, []Location{{Name: NameA}, {Name: NameB}}, page.Data)
+	assert.Equal(t, []Location{locationA, locationB}, page.Data)
 	assert.Equal(t, filters.Limit, page.Limit)
 }
 
 func Test_BuildCursorPage_NextDirectionWithMoreDataThanLimitWithCursorValue(t *testing.T) {
-	data := []Location{{Name: NameA}, {Name: NameB}, {Name: NameC}}
+	data := []Location{locationA, locationB, locationC}
 	filters := CursorPaginationFilters{
 		Cursor:    CursorValue,
 		Direction: NextPage,
 		Limit:     Limit,
+		Sort:      testSortOrder,
 	}
 
-	page := BuildCursorPage(data, filters)
+	page, err := BuildCursorPage(data, filters, testScope, testCodec)
 
-	assert.Equal(t, NameB, *page.NextPage)
-	assert.Equal(t, NameA, *page.PreviousPage)
-	assert.Equal(t, []Location{{Name: NameA}, {Name: NameB}}, page.Data)
+	assert.Nil(t, err)
+	assertCursorPointsAt(t, locationB, page.NextPage)
+	assertCursorPointsAt(t, locationA, page.PreviousPage)
+	assert.Equal(t, []Location{locationA, locationB}, page.Data)
 	assert.Equal(t, filters.Limit, page.Limit)
 }
 
 func Test_BuildCursorPage_NextDirectionWithLessDataThanLimitWithCursorValue(t *testing.T) {
-	data := []Location{{Name: NameC}}
+	data := []Location{locationC}
 	filters := CursorPaginationFilters{
 		Cursor:    CursorValue,
 		Direction: NextPage,
 		Limit:     Limit,
+		Sort:      testSortOrder,
 	}
 
-	page := BuildCursorPage(data, filters)
+	page, err := BuildCursorPage(data, filters, testScope, testCodec)
 
+	assert.Nil(t, err)
 	assert.Nil(t, page.NextPage)
-	assert.Equal(t, NameC, *page.PreviousPage)
-	assert.Equal(t, []Location{{Name: NameC}}, page.Data)
+	assertCursorPointsAt(t, locationC, page.PreviousPage)
+	assert.Equal(t, []Location{locationC}, page.Data)
 	assert.Equal(t, filters.Limit, page.Limit)
 }
 
 func Test_BuildCursorPage_PrevDirectionWithMoreDataThanLimitWithCursorValue(t *testing.T) {
-	data := []Location{{Name: NameC}, {Name: NameB}, {Name: NameA}}
+	data := []Location{locationC, locationB, locationA}
 	filters := CursorPaginationFilters{
 		Cursor:    CursorValue,
 		Direction: PreviousPage,
 		Limit:     Limit,
+		Sort:      testSortOrder,
 	}
 
-	page := BuildCursorPage(data, filters)
+	page, err := BuildCursorPage(data, filters, testScope, testCodec)
 
-	assert.Equal(t, NameC, *page.NextPage)
-	assert.Equal(t, NameB, *page.PreviousPage)
-	assert.Equal(t, []Location{{Name: NameB}, {Name: NameC}}, page.Data)
+	assert.Nil(t, err)
+	assertCursorPointsAt(t, locationC, page.NextPage)
+	assertCursorPointsAt(t, locationB, page.PreviousPage)
+	assert.Equal(t, []Location{locationB, locationC}, page.Data)
 	assert.Equal(t, filters.Limit, page.Limit)
 }
 
 func Test_BuildCursorPage_PrevDirectionWithLessDataThanLimitWithCursorValue(t *testing.T) {
-	data := []Location{{Name: NameB}, {Name: NameA}}
+	data := []Location{locationB, locationA}
 	filters := CursorPaginationFilters{
 		Cursor:    CursorValue,
 		Direction: PreviousPage,
 		Limit:     Limit,
+		Sort:      testSortOrder,
 	}
 
-	page := BuildCursorPage(data, filters)
+	page, err := BuildCursorPage(data, filters, testScope, testCodec)
 
-	assert.Equal(t, NameB, *page.NextPage)
+	assert.Nil(t, err)
+	assertCursorPointsAt(t, locationB, page.NextPage)
 	assert.Nil(t, page.PreviousPage)
-	assert.Equal(t, []Location{{Name: NameA}, {Name: NameB}}, page.Data)
+	assert.Equal(t, []Location{locationA, locationB}, page.Data)
 	assert.Equal(t, filters.Limit, page.Limit)
 }
 
 func Test_BuildCursorPage_EmptyPageWhenDataIsEmpty(t *testing.T) {
-	page := BuildCursorPage([]Location{}, CursorPaginationFilters{})
+	page, err := BuildCursorPage([]Location{}, CursorPaginationFilters{}, testScope, testCodec)
 
+	assert.Nil(t, err)
 	assert.Nil(t, page.PreviousPage)
 	assert.Nil(t, page.NextPage)
 	assert.Len(t, page.Data, 0)
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"go-service-template/utils"
+	"strings"
+)
+
+const (
+	SortByName      = "name"
+	SortByCreatedAt = "created_at"
+	SortByCity      = "city"
+	SortByState     = "state"
+	SortByZipcode   = "zipcode"
+)
+
+var (
+	LocationSortFields       = []string{SortByName, SortByCreatedAt, SortByCity, SortByState, SortByZipcode}
+	DefaultLocationSortOrder = SortOrder{{Field: SortByName}}
+)
+
+type SortField struct {
+	Field      string `json:"field"`
+	Descending bool   `json:"descending"`
+}
+
+// SortOrder lists the fields a page is sorted by, the unique identifier of the elements is always the last tie-breaker
+type SortOrder []SortField
+
+// ParseSortOrder parses a comma separated list of fields, prefixing a field with '-' sorts it in descending order.
+// E.g. 'created_at,-name'.
+func ParseSortOrder(raw string, allowedFields []string) (SortOrder, error) {
+	var order SortOrder
+	seen := map[string]bool{}
+
+	for _, rawField := range strings.Split(raw, ",") {
+		rawField = strings.TrimSpace(rawField)
+		field := SortField{Field: strings.TrimPrefix(rawField, "-"), Descending: strings.HasPrefix(rawField, "-")}
+
+		if !utils.ListContains(allowedFields, field.Field) {
+			return nil, fmt.Errorf("invalid sort field '%v', accepted fields are %v", rawField, strings.Join(allowedFields, ", "))
+		}
+		if seen[field.Field] {
+			return nil, fmt.Errorf("sort field '%v' is repeated", field.Field)
+		}
+
+		seen[field.Field] = true
+		order = append(order, field)
+	}
+
+	return order, nil
+}
+
+// String returns the order in the same format ParseSortOrder accepts
+func (o SortOrder) String() string {
+	fields := make([]string, 0, len(o))
+	for _, field := range o {
+		if field.Descending {
+			fields = append(fields, "-"+field.Field)
+		} else {
+			fields = append(fields, field.Field)
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
@@ -12,6 +12,18 @@ type SubLocation struct {
 	LocationID      string          `json:"location_id"`
 }
 
+func (s SubLocation) GetUniqueOrderedIdentifier() string {
+	return s.ID
+}
+
+func (s SubLocation) GetSortValue(field string) any {
+	if field == SortByName {
+		return s.Name
+	}
+
+	return nil
+}
+
 type SubLocationType struct {
 	ID   int    `json:"id"`
 	Type string `json:"type"`
@@ -1,6 +1,10 @@
 package domain
 
 const (
-	LocationsNewTopic     = "go-service-template.locations.new"
-	LocationsUpdatedTopic = "go-service-template.locations.updated"
+	LocationsNewTopic        = "go-service-template.locations.new"
+	LocationsUpdatedTopic    = "go-service-template.locations.updated"
+	LocationsDeletedTopic    = "go-service-template.locations.deleted"
+	LocationsRestoredTopic   = "go-service-template.locations.restored"
+	SubLocationsNewTopic     = "go-service-template.sub-locations.new"
+	SubLocationsUpdatedTopic = "go-service-template.sub-locations.updated"
 )
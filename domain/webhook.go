@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookStatusActive = "active"
+	WebhookStatusPaused = "paused"
+
+	WebhookEventLocationCreated = "location.created"
+	WebhookEventLocationUpdated = "location.updated"
+
+	WebhookDeliveryRetrying  = "retrying"
+	WebhookDeliveryFailed    = "failed"
+	WebhookDeliverySucceeded = "succeeded"
+)
+
+// WebhookSubscription is an HTTP callback of a partner, which receives the events of the subscribed types signed with
+// its secret. A subscription restricted to a supplier only receives the events of the locations of that supplier.
+type WebhookSubscription struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"-"`
+	SupplierID *int      `json:"supplier_id,omitempty"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookEvent is the body sent to the subscriptions. Its ID is the one of the broker message, receivers can use it
+// to discard the duplicates that at-least-once delivery can produce.
+type WebhookEvent struct {
+	ID   string   `json:"id"`
+	Type string   `json:"type"`
+	Data Location `json:"data"`
+}
+
+// WebhookDelivery is an entry of the delivery log, written when an event could not be delivered. It is retried in the
+// background until its next attempt is due or it runs out of attempts and is marked failed. The payload is kept as it
+// was sent so retries and redeliveries send the same event.
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	LastAttemptAt  time.Time       `json:"last_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+}
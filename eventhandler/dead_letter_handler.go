@@ -0,0 +1,64 @@
+package eventhandler
+
+import (
+	"encoding/json"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"go-service-template/services"
+	"slices"
+)
+
+// DeadLetterEventHandler stores the dead letters published to the poison topic of a handler, so they can be inspected
+// and redriven through the API
+type DeadLetterEventHandler struct {
+	logger            monitor.AppLogger
+	name              string
+	topic             string
+	deadLetterService services.IDeadLetterService
+}
+
+// CreateDeadLetterHandlers creates a handler for each poison topic, named after the handler that publishes to it
+func CreateDeadLetterHandlers(deadLetterService services.IDeadLetterService, poisonTopics map[string]string) []pubsub.EventHandler {
+	handlerNames := make([]string, 0, len(poisonTopics))
+	for handlerName := range poisonTopics {
+		handlerNames = append(handlerNames, handlerName)
+	}
+	slices.Sort(handlerNames)
+
+	handlers := make([]pubsub.EventHandler, 0, len(poisonTopics))
+	for _, handlerName := range handlerNames {
+		handlers = append(handlers, &DeadLetterEventHandler{
+			logger:            monitor.GetStdLogger("DeadLetterConsumer"),
+			name:              handlerName + "DeadLetters",
+			topic:             poisonTopics[handlerName],
+			deadLetterService: deadLetterService,
+		})
+	}
+
+	return handlers
+}
+
+func (c *DeadLetterEventHandler) GetData() (name string, topic string) {
+	return c.name, c.topic
+}
+
+func (c *DeadLetterEventHandler) Process(msg *message.Message) error {
+	fnName := "DeadLetterEventHandler.Process"
+	var appCtx monitor.ApplicationContext
+
+	appCtx = monitor.CreateAppContextFromContext(msg.Context(), msg.Metadata.Get(monitor.CorrelationIDField))
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	var deadLetter domain.DeadLetter
+	if err := json.Unmarshal(msg.Payload, &deadLetter); err != nil {
+		// Redelivering the message would not make it readable, it is skipped so the poison topic does not stall
+		c.logger.ErrorCtx(appCtx, fnName, "failed to unmarshal dead letter, it is not stored", err)
+		return nil
+	}
+
+	return c.deadLetterService.StoreDeadLetter(appCtx, deadLetter)
+}
@@ -0,0 +1,70 @@
+package eventhandler_test
+
+import (
+	"encoding/json"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/eventhandler"
+	"go-service-template/mocks"
+	"go-service-template/pubsub"
+	"testing"
+)
+
+type DeadLetterHandlerSuite struct {
+	suite.Suite
+	deadLetterServiceMock *mocks.IDeadLetterService
+	handlers              []pubsub.EventHandler
+}
+
+func (s *DeadLetterHandlerSuite) SetupTest() {
+	s.deadLetterServiceMock = new(mocks.IDeadLetterService)
+	s.handlers = eventhandler.CreateDeadLetterHandlers(s.deadLetterServiceMock, map[string]string{
+		"UpdatedLocationWebhookHandler": domain.LocationsUpdatedTopic + ".poison.UpdatedLocationWebhookHandler",
+		"NewLocationWebhookHandler":     domain.LocationsNewTopic + ".poison.NewLocationWebhookHandler",
+	})
+}
+
+func TestDeadLetterHandlerSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterHandlerSuite))
+}
+
+func (s *DeadLetterHandlerSuite) Test_CreateDeadLetterHandlers_OnePerPoisonTopic() {
+	s.Require().Len(s.handlers, 2)
+
+	name, topic := s.handlers[0].GetData()
+	assert.Equal(s.T(), "NewLocationWebhookHandlerDeadLetters", name)
+	assert.Equal(s.T(), domain.LocationsNewTopic+".poison.NewLocationWebhookHandler", topic)
+
+	name, topic = s.handlers[1].GetData()
+	assert.Equal(s.T(), "UpdatedLocationWebhookHandlerDeadLetters", name)
+	assert.Equal(s.T(), domain.LocationsUpdatedTopic+".poison.UpdatedLocationWebhookHandler", topic)
+}
+
+func (s *DeadLetterHandlerSuite) Test_Process_StoresDeadLetter() {
+	deadLetter := domain.DeadLetter{
+		ID:            uuid.NewString(),
+		MessageID:     uuid.NewString(),
+		Handler:       "NewLocationWebhookHandler",
+		OriginalTopic: domain.LocationsNewTopic,
+		Error:         "webhook delivery failed",
+		Attempts:      6,
+		Payload:       []byte(`{"id":"location1"}`),
+		Metadata:      map[string]string{},
+	}
+	deadLetterBytes, err := json.Marshal(deadLetter)
+	s.Require().NoError(err)
+
+	s.deadLetterServiceMock.On("StoreDeadLetter", mock.Anything, deadLetter).Return(nil).Once()
+
+	assert.Nil(s.T(), s.handlers[0].Process(message.NewMessage(deadLetter.ID, deadLetterBytes)))
+	s.deadLetterServiceMock.AssertExpectations(s.T())
+}
+
+func (s *DeadLetterHandlerSuite) Test_Process_SkipsUnreadablePayload() {
+	assert.Nil(s.T(), s.handlers[0].Process(message.NewMessage(uuid.NewString(), []byte("{"))))
+	s.deadLetterServiceMock.AssertExpectations(s.T())
+}
@@ -0,0 +1,78 @@
+package eventhandler
+
+import (
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"go-service-template/services"
+)
+
+// locationChangeTopics are the topics pushed to the location changes stream with the operation they carry
+var locationChangeTopics = []struct {
+	name      string
+	topic     string
+	operation string
+}{
+	{"NewLocationChangeHandler", domain.LocationsNewTopic, domain.LocationCreatedOperation},
+	{"UpdatedLocationChangeHandler", domain.LocationsUpdatedTopic, domain.LocationUpdatedOperation},
+	{"DeletedLocationChangeHandler", domain.LocationsDeletedTopic, domain.LocationDeletedOperation},
+	{"RestoredLocationChangeHandler", domain.LocationsRestoredTopic, domain.LocationRestoredOperation},
+}
+
+// LocationChangeEventHandler pushes the location events of a topic to the location changes stream. The stream lives
+// in the memory of each instance, so the events are consumed with a subscriber that delivers them to every instance.
+type LocationChangeEventHandler struct {
+	logger     monitor.AppLogger
+	name       string
+	topic      string
+	operation  string
+	stream     services.ILocationChangeStream
+	subscriber message.Subscriber
+}
+
+// CreateLocationChangeHandlers creates a handler for each location topic, all of them consuming with the subscriber
+func CreateLocationChangeHandlers(stream services.ILocationChangeStream, subscriber message.Subscriber) []pubsub.EventHandler {
+	handlers := make([]pubsub.EventHandler, 0, len(locationChangeTopics))
+	for _, topic := range locationChangeTopics {
+		handlers = append(handlers, &LocationChangeEventHandler{
+			logger:     monitor.GetStdLogger("LocationChangeConsumer"),
+			name:       topic.name,
+			topic:      topic.topic,
+			operation:  topic.operation,
+			stream:     stream,
+			subscriber: subscriber,
+		})
+	}
+
+	return handlers
+}
+
+func (c *LocationChangeEventHandler) GetData() (name string, topic string) {
+	return c.name, c.topic
+}
+
+func (c *LocationChangeEventHandler) GetSubscriber() message.Subscriber {
+	return c.subscriber
+}
+
+func (c *LocationChangeEventHandler) Process(msg *message.Message) error {
+	fnName := "LocationChangeEventHandler.Process"
+	var appCtx monitor.ApplicationContext
+
+	appCtx = monitor.CreateAppContextFromContext(msg.Context(), msg.Metadata.Get(monitor.CorrelationIDField))
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationEvent, err := readLocationEvent(msg, c.topic)
+	if err != nil {
+		// Redelivering the message would not make it readable, it is skipped so the stream does not stall
+		c.logger.ErrorCtx(appCtx, fnName, "failed to read location event, the change is not streamed", err)
+		return nil
+	}
+
+	c.stream.Publish(domain.LocationChange{ID: msg.UUID, Operation: c.operation, Location: locationEvent.Location})
+
+	return nil
+}
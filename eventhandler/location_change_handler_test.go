@@ -0,0 +1,67 @@
+package eventhandler_test
+
+import (
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/eventhandler"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"go-service-template/services"
+	"testing"
+)
+
+type LocationChangeHandlerSuite struct {
+	suite.Suite
+	stream   *services.LocationChangeStream
+	handlers map[string]pubsub.EventHandler
+}
+
+func (s *LocationChangeHandlerSuite) SetupTest() {
+	s.stream = services.NewLocationChangeStream(config.LocationStreamConfig{})
+	s.handlers = map[string]pubsub.EventHandler{}
+	for _, handler := range eventhandler.CreateLocationChangeHandlers(s.stream, nil) {
+		_, topic := handler.GetData()
+		s.handlers[topic] = handler
+	}
+}
+
+func TestLocationChangeHandlerSuite(t *testing.T) {
+	suite.Run(t, new(LocationChangeHandlerSuite))
+}
+
+func (s *LocationChangeHandlerSuite) Test_CreateLocationChangeHandlers_ConsumeEveryLocationTopicWithOwnSubscriber() {
+	assert.Len(s.T(), s.handlers, 4)
+	for _, topic := range []string{
+		domain.LocationsNewTopic, domain.LocationsUpdatedTopic, domain.LocationsDeletedTopic, domain.LocationsRestoredTopic,
+	} {
+		assert.Implements(s.T(), (*pubsub.SubscriberEventHandler)(nil), s.handlers[topic])
+	}
+}
+
+func (s *LocationChangeHandlerSuite) Test_Process_PublishesChangeWithMessageID() {
+	subscription, err := s.stream.Subscribe(monitor.CreateMockAppContext(""), "", domain.LocationChangeFilters{})
+	s.Require().NoError(err)
+	defer subscription.Close()
+
+	msg, err := pubsub.CreateJSONMessage(monitor.CreateMockAppContext(""), "key", location)
+	s.Require().NoError(err)
+
+	assert.Nil(s.T(), s.handlers[domain.LocationsDeletedTopic].Process(msg))
+
+	change := <-subscription.Changes()
+	assert.Equal(s.T(), msg.UUID, change.ID)
+	assert.Equal(s.T(), domain.LocationDeletedOperation, change.Operation)
+	assert.Equal(s.T(), location.Name, change.Location.Name)
+}
+
+func (s *LocationChangeHandlerSuite) Test_Process_SkipsUnreadableMessages() {
+	subscription, err := s.stream.Subscribe(monitor.CreateMockAppContext(""), "", domain.LocationChangeFilters{})
+	s.Require().NoError(err)
+	defer subscription.Close()
+
+	assert.Nil(s.T(), s.handlers[domain.LocationsNewTopic].Process(message.NewMessage("id", []byte("not json"))))
+	assert.Empty(s.T(), subscription.Changes())
+}
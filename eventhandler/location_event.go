@@ -0,0 +1,50 @@
+package eventhandler
+
+import (
+	"encoding/json"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/domain"
+	"go-service-template/pubsub"
+)
+
+// locationEventUpcasters bring the location events of every schema version to domain.LocationEventSchemaVersion
+var locationEventUpcasters = pubsub.NewUpcasterRegistry().Register(
+	1,
+	upcastLocationEventV1,
+	domain.LocationCreatedEventType,
+	domain.LocationUpdatedEventType,
+	domain.LocationDeletedEventType,
+	domain.LocationRestoredEventType,
+)
+
+// readLocationEvent reads the location event of a message published to the topic, whatever the schema version it was
+// published with. Events published before the CloudEvents envelope carry no type, the one of the topic is assumed.
+func readLocationEvent(msg *message.Message, topic string) (domain.LocationEventV2, error) {
+	var locationEvent domain.LocationEventV2
+
+	event, err := pubsub.ReadCloudEvent(msg)
+	if err != nil {
+		return locationEvent, err
+	}
+	if event.Type == "" {
+		event.Type = domain.LocationEventTypes[topic]
+	}
+
+	data, err := locationEventUpcasters.Upcast(event, domain.LocationEventSchemaVersion)
+	if err != nil {
+		return locationEvent, err
+	}
+
+	err = json.Unmarshal(data, &locationEvent)
+
+	return locationEvent, err
+}
+
+func upcastLocationEventV1(data json.RawMessage) (json.RawMessage, error) {
+	var location domain.LocationEventV1
+	if err := json.Unmarshal(data, &location); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(domain.LocationEventV2{Location: location})
+}
@@ -2,7 +2,6 @@
 package eventhandler
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"go-service-template/domain"
@@ -32,11 +31,12 @@ func (c *NewLocationEventHandler) Process(msg *message.Message) error {
 	appCtx, span := appCtx.StartSpan(fnName)
 	defer span.End()
 
-	var newLocation domain.Location
-	if err := json.Unmarshal(msg.Payload, &newLocation); err != nil {
-		c.logger.ErrorCtx(appCtx, fnName, "failed to unmarshal location", err)
+	locationEvent, err := readLocationEvent(msg, domain.LocationsNewTopic)
+	if err != nil {
+		c.logger.ErrorCtx(appCtx, fnName, "failed to read location event", err)
 		return err
 	}
+	newLocation := locationEvent.Location
 
 	c.logger.InfoCtx(appCtx, fnName, fmt.Sprintf("Received new location: %v", newLocation))
 
@@ -0,0 +1,83 @@
+package eventhandler
+
+import (
+	"errors"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"go-service-template/services"
+)
+
+// webhookTopics are the topics delivered to the webhook subscriptions with the event type they carry
+var webhookTopics = []struct {
+	name      string
+	topic     string
+	eventType string
+}{
+	{"NewLocationWebhookHandler", domain.LocationsNewTopic, domain.WebhookEventLocationCreated},
+	{"UpdatedLocationWebhookHandler", domain.LocationsUpdatedTopic, domain.WebhookEventLocationUpdated},
+}
+
+// WebhookEventHandler delivers the location events of a topic to the webhook subscriptions. The topics are also
+// consumed by other handlers, so the events are consumed with a subscriber of its own consumer group, which still
+// delivers each event to a single instance.
+type WebhookEventHandler struct {
+	logger         monitor.AppLogger
+	name           string
+	topic          string
+	eventType      string
+	webhookService services.IWebhookService
+	subscriber     message.Subscriber
+}
+
+// CreateWebhookHandlers creates a handler for each topic delivered to the webhook subscriptions, all of them consuming
+// with the subscriber
+func CreateWebhookHandlers(webhookService services.IWebhookService, subscriber message.Subscriber) []pubsub.EventHandler {
+	handlers := make([]pubsub.EventHandler, 0, len(webhookTopics))
+	for _, topic := range webhookTopics {
+		handlers = append(handlers, &WebhookEventHandler{
+			logger:         monitor.GetStdLogger("WebhookConsumer"),
+			name:           topic.name,
+			topic:          topic.topic,
+			eventType:      topic.eventType,
+			webhookService: webhookService,
+			subscriber:     subscriber,
+		})
+	}
+
+	return handlers
+}
+
+func (c *WebhookEventHandler) GetData() (name string, topic string) {
+	return c.name, c.topic
+}
+
+func (c *WebhookEventHandler) GetSubscriber() message.Subscriber {
+	return c.subscriber
+}
+
+func (c *WebhookEventHandler) Process(msg *message.Message) error {
+	fnName := "WebhookEventHandler.Process"
+	var appCtx monitor.ApplicationContext
+
+	appCtx = monitor.CreateAppContextFromContext(msg.Context(), msg.Metadata.Get(monitor.CorrelationIDField))
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationEvent, err := readLocationEvent(msg, c.topic)
+	if errors.Is(err, pubsub.ErrUnsupportedSchemaVersion) {
+		// Events of a newer schema version end in the dead letters, they can be redriven once this version is deployed
+		c.logger.ErrorCtx(appCtx, fnName, "failed to read location event, the event is not delivered", err)
+		return err
+	}
+	if err != nil {
+		// Redelivering the message would not make it readable, it is skipped so the consumer does not stall
+		c.logger.ErrorCtx(appCtx, fnName, "failed to read location event, the event is not delivered", err)
+		return nil
+	}
+
+	// Failed deliveries are kept in the delivery log, the message is only redelivered when the log could not be written
+	return c.webhookService.DeliverLocationEvent(appCtx, msg.UUID, c.eventType, locationEvent.Location)
+}
@@ -0,0 +1,96 @@
+package eventhandler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/eventhandler"
+	"go-service-template/mocks"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"testing"
+)
+
+type WebhookHandlerSuite struct {
+	suite.Suite
+	webhookServiceMock *mocks.IWebhookService
+	handlers           map[string]pubsub.EventHandler
+}
+
+func (s *WebhookHandlerSuite) SetupTest() {
+	s.webhookServiceMock = new(mocks.IWebhookService)
+	s.handlers = map[string]pubsub.EventHandler{}
+	for _, handler := range eventhandler.CreateWebhookHandlers(s.webhookServiceMock, nil) {
+		_, topic := handler.GetData()
+		s.handlers[topic] = handler
+	}
+}
+
+func TestWebhookHandlerSuite(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerSuite))
+}
+
+func (s *WebhookHandlerSuite) Test_CreateWebhookHandlers_ConsumeWithOwnSubscriber() {
+	assert.Len(s.T(), s.handlers, 2)
+	for _, topic := range []string{domain.LocationsNewTopic, domain.LocationsUpdatedTopic} {
+		assert.Implements(s.T(), (*pubsub.SubscriberEventHandler)(nil), s.handlers[topic])
+	}
+}
+
+func (s *WebhookHandlerSuite) Test_Process_DeliversEventWithMessageID() {
+	locationBytes, err := json.Marshal(location)
+	s.Require().NoError(err)
+	msg := message.NewMessage(uuid.NewString(), locationBytes)
+
+	s.webhookServiceMock.On("DeliverLocationEvent", mock.Anything, msg.UUID, domain.WebhookEventLocationUpdated, location).
+		Return(nil).Once()
+
+	assert.Nil(s.T(), s.handlers[domain.LocationsUpdatedTopic].Process(msg))
+	s.webhookServiceMock.AssertExpectations(s.T())
+}
+
+func (s *WebhookHandlerSuite) Test_Process_ReturnsDeliveryErrorSoMessageIsRedelivered() {
+	locationBytes, err := json.Marshal(location)
+	s.Require().NoError(err)
+	msg := message.NewMessage(uuid.NewString(), locationBytes)
+
+	s.webhookServiceMock.On("DeliverLocationEvent", mock.Anything, msg.UUID, domain.WebhookEventLocationCreated, location).
+		Return(errors.New("db down")).Once()
+
+	assert.NotNil(s.T(), s.handlers[domain.LocationsNewTopic].Process(msg))
+	s.webhookServiceMock.AssertExpectations(s.T())
+}
+
+func (s *WebhookHandlerSuite) Test_Process_SkipsUnreadablePayload() {
+	assert.Nil(s.T(), s.handlers[domain.LocationsNewTopic].Process(message.NewMessage(uuid.NewString(), []byte("{"))))
+	s.webhookServiceMock.AssertExpectations(s.T())
+}
+
+func (s *WebhookHandlerSuite) Test_Process_ReadsCloudEvent() {
+	msg, err := pubsub.CreateCloudEventMessage(monitor.CreateMockAppContext(""), "key", domain.LocationCreatedEventType,
+		domain.LocationEventSchemaVersion, domain.LocationEventV2{Location: location, Actor: "user"})
+	s.Require().NoError(err)
+	structuredMsg, err := pubsub.ToStructuredCloudEvent(msg)
+	s.Require().NoError(err)
+
+	s.webhookServiceMock.On("DeliverLocationEvent", mock.Anything, msg.UUID, domain.WebhookEventLocationCreated, location).
+		Return(nil).Twice()
+
+	assert.Nil(s.T(), s.handlers[domain.LocationsNewTopic].Process(msg))
+	assert.Nil(s.T(), s.handlers[domain.LocationsNewTopic].Process(structuredMsg))
+	s.webhookServiceMock.AssertExpectations(s.T())
+}
+
+func (s *WebhookHandlerSuite) Test_Process_ReturnsErrorOnNewerSchemaVersion() {
+	msg, err := pubsub.CreateCloudEventMessage(monitor.CreateMockAppContext(""), "key", domain.LocationCreatedEventType,
+		domain.LocationEventSchemaVersion+1, map[string]any{"location": location})
+	s.Require().NoError(err)
+
+	assert.ErrorIs(s.T(), s.handlers[domain.LocationsNewTopic].Process(msg), pubsub.ErrUnsupportedSchemaVersion)
+	s.webhookServiceMock.AssertExpectations(s.T())
+}
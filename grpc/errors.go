@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo details, whose reason is the code of the error in the HTTP problems
+const errorDomain = "go-service-template"
+
+// FieldErr is an invalid field of a request, reported as a field violation
+type FieldErr struct {
+	Field string
+	Err   error
+}
+
+func (e FieldErr) Error() string {
+	return e.Err.Error()
+}
+
+func (e FieldErr) Unwrap() error {
+	return e.Err
+}
+
+// codeFromError maps the domain errors to the gRPC codes, following the meaning of the HTTP statuses they get
+func codeFromError(err error) codes.Code {
+	switch {
+	case errors.As(err, &domain.NotFoundErr{}):
+		return codes.NotFound
+	case errors.As(err, &domain.NameAlreadyInUseErr{}):
+		return codes.AlreadyExists
+	case errors.As(err, &domain.ConflictErr{}), errors.As(err, &domain.IdempotencyKeyInProgressErr{}):
+		return codes.Aborted
+	case errors.As(err, &domain.AddressNotValidErr{}), errors.As(err, &validator.ValidationErrors{}),
+		errors.As(err, &FieldErr{}):
+		return codes.InvalidArgument
+	case errors.As(err, &domain.UnknownReferenceErr{}), errors.As(err, &domain.PreconditionFailedErr{}),
+		errors.As(err, &domain.BusinessErr{}), errors.As(err, &domain.IdempotencyKeyMismatchErr{}):
+		return codes.FailedPrecondition
+	case errors.As(err, &domain.TimeoutErr{}), errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case controllers.IsDependencyFailure(err):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// statusFromError builds the status of a failed call. The code of domain errors, or the one of invalid requests, is
+// sent as the reason of an ErrorInfo and the invalid fields as a BadRequest.
+func statusFromError(err error, correlationID string) error {
+	code := codeFromError(err)
+	st := status.New(code, err.Error())
+
+	var details []protoadapt.MessageV1
+	var codedErr domain.CodedErr
+	reason := ""
+	if errors.As(err, &codedErr) {
+		reason = codedErr.Code()
+	} else if code == codes.InvalidArgument {
+		reason = customHTTP.CodeInvalidRequest
+	}
+
+	if reason != "" {
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   reason,
+			Domain:   errorDomain,
+			Metadata: map[string]string{"correlation_id": correlationID},
+		})
+	}
+
+	if violations := fieldViolationsFromError(err); len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	if len(details) > 0 {
+		if detailed, detailsErr := st.WithDetails(details...); detailsErr == nil {
+			st = detailed
+		}
+	}
+
+	return st.Err()
+}
+
+func fieldViolationsFromError(err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	var fieldErr FieldErr
+	var validationErrs validator.ValidationErrors
+
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, valErr := range validationErrs {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       valErr.Field(),
+				Description: fmt.Sprintf("field '%v' failed on the '%v' rule", valErr.Field(), valErr.Tag()),
+			})
+		}
+	case isJoinedError(err):
+		for _, joinedErr := range err.(interface{ Unwrap() []error }).Unwrap() { //nolint
+			violations = append(violations, fieldViolationsFromError(joinedErr)...)
+		}
+	case errors.As(err, &fieldErr):
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldErr.Field,
+			Description: fieldErr.Err.Error(),
+		})
+	}
+
+	return violations
+}
+
+func isJoinedError(err error) bool {
+	_, ok := err.(interface{ Unwrap() []error }) //nolint
+
+	return ok
+}
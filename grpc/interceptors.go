@@ -0,0 +1,213 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+type contextKey string
+
+const appContextKey contextKey = "appContextKey"
+
+// Metadata keys, the same headers of the HTTP API in lower case
+var (
+	authorizationMetadata = strings.ToLower(middleware.AuthorizationHeader)
+	apiKeyMetadata        = strings.ToLower(middleware.APIKeyHeader)
+	correlationIDMetadata = strings.ToLower(middleware.CorrelationIDHeader)
+)
+
+var ErrMethodNotExposed = errors.New("the method does not declare its required scopes")
+
+// Authenticator authenticates the calls with an API key, sent in the x-api-key metadata, or with a bearer token sent
+// in the authorization one, as the HTTP API does
+type Authenticator struct {
+	tokenAuthenticator  middleware.TokenAuthenticator
+	apiKeyAuthenticator middleware.APIKeyAuthenticator
+	logger              monitor.AppLogger
+}
+
+func NewAuthenticator(
+	tokenAuthenticator middleware.TokenAuthenticator,
+	apiKeyAuthenticator middleware.APIKeyAuthenticator,
+) *Authenticator {
+	return &Authenticator{
+		tokenAuthenticator:  tokenAuthenticator,
+		apiKeyAuthenticator: apiKeyAuthenticator,
+		logger:              monitor.GetStdLogger("GRPCAuthenticator"),
+	}
+}
+
+// GetAppContext returns the AppContext of the call, created by the interceptors
+func GetAppContext(ctx context.Context) *monitor.AppContext {
+	appCtx, ok := ctx.Value(appContextKey).(*monitor.AppContext)
+	if ok {
+		return appCtx
+	}
+
+	return monitor.CreateAppContextFromContext(ctx, "")
+}
+
+func withAppContext(appCtx *monitor.AppContext) context.Context {
+	return context.WithValue(appCtx, appContextKey, appCtx)
+}
+
+// serverStream replaces the context of a stream with the one built by the interceptors
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// createAppContext builds the AppContext of a call with the correlation ID sent by the caller, which is sent back in
+// the response header. The actor is only set once the caller is authenticated.
+func createAppContext(ctx context.Context) (*monitor.AppContext, metadata.MD) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	appCtx := monitor.CreateAppContextFromContext(ctx, firstMetadataValue(md, correlationIDMetadata))
+
+	return appCtx, metadata.Pairs(correlationIDMetadata, appCtx.GetCorrelationID())
+}
+
+func appContextUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	appCtx, header := createAppContext(ctx)
+	_ = grpc.SetHeader(ctx, header)
+
+	return handler(withAppContext(appCtx), req)
+}
+
+func appContextStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	appCtx, header := createAppContext(stream.Context())
+	_ = stream.SetHeader(header)
+
+	return handler(srv, &serverStream{ServerStream: stream, ctx: withAppContext(appCtx)})
+}
+
+// authorize authenticates the caller and checks it has every scope required by the method. The returned context
+// holds the principal of the caller.
+func (a *Authenticator) authorize(ctx context.Context, method string, requiredScopes map[string][]string) (context.Context, error) {
+	fnName := "Authenticator.authorize"
+	appCtx := GetAppContext(ctx)
+
+	scopes, ok := requiredScopes[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, ErrMethodNotExposed.Error())
+	}
+
+	principal, err := a.authenticate(appCtx)
+	if err != nil {
+		a.logger.WarnCtx(appCtx, fnName, "call rejected", monitor.LoggingParam{Name: "error", Value: err.Error()})
+		return nil, err
+	}
+
+	if !principal.HasScopes(scopes...) {
+		return nil, status.Errorf(codes.PermissionDenied, "the scopes %v are required", strings.Join(scopes, ", "))
+	}
+
+	return withAppContext(appCtx.WithPrincipal(principal)), nil
+}
+
+func (a *Authenticator) authenticate(appCtx *monitor.AppContext) (monitor.Principal, error) {
+	md, _ := metadata.FromIncomingContext(appCtx)
+
+	if key := firstMetadataValue(md, apiKeyMetadata); key != "" {
+		apiKey, err := a.apiKeyAuthenticator.Authenticate(appCtx, key)
+		if err != nil {
+			a.logger.ErrorCtx(appCtx, "Authenticator.authenticate", "failed to authenticate API key", err)
+			return monitor.Principal{}, status.Error(codes.Internal, "failed to authenticate API key")
+		}
+		if apiKey == nil {
+			return monitor.Principal{}, status.Error(codes.Unauthenticated, middleware.ErrInvalidAPIKey.Error())
+		}
+
+		return middleware.APIKeyPrincipal(*apiKey), nil
+	}
+
+	principal, err := a.tokenAuthenticator(firstMetadataValue(md, authorizationMetadata))
+	if err != nil {
+		return monitor.Principal{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return principal, nil
+}
+
+func (a *Authenticator) unaryInterceptor(requiredScopes map[string][]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod, requiredScopes)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) streamInterceptor(requiredScopes map[string][]string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(stream.Context(), info.FullMethod, requiredScopes)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// recoverPanic turns a panic of a handler into an internal error, as the Recover middleware of the HTTP server does
+func recoverPanic(ctx context.Context, method string, err *error) {
+	if recovered := recover(); recovered != nil {
+		panicErr := fmt.Errorf("panic in %v: %v", method, recovered)
+		monitor.GetStdLogger("GRPCRecovery").ErrorCtx(GetAppContext(ctx), "recoverPanic", "call panicked", panicErr)
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+func recoveryUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	defer recoverPanic(ctx, info.FullMethod, &err)
+
+	return handler(ctx, req)
+}
+
+func recoveryStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer recoverPanic(stream.Context(), info.FullMethod, &err)
+
+	return handler(srv, stream)
+}
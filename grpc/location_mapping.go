@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"errors"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/grpc/locationspb"
+	"go-service-template/http/controllers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("the limit cannot be negative")
+
+func locationToProto(location domain.Location) *locationspb.Location {
+	var deletedAt *timestamppb.Timestamp
+	if location.DeletedAt != nil {
+		deletedAt = timestamppb.New(*location.DeletedAt)
+	}
+
+	return &locationspb.Location{
+		Id:   location.ID,
+		Name: location.Name,
+		Information: &locationspb.LocationInformation{
+			Address:   location.Information.Address,
+			City:      location.Information.City,
+			State:     location.Information.State,
+			Zipcode:   location.Information.Zipcode,
+			Latitude:  location.Information.Latitude,
+			Longitude: location.Information.Longitude,
+			ContactInformation: &locationspb.ContactInformation{
+				ContactPerson: location.Information.ContactInformation.ContactPerson,
+				PhoneNumber:   location.Information.ContactInformation.PhoneNumber,
+				Email:         location.Information.ContactInformation.Email,
+			},
+		},
+		LocationType: &locationspb.LocationType{
+			Id:   int32(location.LocationType.ID),
+			Type: location.LocationType.Type,
+		},
+		Supplier: &locationspb.Supplier{
+			Id:   int32(location.Supplier.ID),
+			Name: location.Supplier.Name,
+		},
+		Active:    location.Active,
+		DeletedAt: deletedAt,
+		Version:   int32(location.Version),
+		CreatedAt: timestamppb.New(location.CreatedAt),
+	}
+}
+
+func createLocationRequestFromProto(request *locationspb.CreateLocationRequest) dto.CreateLocationRequest {
+	return dto.CreateLocationRequest{
+		SupplierID:     int(request.GetSupplierId()),
+		Name:           request.GetName(),
+		Address:        request.GetAddress(),
+		City:           request.GetCity(),
+		State:          request.GetState(),
+		Zipcode:        request.GetZipcode(),
+		LocationTypeID: int(request.GetLocationTypeId()),
+		ContactPerson:  request.ContactPerson,
+		PhoneNumber:    request.PhoneNumber,
+		Email:          request.Email,
+	}
+}
+
+func updateLocationRequestFromProto(request *locationspb.UpdateLocationRequest) dto.UpdateLocationRequest {
+	return dto.UpdateLocationRequest{
+		ID:             request.GetId(),
+		SupplierID:     int(request.GetSupplierId()),
+		Name:           request.GetName(),
+		Address:        request.GetAddress(),
+		City:           request.GetCity(),
+		State:          request.GetState(),
+		Zipcode:        request.GetZipcode(),
+		LocationTypeID: int(request.GetLocationTypeId()),
+		ContactPerson:  request.ContactPerson,
+		PhoneNumber:    request.PhoneNumber,
+		Email:          request.Email,
+		Active:         request.GetActive(),
+	}
+}
+
+// locationFiltersFromProto builds the filters of a location search, every invalid field is reported as a FieldErr
+func locationFiltersFromProto(filters *locationspb.LocationFilters) (domain.LocationsFilters, error) {
+	var errs []error
+
+	// The filters can be omitted, the optional fields are read directly so nil is replaced with an empty message
+	if filters == nil {
+		filters = &locationspb.LocationFilters{}
+	}
+
+	locationFilters := domain.LocationsFilters{
+		Name:            filters.Name,
+		City:            filters.City,
+		State:           filters.State,
+		Zipcode:         filters.Zipcode,
+		LocationTypeIDs: intsFromProto(filters.GetLocationTypeIds()),
+		SupplierIDs:     intsFromProto(filters.GetSupplierIds()),
+		Active:          filters.Active,
+		IncludeDeleted:  filters.GetIncludeDeleted(),
+	}
+
+	var err error
+	if locationFilters.CreatedFrom, locationFilters.CreatedTo, err = dateRangeFromProto(
+		filters.GetCreatedFrom(), filters.GetCreatedTo(), "filters.created_to",
+	); err != nil {
+		errs = append(errs, err)
+	}
+
+	if locationFilters.UpdatedFrom, locationFilters.UpdatedTo, err = dateRangeFromProto(
+		filters.GetUpdatedFrom(), filters.GetUpdatedTo(), "filters.updated_to",
+	); err != nil {
+		errs = append(errs, err)
+	}
+
+	locationFilters.Sort = domain.DefaultLocationSortOrder
+	if filters.GetSort() != "" {
+		if locationFilters.Sort, err = domain.ParseSortOrder(filters.GetSort(), domain.LocationSortFields); err != nil {
+			errs = append(errs, FieldErr{Field: "filters.sort", Err: err})
+		}
+	}
+
+	return locationFilters, errors.Join(errs...)
+}
+
+// cursorPaginationFiltersFromProto builds the pagination of a list, as the HTTP API the first page can only be read
+// moving to the next one
+func cursorPaginationFiltersFromProto(request *locationspb.ListLocationsRequest) (domain.CursorPaginationFilters, error) {
+	pagination := domain.CursorPaginationFilters{
+		Cursor:    request.GetCursor(),
+		Direction: domain.NextPage,
+		Limit:     int(request.GetLimit()),
+	}
+
+	if request.GetDirection() == locationspb.PageDirection_PAGE_DIRECTION_PREVIOUS {
+		pagination.Direction = domain.PreviousPage
+	}
+
+	var errs []error
+	switch {
+	case pagination.Limit < 0:
+		errs = append(errs, FieldErr{Field: "limit", Err: ErrInvalidLimit})
+	case pagination.Limit == 0:
+		pagination.Limit = controllers.DefaultLimit
+	}
+
+	if pagination.Cursor == "" && pagination.Direction != domain.NextPage {
+		errs = append(errs, FieldErr{Field: "direction", Err: controllers.ErrInitialCursorDirection})
+	}
+
+	return pagination, errors.Join(errs...)
+}
+
+func dateRangeFromProto(from, to *timestamppb.Timestamp, toField string) (*time.Time, *time.Time, error) {
+	var fromTime, toTime *time.Time
+	if from != nil {
+		value := from.AsTime()
+		fromTime = &value
+	}
+	if to != nil {
+		value := to.AsTime()
+		toTime = &value
+	}
+
+	if fromTime != nil && toTime != nil && !toTime.After(*fromTime) {
+		return nil, nil, FieldErr{Field: toField, Err: controllers.ErrInvalidDateRange}
+	}
+
+	return fromTime, toTime, nil
+}
+
+func intsFromProto(values []int32) []int {
+	if len(values) == 0 {
+		return nil
+	}
+
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		ints = append(ints, int(value))
+	}
+
+	return ints
+}
@@ -0,0 +1,191 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"go-service-template/domain"
+	"go-service-template/grpc/locationspb"
+	"go-service-template/http/controllers"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	ErrMissingLocationID      = errors.New("the location ID is required")
+	ErrMissingExpectedVersion = errors.New("the expected version of the location is required")
+)
+
+// LocationServer serves the location operations of the HTTP API over gRPC
+type LocationServer struct {
+	locationspb.UnimplementedLocationServiceServer
+	logger          monitor.AppLogger
+	locationService services.ILocationService
+	validator       *validator.Validate
+}
+
+func NewLocationServer(locationService services.ILocationService, validator *validator.Validate) *LocationServer {
+	return &LocationServer{
+		locationService: locationService,
+		logger:          monitor.GetStdLogger("LocationServer"),
+		validator:       validator,
+	}
+}
+
+// Service returns the gRPC service of the server, the read methods require the read scope and the rest the write one
+func (sv *LocationServer) Service() Service {
+	return Service{
+		Desc: &locationspb.LocationService_ServiceDesc,
+		Impl: sv,
+		RequiredScopes: map[string][]string{
+			locationspb.LocationService_CreateLocation_FullMethodName:  {controllers.ScopeLocationsWrite},
+			locationspb.LocationService_UpdateLocation_FullMethodName:  {controllers.ScopeLocationsWrite},
+			locationspb.LocationService_GetLocation_FullMethodName:     {controllers.ScopeLocationsRead},
+			locationspb.LocationService_ListLocations_FullMethodName:   {controllers.ScopeLocationsRead},
+			locationspb.LocationService_StreamLocations_FullMethodName: {controllers.ScopeLocationsRead},
+		},
+	}
+}
+
+func (sv *LocationServer) CreateLocation(ctx context.Context, request *locationspb.CreateLocationRequest) (*locationspb.Location, error) {
+	fnName := "LocationServer.CreateLocation"
+	var appCtx monitor.ApplicationContext = GetAppContext(ctx)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	createLocationRequest := createLocationRequestFromProto(request)
+	if err := sv.validator.Struct(createLocationRequest); err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "failed to validate request", err)
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	location, err := sv.locationService.CreateLocation(appCtx, createLocationRequest)
+	if err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "failed to create location", err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	return locationToProto(location), nil
+}
+
+func (sv *LocationServer) UpdateLocation(ctx context.Context, request *locationspb.UpdateLocationRequest) (*locationspb.Location, error) {
+	fnName := "LocationServer.UpdateLocation"
+	var appCtx monitor.ApplicationContext = GetAppContext(ctx)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	updateLocationRequest := updateLocationRequestFromProto(request)
+	err := sv.validator.Struct(updateLocationRequest)
+	if request.GetExpectedVersion() <= 0 {
+		err = errors.Join(err, FieldErr{Field: "expected_version", Err: ErrMissingExpectedVersion})
+	}
+	if err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "failed to validate request", err)
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	location, err := sv.locationService.UpdateLocation(appCtx, updateLocationRequest, int(request.GetExpectedVersion()))
+	if err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "failed to update location", err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	return locationToProto(location), nil
+}
+
+func (sv *LocationServer) GetLocation(ctx context.Context, request *locationspb.GetLocationRequest) (*locationspb.Location, error) {
+	fnName := "LocationServer.GetLocation"
+	var appCtx monitor.ApplicationContext = GetAppContext(ctx)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	if request.GetId() == "" {
+		err := FieldErr{Field: "id", Err: ErrMissingLocationID}
+		sv.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	location, err := sv.locationService.GetLocationByID(appCtx, request.GetId(), request.GetIncludeDeleted())
+	if err == nil && location == nil {
+		err = domain.NotFoundErr{Msg: "location not found", Resource: domain.ResourceLocation}
+	}
+	if err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "failed to retrieve location", err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	return locationToProto(*location), nil
+}
+
+func (sv *LocationServer) ListLocations(ctx context.Context, request *locationspb.ListLocationsRequest) (*locationspb.ListLocationsResponse, error) {
+	fnName := "LocationServer.ListLocations"
+	var appCtx monitor.ApplicationContext = GetAppContext(ctx)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	filters, filtersErr := locationFiltersFromProto(request.GetFilters())
+	pagination, paginationErr := cursorPaginationFiltersFromProto(request)
+	if err := errors.Join(filtersErr, paginationErr); err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "error building location filters", err)
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	pagination.Sort = filters.Sort
+	filters.CursorPaginationFilters = pagination
+
+	locationPage, err := sv.locationService.GetPaginatedLocations(appCtx, filters)
+	if err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "failed to get paginated locations", err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	response := &locationspb.ListLocationsResponse{
+		Locations:    make([]*locationspb.Location, 0, len(locationPage.Data)),
+		Limit:        int32(locationPage.Limit),
+		NextPage:     locationPage.NextPage,
+		PreviousPage: locationPage.PreviousPage,
+	}
+	for _, location := range locationPage.Data {
+		response.Locations = append(response.Locations, locationToProto(location))
+	}
+
+	return response, nil
+}
+
+func (sv *LocationServer) StreamLocations(
+	request *locationspb.StreamLocationsRequest,
+	stream locationspb.LocationService_StreamLocationsServer,
+) error {
+	fnName := "LocationServer.StreamLocations"
+	var appCtx monitor.ApplicationContext = GetAppContext(stream.Context())
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	filters, err := locationFiltersFromProto(request.GetFilters())
+	if err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "error building location filters", err)
+		return statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	// Locations are sent as they are read, a failure after the first one ends the stream with its status
+	err = sv.locationService.ExportLocations(appCtx, filters, func(location domain.Location) error {
+		return stream.Send(locationToProto(location))
+	})
+	if err != nil {
+		sv.logger.ErrorCtx(appCtx, fnName, "failed to stream locations", err)
+		span.SetStatus(codes.Error, err.Error())
+		return statusFromError(err, appCtx.GetCorrelationID())
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package grpc_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	customGRPC "go-service-template/grpc"
+	"go-service-template/grpc/locationspb"
+	"go-service-template/http/controllers"
+	"go-service-template/mocks"
+	"go-service-template/monitor"
+	"go-service-template/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testToken         = "Bearer valid-token"
+	testReadOnlyToken = "Bearer read-only-token"
+	testAPIKey        = "prefix.secret"
+)
+
+var testLocation = domain.Location{
+	ID:           "5b0cbb42-3f57-4f3e-9f0c-8d3c6f1a1e0b",
+	Name:         "Warehouse",
+	Information:  domain.LocationInformation{Address: "Street 1", City: "City", State: "ST", Zipcode: "12345"},
+	LocationType: domain.LocationType{ID: 1, Type: "warehouse"},
+	Supplier:     domain.Supplier{ID: 7, Name: "Supplier"},
+	Active:       true,
+	Version:      2,
+	CreatedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+}
+
+// testTokenAuthenticator accepts two fixed tokens instead of signed JWTs
+func testTokenAuthenticator(authorization string) (monitor.Principal, error) {
+	switch authorization {
+	case testToken:
+		return monitor.Principal{
+			Subject: "jane.doe",
+			Scopes:  []string{controllers.ScopeLocationsRead, controllers.ScopeLocationsWrite},
+		}, nil
+	case testReadOnlyToken:
+		return monitor.Principal{Subject: "john.doe", Scopes: []string{controllers.ScopeLocationsRead}}, nil
+	default:
+		return monitor.Principal{}, errors.New("invalid token")
+	}
+}
+
+type LocationServerSuite struct {
+	suite.Suite
+	locationServiceMock *mocks.ILocationService
+	apiKeyServiceMock   *mocks.IAPIKeyService
+	server              *grpc.Server
+	conn                *grpc.ClientConn
+	client              locationspb.LocationServiceClient
+}
+
+func (s *LocationServerSuite) SetupSuite() {
+	s.locationServiceMock = new(mocks.ILocationService)
+	s.apiKeyServiceMock = new(mocks.IAPIKeyService)
+
+	s.server = customGRPC.CreateGRPCServer(
+		customGRPC.NewAuthenticator(testTokenAuthenticator, s.apiKeyServiceMock),
+		[]customGRPC.Service{
+			customGRPC.NewLocationServer(s.locationServiceMock, controllers.NewValidator()).Service(),
+		},
+	)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+
+	s.conn = conn
+	s.client = locationspb.NewLocationServiceClient(conn)
+}
+
+func (s *LocationServerSuite) TearDownSuite() {
+	_ = s.conn.Close()
+	s.server.Stop()
+}
+
+func (s *LocationServerSuite) SetupTest() {
+	s.locationServiceMock.ExpectedCalls = nil
+	s.apiKeyServiceMock.ExpectedCalls = nil
+}
+
+func (s *LocationServerSuite) assertMockExpectations() {
+	s.locationServiceMock.AssertExpectations(s.T())
+	s.apiKeyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *LocationServerSuite) withMetadata(kv ...string) context.Context {
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+func TestLocationServerSuite(t *testing.T) {
+	suite.Run(t, new(LocationServerSuite))
+}
+
+func (s *LocationServerSuite) Test_GetLocation_ReturnsLocationAndCorrelationID() {
+	s.locationServiceMock.On("GetLocationByID", mock.MatchedBy(func(ctx monitor.ApplicationContext) bool {
+		principal, ok := ctx.GetPrincipal()
+		return ok && principal.Subject == "jane.doe" && ctx.GetCorrelationID() == "correlation"
+	}), testLocation.ID, false).Return(&testLocation, nil).Once()
+
+	var header metadata.MD
+	location, err := s.client.GetLocation(
+		s.withMetadata("authorization", testToken, "correlation-id", "correlation"),
+		&locationspb.GetLocationRequest{Id: testLocation.ID},
+		grpc.Header(&header),
+	)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), testLocation.ID, location.GetId())
+	assert.Equal(s.T(), int32(7), location.GetSupplier().GetId())
+	assert.Equal(s.T(), testLocation.CreatedAt, location.GetCreatedAt().AsTime())
+	assert.Nil(s.T(), location.GetDeletedAt())
+	assert.Equal(s.T(), []string{"correlation"}, header.Get("correlation-id"))
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_GetLocation_ReturnsNotFoundWithCodeOfError() {
+	s.locationServiceMock.On("GetLocationByID", mock.Anything, testLocation.ID, false).Return(nil, nil).Once()
+
+	_, err := s.client.GetLocation(s.withMetadata("authorization", testToken), &locationspb.GetLocationRequest{Id: testLocation.ID})
+
+	st := status.Convert(err)
+	assert.Equal(s.T(), codes.NotFound, st.Code())
+	s.Require().Len(st.Details(), 1)
+	assert.Equal(s.T(), "location.not_found", st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_GetLocation_RejectsUnauthenticatedCalls() {
+	_, err := s.client.GetLocation(context.Background(), &locationspb.GetLocationRequest{Id: testLocation.ID})
+
+	assert.Equal(s.T(), codes.Unauthenticated, status.Code(err))
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_CreateLocation_RejectsCallsWithoutWriteScope() {
+	_, err := s.client.CreateLocation(s.withMetadata("authorization", testReadOnlyToken), &locationspb.CreateLocationRequest{})
+
+	assert.Equal(s.T(), codes.PermissionDenied, status.Code(err))
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_CreateLocation_ReturnsFieldViolationsOnInvalidRequest() {
+	_, err := s.client.CreateLocation(s.withMetadata("authorization", testToken), &locationspb.CreateLocationRequest{
+		SupplierId:     7,
+		LocationTypeId: 1,
+		Address:        "Street 1",
+		City:           "City",
+		State:          "ST",
+	})
+
+	st := status.Convert(err)
+	assert.Equal(s.T(), codes.InvalidArgument, st.Code())
+
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields = append(fields, violation.GetField())
+			}
+		}
+	}
+	assert.ElementsMatch(s.T(), []string{"name", "zipcode"}, fields)
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_CreateLocation_ActsOnSupplierOfAPIKey() {
+	s.apiKeyServiceMock.On("Authenticate", mock.Anything, testAPIKey).Return(&domain.APIKey{
+		ID:         "key",
+		Scopes:     []string{controllers.ScopeLocationsWrite},
+		SupplierID: utils.ToPointer(7),
+	}, nil).Once()
+	s.locationServiceMock.On("CreateLocation", mock.MatchedBy(func(ctx monitor.ApplicationContext) bool {
+		supplierID, ok := ctx.GetTenantSupplierID()
+		return ok && supplierID == 7
+	}), mock.Anything).Return(testLocation, nil).Once()
+
+	location, err := s.client.CreateLocation(s.withMetadata("x-api-key", testAPIKey), &locationspb.CreateLocationRequest{
+		SupplierId:     7,
+		Name:           testLocation.Name,
+		Address:        "Street 1",
+		City:           "City",
+		State:          "ST",
+		Zipcode:        "12345",
+		LocationTypeId: 1,
+	})
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), testLocation.ID, location.GetId())
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_UpdateLocation_ReturnsFailedPreconditionOnVersionMismatch() {
+	s.locationServiceMock.On("UpdateLocation", mock.Anything, mock.Anything, 1).
+		Return(domain.Location{}, domain.PreconditionFailedErr{Msg: "stale", Resource: domain.ResourceLocation}).Once()
+
+	_, err := s.client.UpdateLocation(s.withMetadata("authorization", testToken), &locationspb.UpdateLocationRequest{
+		Id:              testLocation.ID,
+		SupplierId:      7,
+		Name:            testLocation.Name,
+		Address:         "Street 1",
+		City:            "City",
+		State:           "ST",
+		Zipcode:         "12345",
+		LocationTypeId:  1,
+		ExpectedVersion: 1,
+	})
+
+	assert.Equal(s.T(), codes.FailedPrecondition, status.Code(err))
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_ListLocations_ReturnsUnavailableWhenDatabaseIsUnreachable() {
+	s.locationServiceMock.On("GetPaginatedLocations", mock.Anything, mock.MatchedBy(func(filters domain.LocationsFilters) bool {
+		return filters.Limit == controllers.DefaultLimit && filters.Direction == domain.NextPage
+	})).Return(domain.CursorPage[domain.Location]{}, driver.ErrBadConn).Once()
+
+	_, err := s.client.ListLocations(s.withMetadata("authorization", testToken), &locationspb.ListLocationsRequest{})
+
+	assert.Equal(s.T(), codes.Unavailable, status.Code(err))
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_ListLocations_RejectsPreviousPageWithoutCursor() {
+	_, err := s.client.ListLocations(s.withMetadata("authorization", testToken), &locationspb.ListLocationsRequest{
+		Direction: locationspb.PageDirection_PAGE_DIRECTION_PREVIOUS,
+	})
+
+	assert.Equal(s.T(), codes.InvalidArgument, status.Code(err))
+	s.assertMockExpectations()
+}
+
+func (s *LocationServerSuite) Test_StreamLocations_SendsEveryLocation() {
+	s.locationServiceMock.On("ExportLocations", mock.Anything, mock.MatchedBy(func(filters domain.LocationsFilters) bool {
+		return filters.City != nil && *filters.City == "City"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		send := args.Get(2).(func(location domain.Location) error)
+		for _, id := range []string{"1", "2", "3"} {
+			location := testLocation
+			location.ID = id
+			if err := send(location); err != nil {
+				return
+			}
+		}
+	}).Return(nil).Once()
+
+	stream, err := s.client.StreamLocations(s.withMetadata("authorization", testToken), &locationspb.StreamLocationsRequest{
+		Filters: &locationspb.LocationFilters{City: utils.ToPointer("City")},
+	})
+	s.Require().NoError(err)
+
+	var ids []string
+	for {
+		location, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		s.Require().NoError(recvErr)
+		ids = append(ids, location.GetId())
+	}
+
+	assert.Equal(s.T(), "1,2,3", strings.Join(ids, ","))
+	s.assertMockExpectations()
+}
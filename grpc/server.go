@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"net"
+)
+
+// Service is a gRPC service implementation along with the scopes required by each of its methods, by full method
+// name. Methods without required scopes are rejected.
+type Service struct {
+	Desc           *grpc.ServiceDesc
+	Impl           any
+	RequiredScopes map[string][]string
+}
+
+// CreateGRPCServer creates the gRPC server of the services. Every call is traced, gets an AppContext with the
+// correlation ID of the caller and is authenticated before the scopes of the method are checked.
+func CreateGRPCServer(authenticator *Authenticator, services []Service) *grpc.Server {
+	requiredScopes := make(map[string][]string)
+	for _, service := range services {
+		for method, scopes := range service.RequiredScopes {
+			requiredScopes[method] = scopes
+		}
+	}
+
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// Interceptors are run in the slice order
+		grpc.ChainUnaryInterceptor(
+			recoveryUnaryInterceptor,
+			appContextUnaryInterceptor,
+			authenticator.unaryInterceptor(requiredScopes),
+		),
+		grpc.ChainStreamInterceptor(
+			recoveryStreamInterceptor,
+			appContextStreamInterceptor,
+			authenticator.streamInterceptor(requiredScopes),
+		),
+	)
+
+	for _, service := range services {
+		server.RegisterService(service.Desc, service.Impl)
+	}
+
+	return server
+}
+
+// ListenAndServe serves the gRPC requests on the address until the server is stopped
+func ListenAndServe(server *grpc.Server, address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %v: %w", address, err)
+	}
+
+	if err = server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting calls and waits for the running ones, which are cancelled once the context is done
+func Shutdown(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
@@ -13,6 +13,9 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
 	"time"
 )
 
@@ -26,9 +29,16 @@ const (
 	DefaultDialerTimeoutSec       = 30
 	DefaultDialerKeepAliveSec     = 30
 	DefaultTLSHandshakeSec        = 10
+	DefaultMaxResponseBytes       = 64 * 1024
 )
 
-var ErrRetryAmountExceeded = errors.New("failed to execute request, retry amount exceeded")
+var (
+	ErrRetryAmountExceeded = errors.New("failed to execute request, retry amount exceeded")
+	ErrNonPublicAddress    = errors.New("the target address is not a public address")
+)
+
+// sharedAddressSpace is the range of the carrier-grade NAT addresses, which are not reachable from the internet
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
 
 type CustomHTTPClient interface {
 	Do(ctx monitor.ApplicationContext, requestValues RequestValues) (CustomHTTPResponse, error)
@@ -56,8 +66,9 @@ type BasicAuth struct {
 }
 
 type CustomClient struct {
-	baseClient *http.Client
-	logger     monitor.AppLogger
+	baseClient       *http.Client
+	logger           monitor.AppLogger
+	maxResponseBytes int64
 }
 
 type CustomHTTPResponse struct {
@@ -76,7 +87,64 @@ func CreateCustomHTTPClient(cfg config.HTTPClientConfig) *CustomClient {
 	}
 }
 
+// CreateExternalHTTPClient returns a client for the URLs given by the users of the service, such as the webhook
+// endpoints. Unless local targets are allowed, it only connects to public addresses, checked on the address resolved
+// when dialing so a DNS record cannot point it to an internal one later. Redirects are not followed, the redirect
+// response is returned instead, and only the first maxResponseBytes of the response body are read.
+func CreateExternalHTTPClient(cfg config.HTTPClientConfig, allowLocalTargets bool, maxResponseBytes int) *CustomClient {
+	dialer := &net.Dialer{
+		Timeout:   DefaultDialerTimeoutSec * time.Second,
+		KeepAlive: DefaultDialerKeepAliveSec * time.Second,
+	}
+	proxy := http.ProxyFromEnvironment
+	if !allowLocalTargets {
+		dialer.Control = rejectNonPublicAddress
+		// The proxy would connect to the target instead, bypassing the check of the address
+		proxy = nil
+	}
+
+	baseHTTPClient := buildClientWithDialer(cfg, dialer, proxy)
+	baseHTTPClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &CustomClient{
+		baseClient:       baseHTTPClient,
+		logger:           monitor.GetStdLogger("ExternalHTTPClient"),
+		maxResponseBytes: int64(config.GetIntValueOrDefault(maxResponseBytes, DefaultMaxResponseBytes)),
+	}
+}
+
+// IsPublicAddress reports whether the address can be reached from the internet, loopback, private, link-local,
+// multicast and unspecified addresses are not
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() && addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// rejectNonPublicAddress is the Control hook of the dialer, it runs with the resolved address of every connection
+func rejectNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNonPublicAddress, address)
+	}
+
+	if !IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %v", ErrNonPublicAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
 func buildClient(cfg config.HTTPClientConfig) *http.Client {
+	return buildClientWithDialer(cfg, &net.Dialer{
+		Timeout:   DefaultDialerTimeoutSec * time.Second,
+		KeepAlive: DefaultDialerKeepAliveSec * time.Second,
+	}, http.ProxyFromEnvironment)
+}
+
+func buildClientWithDialer(cfg config.HTTPClientConfig, dialer *net.Dialer, proxy func(*http.Request) (*url.URL, error)) *http.Client {
 	var maxIdleConns = config.GetIntValueOrDefault(cfg.MaxIdleConns, DefaultMaxIdleConns)
 	var maxConnsPerHost = config.GetIntValueOrDefault(cfg.MaxConnsPerHost, DefaultMaxConnsPerHost)
 	var maxIdleConnsPerHost = config.GetIntValueOrDefault(cfg.MaxIdleConnsPerHost, DefaultMaxIdleConnsPerHost)
@@ -84,11 +152,8 @@ func buildClient(cfg config.HTTPClientConfig) *http.Client {
 	var requestTimeoutSeconds = config.GetIntValueOrDefault(cfg.RequestTimeoutSeconds, DefaultRequestTimeoutSeconds)
 
 	transport := http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   DefaultDialerTimeoutSec * time.Second,
-			KeepAlive: DefaultDialerKeepAliveSec * time.Second,
-		}).DialContext,
+		Proxy:               proxy,
+		DialContext:         dialer.DialContext,
 		TLSHandshakeTimeout: DefaultTLSHandshakeSec * time.Second,
 		MaxIdleConns:        maxIdleConns,
 		MaxConnsPerHost:     maxConnsPerHost,
@@ -191,7 +256,12 @@ func (cli *CustomClient) handleResponse(
 		}
 	}()
 
-	body, err := io.ReadAll(response.Body)
+	var bodyReader io.Reader = response.Body
+	if cli.maxResponseBytes > 0 {
+		bodyReader = io.LimitReader(response.Body, cli.maxResponseBytes)
+	}
+
+	body, err := io.ReadAll(bodyReader)
 	if err != nil {
 		cli.logger.ErrorCtx(ctx, functionName, "failed to read response body", err)
 		return CustomHTTPResponse{}, err
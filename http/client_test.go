@@ -10,6 +10,8 @@ import (
 	"go-service-template/monitor"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"strings"
 	"testing"
 	"time"
 )
@@ -121,6 +123,13 @@ func (s *CustomHTTPClientSuite) configureTestHTTPServer() {
 
 			w.WriteHeader(200)
 		})
+		testMux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/resource", http.StatusFound)
+		})
+		testMux.HandleFunc("/large-response", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(200)
+			_, _ = w.Write([]byte(strings.Repeat("a", 1000)))
+		})
 		testServer := httptest.NewServer(testMux)
 		s.testHTTPServer = testServer
 		done <- true
@@ -338,3 +347,50 @@ func (s *CustomHTTPClientSuite) Test_DoWithRetry_ExecutesPostRequestWith2Retries
 	assert.Equal(s.T(), 200, resp.StatusCode)
 	assert.Equal(s.T(), 2, timesExecuted)
 }
+
+func (s *CustomHTTPClientSuite) Test_ExternalClient_RejectsLoopbackTarget() {
+	client := customHTTP.CreateExternalHTTPClient(config.HTTPClientConfig{}, false, 0)
+
+	_, err := client.Do(mockCtx, customHTTP.RequestValues{URL: s.testHTTPServer.URL + "/resource", Method: http.MethodGet})
+
+	assert.ErrorIs(s.T(), err, customHTTP.ErrNonPublicAddress)
+}
+
+func (s *CustomHTTPClientSuite) Test_ExternalClient_DoesNotFollowRedirects() {
+	client := customHTTP.CreateExternalHTTPClient(config.HTTPClientConfig{}, true, 0)
+
+	resp, err := client.Do(mockCtx, customHTTP.RequestValues{URL: s.testHTTPServer.URL + "/redirect", Method: http.MethodGet})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusFound, resp.StatusCode)
+}
+
+func (s *CustomHTTPClientSuite) Test_ExternalClient_LimitsResponseBody() {
+	client := customHTTP.CreateExternalHTTPClient(config.HTTPClientConfig{}, true, 10)
+
+	resp, err := client.Do(mockCtx, customHTTP.RequestValues{URL: s.testHTTPServer.URL + "/large-response", Method: http.MethodGet})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "aaaaaaaaaa", string(resp.BodyPayload))
+}
+
+func (s *CustomHTTPClientSuite) Test_IsPublicAddress() {
+	for address, public := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::ffff:127.0.0.1": false,
+		"224.0.0.1":        false,
+	} {
+		assert.Equal(s.T(), public, customHTTP.IsPublicAddress(netip.MustParseAddr(address)), address)
+	}
+}
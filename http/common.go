@@ -7,9 +7,27 @@ import (
 type Middleware = echo.MiddlewareFunc
 type Handler = echo.HandlerFunc
 
+// Authorizer builds the middleware that lets a request through only when its caller was granted the scopes
+type Authorizer func(requiredScopes []string) Middleware
+
+// Endpoint is a route of the web server. Callers must be granted every one of the RequiredScopes, which the web
+// server checks before the endpoint middlewares.
 type Endpoint struct {
-	Method      string
-	Path        string
-	Handler     Handler
-	Middlewares []Middleware
+	Method         string
+	Path           string
+	Handler        Handler
+	Middlewares    []Middleware
+	RequiredScopes []string
+}
+
+// APIResponse is the envelope of the successful responses, failed requests are answered with a Problem
+type APIResponse struct {
+	Data any `json:"data,omitempty"`
+}
+
+// With returns a copy of the endpoint that also runs the given middlewares
+func (e Endpoint) With(middlewares ...Middleware) Endpoint {
+	e.Middlewares = append(append([]Middleware{}, e.Middlewares...), middlewares...)
+
+	return e
 }
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"net/http"
+)
+
+var ErrInvalidAPIKeyID = errors.New("invalid 'apiKeyID' path param, it must be a UUID")
+
+type APIKeyController struct {
+	logger        monitor.AppLogger
+	apiKeyService services.IAPIKeyService
+	validator     *validator.Validate
+}
+
+func NewAPIKeyController(apiKeyService services.IAPIKeyService, validator *validator.Validate) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: apiKeyService,
+		logger:        monitor.GetStdLogger("APIKeyController"),
+		validator:     validator,
+	}
+}
+
+// Nada godoc
+// @Summary List API keys
+// @Description Get all the API keys, including the revoked ones. Keys are never returned, only their prefix. Callers restricted to a supplier only get the keys of their supplier
+// @Produce json
+// @Success 200 {object} []domain.APIKey
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/api-keys [get]
+func (ct *APIKeyController) APIKeysEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/api-keys",
+		Handler:        ct.getAPIKeys,
+		RequiredScopes: []string{ScopeAPIKeysAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Create API key
+// @Description Create an API key for a machine client, sent in the X-API-Key header. The key is only returned in this response. A key restricted to a supplier can only act on the locations of that supplier. The key can only be granted scopes the caller has, and the keys created by a caller restricted to a supplier are restricted to the same supplier
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "API key attributes"
+// @Success 200 {object} domain.IssuedAPIKey
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/api-keys [post]
+func (ct *APIKeyController) CreateAPIKeyEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/api-keys",
+		Handler:        ct.createAPIKey,
+		RequiredScopes: []string{ScopeAPIKeysAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Rotate API key
+// @Description Replace an active API key with a new one keeping its name, scopes and supplier. The new key is only returned in this response, the previous one stops working
+// @Produce json
+// @Param apiKeyID path string true "API key ID"
+// @Success 200 {object} domain.IssuedAPIKey
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/api-keys/{apiKeyID}/rotate [post]
+func (ct *APIKeyController) RotateAPIKeyEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/api-keys/:apiKeyID/rotate",
+		Handler:        ct.rotateAPIKey,
+		RequiredScopes: []string{ScopeAPIKeysAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Revoke API key
+// @Description Revoke an active API key, revoked keys are still listed
+// @Param apiKeyID path string true "API key ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/api-keys/{apiKeyID} [delete]
+func (ct *APIKeyController) RevokeAPIKeyEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodDelete,
+		Path:           "/v1/api-keys/:apiKeyID",
+		Handler:        ct.revokeAPIKey,
+		RequiredScopes: []string{ScopeAPIKeysAdmin},
+	}
+}
+
+func (ct *APIKeyController) getAPIKeys(c echo.Context) error {
+	fnName := "APIKeyController.getAPIKeys"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	apiKeys, err := ct.apiKeyService.GetAPIKeys(appCtx)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get API keys", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get API keys", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(apiKeys))
+}
+
+func (ct *APIKeyController) createAPIKey(c echo.Context) error {
+	fnName := "APIKeyController.createAPIKey"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	request, err := parseAndValidateBody[dto.CreateAPIKeyRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	apiKey, err := ct.apiKeyService.CreateAPIKey(appCtx, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to create API key", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create API key", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(apiKey))
+}
+
+func (ct *APIKeyController) rotateAPIKey(c echo.Context) error {
+	fnName := "APIKeyController.rotateAPIKey"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getAPIKeyIDPathParam(c)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	apiKey, err := ct.apiKeyService.RotateAPIKey(appCtx, id)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to rotate API key", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to rotate API key", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(apiKey))
+}
+
+func (ct *APIKeyController) revokeAPIKey(c echo.Context) error {
+	fnName := "APIKeyController.revokeAPIKey"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getAPIKeyIDPathParam(c)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err = ct.apiKeyService.RevokeAPIKey(appCtx, id); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to revoke API key", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to revoke API key", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getAPIKeyIDPathParam(c echo.Context) (string, error) {
+	id, err := uuid.Parse(c.Param("apiKeyID"))
+	if err != nil {
+		return "", ErrInvalidAPIKeyID
+	}
+
+	return id.String(), nil
+}
@@ -0,0 +1,126 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testAPIKeyID = "5b0cbb42-3f57-4f3e-9f0c-8d3c6f1a1e0b"
+
+type APIKeyControllerSuite struct {
+	suite.Suite
+	apiKeyServiceMock *mocks.IAPIKeyService
+	createAPIKeyEP    customHTTP.Endpoint
+	rotateAPIKeyEP    customHTTP.Endpoint
+	revokeAPIKeyEP    customHTTP.Endpoint
+	echoRouter        *echo.Echo
+	recorder          *httptest.ResponseRecorder
+}
+
+func (s *APIKeyControllerSuite) SetupSuite() {
+	apiKeyServiceMock := new(mocks.IAPIKeyService)
+	controller := controllers.NewAPIKeyController(apiKeyServiceMock, validator.New())
+
+	s.createAPIKeyEP = controller.CreateAPIKeyEndpoint()
+	s.rotateAPIKeyEP = controller.RotateAPIKeyEndpoint()
+	s.revokeAPIKeyEP = controller.RevokeAPIKeyEndpoint()
+	s.apiKeyServiceMock = apiKeyServiceMock
+
+	s.echoRouter = echo.New()
+}
+
+func (s *APIKeyControllerSuite) SetupTest() {
+	s.apiKeyServiceMock.ExpectedCalls = nil
+	s.recorder = httptest.NewRecorder()
+}
+
+func (s *APIKeyControllerSuite) assertMockExpectations() {
+	s.apiKeyServiceMock.AssertExpectations(s.T())
+}
+
+func TestAPIKeyControllerSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyControllerSuite))
+}
+
+func (s *APIKeyControllerSuite) Test_createAPIKey_ReturnsKeyOnce() {
+	request := dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}}
+	bodyBytes, _ := json.Marshal(request)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/api-keys", bytes.NewBuffer(bodyBytes))
+
+	s.apiKeyServiceMock.On("CreateAPIKey", mock.Anything, request).Return(domain.IssuedAPIKey{
+		APIKey: domain.APIKey{ID: testAPIKeyID, Name: "batch job", Prefix: "prefix", Hash: "hash", Scopes: request.Scopes},
+		Key:    "prefix.secret",
+	}, nil).Once()
+
+	assert.Nil(s.T(), s.createAPIKeyEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), `"key":"prefix.secret"`)
+	assert.NotContains(s.T(), s.recorder.Body.String(), "hash")
+	s.assertMockExpectations()
+}
+
+func (s *APIKeyControllerSuite) Test_createAPIKey_Returns400WithoutScopes() {
+	bodyBytes, _ := json.Marshal(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{}})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/api-keys", bytes.NewBuffer(bodyBytes))
+
+	assert.Nil(s.T(), s.createAPIKeyEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *APIKeyControllerSuite) Test_rotateAPIKey_Returns404WhenKeyIsNotActive() {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/api-keys/"+testAPIKeyID+"/rotate", http.NoBody)
+
+	s.apiKeyServiceMock.On("RotateAPIKey", mock.Anything, testAPIKeyID).
+		Return(domain.IssuedAPIKey{}, domain.NotFoundErr{Msg: "not found"}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.rotateAPIKeyEP.Path)
+	echoCtx.SetParamNames("apiKeyID")
+	echoCtx.SetParamValues(testAPIKeyID)
+
+	assert.Nil(s.T(), s.rotateAPIKeyEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *APIKeyControllerSuite) Test_revokeAPIKey_Success() {
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/api-keys/"+testAPIKeyID, http.NoBody)
+
+	s.apiKeyServiceMock.On("RevokeAPIKey", mock.Anything, testAPIKeyID).Return(nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.revokeAPIKeyEP.Path)
+	echoCtx.SetParamNames("apiKeyID")
+	echoCtx.SetParamValues(testAPIKeyID)
+
+	assert.Nil(s.T(), s.revokeAPIKeyEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNoContent, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *APIKeyControllerSuite) Test_revokeAPIKey_Returns400OnInvalidID() {
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/api-keys/abc", http.NoBody)
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.revokeAPIKeyEP.Path)
+	echoCtx.SetParamNames("apiKeyID")
+	echoCtx.SetParamValues("abc")
+
+	assert.Nil(s.T(), s.revokeAPIKeyEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
@@ -1,14 +1,23 @@
 package controllers
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
 	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
 	"go-service-template/utils"
 	"io"
+	"net"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -19,33 +28,59 @@ const (
 	LimitQP                      = "limit"
 )
 
-var (
-	businessErr         = &domain.BusinessErr{}
-	nameAlreadyInUseErr = &domain.NameAlreadyInUseErr{}
-	addressNotValidErr  = &domain.AddressNotValidErr{}
-	validationErr       = &validator.ValidationErrors{}
+// Scopes required by the endpoints, GET endpoints require the read scope and the rest the write one
+const (
+	ScopeLocationsRead      = "locations:read"
+	ScopeLocationsWrite     = "locations:write"
+	ScopeReferenceDataRead  = "reference-data:read"
+	ScopeReferenceDataWrite = "reference-data:write"
+	ScopeAPIKeysAdmin       = "api-keys:admin"
+	ScopeWebhooksAdmin      = "webhooks:admin"
+	ScopeDeadLettersAdmin   = "dead-letters:admin"
+	ScopeOutboxAdmin        = "outbox:admin"
+)
 
+var (
 	ErrNoDirectionQueryParam  = errors.New("'direction' query param not provided")
 	ErrInitialCursorDirection = errors.New("if the cursor is empty, the only allowed direction value is '" + domain.NextPage + "'")
 	ErrInvalidLimitValue      = errors.New("invalid limit value")
 	ErrInvalidDirectionValue  = errors.New("invalid direction value")
 )
 
-type APIResponse struct {
-	Error *APIError `json:"error,omitempty"`
-	Data  any       `json:"data,omitempty"`
+// The response envelope and the problem details are shared with the HTTP middlewares
+type (
+	APIResponse  = customHTTP.APIResponse
+	Problem      = customHTTP.Problem
+	ProblemError = customHTTP.ProblemError
+)
+
+// FieldErr is an invalid value sent in a query param or header, its name is reported as the problem error parameter
+type FieldErr struct {
+	Field string
+	Err   error
+}
+
+func (e FieldErr) Error() string {
+	return e.Err.Error()
 }
 
-type APIError struct {
-	Type          string   `json:"type,omitempty"`
-	Title         string   `json:"title"`
-	Details       []Detail `json:"details"`
-	CorrelationID string   `json:"correlation_id"`
+func (e FieldErr) Unwrap() error {
+	return e.Err
 }
 
-type Detail struct {
-	Message string            `json:"message"`
-	Meta    map[string]string `json:"metadata,omitempty"`
+// NewValidator returns the validator of the request bodies, the invalid fields are reported by their JSON name
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	return v
 }
 
 func buildSuccessResponse(payload any) APIResponse {
@@ -54,38 +89,119 @@ func buildSuccessResponse(payload any) APIResponse {
 	}
 }
 
-func buildFailResponse(err error, title, correlationID string) APIResponse {
-	return APIResponse{
-		Error: &APIError{
-			Title:         title,
-			CorrelationID: correlationID,
-			Details:       errorDetailsFromError(err),
-		},
+// buildFailResponse builds the problem of a failed request, domain errors carry their own code and the rest get the
+// code of the status
+func buildFailResponse(c echo.Context, status int, err error, title, correlationID string) Problem {
+	var code string
+	var codedErr domain.CodedErr
+	if errors.As(err, &codedErr) {
+		code = codedErr.Code()
 	}
+
+	problem := customHTTP.NewProblem(c.Request(), status, code, title, err.Error(), correlationID)
+	problem.Errors = problemErrorsFromError(err)
+
+	return problem
+}
+
+// respondWithError sends the error as a problem+json response
+func respondWithError(c echo.Context, status int, err error, title, correlationID string) error {
+	return customHTTP.WriteProblem(c, buildFailResponse(c, status, err, title, correlationID))
 }
 
 func httpStatusFromError(err error) int {
+	// The targets are declared per call, errors.As writes the matched error into them
+	var (
+		notFoundErr         domain.NotFoundErr
+		nameAlreadyInUseErr domain.NameAlreadyInUseErr
+		keyInProgressErr    domain.IdempotencyKeyInProgressErr
+		conflictErr         domain.ConflictErr
+		addressNotValidErr  domain.AddressNotValidErr
+		unknownReferenceErr domain.UnknownReferenceErr
+		keyMismatchErr      domain.IdempotencyKeyMismatchErr
+		preconditionErr     domain.PreconditionFailedErr
+		businessErr         domain.BusinessErr
+		timeoutErr          domain.TimeoutErr
+	)
+
 	switch {
-	case errors.As(err, nameAlreadyInUseErr), errors.As(err, addressNotValidErr), errors.As(err, businessErr):
+	case errors.As(err, &notFoundErr):
+		return http.StatusNotFound
+	case errors.As(err, &nameAlreadyInUseErr), errors.As(err, &keyInProgressErr), errors.As(err, &conflictErr):
+		return http.StatusConflict
+	case errors.As(err, &addressNotValidErr), errors.As(err, &unknownReferenceErr), errors.As(err, &keyMismatchErr):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &preconditionErr):
+		return http.StatusPreconditionFailed
+	case errors.As(err, &businessErr):
 		return http.StatusBadRequest
+	case errors.As(err, &timeoutErr):
+		return http.StatusGatewayTimeout
+	case IsDependencyFailure(err):
+		return http.StatusServiceUnavailable
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
-func errorDetailsFromError(err error) []Detail {
-	var details []Detail
+// IsDependencyFailure reports whether the error comes from a dependency that failed or could not be reached, in which
+// case the request can be retried later
+func IsDependencyFailure(err error) bool {
+	var dependencyErr domain.DependencyErr
+	var netErr net.Error
+
+	return errors.As(err, &dependencyErr) || errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone)
+}
+
+// problemErrorsFromError lists the invalid fields of the request, with the JSON pointer of the body fields and the
+// name of the query params and headers
+func problemErrorsFromError(err error) []ProblemError {
+	var problemErrors []ProblemError
+	var fieldErr FieldErr
+	var bodyFieldErr dto.BodyFieldErr
+	var typeErr *json.UnmarshalTypeError
+	var validationErrs validator.ValidationErrors
 
 	switch {
-	case errors.As(err, validationErr):
-		for _, valErr := range err.(validator.ValidationErrors) { //nolint
-			details = append(details, Detail{Message: valErr.Error()})
+	case errors.As(err, &validationErrs):
+		for _, valErr := range validationErrs {
+			problemErrors = append(problemErrors, ProblemError{
+				Detail:  fmt.Sprintf("field '%v' failed on the '%v' rule", valErr.Field(), valErr.Tag()),
+				Pointer: jsonPointerFromNamespace(valErr.Namespace()),
+			})
 		}
-	default:
-		details = append(details, Detail{Message: err.Error()})
+	case isJoinedError(err):
+		for _, joinedErr := range err.(interface{ Unwrap() []error }).Unwrap() { //nolint
+			problemErrors = append(problemErrors, problemErrorsFromError(joinedErr)...)
+		}
+	case errors.As(err, &fieldErr):
+		problemErrors = append(problemErrors, ProblemError{Detail: err.Error(), Parameter: fieldErr.Field})
+	case errors.As(err, &bodyFieldErr):
+		problemErrors = append(problemErrors, ProblemError{Detail: err.Error(), Pointer: "/" + bodyFieldErr.Field})
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		problemErrors = append(problemErrors, ProblemError{
+			Detail:  fmt.Sprintf("field '%v' must be of type %v", typeErr.Field, typeErr.Type),
+			Pointer: "/" + strings.ReplaceAll(typeErr.Field, ".", "/"),
+		})
 	}
 
-	return details
+	return problemErrors
+}
+
+// jsonPointerFromNamespace turns a validator namespace such as "Request.scopes[0]" into "/scopes/0", the first
+// element is the name of the validated struct
+func jsonPointerFromNamespace(namespace string) string {
+	_, path, _ := strings.Cut(namespace, ".")
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+
+	return "/" + strings.ReplaceAll(path, ".", "/")
+}
+
+func isJoinedError(err error) bool {
+	_, ok := err.(interface{ Unwrap() []error }) //nolint
+
+	return ok
 }
 
 func parseAndValidateBody[T any](body io.ReadCloser, v *validator.Validate) (T, error) {
@@ -101,6 +217,15 @@ func parseAndValidateBody[T any](body io.ReadCloser, v *validator.Validate) (T,
 	return bodyStruct, nil
 }
 
+func getIntPathParam(c echo.Context, name string) (int, error) {
+	value, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		return 0, fmt.Errorf("invalid '%v' path param, it must be an integer", name)
+	}
+
+	return value, nil
+}
+
 func buildCursorPaginationFilters(req *http.Request) (domain.CursorPaginationFilters, error) {
 	var cursorPagFilters domain.CursorPaginationFilters
 	queryString := req.URL.Query()
@@ -114,7 +239,7 @@ func buildCursorPaginationFilters(req *http.Request) (domain.CursorPaginationFil
 	if limit, ok := queryString[LimitQP]; ok {
 		limitVal, err := strconv.Atoi(limit[0])
 		if err != nil {
-			return cursorPagFilters, ErrInvalidLimitValue
+			return cursorPagFilters, FieldErr{Field: LimitQP, Err: ErrInvalidLimitValue}
 		}
 		cursorPagFilters.Limit = limitVal
 	} else {
@@ -125,15 +250,15 @@ func buildCursorPaginationFilters(req *http.Request) (domain.CursorPaginationFil
 		if utils.ListContains([]string{domain.PreviousPage, domain.NextPage}, directionVal[0]) {
 			cursorPagFilters.Direction = directionVal[0]
 		} else {
-			return cursorPagFilters, ErrInvalidDirectionValue
+			return cursorPagFilters, FieldErr{Field: DirectionQP, Err: ErrInvalidDirectionValue}
 		}
 	} else {
-		return cursorPagFilters, ErrNoDirectionQueryParam
+		return cursorPagFilters, FieldErr{Field: DirectionQP, Err: ErrNoDirectionQueryParam}
 	}
 
 	// Validate initial cursor
 	if cursorPagFilters.Cursor == "" && cursorPagFilters.Direction != domain.NextPage {
-		return cursorPagFilters, ErrInitialCursorDirection
+		return cursorPagFilters, FieldErr{Field: DirectionQP, Err: ErrInitialCursorDirection}
 	}
 
 	return cursorPagFilters, nil
@@ -1,11 +1,16 @@
 package controllers
 
 import (
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -18,22 +23,64 @@ func Test_httpStatusFromError_returns400OnBusinessErr(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, code)
 }
 
-func Test_httpStatusFromError_returns400OnAddressNotValidErr(t *testing.T) {
+func Test_httpStatusFromError_returns422OnAddressNotValidErr(t *testing.T) {
 	testErr := domain.AddressNotValidErr{Msg: "err"}
 	wrappedTestErr := fmt.Errorf("some err: %w", testErr)
 
 	code := httpStatusFromError(wrappedTestErr)
 
-	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
 }
 
-func Test_httpStatusFromError_returns400OnNameAlreadyInUseErr(t *testing.T) {
+func Test_httpStatusFromError_returns409OnNameAlreadyInUseErr(t *testing.T) {
 	testErr := domain.NameAlreadyInUseErr{Msg: "err"}
 	wrappedTestErr := fmt.Errorf("some err: %w", testErr)
 
 	code := httpStatusFromError(wrappedTestErr)
 
-	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func Test_httpStatusFromError_returns404OnNotFoundErr(t *testing.T) {
+	code := httpStatusFromError(fmt.Errorf("some err: %w", domain.NotFoundErr{Msg: "err"}))
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func Test_httpStatusFromError_returns503OnDependencyErr(t *testing.T) {
+	code := httpStatusFromError(domain.DependencyErr{Dependency: "Google Maps API", Err: errors.New("timeout")})
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func Test_httpStatusFromError_returns503OnBadConnection(t *testing.T) {
+	code := httpStatusFromError(fmt.Errorf("error finding location: %w", driver.ErrBadConn))
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func Test_httpStatusFromError_returns409OnIdempotencyKeyInProgressErr(t *testing.T) {
+	code := httpStatusFromError(domain.IdempotencyKeyInProgressErr{Msg: "err"})
+
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func Test_httpStatusFromError_returns422OnIdempotencyKeyMismatchErr(t *testing.T) {
+	code := httpStatusFromError(domain.IdempotencyKeyMismatchErr{Msg: "err"})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func Test_httpStatusFromError_returns409OnConflictErr(t *testing.T) {
+	code := httpStatusFromError(fmt.Errorf("tx failed: %w", domain.ConflictErr{Msg: "err"}))
+
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func Test_httpStatusFromError_returns504OnTimeoutErr(t *testing.T) {
+	code := httpStatusFromError(domain.TimeoutErr{Msg: "err"})
+
+	assert.Equal(t, http.StatusGatewayTimeout, code)
 }
 
 func Test_httpStatusFromError_returns500OnUnhandledError(t *testing.T) {
@@ -43,3 +90,73 @@ func Test_httpStatusFromError_returns500OnUnhandledError(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, code)
 }
+
+func Test_problemErrorsFromError_reportsEachJoinedFieldErr(t *testing.T) {
+	err := errors.Join(
+		FieldErr{Field: "city", Err: errors.New("invalid city")},
+		dto.BodyFieldErr{Field: "name", Msg: "field 'name' cannot be null or empty"},
+		errors.New("some err"),
+	)
+
+	problemErrors := problemErrorsFromError(err)
+
+	assert.Equal(t, []ProblemError{
+		{Detail: "invalid city", Parameter: "city"},
+		{Detail: "field 'name' cannot be null or empty", Pointer: "/name"},
+	}, problemErrors)
+}
+
+func Test_problemErrorsFromError_reportsPointerOfEachValidationErr(t *testing.T) {
+	err := NewValidator().Struct(dto.CreateAPIKeyRequest{Scopes: []string{""}})
+
+	problemErrors := problemErrorsFromError(err)
+
+	assert.Equal(t, []ProblemError{
+		{Detail: "field 'name' failed on the 'required' rule", Pointer: "/name"},
+		{Detail: "field 'scopes[0]' failed on the 'required' rule", Pointer: "/scopes/0"},
+	}, problemErrors)
+}
+
+func Test_buildFailResponse_usesCodeOfDomainErr(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/v1/locations/id", http.NoBody)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	err := fmt.Errorf("tx failed: %w", domain.NameAlreadyInUseErr{Msg: "location name 'a' is already in use", Resource: domain.ResourceLocation})
+
+	problem := buildFailResponse(c, http.StatusConflict, err, "failed to update location", "correlation")
+
+	assert.Equal(t, "location.name_conflict", problem.Code)
+	assert.Equal(t, "urn:problem-type:location.name_conflict", problem.Type)
+	assert.Equal(t, http.StatusConflict, problem.Status)
+	assert.Equal(t, "/v1/locations/id", problem.Instance)
+}
+
+func Test_buildFailResponse_usesCodeOfStatusOnUnknownErr(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/locations", http.NoBody)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	problem := buildFailResponse(c, http.StatusInternalServerError, errors.New("some err"), "failed", "correlation")
+
+	assert.Equal(t, customHTTP.CodeInternalError, problem.Code)
+}
+
+func Test_buildFailResponse_hidesErrorOfServerErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/locations", http.NoBody)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	err := errors.New(`pq: relation "location.locations" does not exist`)
+
+	for _, status := range []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		problem := buildFailResponse(c, status, err, "failed to get locations", "correlation")
+
+		assert.NotEmpty(t, problem.Detail)
+		assert.NotContains(t, problem.Detail, "location.locations")
+	}
+}
+
+func Test_buildFailResponse_keepsErrorOfClientErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/locations", http.NoBody)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	problem := buildFailResponse(c, http.StatusBadRequest, domain.BusinessErr{Msg: "invalid location"}, "failed", "correlation")
+
+	assert.Equal(t, "invalid location", problem.Detail)
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	HandlerQP  = "handler"
+	RedrivenQP = "redriven"
+)
+
+var (
+	ErrInvalidDeadLetterID = errors.New("invalid 'deadLetterID' path param, it must be a UUID")
+	ErrInvalidRedrivenQP   = errors.New("invalid redriven value")
+)
+
+type DeadLetterController struct {
+	logger            monitor.AppLogger
+	deadLetterService services.IDeadLetterService
+}
+
+func NewDeadLetterController(deadLetterService services.IDeadLetterService) *DeadLetterController {
+	return &DeadLetterController{
+		deadLetterService: deadLetterService,
+		logger:            monitor.GetStdLogger("DeadLetterController"),
+	}
+}
+
+// Nada godoc
+// @Summary List dead letters
+// @Description Get the newest messages the event handlers could not process after every retry, along with the error of the last attempt
+// @Produce json
+// @Param handler query string false "Optional filter by the name of the handler that failed"
+// @Param redriven query bool false "Optional filter, true to get only the redriven dead letters and false to get only the pending ones"
+// @Success 200 {object} []domain.DeadLetter
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/dead-letters [get]
+func (ct *DeadLetterController) DeadLettersEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/dead-letters",
+		Handler:        ct.getDeadLetters,
+		RequiredScopes: []string{ScopeDeadLettersAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Get dead letter
+// @Description Get a dead letter by its ID, including the payload and metadata of the original message
+// @Produce json
+// @Param deadLetterID path string true "Dead letter ID"
+// @Success 200 {object} domain.DeadLetter
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/dead-letters/{deadLetterID} [get]
+func (ct *DeadLetterController) DeadLetterDetailsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/dead-letters/:deadLetterID",
+		Handler:        ct.getDeadLetterDetails,
+		RequiredScopes: []string{ScopeDeadLettersAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Redrive dead letter
+// @Description Publish the message of a dead letter to its original topic again, with the same ID, key and metadata. Only the handler that failed it processes it again. A dead letter can only be redriven once, a message that fails again produces a new one
+// @Produce json
+// @Param deadLetterID path string true "Dead letter ID"
+// @Success 200 {object} domain.DeadLetter
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/dead-letters/{deadLetterID}/redrive [post]
+func (ct *DeadLetterController) RedriveDeadLetterEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/dead-letters/:deadLetterID/redrive",
+		Handler:        ct.redriveDeadLetter,
+		RequiredScopes: []string{ScopeDeadLettersAdmin},
+	}
+}
+
+func (ct *DeadLetterController) getDeadLetters(c echo.Context) error {
+	fnName := "DeadLetterController.getDeadLetters"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	var filters domain.DeadLetterFilters
+	if handler := strings.TrimSpace(c.QueryParam(HandlerQP)); handler != "" {
+		filters.Handler = &handler
+	}
+	if redrivenVal := c.QueryParam(RedrivenQP); redrivenVal != "" {
+		redriven, err := strconv.ParseBool(redrivenVal)
+		if err != nil {
+			err = FieldErr{Field: RedrivenQP, Err: ErrInvalidRedrivenQP}
+			ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+			return respondWithError(c, http.StatusBadRequest, err, "invalid dead letter filters", appCtx.GetCorrelationID())
+		}
+		filters.Redriven = &redriven
+	}
+
+	deadLetters, err := ct.deadLetterService.GetDeadLetters(appCtx, filters)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get dead letters", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get dead letters", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(deadLetters))
+}
+
+func (ct *DeadLetterController) getDeadLetterDetails(c echo.Context) error {
+	fnName := "DeadLetterController.getDeadLetterDetails"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "deadLetterID", ErrInvalidDeadLetterID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	deadLetter, err := ct.deadLetterService.GetDeadLetterByID(appCtx, id)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get dead letter", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get dead letter", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(deadLetter))
+}
+
+func (ct *DeadLetterController) redriveDeadLetter(c echo.Context) error {
+	fnName := "DeadLetterController.redriveDeadLetter"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "deadLetterID", ErrInvalidDeadLetterID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	deadLetter, err := ct.deadLetterService.RedriveDeadLetter(appCtx, id)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to redrive dead letter", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to redrive dead letter", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(deadLetter))
+}
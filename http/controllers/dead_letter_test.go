@@ -0,0 +1,120 @@
+package controllers_test
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/mocks"
+	"go-service-template/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testDeadLetterID = "5d0f1a8e-3c52-4d55-9f1e-2b7f3c9a6e21"
+
+type DeadLetterControllerSuite struct {
+	suite.Suite
+	deadLetterServiceMock *mocks.IDeadLetterService
+	deadLettersEP         customHTTP.Endpoint
+	redriveDeadLetterEP   customHTTP.Endpoint
+	echoRouter            *echo.Echo
+	recorder              *httptest.ResponseRecorder
+}
+
+func (s *DeadLetterControllerSuite) SetupSuite() {
+	deadLetterServiceMock := new(mocks.IDeadLetterService)
+	controller := controllers.NewDeadLetterController(deadLetterServiceMock)
+
+	s.deadLettersEP = controller.DeadLettersEndpoint()
+	s.redriveDeadLetterEP = controller.RedriveDeadLetterEndpoint()
+	s.deadLetterServiceMock = deadLetterServiceMock
+
+	s.echoRouter = echo.New()
+}
+
+func (s *DeadLetterControllerSuite) SetupTest() {
+	s.deadLetterServiceMock.ExpectedCalls = nil
+	s.recorder = httptest.NewRecorder()
+}
+
+func (s *DeadLetterControllerSuite) assertMockExpectations() {
+	s.deadLetterServiceMock.AssertExpectations(s.T())
+}
+
+func TestDeadLetterControllerSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterControllerSuite))
+}
+
+func (s *DeadLetterControllerSuite) Test_getDeadLetters_ParsesFilters() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/dead-letters?handler=NewLocationWebhookHandler&redriven=false", http.NoBody)
+
+	s.deadLetterServiceMock.On("GetDeadLetters", mock.Anything, domain.DeadLetterFilters{
+		Handler:  utils.ToPointer("NewLocationWebhookHandler"),
+		Redriven: utils.ToPointer(false),
+	}).Return([]domain.DeadLetter{{ID: testDeadLetterID}}, nil).Once()
+
+	assert.Nil(s.T(), s.deadLettersEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), testDeadLetterID)
+	s.assertMockExpectations()
+}
+
+func (s *DeadLetterControllerSuite) Test_getDeadLetters_Returns400OnInvalidRedriven() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/dead-letters?redriven=maybe", http.NoBody)
+
+	assert.Nil(s.T(), s.deadLettersEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), controllers.RedrivenQP)
+	s.assertMockExpectations()
+}
+
+func (s *DeadLetterControllerSuite) Test_redriveDeadLetter_Success() {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/dead-letters/"+testDeadLetterID+"/redrive", http.NoBody)
+
+	s.deadLetterServiceMock.On("RedriveDeadLetter", mock.Anything, testDeadLetterID).
+		Return(domain.DeadLetter{ID: testDeadLetterID, RedrivenAt: utils.ToPointer(time.Now())}, nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.redriveDeadLetterEP.Path)
+	echoCtx.SetParamNames("deadLetterID")
+	echoCtx.SetParamValues(testDeadLetterID)
+
+	assert.Nil(s.T(), s.redriveDeadLetterEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *DeadLetterControllerSuite) Test_redriveDeadLetter_Returns400WhenAlreadyRedriven() {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/dead-letters/"+testDeadLetterID+"/redrive", http.NoBody)
+
+	s.deadLetterServiceMock.On("RedriveDeadLetter", mock.Anything, testDeadLetterID).
+		Return(domain.DeadLetter{}, domain.BusinessErr{Msg: "already redriven", Resource: domain.ResourceDeadLetter}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.redriveDeadLetterEP.Path)
+	echoCtx.SetParamNames("deadLetterID")
+	echoCtx.SetParamValues(testDeadLetterID)
+
+	assert.Nil(s.T(), s.redriveDeadLetterEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *DeadLetterControllerSuite) Test_redriveDeadLetter_Returns400OnInvalidID() {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/dead-letters/nope/redrive", http.NoBody)
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.redriveDeadLetterEP.Path)
+	echoCtx.SetParamNames("deadLetterID")
+	echoCtx.SetParamValues("nope")
+
+	assert.Nil(s.T(), s.redriveDeadLetterEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
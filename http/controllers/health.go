@@ -2,35 +2,81 @@ package controllers
 
 import (
 	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
 	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
 	"go-service-template/monitor"
+	"go-service-template/services"
 	"net/http"
 )
 
 type HealthController struct {
-	logger monitor.AppLogger
+	logger         monitor.AppLogger
+	healthRegistry services.IHealthRegistry
 }
 
-func NewHealthController() *HealthController {
+func NewHealthController(healthRegistry services.IHealthRegistry) *HealthController {
 	return &HealthController{
-		logger: monitor.GetStdLogger("Health Controller"),
+		logger:         monitor.GetStdLogger("Health Controller"),
+		healthRegistry: healthRegistry,
 	}
 }
 
 // Nada godoc
 // @Summary Check health
-// @Description Simple healthcheck endpoint
-// @Produce plain
-// @Success 200
+// @Description Same as the liveness check, kept for the probes configured before it existed
+// @Produce json
+// @Success 200 {object} domain.HealthReport
 // @Router /health [get]
 func (hc *HealthController) HealthEndpoint() customHTTP.Endpoint {
 	return customHTTP.Endpoint{
 		Method:  http.MethodGet,
 		Path:    "/health",
-		Handler: hc.health,
+		Handler: hc.live,
 	}
 }
 
-func (hc *HealthController) health(c echo.Context) error {
-	return c.String(http.StatusOK, "Service is healthy")
+// Nada godoc
+// @Summary Check liveness
+// @Description Answers 503 when the service cannot recover by itself and has to be restarted, along with the result of each check
+// @Produce json
+// @Success 200 {object} domain.HealthReport
+// @Router /health/live [get]
+func (hc *HealthController) LivenessEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:  http.MethodGet,
+		Path:    "/health/live",
+		Handler: hc.live,
+	}
+}
+
+// Nada godoc
+// @Summary Check readiness
+// @Description Answers 503 when a dependency is down or the service is shutting down, along with the result of each check. Results are cached for a few seconds
+// @Produce json
+// @Success 200 {object} domain.HealthReport
+// @Router /health/ready [get]
+func (hc *HealthController) ReadinessEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:  http.MethodGet,
+		Path:    "/health/ready",
+		Handler: hc.ready,
+	}
+}
+
+func (hc *HealthController) live(c echo.Context) error {
+	return respondWithHealthReport(c, hc.healthRegistry.Live(middleware.GetAppContext(c)))
+}
+
+func (hc *HealthController) ready(c echo.Context) error {
+	return respondWithHealthReport(c, hc.healthRegistry.Ready(middleware.GetAppContext(c)))
+}
+
+// respondWithHealthReport sends the report as is, probes only look at the status code
+func respondWithHealthReport(c echo.Context, report domain.HealthReport) error {
+	if report.Status != domain.HealthStatusUp {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+
+	return c.JSON(http.StatusOK, report)
 }
@@ -1,20 +1,43 @@
 package controllers_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
 	customHTTP "go-service-template/http"
 	"go-service-template/http/controllers"
+	"go-service-template/services"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 )
 
+// stubHealthCheck is a check that always returns the same error
+type stubHealthCheck struct {
+	name string
+	err  error
+}
+
+func (c stubHealthCheck) Name() string {
+	return c.name
+}
+
+func (c stubHealthCheck) Check(_ context.Context) error {
+	return c.err
+}
+
 type HealthControllerSuite struct {
 	suite.Suite
-	healthEndpoint customHTTP.Endpoint
-	echoRouter     *echo.Echo
+	healthRegistry    *services.HealthRegistry
+	healthEndpoint    customHTTP.Endpoint
+	livenessEndpoint  customHTTP.Endpoint
+	readinessEndpoint customHTTP.Endpoint
+	echoRouter        *echo.Echo
 }
 
 func (s *HealthControllerSuite) SetupSuite() {
@@ -22,15 +45,30 @@ func (s *HealthControllerSuite) SetupSuite() {
 }
 
 func (s *HealthControllerSuite) SetupTest() {
-	healthController := controllers.NewHealthController()
+	s.healthRegistry = services.NewHealthRegistry(config.HealthConfig{})
+	healthController := controllers.NewHealthController(s.healthRegistry)
 
 	s.healthEndpoint = healthController.HealthEndpoint()
+	s.livenessEndpoint = healthController.LivenessEndpoint()
+	s.readinessEndpoint = healthController.ReadinessEndpoint()
 }
 
 func TestHealthControllerSuite(t *testing.T) {
 	suite.Run(t, new(HealthControllerSuite))
 }
 
+func (s *HealthControllerSuite) serve(endpoint customHTTP.Endpoint) (*httptest.ResponseRecorder, domain.HealthReport) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, endpoint.Path, http.NoBody)
+
+	s.Require().NoError(endpoint.Handler(s.echoRouter.NewContext(req, w)))
+
+	var report domain.HealthReport
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+
+	return w, report
+}
+
 func (s *HealthControllerSuite) Test_Health_Success() {
 	w := httptest.NewRecorder()
 
@@ -41,3 +79,36 @@ func (s *HealthControllerSuite) Test_Health_Success() {
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), http.StatusOK, w.Code)
 }
+
+func (s *HealthControllerSuite) Test_Liveness_IgnoresReadinessChecks() {
+	s.healthRegistry.RegisterReadinessCheck(stubHealthCheck{name: "postgres", err: errors.New("connection refused")})
+
+	w, report := s.serve(s.livenessEndpoint)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), domain.HealthStatusUp, report.Status)
+	assert.Empty(s.T(), report.Checks)
+}
+
+func (s *HealthControllerSuite) Test_Readiness_ReturnsBreakdown() {
+	s.healthRegistry.RegisterReadinessCheck(stubHealthCheck{name: "postgres"})
+	s.healthRegistry.RegisterReadinessCheck(stubHealthCheck{name: "kafka", err: errors.New("connection refused")})
+
+	w, report := s.serve(s.readinessEndpoint)
+
+	assert.Equal(s.T(), http.StatusServiceUnavailable, w.Code)
+	assert.Equal(s.T(), domain.HealthStatusDown, report.Status)
+	assert.Equal(s.T(), domain.HealthStatusUp, report.Checks["postgres"].Status)
+	assert.Equal(s.T(), domain.HealthStatusDown, report.Checks["kafka"].Status)
+	assert.Equal(s.T(), "connection refused", report.Checks["kafka"].Error)
+}
+
+func (s *HealthControllerSuite) Test_Readiness_FailsOnceShutdownBegins() {
+	s.healthRegistry.RegisterReadinessCheck(stubHealthCheck{name: "postgres"})
+	s.healthRegistry.MarkShuttingDown()
+
+	w, report := s.serve(s.readinessEndpoint)
+
+	assert.Equal(s.T(), http.StatusServiceUnavailable, w.Code)
+	assert.Equal(s.T(), domain.HealthStatusDown, report.Checks[services.ShutdownHealthCheck].Status)
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength  = 255
+)
+
+var ErrInvalidIdempotencyKey = fmt.Errorf("invalid Idempotency-Key header, it must have at most %v characters", MaxIdempotencyKeyLength)
+
+// idempotentResponseHeaders are the response headers stored and replayed with the response body
+var idempotentResponseHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, HeaderETag}
+
+// NewIdempotencyMiddleware makes an endpoint safe to retry for the clients sending an Idempotency-Key header. The
+// response of the first request is stored and returned to every retry of the same caller with the same key and
+// request, without calling the handler again. Responses with a 5xx status are not stored so the request can be retried with the same key.
+// Requests without the header are not affected.
+func NewIdempotencyMiddleware(idempotencyService services.IIdempotencyService) customHTTP.Middleware {
+	logger := monitor.GetStdLogger("IdempotencyMiddleware")
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+
+			fnName := "IdempotencyMiddleware"
+			var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+			appCtx, span := appCtx.StartSpan(fnName)
+			defer span.End()
+
+			if len(key) > MaxIdempotencyKeyLength {
+				err := FieldErr{Field: HeaderIdempotencyKey, Err: ErrInvalidIdempotencyKey}
+				return respondWithError(c, http.StatusBadRequest, err, "invalid idempotency key", appCtx.GetCorrelationID())
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				logger.ErrorCtx(appCtx, fnName, "failed to read request body", err)
+				return respondWithError(c, http.StatusBadRequest, err, "failed to read request body", appCtx.GetCorrelationID())
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := idempotencyScope(c.Request(), appCtx)
+
+			storedResponse, err := idempotencyService.BeginRequest(appCtx, scope, key, hashIdempotentRequest(c.Request(), body))
+			if err != nil {
+				logger.ErrorCtx(appCtx, fnName, "failed to process idempotency key", err)
+				return respondWithError(c, httpStatusFromError(err), err, "failed to process idempotency key", appCtx.GetCorrelationID())
+			}
+
+			if storedResponse != nil {
+				return replayIdempotentResponse(c, *storedResponse)
+			}
+
+			// The key is released unless the response is stored, including when the handler panics
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if releaseErr := idempotencyService.ReleaseKey(appCtx, scope, key); releaseErr != nil {
+					logger.ErrorCtx(appCtx, fnName, "failed to release idempotency key", releaseErr)
+				}
+			}()
+
+			recorder := &responseBodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			if err = next(c); err != nil || c.Response().Status >= http.StatusInternalServerError {
+				return err
+			}
+
+			response := domain.IdempotentResponse{StatusCode: c.Response().Status, Headers: map[string]string{}, Body: recorder.body.Bytes()}
+			for _, header := range idempotentResponseHeaders {
+				if value := c.Response().Header().Get(header); value != "" {
+					response.Headers[header] = value
+				}
+			}
+
+			if err = idempotencyService.CompleteRequest(appCtx, scope, key, response); err != nil {
+				logger.ErrorCtx(appCtx, fnName, "failed to store idempotent response", err)
+				return nil
+			}
+			completed = true
+
+			return nil
+		}
+	}
+}
+
+// idempotencyScope is the endpoint and the caller the keys belong to, so a caller cannot read the responses stored for
+// the keys of another one
+func idempotencyScope(req *http.Request, appCtx monitor.ApplicationContext) string {
+	scope := req.Method + " " + req.URL.Path
+	if principal, ok := appCtx.GetPrincipal(); ok {
+		scope += " " + principal.Subject
+	}
+
+	return scope
+}
+
+// hashIdempotentRequest identifies the request a key was used with by its query string and body
+func hashIdempotentRequest(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.URL.RawQuery))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replayIdempotentResponse(c echo.Context, response domain.IdempotentResponse) error {
+	for header, value := range response.Headers {
+		c.Response().Header().Set(header, value)
+	}
+	c.Response().Header().Set(HeaderIdempotentReplayed, strconv.FormatBool(true))
+	c.Response().WriteHeader(response.StatusCode)
+
+	_, err := c.Response().Write(response.Body)
+
+	return err
+}
+
+// responseBodyRecorder keeps a copy of the response body written through it
+type responseBodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseBodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,183 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/http/middleware"
+	"go-service-template/mocks"
+	"go-service-template/monitor"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const idempotencyTestScope = "POST /v1/locations"
+
+type IdempotencyMiddlewareSuite struct {
+	suite.Suite
+	idempotencyServiceMock *mocks.IIdempotencyService
+	idempotencyMiddleware  customHTTP.Middleware
+	echoRouter             *echo.Echo
+	recorder               *httptest.ResponseRecorder
+	handlerCalls           int
+	principal              *monitor.Principal
+}
+
+func (s *IdempotencyMiddlewareSuite) SetupSuite() {
+	s.idempotencyServiceMock = new(mocks.IIdempotencyService)
+	s.idempotencyMiddleware = controllers.NewIdempotencyMiddleware(s.idempotencyServiceMock)
+	s.echoRouter = echo.New()
+}
+
+func (s *IdempotencyMiddlewareSuite) SetupTest() {
+	s.idempotencyServiceMock.ExpectedCalls = nil
+	s.recorder = httptest.NewRecorder()
+	s.handlerCalls = 0
+	s.principal = nil
+}
+
+func TestIdempotencyMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyMiddlewareSuite))
+}
+
+// serve runs a request through the middleware with a handler that answers with the given status and echoes the body
+func (s *IdempotencyMiddlewareSuite) serve(key, body string, status int) {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/locations", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(controllers.HeaderIdempotencyKey, key)
+	}
+	if s.principal != nil {
+		appCtx := monitor.CreateAppContextFromRequest(req, "").WithPrincipal(*s.principal)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.AppContextKey, appCtx))
+	}
+
+	handler := s.idempotencyMiddleware(func(c echo.Context) error {
+		s.handlerCalls++
+		requestBody, _ := io.ReadAll(c.Request().Body)
+		c.Response().Header().Set(controllers.HeaderETag, `"1"`)
+		return c.JSONBlob(status, requestBody)
+	})
+
+	assert.Nil(s.T(), handler(s.echoRouter.NewContext(req, s.recorder)))
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_IgnoresRequestsWithoutKey() {
+	s.serve("", `{"name":"first"}`, http.StatusOK)
+
+	assert.Equal(s.T(), 1, s.handlerCalls)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_StoresResponseOfFirstRequest() {
+	var requestHash string
+	s.idempotencyServiceMock.On("BeginRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).Run(func(args mock.Arguments) {
+		requestHash = args.String(3)
+	}).Return(nil, nil).Once()
+	var stored domain.IdempotentResponse
+	s.idempotencyServiceMock.On("CompleteRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(3).(domain.IdempotentResponse)
+	}).Return(nil).Once()
+
+	s.serve("key", `{"name":"first"}`, http.StatusOK)
+
+	assert.Equal(s.T(), domain.IdempotentResponse{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{echo.HeaderContentType: echo.MIMEApplicationJSONCharsetUTF8, controllers.HeaderETag: `"1"`},
+		Body:       []byte(`{"name":"first"}`),
+	}, stored)
+
+	assert.Equal(s.T(), 1, s.handlerCalls)
+	assert.Equal(s.T(), `{"name":"first"}`, s.recorder.Body.String())
+	assert.Len(s.T(), requestHash, 64)
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_ScopesKeysToCaller() {
+	s.principal = &monitor.Principal{Subject: "client1"}
+	s.idempotencyServiceMock.On("BeginRequest", mock.Anything, idempotencyTestScope+" client1", "key", mock.Anything).Return(nil, nil).Once()
+	s.idempotencyServiceMock.On("CompleteRequest", mock.Anything, idempotencyTestScope+" client1", "key", mock.Anything).Return(nil).Once()
+
+	s.serve("key", `{"name":"first"}`, http.StatusOK)
+
+	assert.Equal(s.T(), 1, s.handlerCalls)
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_ReplaysStoredResponse() {
+	s.idempotencyServiceMock.On("BeginRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).Return(&domain.IdempotentResponse{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{echo.HeaderContentType: echo.MIMEApplicationJSON},
+		Body:       []byte(`{"data":{"id":"stored"}}`),
+	}, nil).Once()
+
+	s.serve("key", `{"name":"first"}`, http.StatusOK)
+
+	assert.Equal(s.T(), 0, s.handlerCalls)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), "true", s.recorder.Header().Get(controllers.HeaderIdempotentReplayed))
+	assert.Equal(s.T(), echo.MIMEApplicationJSON, s.recorder.Header().Get(echo.HeaderContentType))
+	assert.Equal(s.T(), `{"data":{"id":"stored"}}`, s.recorder.Body.String())
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_Returns422WhenKeyWasUsedForAnotherRequest() {
+	s.idempotencyServiceMock.On("BeginRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).
+		Return(nil, domain.IdempotencyKeyMismatchErr{Msg: "used"}).Once()
+
+	s.serve("key", `{"name":"other"}`, http.StatusOK)
+
+	assert.Equal(s.T(), 0, s.handlerCalls)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, s.recorder.Code)
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_Returns409WhileFirstRequestIsInProgress() {
+	s.idempotencyServiceMock.On("BeginRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).
+		Return(nil, domain.IdempotencyKeyInProgressErr{Msg: "in progress"}).Once()
+
+	s.serve("key", `{"name":"first"}`, http.StatusOK)
+
+	assert.Equal(s.T(), 0, s.handlerCalls)
+	assert.Equal(s.T(), http.StatusConflict, s.recorder.Code)
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_ReleasesKeyOnServerError() {
+	s.idempotencyServiceMock.On("BeginRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).Return(nil, nil).Once()
+	s.idempotencyServiceMock.On("ReleaseKey", mock.Anything, idempotencyTestScope, "key").Return(nil).Once()
+
+	s.serve("key", `{"name":"first"}`, http.StatusInternalServerError)
+
+	assert.Equal(s.T(), 1, s.handlerCalls)
+	assert.Equal(s.T(), http.StatusInternalServerError, s.recorder.Code)
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_ReleasesKeyWhenResponseCannotBeStored() {
+	s.idempotencyServiceMock.On("BeginRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).Return(nil, nil).Once()
+	s.idempotencyServiceMock.On("CompleteRequest", mock.Anything, idempotencyTestScope, "key", mock.Anything).Return(errors.New("db down")).Once()
+	s.idempotencyServiceMock.On("ReleaseKey", mock.Anything, idempotencyTestScope, "key").Return(nil).Once()
+
+	s.serve("key", `{"name":"first"}`, http.StatusOK)
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.idempotencyServiceMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyMiddlewareSuite) Test_IdempotencyMiddleware_Returns400OnTooLongKey() {
+	s.serve(strings.Repeat("k", controllers.MaxIdempotencyKeyLength+1), `{}`, http.StatusOK)
+
+	assert.Equal(s.T(), 0, s.handlerCalls)
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), `"parameter":"Idempotency-Key"`)
+}
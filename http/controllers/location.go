@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -13,12 +14,49 @@ import (
 	"go-service-template/services"
 	"go-service-template/utils"
 	"go.opentelemetry.io/otel/codes"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IncludeDeletedQP   = "include_deleted"
+	MergePatchMIMEType = "application/merge-patch+json"
+	HeaderETag         = "ETag"
+	HeaderIfMatch      = "If-Match"
+	NameQP             = "name"
+	CityQP             = "city"
+	StateQP            = "state"
+	ZipcodeQP          = "zipcode"
+	LocationTypeIDQP   = "location_type_id"
+	SupplierIDQP       = "supplier_id"
+	ActiveQP           = "active"
+	CreatedFromQP      = "created_from"
+	CreatedToQP        = "created_to"
+	UpdatedFromQP      = "updated_from"
+	UpdatedToQP        = "updated_to"
+	SortQP             = "sort"
+	LatitudeQP         = "lat"
+	LongitudeQP        = "lng"
+	RadiusQP           = "radius_m"
+
+	DefaultNearbyLimit    = 100
+	MaxNearbyLimit        = 1000
+	MaxNearbyRadiusMeters = 100000
 )
 
 var (
-	ErrNoLocationIDSend   = errors.New("no locationID sent in URL")
-	ErrLocationIDMismatch = errors.New("mismatch between location ID in url and the one in the request payload")
+	ErrNoLocationIDSend        = errors.New("no locationID sent in URL")
+	ErrLocationIDMismatch      = errors.New("mismatch between location ID in url and the one in the request payload")
+	ErrInvalidIncludeDeletedQP = errors.New("invalid include_deleted value")
+	ErrInvalidActiveQP         = errors.New("invalid active value")
+	ErrInvalidDateRange        = errors.New("the end of the date range must be after its start")
+	ErrUnsupportedPatchType    = errors.New("unsupported content type, patch documents must be sent as '" + MergePatchMIMEType + "'")
+	ErrMissingIfMatch          = errors.New("the If-Match header is required, send the ETag of the location being modified")
+	ErrInvalidIfMatch          = errors.New("invalid If-Match header, it must be a single ETag returned by this service")
 )
 
 type LocationController struct {
@@ -40,27 +78,35 @@ func NewLocationController(locService services.ILocationService, validator *vali
 // @Description Receives a request and mocks a location creation
 // @Produce json
 // @Success 200
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /v1/location-mock [post]
 func (ct *LocationController) CreateLocationMockEndpoint() customHTTP.Endpoint {
 	return customHTTP.Endpoint{
-		Method:  http.MethodPost,
-		Path:    "/v1/location-mock",
-		Handler: ct.createLocationMock,
+		Method:         http.MethodPost,
+		Path:           "/v1/location-mock",
+		Handler:        ct.createLocationMock,
+		RequiredScopes: []string{ScopeLocationsWrite},
 	}
 }
 
 // Nada godoc
 // @Summary Create location
-// @Description Create a new location and a default sub location
+// @Description Create a new location and a default sub location. Requests sent with an Idempotency-Key can be safely retried, the same key with a different body is rejected with 422 and a retry sent while the first request is still running with 409
 // @Produce json
+// @Param Idempotency-Key header string false "Unique key of the request. Retries with the same key and body get the original response instead of creating the location again"
 // @Param request body dto.CreateLocationRequest true "Location attributes"
 // @Success 200 {object} []domain.Location
+// @Header 200 {string} Idempotent-Replayed "Set to true when the response is the stored response of a previous request with the same Idempotency-Key"
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /v1/locations [post]
 func (ct *LocationController) CreateLocationEndpoint() customHTTP.Endpoint {
 	return customHTTP.Endpoint{
-		Method:  http.MethodPost,
-		Path:    "/v1/locations",
-		Handler: ct.createLocation,
+		Method:         http.MethodPost,
+		Path:           "/v1/locations",
+		Handler:        ct.createLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
 	}
 }
 
@@ -69,14 +115,41 @@ func (ct *LocationController) CreateLocationEndpoint() customHTTP.Endpoint {
 // @Description Update an existing location
 // @Produce json
 // @Param locationID path string true "Location ID"
+// @Param If-Match header string true "ETag of the location being updated"
 // @Param request body dto.UpdateLocationRequest true "Location attributes"
 // @Success 200 {object} []domain.Location
+// @Header 200 {string} ETag "Version of the updated location"
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /v1/locations/{locationID} [put]
 func (ct *LocationController) UpdateLocationEndpoint() customHTTP.Endpoint {
 	return customHTTP.Endpoint{
-		Method:  http.MethodPut,
-		Path:    "/v1/locations/:locationID",
-		Handler: ct.updateLocation,
+		Method:         http.MethodPut,
+		Path:           "/v1/locations/:locationID",
+		Handler:        ct.updateLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Partially update existing location
+// @Description Apply a JSON merge patch (RFC 7396) to an existing location. Only the fields present in the document are updated
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Param If-Match header string true "ETag of the location being updated"
+// @Param request body dto.PatchLocationRequest true "Location attributes to change"
+// @Success 200 {object} domain.Location
+// @Header 200 {string} ETag "Version of the updated location"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID} [patch]
+func (ct *LocationController) PatchLocationEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPatch,
+		Path:           "/v1/locations/:locationID",
+		Handler:        ct.patchLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
 	}
 }
 
@@ -85,16 +158,60 @@ func (ct *LocationController) UpdateLocationEndpoint() customHTTP.Endpoint {
 // @Description Get paginated locations
 // @Produce json
 // @Param name query string false "Optional location name section. Service will filter locations that include this string"
+// @Param city query string false "Optional filter by city, case insensitive"
+// @Param state query string false "Optional filter by state, case insensitive"
+// @Param zipcode query string false "Optional filter by zipcode"
+// @Param location_type_id query []int false "Optional filter by location type IDs, repeat the param or send a comma separated list" collectionFormat(multi)
+// @Param supplier_id query []int false "Optional filter by supplier IDs, repeat the param or send a comma separated list" collectionFormat(multi)
+// @Param active query bool false "Optional filter by active status"
+// @Param created_from query string false "Only locations created at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param created_to query string false "Only locations created before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day"
+// @Param updated_from query string false "Only locations updated at or after this RFC 3339 timestamp or YYYY-MM-DD date, the creation counts as an update"
+// @Param updated_to query string false "Only locations updated before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day, the creation counts as an update"
+// @Param include_deleted query bool false "Include soft deleted locations, default to false"
+// @Param sort query string false "Comma separated sort fields, prefix a field with '-' to sort it descending. Accepted fields: name, created_at, city, state, zipcode. Default to 'name'"
 // @Param limit query int false "Pagination limit, default to 10000"
-// @Param cursor query string false "Cursor value, default to empty string"
+// @Param cursor query string false "Opaque cursor returned by a previous page, it must be used with the same sort. Default to empty string"
 // @Param direction query string true "Indicates the cursor direction. Accepted values: 'next' or 'prev'"
 // @Success 200 {object} []domain.ExampleCursorPage
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /v1/locations [get]
 func (ct *LocationController) PaginatedLocationsEndpoint() customHTTP.Endpoint {
 	return customHTTP.Endpoint{
-		Method:  http.MethodGet,
-		Path:    "/v1/locations",
-		Handler: ct.getPaginatedLocations,
+		Method:         http.MethodGet,
+		Path:           "/v1/locations",
+		Handler:        ct.getPaginatedLocations,
+		RequiredScopes: []string{ScopeLocationsRead},
+	}
+}
+
+// Nada godoc
+// @Summary Search nearby locations
+// @Description Get the locations within a radius of a point, ordered by great-circle distance
+// @Produce json
+// @Param lat query number true "Latitude of the search center"
+// @Param lng query number true "Longitude of the search center"
+// @Param radius_m query number true "Search radius in meters, up to 100000"
+// @Param limit query int false "Maximum number of results, default to 100 and up to 1000"
+// @Param name query string false "Optional location name section. Service will filter locations that include this string"
+// @Param city query string false "Optional filter by city, case insensitive"
+// @Param state query string false "Optional filter by state, case insensitive"
+// @Param zipcode query string false "Optional filter by zipcode"
+// @Param location_type_id query []int false "Optional filter by location type IDs" collectionFormat(multi)
+// @Param supplier_id query []int false "Optional filter by supplier IDs" collectionFormat(multi)
+// @Param active query bool false "Optional filter by active status"
+// @Param include_deleted query bool false "Include soft deleted locations, default to false"
+// @Success 200 {object} []domain.NearbyLocation
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/nearby [get]
+func (ct *LocationController) NearbyLocationsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/locations/nearby",
+		Handler:        ct.getNearbyLocations,
+		RequiredScopes: []string{ScopeLocationsRead},
 	}
 }
 
@@ -103,13 +220,75 @@ func (ct *LocationController) PaginatedLocationsEndpoint() customHTTP.Endpoint {
 // @Description Get location details
 // @Produce json
 // @Param locationID path string true "Location ID"
+// @Param include_deleted query bool false "Return the location even if it was soft deleted, default to false"
 // @Success 200 {object} domain.Location
+// @Header 200 {string} ETag "Version of the location, send it back as If-Match when updating it"
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /v1/locations/{locationID} [get]
 func (ct *LocationController) LocationDetailsEndpoint() customHTTP.Endpoint {
 	return customHTTP.Endpoint{
-		Method:  http.MethodGet,
-		Path:    "/v1/locations/:locationID",
-		Handler: ct.getLocationDetails,
+		Method:         http.MethodGet,
+		Path:           "/v1/locations/:locationID",
+		Handler:        ct.getLocationDetails,
+		RequiredScopes: []string{ScopeLocationsRead},
+	}
+}
+
+// Nada godoc
+// @Summary Retrieve location history
+// @Description Get the paginated list of changes made to a location, oldest first
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Param limit query int false "Pagination limit, default to 10000"
+// @Param cursor query string false "Cursor value, default to empty string"
+// @Param direction query string true "Indicates the cursor direction. Accepted values: 'next' or 'prev'"
+// @Success 200 {object} []domain.ExampleCursorPage
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID}/history [get]
+func (ct *LocationController) LocationHistoryEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/locations/:locationID/history",
+		Handler:        ct.getLocationHistory,
+		RequiredScopes: []string{ScopeLocationsRead},
+	}
+}
+
+// Nada godoc
+// @Summary Delete location
+// @Description Soft delete a location. Deleted locations can be brought back with the restore endpoint
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID} [delete]
+func (ct *LocationController) DeleteLocationEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodDelete,
+		Path:           "/v1/locations/:locationID",
+		Handler:        ct.deleteLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Restore location
+// @Description Restore a soft deleted location
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Success 200 {object} domain.Location
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID}/restore [post]
+func (ct *LocationController) RestoreLocationEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/locations/:locationID/restore",
+		Handler:        ct.restoreLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
 	}
 }
 
@@ -123,7 +302,7 @@ func (ct *LocationController) createLocationMock(c echo.Context) error {
 	err := ct.locationService.CreateLocationMock(appCtx)
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "failed to create location mock", err)
-		return c.JSON(httpStatusFromError(err), buildFailResponse(err, err.Error(), appCtx.GetCorrelationID()))
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create location mock", appCtx.GetCorrelationID())
 	}
 
 	return c.JSON(http.StatusOK, "ok")
@@ -139,13 +318,13 @@ func (ct *LocationController) createLocation(c echo.Context) error {
 	createLocationRequest, err := parseAndValidateBody[dto.CreateLocationRequest](c.Request().Body, ct.validator)
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
-		return c.JSON(http.StatusBadRequest, buildFailResponse(err, "failed to parse or validate request body", appCtx.GetCorrelationID()))
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
 	}
 
 	location, err := ct.locationService.CreateLocation(appCtx, createLocationRequest)
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "failed to create location", err)
-		return c.JSON(httpStatusFromError(err), buildFailResponse(err, err.Error(), appCtx.GetCorrelationID()))
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create location", appCtx.GetCorrelationID())
 	}
 
 	return c.JSON(http.StatusOK, buildSuccessResponse(location))
@@ -161,26 +340,80 @@ func (ct *LocationController) updateLocation(c echo.Context) error {
 	locationID := c.Param("locationID")
 	if locationID == "" {
 		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
-		return c.JSON(http.StatusBadRequest, buildFailResponse(ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID()))
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
 	}
 
 	updateLocationRequest, err := parseAndValidateBody[dto.UpdateLocationRequest](c.Request().Body, ct.validator)
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
-		return c.JSON(http.StatusBadRequest, buildFailResponse(err, "failed to parse or validate request body", appCtx.GetCorrelationID()))
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
 	}
 
 	if locationID != updateLocationRequest.ID {
 		ct.logger.ErrorCtx(appCtx, fnName, ErrLocationIDMismatch.Error(), ErrLocationIDMismatch)
-		return c.JSON(http.StatusBadRequest, buildFailResponse(ErrLocationIDMismatch, ErrLocationIDMismatch.Error(), appCtx.GetCorrelationID()))
+		return respondWithError(c, http.StatusBadRequest, ErrLocationIDMismatch, ErrLocationIDMismatch.Error(), appCtx.GetCorrelationID())
+	}
+
+	expectedVersion, err := parseIfMatch(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, ifMatchStatusFromError(err), err, err.Error(), appCtx.GetCorrelationID())
 	}
 
-	location, err := ct.locationService.UpdateLocation(appCtx, updateLocationRequest)
+	location, err := ct.locationService.UpdateLocation(appCtx, updateLocationRequest, expectedVersion)
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "failed to update location", err)
-		return c.JSON(http.StatusBadRequest, buildFailResponse(err, "failed to update location", appCtx.GetCorrelationID()))
+		return respondWithError(c, httpStatusFromError(err), err, "failed to update location", appCtx.GetCorrelationID())
+	}
+
+	setLocationETag(c, location)
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(location))
+}
+
+func (ct *LocationController) patchLocation(c echo.Context) error {
+	fnName := "LocationController.patchLocation"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID := c.Param("locationID")
+	if locationID == "" {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
+	}
+
+	expectedVersion, err := parseIfMatch(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, ifMatchStatusFromError(err), err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if !isMergePatchRequest(c.Request()) {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrUnsupportedPatchType.Error(), ErrUnsupportedPatchType)
+		return respondWithError(c, http.StatusUnsupportedMediaType, ErrUnsupportedPatchType, ErrUnsupportedPatchType.Error(), appCtx.GetCorrelationID())
+	}
+
+	var patchLocationRequest dto.PatchLocationRequest
+	if err = json.NewDecoder(c.Request().Body).Decode(&patchLocationRequest); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	if err = patchLocationRequest.Validate(); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
 	}
 
+	location, err := ct.locationService.PatchLocation(appCtx, locationID, patchLocationRequest, expectedVersion)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to patch location", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to patch location", appCtx.GetCorrelationID())
+	}
+
+	setLocationETag(c, location)
+
 	return c.JSON(http.StatusOK, buildSuccessResponse(location))
 }
 
@@ -195,19 +428,43 @@ func (ct *LocationController) getPaginatedLocations(c echo.Context) error {
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "error building location filters", err)
 		span.SetStatus(codes.Error, err.Error())
-		return c.JSON(http.StatusBadRequest, buildFailResponse(err, err.Error(), appCtx.GetCorrelationID()))
+		return respondWithError(c, http.StatusBadRequest, err, "invalid location filters", appCtx.GetCorrelationID())
 	}
 
 	locationPage, err := ct.locationService.GetPaginatedLocations(appCtx, filters)
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "failed to get paginated locations", err)
 		span.SetStatus(codes.Error, err.Error())
-		return c.JSON(http.StatusBadRequest, buildFailResponse(err, "failed to get paginated locations", appCtx.GetCorrelationID()))
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get paginated locations", appCtx.GetCorrelationID())
 	}
 
 	return c.JSON(http.StatusOK, buildSuccessResponse(locationPage))
 }
 
+func (ct *LocationController) getNearbyLocations(c echo.Context) error {
+	fnName := "LocationController.getNearbyLocations"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	filters, err := buildNearbyLocationsFilters(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "error building nearby location filters", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, http.StatusBadRequest, err, "invalid location filters", appCtx.GetCorrelationID())
+	}
+
+	locations, err := ct.locationService.GetNearbyLocations(appCtx, filters)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get nearby locations", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get nearby locations", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(locations))
+}
+
 func (ct *LocationController) getLocationDetails(c echo.Context) error {
 	fnName := "LocationController.getLocationDetails"
 	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
@@ -218,37 +475,353 @@ func (ct *LocationController) getLocationDetails(c echo.Context) error {
 	locationID := c.Param("locationID")
 	if locationID == "" {
 		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
-		return c.JSON(http.StatusBadRequest, buildFailResponse(ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID()))
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
 	}
 
-	location, err := ct.locationService.GetLocationByID(appCtx, locationID)
+	includeDeleted, err := parseIncludeDeleted(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	location, err := ct.locationService.GetLocationByID(appCtx, locationID, includeDeleted)
 	if err != nil {
 		ct.logger.ErrorCtx(appCtx, fnName, "failed to retrieve location by ID", err)
-		return c.JSON(http.StatusBadRequest, buildFailResponse(err, err.Error(), appCtx.GetCorrelationID()))
+		return respondWithError(c, httpStatusFromError(err), err, "failed to retrieve location", appCtx.GetCorrelationID())
 	}
 
 	if location == nil {
-		errMsg := fmt.Errorf("location with ID %v not found", locationID)
-		ct.logger.ErrorCtx(appCtx, fnName, errMsg.Error(), err)
-		return c.JSON(http.StatusNotFound, buildFailResponse(errMsg, errMsg.Error(), appCtx.GetCorrelationID()))
+		notFoundErr := domain.NotFoundErr{Msg: fmt.Sprintf("location with ID %v not found", locationID), Resource: domain.ResourceLocation}
+		ct.logger.ErrorCtx(appCtx, fnName, notFoundErr.Error(), notFoundErr)
+		return respondWithError(c, http.StatusNotFound, notFoundErr, "failed to retrieve location", appCtx.GetCorrelationID())
 	}
 
+	setLocationETag(c, *location)
+
 	return c.JSON(http.StatusOK, buildSuccessResponse(location))
 }
 
-func buildLocationFilters(req *http.Request) (domain.LocationsFilters, error) {
-	locationFilters := domain.LocationsFilters{}
+func (ct *LocationController) getLocationHistory(c echo.Context) error {
+	fnName := "LocationController.getLocationHistory"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID := c.Param("locationID")
+	if locationID == "" {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
+	}
+
+	cursorPaginationFilters, err := buildCursorPaginationFilters(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "error building location history filters", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
 
-	cursorPaginationFilters, err := buildCursorPaginationFilters(req)
+	historyPage, err := ct.locationService.GetLocationHistory(appCtx, domain.LocationHistoryFilters{
+		CursorPaginationFilters: cursorPaginationFilters,
+		LocationID:              locationID,
+	})
 	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get location history", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get location history", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(historyPage))
+}
+
+func (ct *LocationController) deleteLocation(c echo.Context) error {
+	fnName := "LocationController.deleteLocation"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID := c.Param("locationID")
+	if locationID == "" {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err := ct.locationService.DeleteLocation(appCtx, locationID); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to delete location", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to delete location", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (ct *LocationController) restoreLocation(c echo.Context) error {
+	fnName := "LocationController.restoreLocation"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID := c.Param("locationID")
+	if locationID == "" {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
+	}
+
+	location, err := ct.locationService.RestoreLocation(appCtx, locationID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to restore location", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to restore location", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(location))
+}
+
+func buildLocationFilters(req *http.Request) (domain.LocationsFilters, error) {
+	locationFilters, attributesErr := buildLocationAttributeFilters(req)
+	cursorPaginationFilters, paginationErr := buildCursorPaginationFilters(req)
+
+	var sortErr error
+	cursorPaginationFilters.Sort, sortErr = parseLocationSortQP(req)
+
+	if err := errors.Join(attributesErr, paginationErr, sortErr); err != nil {
 		return locationFilters, err
 	}
 
 	locationFilters.CursorPaginationFilters = cursorPaginationFilters
 
-	if nameVal, ok := req.URL.Query()["name"]; ok {
+	return locationFilters, nil
+}
+
+// parseLocationSortQP parses the sort of the locations, which defaults to the name
+func parseLocationSortQP(req *http.Request) (domain.SortOrder, error) {
+	sortVal := req.URL.Query().Get(SortQP)
+	if sortVal == "" {
+		return domain.DefaultLocationSortOrder, nil
+	}
+
+	sort, err := domain.ParseSortOrder(sortVal, domain.LocationSortFields)
+	if err != nil {
+		return nil, FieldErr{Field: SortQP, Err: err}
+	}
+
+	return sort, nil
+}
+
+// buildLocationAttributeFilters parses the filters shared by every location search. Every invalid query param is
+// reported, each one as a FieldErr.
+func buildLocationAttributeFilters(req *http.Request) (domain.LocationsFilters, error) {
+	locationFilters := domain.LocationsFilters{}
+	queryString := req.URL.Query()
+	var errs []error
+
+	if nameVal, ok := queryString[NameQP]; ok {
 		locationFilters.Name = utils.ToPointer[string](nameVal[0])
 	}
 
-	return locationFilters, nil
+	locationFilters.City = optionalStringQP(queryString, CityQP)
+	locationFilters.State = optionalStringQP(queryString, StateQP)
+	locationFilters.Zipcode = optionalStringQP(queryString, ZipcodeQP)
+
+	var err error
+	if locationFilters.LocationTypeIDs, err = parseIntListQP(queryString, LocationTypeIDQP); err != nil {
+		errs = append(errs, err)
+	}
+
+	if locationFilters.SupplierIDs, err = parseIntListQP(queryString, SupplierIDQP); err != nil {
+		errs = append(errs, err)
+	}
+
+	if activeVal := queryString.Get(ActiveQP); activeVal != "" {
+		active, err := strconv.ParseBool(activeVal)
+		if err != nil {
+			errs = append(errs, FieldErr{Field: ActiveQP, Err: ErrInvalidActiveQP})
+		} else {
+			locationFilters.Active = &active
+		}
+	}
+
+	if locationFilters.CreatedFrom, locationFilters.CreatedTo, err = parseDateRangeQP(queryString, CreatedFromQP, CreatedToQP); err != nil {
+		errs = append(errs, err)
+	}
+
+	if locationFilters.UpdatedFrom, locationFilters.UpdatedTo, err = parseDateRangeQP(queryString, UpdatedFromQP, UpdatedToQP); err != nil {
+		errs = append(errs, err)
+	}
+
+	if locationFilters.IncludeDeleted, err = parseIncludeDeleted(req); err != nil {
+		errs = append(errs, FieldErr{Field: IncludeDeletedQP, Err: err})
+	}
+
+	return locationFilters, errors.Join(errs...)
+}
+
+func optionalStringQP(queryString url.Values, name string) *string {
+	if value := strings.TrimSpace(queryString.Get(name)); value != "" {
+		return &value
+	}
+
+	return nil
+}
+
+// parseIntListQP accepts both repeated query params and comma separated values
+func parseIntListQP(queryString url.Values, name string) ([]int, error) {
+	var values []int
+
+	for _, rawValues := range queryString[name] {
+		for _, rawValue := range strings.Split(rawValues, ",") {
+			value, err := strconv.Atoi(strings.TrimSpace(rawValue))
+			if err != nil {
+				return nil, FieldErr{Field: name, Err: fmt.Errorf("invalid %v value '%v', it must be a list of integers", name, rawValue)}
+			}
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// parseDateRangeQP parses an optional [from, to) range. Dates without time are taken as UTC days and a date sent as
+// the upper bound includes that whole day.
+func parseDateRangeQP(queryString url.Values, fromName, toName string) (*time.Time, *time.Time, error) {
+	from, fromErr := parseTimeQP(queryString, fromName, false)
+	to, toErr := parseTimeQP(queryString, toName, true)
+	if err := errors.Join(fromErr, toErr); err != nil {
+		return nil, nil, err
+	}
+
+	if from != nil && to != nil && !to.After(*from) {
+		return nil, nil, FieldErr{Field: toName, Err: ErrInvalidDateRange}
+	}
+
+	return from, to, nil
+}
+
+func parseTimeQP(queryString url.Values, name string, endOfRange bool) (*time.Time, error) {
+	rawValue := queryString.Get(name)
+	if rawValue == "" {
+		return nil, nil
+	}
+
+	if value, err := time.Parse(time.RFC3339, rawValue); err == nil {
+		return &value, nil
+	}
+
+	value, err := time.Parse(time.DateOnly, rawValue)
+	if err != nil {
+		return nil, FieldErr{Field: name, Err: fmt.Errorf("invalid %v value '%v', it must be an RFC 3339 timestamp or a YYYY-MM-DD date", name, rawValue)}
+	}
+
+	if endOfRange {
+		value = value.AddDate(0, 0, 1)
+	}
+
+	return &value, nil
+}
+
+func buildNearbyLocationsFilters(req *http.Request) (domain.NearbyLocationsFilters, error) {
+	nearbyFilters := domain.NearbyLocationsFilters{}
+
+	locationFilters, err := buildLocationAttributeFilters(req)
+	if err != nil {
+		return nearbyFilters, err
+	}
+
+	nearbyFilters.LocationsFilters = locationFilters
+
+	if nearbyFilters.Latitude, err = parseRequiredFloatQP(req, LatitudeQP, -90, 90); err != nil {
+		return nearbyFilters, err
+	}
+
+	if nearbyFilters.Longitude, err = parseRequiredFloatQP(req, LongitudeQP, -180, 180); err != nil {
+		return nearbyFilters, err
+	}
+
+	if nearbyFilters.RadiusMeters, err = parseRequiredFloatQP(req, RadiusQP, 0, MaxNearbyRadiusMeters); err != nil {
+		return nearbyFilters, err
+	}
+	if nearbyFilters.RadiusMeters == 0 {
+		return nearbyFilters, FieldErr{Field: RadiusQP, Err: fmt.Errorf("'%v' query param must be greater than 0", RadiusQP)}
+	}
+
+	nearbyFilters.Limit = DefaultNearbyLimit
+	if limitVal := req.URL.Query().Get(LimitQP); limitVal != "" {
+		limit, err := strconv.Atoi(limitVal)
+		if err != nil || limit < 1 || limit > MaxNearbyLimit {
+			return nearbyFilters, FieldErr{Field: LimitQP, Err: fmt.Errorf("'%v' query param must be an integer between 1 and %v", LimitQP, MaxNearbyLimit)}
+		}
+		nearbyFilters.Limit = limit
+	}
+
+	return nearbyFilters, nil
+}
+
+func parseRequiredFloatQP(req *http.Request, name string, minValue, maxValue float64) (float64, error) {
+	rawValue := req.URL.Query().Get(name)
+	if rawValue == "" {
+		return 0, FieldErr{Field: name, Err: fmt.Errorf("'%v' query param not provided", name)}
+	}
+
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil || value < minValue || value > maxValue {
+		return 0, FieldErr{Field: name, Err: fmt.Errorf("'%v' query param must be a number between %v and %v", name, minValue, maxValue)}
+	}
+
+	return value, nil
+}
+
+func parseIncludeDeleted(req *http.Request) (bool, error) {
+	includeDeletedVal := req.URL.Query().Get(IncludeDeletedQP)
+	if includeDeletedVal == "" {
+		return false, nil
+	}
+
+	includeDeleted, err := strconv.ParseBool(includeDeletedVal)
+	if err != nil {
+		return false, ErrInvalidIncludeDeletedQP
+	}
+
+	return includeDeleted, nil
+}
+
+// isMergePatchRequest accepts merge patch documents, plain JSON is also accepted for clients that cannot set the type
+func isMergePatchRequest(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == MergePatchMIMEType || mediaType == echo.MIMEApplicationJSON
+}
+
+func setLocationETag(c echo.Context, location domain.Location) {
+	c.Response().Header().Set(HeaderETag, strconv.Quote(strconv.Itoa(location.Version)))
+}
+
+// parseIfMatch returns the location version the client based its changes on. Only a single strong ETag is accepted.
+func parseIfMatch(req *http.Request) (int, error) {
+	ifMatch := strings.TrimSpace(req.Header.Get(HeaderIfMatch))
+	if ifMatch == "" {
+		return 0, ErrMissingIfMatch
+	}
+
+	unquoted, err := strconv.Unquote(ifMatch)
+	if err != nil {
+		return 0, ErrInvalidIfMatch
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil {
+		return 0, ErrInvalidIfMatch
+	}
+
+	return version, nil
+}
+
+func ifMatchStatusFromError(err error) int {
+	if errors.Is(err, ErrMissingIfMatch) {
+		return http.StatusPreconditionRequired
+	}
+
+	return http.StatusBadRequest
 }
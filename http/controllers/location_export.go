@@ -0,0 +1,337 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatQP             = "format"
+	ExportFormatCSV      = "csv"
+	ExportFormatNDJSON   = "ndjson"
+	ExportFormatGeoJSON  = "geojson"
+	GeoJSONMIMEType      = "application/geo+json"
+	exportFlushEveryRows = 500
+)
+
+var ErrInvalidExportFormat = errors.New("invalid format value, accepted values: '" + ExportFormatCSV + "', '" + ExportFormatNDJSON + "' or '" + ExportFormatGeoJSON + "'")
+
+var exportContentTypes = map[string]string{
+	ExportFormatCSV:     CSVMIMEType + "; charset=utf-8",
+	ExportFormatNDJSON:  NDJSONMIMEType,
+	ExportFormatGeoJSON: GeoJSONMIMEType,
+}
+
+var exportCSVHeader = []string{
+	"id", "name", "active", "supplier_id", "supplier_name", "location_type_id", "location_type", "address", "city", "state",
+	"zipcode", "latitude", "longitude", "contact_person", "phone_number", "email", "version", "created_at", "deleted_at",
+}
+
+// Nada godoc
+// @Summary Export locations
+// @Description Stream every location matching the filters as a file download. Unlike the paginated list the whole result is sent in one response, rows are written as they are read from the database. When the export fails after the first row the connection is closed before the end of the file, so a partial file is never received as a complete one
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/geo+json
+// @Param format query string false "Export format. Accepted values: 'csv', 'ndjson' or 'geojson'. Default to 'csv'"
+// @Param name query string false "Optional location name section. Service will filter locations that include this string"
+// @Param city query string false "Optional filter by city"
+// @Param state query string false "Optional filter by state"
+// @Param zipcode query string false "Optional filter by zipcode"
+// @Param location_type_id query []int false "Optional filter by location type IDs, repeat the param or send a comma separated list" collectionFormat(multi)
+// @Param supplier_id query []int false "Optional filter by supplier IDs, repeat the param or send a comma separated list" collectionFormat(multi)
+// @Param active query bool false "Optional filter by active status"
+// @Param created_from query string false "Only locations created at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param created_to query string false "Only locations created before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day"
+// @Param updated_from query string false "Only locations updated at or after this RFC 3339 timestamp or YYYY-MM-DD date, the creation counts as an update"
+// @Param updated_to query string false "Only locations updated before this RFC 3339 timestamp, a YYYY-MM-DD date includes the whole day, the creation counts as an update"
+// @Param include_deleted query bool false "Include soft deleted locations, default to false"
+// @Param sort query string false "Comma separated sort fields, prefix a field with '-' to sort it descending. Accepted fields: name, created_at, city, state, zipcode. Default to 'name'"
+// @Success 200 {file} file
+// @Header 200 {string} Content-Disposition "attachment; filename=locations-<timestamp>.<format>"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/export [get]
+func (ct *LocationController) ExportLocationsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/locations/export",
+		Handler:        ct.exportLocations,
+		RequiredScopes: []string{ScopeLocationsRead},
+	}
+}
+
+func (ct *LocationController) exportLocations(c echo.Context) error {
+	fnName := "LocationController.exportLocations"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	format, filters, err := buildLocationExportFilters(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "error building location export filters", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, http.StatusBadRequest, err, "invalid location filters", appCtx.GetCorrelationID())
+	}
+
+	// The response is only committed with the first location, so errors found before it are still sent as JSON
+	exporter := newLocationExporter(format, c.Response())
+	started := false
+	start := func() error {
+		started = true
+		setExportHeaders(c, format)
+		c.Response().WriteHeader(http.StatusOK)
+		return exporter.begin()
+	}
+
+	exported := 0
+	err = ct.locationService.ExportLocations(appCtx, filters, func(location domain.Location) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		if err := exporter.write(location); err != nil {
+			return err
+		}
+
+		if exported++; exported%exportFlushEveryRows == 0 {
+			return exporter.flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to export locations", err)
+		span.SetStatus(codes.Error, err.Error())
+		if !started {
+			return respondWithError(c, httpStatusFromError(err), err, "failed to export locations", appCtx.GetCorrelationID())
+		}
+		abortExport()
+	}
+
+	if !started {
+		err = start()
+	}
+	if err == nil {
+		err = exporter.end()
+	}
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to write locations export", err)
+		span.SetStatus(codes.Error, err.Error())
+		abortExport()
+	}
+
+	return nil
+}
+
+// abortExport breaks the connection of an export whose status was already sent, so the client sees an incomplete
+// transfer instead of a file that ends where the export failed
+func abortExport() {
+	panic(http.ErrAbortHandler)
+}
+
+func buildLocationExportFilters(req *http.Request) (string, domain.LocationsFilters, error) {
+	filters, attributesErr := buildLocationAttributeFilters(req)
+
+	var sortErr error
+	filters.Sort, sortErr = parseLocationSortQP(req)
+
+	var formatErr error
+	format := req.URL.Query().Get(FormatQP)
+	if format == "" {
+		format = ExportFormatCSV
+	} else if _, ok := exportContentTypes[format]; !ok {
+		formatErr = FieldErr{Field: FormatQP, Err: ErrInvalidExportFormat}
+	}
+
+	return format, filters, errors.Join(attributesErr, sortErr, formatErr)
+}
+
+func setExportHeaders(c echo.Context, format string) {
+	filename := fmt.Sprintf("locations-%v.%v", time.Now().UTC().Format("20060102T150405Z"), format)
+
+	c.Response().Header().Set(echo.HeaderContentType, exportContentTypes[format])
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+}
+
+// locationExporter writes the locations of an export in one of the export formats
+type locationExporter interface {
+	begin() error
+	write(location domain.Location) error
+	flush() error
+	end() error
+}
+
+func newLocationExporter(format string, response *echo.Response) locationExporter {
+	switch format {
+	case ExportFormatNDJSON:
+		return &ndjsonLocationExporter{response: response, encoder: json.NewEncoder(response)}
+	case ExportFormatGeoJSON:
+		return &geoJSONLocationExporter{response: response}
+	default:
+		return &csvLocationExporter{writer: csv.NewWriter(response), response: response}
+	}
+}
+
+type csvLocationExporter struct {
+	writer   *csv.Writer
+	response *echo.Response
+}
+
+func (e *csvLocationExporter) begin() error {
+	return e.writer.Write(exportCSVHeader)
+}
+
+func (e *csvLocationExporter) write(location domain.Location) error {
+	info := location.Information
+	deletedAt := ""
+	if location.DeletedAt != nil {
+		deletedAt = location.DeletedAt.UTC().Format(time.RFC3339)
+	}
+
+	return e.writer.Write([]string{
+		location.ID,
+		location.Name,
+		strconv.FormatBool(location.Active),
+		strconv.Itoa(location.Supplier.ID),
+		location.Supplier.Name,
+		strconv.Itoa(location.LocationType.ID),
+		location.LocationType.Type,
+		info.Address,
+		info.City,
+		info.State,
+		info.Zipcode,
+		strconv.FormatFloat(info.Latitude, 'f', -1, 64),
+		strconv.FormatFloat(info.Longitude, 'f', -1, 64),
+		stringOrEmpty(info.ContactInformation.ContactPerson),
+		stringOrEmpty(info.ContactInformation.PhoneNumber),
+		stringOrEmpty(info.ContactInformation.Email),
+		strconv.Itoa(location.Version),
+		location.CreatedAt.UTC().Format(time.RFC3339),
+		deletedAt,
+	})
+}
+
+func (e *csvLocationExporter) flush() error {
+	e.writer.Flush()
+	e.response.Flush()
+
+	return e.writer.Error()
+}
+
+func (e *csvLocationExporter) end() error {
+	return e.flush()
+}
+
+type ndjsonLocationExporter struct {
+	response *echo.Response
+	encoder  *json.Encoder
+}
+
+func (e *ndjsonLocationExporter) begin() error {
+	return nil
+}
+
+func (e *ndjsonLocationExporter) write(location domain.Location) error {
+	return e.encoder.Encode(location)
+}
+
+func (e *ndjsonLocationExporter) flush() error {
+	e.response.Flush()
+
+	return nil
+}
+
+func (e *ndjsonLocationExporter) end() error {
+	return e.flush()
+}
+
+// geoJSONLocationExporter writes a FeatureCollection with a Point feature per location. The collection is written by
+// hand so features can be streamed one at a time.
+type geoJSONLocationExporter struct {
+	response *echo.Response
+	features int
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties domain.Location `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+func (e *geoJSONLocationExporter) begin() error {
+	_, err := io.WriteString(e.response, `{"type":"FeatureCollection","features":[`)
+
+	return err
+}
+
+func (e *geoJSONLocationExporter) write(location domain.Location) error {
+	feature, err := json.Marshal(geoJSONFeature{
+		Type: "Feature",
+		ID:   location.ID,
+		Geometry: geoJSONGeometry{
+			Type: "Point",
+			// GeoJSON positions are longitude first
+			Coordinates: [2]float64{location.Information.Longitude, location.Information.Latitude},
+		},
+		Properties: location,
+	})
+	if err != nil {
+		return err
+	}
+
+	if e.features > 0 {
+		if _, err = io.WriteString(e.response, ","); err != nil {
+			return err
+		}
+	}
+	e.features++
+
+	_, err = e.response.Write(feature)
+
+	return err
+}
+
+func (e *geoJSONLocationExporter) flush() error {
+	e.response.Flush()
+
+	return nil
+}
+
+func (e *geoJSONLocationExporter) end() error {
+	if _, err := io.WriteString(e.response, "]}\n"); err != nil {
+		return err
+	}
+
+	return e.flush()
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
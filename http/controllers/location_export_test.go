@@ -0,0 +1,149 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-service-template/domain"
+	"go-service-template/http/controllers"
+	"go-service-template/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var exportedLocations = []domain.Location{
+	{
+		ID:           "first",
+		Name:         "First, Inc",
+		Active:       true,
+		Supplier:     domain.Supplier{ID: 1, Name: "Supplier"},
+		LocationType: domain.LocationType{ID: 2, Type: "Type"},
+		Information: domain.LocationInformation{
+			Address: "Address", City: "Miami", State: "FL", Zipcode: "33101", Latitude: 25.77, Longitude: -80.19,
+			ContactInformation: domain.ContactInformation{Email: utils.ToPointer("first@mail.com")},
+		},
+		Version:   1,
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	},
+	{ID: "second", Name: "Second", Information: domain.LocationInformation{Latitude: 1, Longitude: 2}},
+}
+
+func (s *LocationControllerSuite) expectExportedLocations(matcher any) {
+	s.locationServiceMock.On("ExportLocations", mock.Anything, matcher, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(domain.Location) error)
+		for _, location := range exportedLocations {
+			assert.Nil(s.T(), fn(location))
+		}
+	}).Return(nil).Once()
+}
+
+func (s *LocationControllerSuite) exportLocations(query string) {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations/export"+query, nil)
+
+	assert.Nil(s.T(), s.exportLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+}
+
+func (s *LocationControllerSuite) Test_exportLocations_StreamsCSVWithFilters() {
+	s.expectExportedLocations(mock.MatchedBy(func(filters domain.LocationsFilters) bool {
+		return *filters.City == "Miami" && filters.Sort.String() == "-created_at"
+	}))
+
+	s.exportLocations("?city=Miami&sort=-created_at")
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), "text/csv; charset=utf-8", s.recorder.Header().Get(echo.HeaderContentType))
+	assert.Regexp(s.T(), `^attachment; filename=locations-\d{8}T\d{6}Z\.csv$`, s.recorder.Header().Get(echo.HeaderContentDisposition))
+
+	lines := strings.Split(strings.TrimSpace(s.recorder.Body.String()), "\n")
+	assert.Len(s.T(), lines, 3)
+	assert.True(s.T(), strings.HasPrefix(lines[0], "id,name,active,supplier_id"))
+	assert.Equal(s.T(), `first,"First, Inc",true,1,Supplier,2,Type,Address,Miami,FL,33101,25.77,-80.19,,,first@mail.com,1,2024-01-02T03:04:05Z,`, lines[1])
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_exportLocations_StreamsNDJSON() {
+	s.expectExportedLocations(mock.Anything)
+
+	s.exportLocations("?format=ndjson")
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), controllers.NDJSONMIMEType, s.recorder.Header().Get(echo.HeaderContentType))
+
+	lines := strings.Split(strings.TrimSpace(s.recorder.Body.String()), "\n")
+	assert.Len(s.T(), lines, 2)
+	var location domain.Location
+	assert.Nil(s.T(), json.Unmarshal([]byte(lines[1]), &location))
+	assert.Equal(s.T(), "second", location.ID)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_exportLocations_StreamsGeoJSONFeatureCollection() {
+	s.expectExportedLocations(mock.Anything)
+
+	s.exportLocations("?format=geojson")
+
+	assert.Equal(s.T(), controllers.GeoJSONMIMEType, s.recorder.Header().Get(echo.HeaderContentType))
+
+	var collection struct {
+		Type     string
+		Features []struct {
+			ID       string
+			Geometry struct {
+				Type        string
+				Coordinates []float64
+			}
+		}
+	}
+	assert.Nil(s.T(), json.Unmarshal(s.recorder.Body.Bytes(), &collection))
+	assert.Equal(s.T(), "FeatureCollection", collection.Type)
+	assert.Len(s.T(), collection.Features, 2)
+	assert.Equal(s.T(), "Point", collection.Features[0].Geometry.Type)
+	assert.Equal(s.T(), []float64{-80.19, 25.77}, collection.Features[0].Geometry.Coordinates)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_exportLocations_WritesEmptyGeoJSONCollection() {
+	s.locationServiceMock.On("ExportLocations", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	s.exportLocations("?format=geojson")
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.JSONEq(s.T(), `{"type":"FeatureCollection","features":[]}`, s.recorder.Body.String())
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_exportLocations_Returns400OnInvalidFormat() {
+	s.exportLocations("?format=xml&active=maybe")
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), `"parameter":"format"`)
+	assert.Contains(s.T(), s.recorder.Body.String(), `"parameter":"active"`)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_exportLocations_Returns500WhenExportFailsBeforeFirstRow() {
+	s.locationServiceMock.On("ExportLocations", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+
+	s.exportLocations("")
+
+	assert.Equal(s.T(), http.StatusInternalServerError, s.recorder.Code)
+	assert.Empty(s.T(), s.recorder.Header().Get(echo.HeaderContentDisposition))
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_exportLocations_AbortsConnectionWhenExportFailsAfterFirstRow() {
+	s.locationServiceMock.On("ExportLocations", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.Nil(s.T(), args.Get(2).(func(domain.Location) error)(exportedLocations[0]))
+	}).Return(errors.New("statement timeout")).Once()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations/export?format=ndjson", nil)
+
+	assert.PanicsWithValue(s.T(), http.ErrAbortHandler, func() {
+		_ = s.exportLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder))
+	})
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), `"id":"first"`)
+	s.assertMockExpectations()
+}
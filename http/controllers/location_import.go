@@ -0,0 +1,284 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/utils"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	DryRunQP         = "dry_run"
+	CSVMIMEType      = "text/csv"
+	NDJSONMIMEType   = "application/x-ndjson"
+	MaxImportRows    = 5000
+	MaxImportBytes   = 10 << 20
+	maxNDJSONLineLen = 1 << 20
+)
+
+var (
+	ErrUnsupportedImportType = errors.New("unsupported content type, imports must be sent as '" + CSVMIMEType + "' or '" + NDJSONMIMEType + "'")
+	ErrEmptyImport           = errors.New("the import file has no rows")
+	ErrTooManyImportRows     = fmt.Errorf("the import file has more than %v rows", MaxImportRows)
+	ErrInvalidDryRunQP       = errors.New("invalid dry_run value")
+)
+
+// importColumns are named as the JSON fields of dto.CreateLocationRequest
+var importColumns = []string{
+	"supplier_id", "name", "address", "city", "state", "zipcode", "location_type_id", "contact_person", "phone_number", "email",
+}
+
+var requiredImportColumns = []string{"supplier_id", "name", "address", "city", "state", "zipcode", "location_type_id"}
+
+// Nada godoc
+// @Summary Import locations
+// @Description Create locations in bulk from a CSV file with a header row or from NDJSON, one location per line. Columns and fields are the ones of the create location request. The response reports the result of every row, rows are numbered from 1 without counting the CSV header
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param dry_run query bool false "Validate and geocode the rows without creating them, default to false"
+// @Param request body string true "CSV or NDJSON file, up to 5000 rows"
+// @Success 200 {object} domain.LocationImportReport
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/import [post]
+func (ct *LocationController) ImportLocationsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/locations/import",
+		Handler:        ct.importLocations,
+		RequiredScopes: []string{ScopeLocationsWrite},
+	}
+}
+
+func (ct *LocationController) importLocations(c echo.Context) error {
+	fnName := "LocationController.importLocations"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	dryRun, err := parseDryRun(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || (mediaType != CSVMIMEType && mediaType != NDJSONMIMEType) {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrUnsupportedImportType.Error(), ErrUnsupportedImportType)
+		return respondWithError(c, http.StatusUnsupportedMediaType, ErrUnsupportedImportType, ErrUnsupportedImportType.Error(), appCtx.GetCorrelationID())
+	}
+
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, MaxImportBytes)
+
+	var rows []dto.ImportLocationRow
+	if mediaType == CSVMIMEType {
+		rows, err = parseCSVImport(body, ct.validator)
+	} else {
+		rows, err = parseNDJSONImport(body, ct.validator)
+	}
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse import file", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse import file", appCtx.GetCorrelationID())
+	}
+
+	report, err := ct.locationService.ImportLocations(appCtx, rows, dryRun)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to import locations", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to import locations", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(report))
+}
+
+func parseCSVImport(body io.Reader, v *validator.Validate) ([]dto.ImportLocationRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	for i, column := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(column))
+		if !utils.ListContains(importColumns, columns[i]) {
+			return nil, fmt.Errorf("unknown CSV column '%v'", column)
+		}
+	}
+
+	var missingColumns []string
+	for _, required := range requiredImportColumns {
+		if !utils.ListContains(columns, required) {
+			missingColumns = append(missingColumns, required)
+		}
+	}
+	if len(missingColumns) > 0 {
+		return nil, fmt.Errorf("missing CSV columns: %v", strings.Join(missingColumns, ", "))
+	}
+
+	var rows []dto.ImportLocationRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		row := dto.ImportLocationRow{Row: len(rows) + 1}
+		if err != nil {
+			if !errors.Is(err, csv.ErrFieldCount) {
+				return nil, fmt.Errorf("invalid CSV row %v: %w", row.Row, err)
+			}
+			row.Err = fmt.Errorf("the row has %v columns but the header has %v", len(record), len(columns))
+		} else {
+			var errs []error
+			for i, value := range record {
+				errs = append(errs, setImportColumn(&row.Request, columns[i], strings.TrimSpace(value)))
+			}
+			if row.Err = errors.Join(errs...); row.Err == nil {
+				row.Err = validateImportRow(v, row.Request)
+			}
+		}
+
+		if rows = append(rows, row); len(rows) > MaxImportRows {
+			return nil, ErrTooManyImportRows
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	return rows, nil
+}
+
+func parseNDJSONImport(body io.Reader, v *validator.Validate) ([]dto.ImportLocationRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineLen)
+
+	var rows []dto.ImportLocationRow
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		row := dto.ImportLocationRow{Row: len(rows) + 1}
+		if err := json.Unmarshal([]byte(line), &row.Request); err != nil {
+			row.Err = fmt.Errorf("invalid JSON: %w", err)
+		} else {
+			row.Err = validateImportRow(v, row.Request)
+		}
+
+		if rows = append(rows, row); len(rows) > MaxImportRows {
+			return nil, ErrTooManyImportRows
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	return rows, nil
+}
+
+// validateImportRow applies the create location rules, each failed rule is joined as its own error
+func validateImportRow(v *validator.Validate, request dto.CreateLocationRequest) error {
+	err := v.Struct(request)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		errs := make([]error, 0, len(validationErrs))
+		for _, validationErr := range validationErrs {
+			errs = append(errs, validationErr)
+		}
+		return errors.Join(errs...)
+	}
+
+	return err
+}
+
+func setImportColumn(request *dto.CreateLocationRequest, column, value string) error {
+	switch column {
+	case "supplier_id":
+		return setImportInt(&request.SupplierID, column, value)
+	case "name":
+		request.Name = value
+	case "address":
+		request.Address = value
+	case "city":
+		request.City = value
+	case "state":
+		request.State = value
+	case "zipcode":
+		request.Zipcode = value
+	case "location_type_id":
+		return setImportInt(&request.LocationTypeID, column, value)
+	case "contact_person":
+		request.ContactPerson = optionalImportString(value)
+	case "phone_number":
+		request.PhoneNumber = optionalImportString(value)
+	case "email":
+		request.Email = optionalImportString(value)
+	}
+
+	return nil
+}
+
+func setImportInt(target *int, column, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid %v value '%v', it must be an integer", column, value)
+	}
+	*target = intValue
+
+	return nil
+}
+
+func optionalImportString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func parseDryRun(req *http.Request) (bool, error) {
+	dryRunVal := req.URL.Query().Get(DryRunQP)
+	if dryRunVal == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(dryRunVal)
+	if err != nil {
+		return false, FieldErr{Field: DryRunQP, Err: ErrInvalidDryRunQP}
+	}
+
+	return dryRun, nil
+}
@@ -0,0 +1,106 @@
+package controllers_test
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/http/controllers"
+	"net/http"
+	"strings"
+)
+
+func (s *LocationControllerSuite) newImportRequest(query, contentType, body string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/locations/import"+query, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+
+	return req
+}
+
+func (s *LocationControllerSuite) Test_importLocations_ParsesCSVRows() {
+	body := "name,supplier_id,location_type_id,address,city,state,zipcode,email\n" +
+		"First,1,2,Address 1,City,State,11111,first@mail.com\n" +
+		"Second,one,2,Address 2,City,State,22222,\n" +
+		"Third,1,2,,City,State,33333,\n"
+
+	s.locationServiceMock.On("ImportLocations", mock.Anything, mock.Anything, true).Run(func(args mock.Arguments) {
+		rows := args.Get(1).([]dto.ImportLocationRow)
+		assert.Len(s.T(), rows, 3)
+
+		assert.Nil(s.T(), rows[0].Err)
+		assert.Equal(s.T(), 1, rows[0].Row)
+		assert.Equal(s.T(), "First", rows[0].Request.Name)
+		assert.Equal(s.T(), 1, rows[0].Request.SupplierID)
+		assert.Equal(s.T(), 2, rows[0].Request.LocationTypeID)
+		assert.Equal(s.T(), "first@mail.com", *rows[0].Request.Email)
+		assert.Nil(s.T(), rows[0].Request.ContactPerson)
+
+		assert.ErrorContains(s.T(), rows[1].Err, "invalid supplier_id value 'one'")
+		assert.Nil(s.T(), rows[1].Request.Email)
+
+		assert.ErrorContains(s.T(), rows[2].Err, "Address")
+	}).Return(domain.LocationImportReport{DryRun: true, Total: 3}, nil).Once()
+
+	req := s.newImportRequest("?dry_run=true", "text/csv; charset=utf-8", body)
+
+	assert.Nil(s.T(), s.importLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_importLocations_ParsesNDJSONRows() {
+	body := `{"name":"First","supplier_id":1,"location_type_id":2,"address":"A","city":"C","state":"S","zipcode":"Z"}` + "\n\n" +
+		`{"name":"Second",` + "\n"
+
+	s.locationServiceMock.On("ImportLocations", mock.Anything, mock.Anything, false).Run(func(args mock.Arguments) {
+		rows := args.Get(1).([]dto.ImportLocationRow)
+		assert.Len(s.T(), rows, 2)
+		assert.Nil(s.T(), rows[0].Err)
+		assert.Equal(s.T(), "First", rows[0].Request.Name)
+		assert.Equal(s.T(), 2, rows[1].Row)
+		assert.ErrorContains(s.T(), rows[1].Err, "invalid JSON")
+	}).Return(domain.LocationImportReport{Total: 2}, nil).Once()
+
+	req := s.newImportRequest("", controllers.NDJSONMIMEType, body)
+
+	assert.Nil(s.T(), s.importLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_importLocations_Returns400OnUnknownCSVColumn() {
+	req := s.newImportRequest("", controllers.CSVMIMEType, "name,website\nFirst,http://first.com\n")
+
+	assert.Nil(s.T(), s.importLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_importLocations_Returns400OnEmptyFile() {
+	req := s.newImportRequest("", controllers.NDJSONMIMEType, "\n")
+
+	assert.Nil(s.T(), s.importLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_importLocations_Returns415OnUnsupportedContentType() {
+	req := s.newImportRequest("", echo.MIMEApplicationJSON, "[]")
+
+	assert.Nil(s.T(), s.importLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusUnsupportedMediaType, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_importLocations_Returns500WhenImportFails() {
+	s.locationServiceMock.On("ImportLocations", mock.Anything, mock.Anything, false).
+		Return(domain.LocationImportReport{}, errors.New("db down")).Once()
+
+	req := s.newImportRequest("", controllers.CSVMIMEType, "name,supplier_id,location_type_id,address,city,state,zipcode\nA,1,1,A,C,S,Z\n")
+
+	assert.Nil(s.T(), s.importLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusInternalServerError, s.recorder.Code)
+	s.assertMockExpectations()
+}
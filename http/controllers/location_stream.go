@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go-service-template/config"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"go.opentelemetry.io/otel/codes"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	EventStreamMIMEType                   = "text/event-stream"
+	HeaderLastEventID                     = "Last-Event-ID"
+	LocationResetEvent                    = "location.reset"
+	DefaultStreamHeartbeatIntervalSeconds = 15
+	streamRetryMs                         = 3000
+	locationChangeEventPrefix             = "location."
+)
+
+type LocationStreamController struct {
+	logger            monitor.AppLogger
+	stream            services.ILocationChangeStream
+	heartbeatInterval time.Duration
+}
+
+func NewLocationStreamController(stream services.ILocationChangeStream, cfg config.LocationStreamConfig) *LocationStreamController {
+	heartbeatIntervalSeconds := config.GetIntValueOrDefault(cfg.HeartbeatIntervalSeconds, DefaultStreamHeartbeatIntervalSeconds)
+
+	return &LocationStreamController{
+		logger:            monitor.GetStdLogger("LocationStreamController"),
+		stream:            stream,
+		heartbeatInterval: time.Duration(heartbeatIntervalSeconds) * time.Second,
+	}
+}
+
+// Nada godoc
+// @Summary Stream location changes
+// @Description Push the created, updated, deleted and restored locations as Server-Sent Events. Each event is named after the change (location.created, location.updated, location.deleted or location.restored) and its data is the change with the location after it. Reconnecting clients send the Last-Event-ID header to receive the changes they missed, when that change is not buffered anymore a location.reset event is sent first and the locations should be reloaded. Idle streams receive a heartbeat comment periodically
+// @Produce text/event-stream
+// @Param supplier_id query []int false "Optional filter by supplier IDs, repeat the param or send a comma separated list" collectionFormat(multi)
+// @Param location_type_id query []int false "Optional filter by location type IDs, repeat the param or send a comma separated list" collectionFormat(multi)
+// @Param Last-Event-ID header string false "ID of the last event received, the stream resumes after it"
+// @Success 200 {object} domain.LocationChange
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/stream [get]
+func (ct *LocationStreamController) LocationChangesEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/locations/stream",
+		Handler:        ct.streamLocationChanges,
+		RequiredScopes: []string{ScopeLocationsRead},
+	}
+}
+
+func (ct *LocationStreamController) streamLocationChanges(c echo.Context) error {
+	fnName := "LocationStreamController.streamLocationChanges"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	filters, err := buildLocationChangeFilters(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "error building location change filters", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, http.StatusBadRequest, err, "invalid location filters", appCtx.GetCorrelationID())
+	}
+
+	subscription, err := ct.stream.Subscribe(appCtx, c.Request().Header.Get(HeaderLastEventID), filters)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to subscribe to location changes", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, http.StatusServiceUnavailable, err, "failed to stream location changes", appCtx.GetCorrelationID())
+	}
+	defer subscription.Close()
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, EventStreamMIMEType)
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Proxies that buffer responses would hold the events back
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+
+	if err = ct.writeBacklog(response, subscription); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to write location changes", err)
+		return nil
+	}
+
+	heartbeat := time.NewTicker(ct.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	// The stream only ends when the client disconnects, it falls behind or the server shuts down. In the last two
+	// cases the client reconnects and resumes from the last event it received.
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case change, ok := <-subscription.Changes():
+			if !ok {
+				return nil
+			}
+			err = writeLocationChangeEvent(response, change)
+		case <-heartbeat.C:
+			_, err = io.WriteString(response, ": heartbeat\n\n")
+		}
+
+		if err != nil {
+			ct.logger.ErrorCtx(appCtx, fnName, "failed to write location changes", err)
+			return nil
+		}
+		response.Flush()
+	}
+}
+
+func (ct *LocationStreamController) writeBacklog(response *echo.Response, subscription *services.LocationChangeSubscription) error {
+	if _, err := fmt.Fprintf(response, "retry: %d\n\n", streamRetryMs); err != nil {
+		return err
+	}
+
+	if subscription.Missed {
+		if _, err := fmt.Fprintf(response, "event: %v\ndata: {}\n\n", LocationResetEvent); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range subscription.Backlog {
+		if err := writeLocationChangeEvent(response, change); err != nil {
+			return err
+		}
+	}
+
+	response.Flush()
+
+	return nil
+}
+
+func writeLocationChangeEvent(w io.Writer, change domain.LocationChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %v\nevent: %v%v\ndata: %s\n\n", change.ID, locationChangeEventPrefix, change.Operation, data)
+
+	return err
+}
+
+func buildLocationChangeFilters(req *http.Request) (domain.LocationChangeFilters, error) {
+	queryString := req.URL.Query()
+	supplierIDs, supplierErr := parseIntListQP(queryString, SupplierIDQP)
+	locationTypeIDs, locationTypeErr := parseIntListQP(queryString, LocationTypeIDQP)
+
+	return domain.LocationChangeFilters{
+		SupplierIDs:     supplierIDs,
+		LocationTypeIDs: locationTypeIDs,
+	}, errors.Join(supplierErr, locationTypeErr)
+}
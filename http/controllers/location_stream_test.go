@@ -0,0 +1,146 @@
+package controllers_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type LocationStreamControllerSuite struct {
+	suite.Suite
+	stream   *services.LocationChangeStream
+	streamEP customHTTP.Endpoint
+	server   *httptest.Server
+}
+
+func (s *LocationStreamControllerSuite) SetupTest() {
+	s.stream = services.NewLocationChangeStream(config.LocationStreamConfig{})
+	s.streamEP = controllers.NewLocationStreamController(s.stream, config.LocationStreamConfig{HeartbeatIntervalSeconds: 1}).
+		LocationChangesEndpoint()
+
+	router := echo.New()
+	router.GET(s.streamEP.Path, s.streamEP.Handler)
+	s.server = httptest.NewServer(router)
+}
+
+func (s *LocationStreamControllerSuite) TearDownTest() {
+	s.stream.Close()
+	s.server.Close()
+}
+
+func TestLocationStreamControllerSuite(t *testing.T) {
+	suite.Run(t, new(LocationStreamControllerSuite))
+}
+
+// sseEvent is an event or comment read from the stream, the fields of an event are keyed by their name
+type sseEvent map[string]string
+
+// connect opens the stream and waits for the retry hint, which is only sent once the subscription is registered
+func (s *LocationStreamControllerSuite) connect(query, lastEventID string) (*http.Response, func() sseEvent) {
+	req, err := http.NewRequest(http.MethodGet, s.server.URL+s.streamEP.Path+query, nil)
+	s.Require().NoError(err)
+	if lastEventID != "" {
+		req.Header.Set(controllers.HeaderLastEventID, lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = resp.Body.Close() })
+
+	reader := bufio.NewReader(resp.Body)
+	next := func() sseEvent {
+		event := sseEvent{}
+		for {
+			line, readErr := reader.ReadString('\n')
+			s.Require().NoError(readErr)
+
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return event
+			}
+			name, value, _ := strings.Cut(line, ":")
+			event[name] = strings.TrimPrefix(value, " ")
+		}
+	}
+
+	assert.Equal(s.T(), "3000", next()["retry"])
+
+	return resp, next
+}
+
+func (s *LocationStreamControllerSuite) Test_streamLocationChanges_SendsMatchingChanges() {
+	resp, next := s.connect("?supplier_id=1", "")
+
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(s.T(), controllers.EventStreamMIMEType, resp.Header.Get(echo.HeaderContentType))
+	assert.Equal(s.T(), "no-cache", resp.Header.Get(echo.HeaderCacheControl))
+
+	s.stream.Publish(domain.LocationChange{ID: "1", Operation: domain.LocationCreatedOperation, Location: domain.Location{ID: "other", Supplier: domain.Supplier{ID: 2}}})
+	s.stream.Publish(domain.LocationChange{ID: "2", Operation: domain.LocationCreatedOperation, Location: domain.Location{ID: "first", Supplier: domain.Supplier{ID: 1}}})
+
+	event := next()
+	assert.Equal(s.T(), "2", event["id"])
+	assert.Equal(s.T(), "location.created", event["event"])
+
+	var change domain.LocationChange
+	s.Require().NoError(json.Unmarshal([]byte(event["data"]), &change))
+	assert.Equal(s.T(), "first", change.Location.ID)
+}
+
+func (s *LocationStreamControllerSuite) Test_streamLocationChanges_ResumesAfterLastEventID() {
+	for _, id := range []string{"1", "2", "3"} {
+		s.stream.Publish(domain.LocationChange{ID: id, Operation: domain.LocationUpdatedOperation})
+	}
+
+	_, next := s.connect("", "1")
+
+	assert.Equal(s.T(), "2", next()["id"])
+	assert.Equal(s.T(), "3", next()["id"])
+
+	s.stream.Publish(domain.LocationChange{ID: "4", Operation: domain.LocationDeletedOperation})
+	event := next()
+	assert.Equal(s.T(), "4", event["id"])
+	assert.Equal(s.T(), "location.deleted", event["event"])
+}
+
+func (s *LocationStreamControllerSuite) Test_streamLocationChanges_SendsResetWhenLastEventIDIsNotBuffered() {
+	_, next := s.connect("", "unknown")
+
+	assert.Equal(s.T(), controllers.LocationResetEvent, next()["event"])
+}
+
+func (s *LocationStreamControllerSuite) Test_streamLocationChanges_SendsHeartbeats() {
+	_, next := s.connect("", "")
+
+	_, isComment := next()[""]
+	assert.True(s.T(), isComment)
+}
+
+func (s *LocationStreamControllerSuite) Test_streamLocationChanges_Returns400OnInvalidFilters() {
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations/stream?location_type_id=abc", nil)
+
+	assert.Nil(s.T(), s.streamEP.Handler(echo.New().NewContext(req, recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, recorder.Code)
+	assert.Contains(s.T(), recorder.Body.String(), controllers.LocationTypeIDQP)
+}
+
+func (s *LocationStreamControllerSuite) Test_streamLocationChanges_Returns503WhenStreamIsClosed() {
+	s.stream.Close()
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations/stream", nil)
+
+	assert.Nil(s.T(), s.streamEP.Handler(echo.New().NewContext(req, recorder)))
+	assert.Equal(s.T(), http.StatusServiceUnavailable, recorder.Code)
+}
@@ -2,6 +2,7 @@ package controllers_test
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -20,6 +21,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 const (
@@ -63,8 +65,15 @@ type LocationControllerSuite struct {
 	locationServiceMock     *mocks.ILocationService
 	createLocationEP        customHTTP.Endpoint
 	updateLocationEP        customHTTP.Endpoint
+	patchLocationEP         customHTTP.Endpoint
 	getPaginatedLocationsEP customHTTP.Endpoint
 	getLocationDetailsEP    customHTTP.Endpoint
+	deleteLocationEP        customHTTP.Endpoint
+	restoreLocationEP       customHTTP.Endpoint
+	locationHistoryEP       customHTTP.Endpoint
+	nearbyLocationsEP       customHTTP.Endpoint
+	importLocationsEP       customHTTP.Endpoint
+	exportLocationsEP       customHTTP.Endpoint
 	echoRouter              *echo.Echo
 	recorder                *httptest.ResponseRecorder
 }
@@ -75,8 +84,15 @@ func (s *LocationControllerSuite) SetupSuite() {
 
 	s.createLocationEP = controller.CreateLocationEndpoint()
 	s.updateLocationEP = controller.UpdateLocationEndpoint()
+	s.patchLocationEP = controller.PatchLocationEndpoint()
 	s.getPaginatedLocationsEP = controller.PaginatedLocationsEndpoint()
 	s.getLocationDetailsEP = controller.LocationDetailsEndpoint()
+	s.deleteLocationEP = controller.DeleteLocationEndpoint()
+	s.restoreLocationEP = controller.RestoreLocationEndpoint()
+	s.locationHistoryEP = controller.LocationHistoryEndpoint()
+	s.nearbyLocationsEP = controller.NearbyLocationsEndpoint()
+	s.importLocationsEP = controller.ImportLocationsEndpoint()
+	s.exportLocationsEP = controller.ExportLocationsEndpoint()
 	s.locationServiceMock = locationServiceMock
 
 	s.echoRouter = echo.New()
@@ -131,8 +147,9 @@ func (s *LocationControllerSuite) Test_createLocation_Returns400OnInvalidBody()
 func (s *LocationControllerSuite) Test_updateLocation_Success() {
 	bodyBytes, _ := json.Marshal(mockUpdateLocationRequest)
 	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/locations/%v", mockUpdateLocationRequest.ID), bytes.NewBuffer(bodyBytes))
+	req.Header.Set(controllers.HeaderIfMatch, `"3"`)
 
-	s.locationServiceMock.On("UpdateLocation", mock.Anything, mock.Anything).Return(domain.Location{ID: "1"}, nil).Once()
+	s.locationServiceMock.On("UpdateLocation", mock.Anything, mock.Anything, 3).Return(domain.Location{ID: "1", Version: 4}, nil).Once()
 
 	echoCtx := s.echoRouter.NewContext(req, s.recorder)
 	echoCtx.SetPath(s.updateLocationEP.Path)
@@ -151,6 +168,7 @@ func (s *LocationControllerSuite) Test_updateLocation_Success() {
 
 	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
 	assert.Equal(s.T(), "1", response.Data.ID)
+	assert.Equal(s.T(), `"4"`, s.recorder.Header().Get(controllers.HeaderETag))
 	s.assertMockExpectations()
 }
 
@@ -181,6 +199,41 @@ func (s *LocationControllerSuite) Test_updateLocation_Returns400OnLocationIDMism
 	s.assertMockExpectations()
 }
 
+func (s *LocationControllerSuite) Test_updateLocation_Returns404WhenLocationDoesNotExist() {
+	bodyBytes, _ := json.Marshal(mockUpdateLocationRequest)
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/locations/%v", mockUpdateLocationRequest.ID), bytes.NewBuffer(bodyBytes))
+	req.Header.Set(controllers.HeaderIfMatch, `"3"`)
+
+	s.locationServiceMock.On("UpdateLocation", mock.Anything, mock.Anything, 3).
+		Return(domain.Location{}, domain.NotFoundErr{Msg: "not found", Resource: domain.ResourceLocation}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.updateLocationEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(mockUpdateLocationRequest.ID)
+
+	assert.Nil(s.T(), s.updateLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), `"code":"location.not_found"`)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getPaginatedLocations_Returns503WhenDatabaseIsUnreachable() {
+	req, _ := http.NewRequest(
+		http.MethodGet,
+		fmt.Sprintf("/v1/locations?limit=%v&direction=%v", controllers.DefaultLimit, domain.NextPage),
+		http.NoBody,
+	)
+
+	s.locationServiceMock.On("GetPaginatedLocations", mock.Anything, mock.Anything).
+		Return(domain.CursorPage[domain.Location]{}, driver.ErrBadConn).Once()
+
+	assert.Nil(s.T(), s.getPaginatedLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusServiceUnavailable, s.recorder.Code)
+	assert.Equal(s.T(), customHTTP.MIMEApplicationProblemJSON, s.recorder.Header().Get(echo.HeaderContentType))
+	s.assertMockExpectations()
+}
+
 func (s *LocationControllerSuite) Test_getPaginatedLocations_Success() {
 	req, _ := http.NewRequest(
 		http.MethodGet,
@@ -257,12 +310,86 @@ func (s *LocationControllerSuite) Test_getPaginatedLocations_Returns400OnInvalid
 	s.assertMockExpectations()
 }
 
+func (s *LocationControllerSuite) Test_getPaginatedLocations_ParsesAttributeFilters() {
+	req, _ := http.NewRequest(
+		http.MethodGet,
+		"/v1/locations?direction=next&city=Springfield&state=IL&zipcode=62701&location_type_id=1,2&location_type_id=3"+
+			"&supplier_id=7&active=false&created_from=2024-01-01&created_to=2024-01-31&updated_from=2024-02-01T10:00:00Z",
+		http.NoBody,
+	)
+
+	s.locationServiceMock.On("GetPaginatedLocations", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		filters := args.Get(1).(domain.LocationsFilters)
+		assert.Equal(s.T(), "Springfield", *filters.City)
+		assert.Equal(s.T(), "IL", *filters.State)
+		assert.Equal(s.T(), "62701", *filters.Zipcode)
+		assert.Equal(s.T(), []int{1, 2, 3}, filters.LocationTypeIDs)
+		assert.Equal(s.T(), []int{7}, filters.SupplierIDs)
+		assert.False(s.T(), *filters.Active)
+		assert.Equal(s.T(), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *filters.CreatedFrom)
+		assert.Equal(s.T(), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *filters.CreatedTo)
+		assert.Equal(s.T(), time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC), *filters.UpdatedFrom)
+		assert.Nil(s.T(), filters.UpdatedTo)
+	}).Return(domain.CursorPage[domain.Location]{}, nil).Once()
+
+	assert.Nil(s.T(), s.getPaginatedLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getPaginatedLocations_ParsesSortOrder() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations?direction=next&sort=-created_at,name", http.NoBody)
+
+	s.locationServiceMock.On("GetPaginatedLocations", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		filters := args.Get(1).(domain.LocationsFilters)
+		assert.Equal(s.T(), domain.SortOrder{{Field: domain.SortByCreatedAt, Descending: true}, {Field: domain.SortByName}}, filters.Sort)
+	}).Return(domain.CursorPage[domain.Location]{}, nil).Once()
+
+	assert.Nil(s.T(), s.getPaginatedLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getPaginatedLocations_Returns400OnUnknownSortField() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations?direction=next&sort=email", http.NoBody)
+
+	assert.Nil(s.T(), s.getPaginatedLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getPaginatedLocations_Returns400WithFieldDetailsOnInvalidFilters() {
+	req, _ := http.NewRequest(
+		http.MethodGet,
+		"/v1/locations?direction=next&location_type_id=one&active=maybe&created_from=2024-02-01&created_to=2024-01-01&limit=ten",
+		http.NoBody,
+	)
+
+	assert.Nil(s.T(), s.getPaginatedLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+
+	var response controllers.Problem
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	if err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	var fields []string
+	for _, problemErr := range response.Errors {
+		fields = append(fields, problemErr.Parameter)
+	}
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	assert.Equal(s.T(), customHTTP.MIMEApplicationProblemJSON, s.recorder.Header().Get(echo.HeaderContentType))
+	assert.Equal(s.T(), []string{controllers.LocationTypeIDQP, controllers.ActiveQP, controllers.CreatedToQP, controllers.LimitQP}, fields)
+	s.assertMockExpectations()
+}
+
 func (s *LocationControllerSuite) Test_getLocationDetails_Success() {
 	locationID := uuid.New().String()
 
 	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/locations/%v", locationID), http.NoBody)
 
-	s.locationServiceMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID}, nil).Once()
+	s.locationServiceMock.On("GetLocationByID", mock.Anything, locationID, false).Return(&domain.Location{ID: locationID}, nil).Once()
 
 	echoCtx := s.echoRouter.NewContext(req, s.recorder)
 	echoCtx.SetPath(s.getLocationDetailsEP.Path)
@@ -281,6 +408,7 @@ func (s *LocationControllerSuite) Test_getLocationDetails_Success() {
 
 	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
 	assert.Equal(s.T(), locationID, response.Data.ID)
+	assert.Equal(s.T(), `"0"`, s.recorder.Header().Get(controllers.HeaderETag))
 	s.assertMockExpectations()
 }
 
@@ -289,7 +417,7 @@ func (s *LocationControllerSuite) Test_getLocationDetails_Returns404WhenLocation
 
 	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/locations/%v", locationID), http.NoBody)
 
-	s.locationServiceMock.On("GetLocationByID", mock.Anything, locationID).Return(nil, nil).Once()
+	s.locationServiceMock.On("GetLocationByID", mock.Anything, locationID, false).Return(nil, nil).Once()
 
 	echoCtx := s.echoRouter.NewContext(req, s.recorder)
 	echoCtx.SetPath(s.getLocationDetailsEP.Path)
@@ -300,3 +428,261 @@ func (s *LocationControllerSuite) Test_getLocationDetails_Returns404WhenLocation
 	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
 	s.assertMockExpectations()
 }
+
+func (s *LocationControllerSuite) Test_getLocationDetails_PassesIncludeDeletedToService() {
+	locationID := uuid.New().String()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/locations/%v?include_deleted=true", locationID), http.NoBody)
+
+	s.locationServiceMock.On("GetLocationByID", mock.Anything, locationID, true).Return(&domain.Location{ID: locationID}, nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.getLocationDetailsEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(locationID)
+
+	assert.Nil(s.T(), s.getLocationDetailsEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getPaginatedLocations_Returns400OnInvalidIncludeDeletedValue() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations?direction=next&include_deleted=maybe", http.NoBody)
+
+	assert.Nil(s.T(), s.getPaginatedLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_deleteLocation_Success() {
+	locationID := uuid.New().String()
+
+	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/v1/locations/%v", locationID), http.NoBody)
+
+	s.locationServiceMock.On("DeleteLocation", mock.Anything, locationID).Return(nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.deleteLocationEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(locationID)
+
+	assert.Nil(s.T(), s.deleteLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNoContent, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_deleteLocation_Returns404WhenLocationCannotBeFound() {
+	locationID := uuid.New().String()
+
+	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/v1/locations/%v", locationID), http.NoBody)
+
+	s.locationServiceMock.On("DeleteLocation", mock.Anything, locationID).Return(domain.NotFoundErr{Msg: "not found"}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.deleteLocationEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(locationID)
+
+	assert.Nil(s.T(), s.deleteLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_restoreLocation_Success() {
+	locationID := uuid.New().String()
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/locations/%v/restore", locationID), http.NoBody)
+
+	s.locationServiceMock.On("RestoreLocation", mock.Anything, locationID).Return(domain.Location{ID: locationID}, nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.restoreLocationEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(locationID)
+
+	assert.Nil(s.T(), s.restoreLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) newPatchContext(locationID, contentType, body string) echo.Context {
+	req, _ := http.NewRequest(http.MethodPatch, fmt.Sprintf("/v1/locations/%v", locationID), bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	req.Header.Set(controllers.HeaderIfMatch, `"1"`)
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.patchLocationEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(locationID)
+
+	return echoCtx
+}
+
+func (s *LocationControllerSuite) Test_patchLocation_Success() {
+	locationID := uuid.New().String()
+
+	s.locationServiceMock.On("PatchLocation", mock.Anything, locationID, mock.Anything, 1).Run(func(args mock.Arguments) {
+		patch := args.Get(2).(dto.PatchLocationRequest)
+		assert.Equal(s.T(), dto.Optional[string]{Value: "New name", Set: true}, patch.Name)
+		assert.True(s.T(), patch.Email.Null)
+		assert.False(s.T(), patch.Address.Set)
+	}).Return(domain.Location{ID: locationID}, nil).Once()
+
+	echoCtx := s.newPatchContext(locationID, controllers.MergePatchMIMEType, `{"name": "New name", "email": null}`)
+
+	assert.Nil(s.T(), s.patchLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_patchLocation_Returns400WhenRequiredFieldIsNull() {
+	echoCtx := s.newPatchContext(uuid.New().String(), controllers.MergePatchMIMEType, `{"name": null, "city": ""}`)
+
+	assert.Nil(s.T(), s.patchLocationEP.Handler(echoCtx))
+
+	var response controllers.Problem
+	if err := json.Unmarshal(s.recorder.Body.Bytes(), &response); err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	assert.Equal(s.T(), []controllers.ProblemError{
+		{Detail: "field 'name' cannot be null or empty", Pointer: "/name"},
+		{Detail: "field 'city' cannot be null or empty", Pointer: "/city"},
+	}, response.Errors)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_patchLocation_Returns415OnUnsupportedContentType() {
+	echoCtx := s.newPatchContext(uuid.New().String(), "text/plain", `{"name": "New name"}`)
+
+	assert.Nil(s.T(), s.patchLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusUnsupportedMediaType, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_updateLocation_Returns428WhenIfMatchIsMissing() {
+	bodyBytes, _ := json.Marshal(mockUpdateLocationRequest)
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/locations/%v", mockUpdateLocationRequest.ID), bytes.NewBuffer(bodyBytes))
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.updateLocationEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(mockUpdateLocationRequest.ID)
+
+	assert.Nil(s.T(), s.updateLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusPreconditionRequired, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_patchLocation_Returns412WhenVersionDoesNotMatch() {
+	locationID := uuid.New().String()
+
+	s.locationServiceMock.On("PatchLocation", mock.Anything, locationID, mock.Anything, 1).
+		Return(domain.Location{}, domain.PreconditionFailedErr{Msg: "location was modified"}).Once()
+
+	echoCtx := s.newPatchContext(locationID, controllers.MergePatchMIMEType, `{"name": "New name"}`)
+
+	assert.Nil(s.T(), s.patchLocationEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusPreconditionFailed, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getLocationHistory_Success() {
+	locationID := uuid.New().String()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/locations/%v/history?direction=next&limit=5", locationID), http.NoBody)
+
+	expectedFilters := domain.LocationHistoryFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{Cursor: "", Direction: domain.NextPage, Limit: 5},
+		LocationID:              locationID,
+	}
+	s.locationServiceMock.On("GetLocationHistory", mock.Anything, expectedFilters).Return(domain.CursorPage[domain.LocationHistoryEntry]{
+		Data:  []domain.LocationHistoryEntry{{ID: 1, LocationID: locationID, Operation: domain.LocationCreatedOperation}},
+		Limit: 5,
+	}, nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.locationHistoryEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(locationID)
+
+	assert.Nil(s.T(), s.locationHistoryEP.Handler(echoCtx))
+
+	var response struct {
+		Data domain.CursorPage[domain.LocationHistoryEntry] `json:"data"`
+	}
+	if err := json.Unmarshal(s.recorder.Body.Bytes(), &response); err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Len(s.T(), response.Data.Data, 1)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getLocationHistory_Returns404WhenLocationCannotBeFound() {
+	locationID := uuid.New().String()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/locations/%v/history?direction=next", locationID), http.NoBody)
+
+	s.locationServiceMock.On("GetLocationHistory", mock.Anything, mock.Anything).
+		Return(domain.CursorPage[domain.LocationHistoryEntry]{}, domain.NotFoundErr{Msg: "not found"}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.locationHistoryEP.Path)
+	echoCtx.SetParamNames("locationID")
+	echoCtx.SetParamValues(locationID)
+
+	assert.Nil(s.T(), s.locationHistoryEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getNearbyLocations_Success() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/locations/nearby?lat=-34.6&lng=-58.38&radius_m=2500&active=true&name=Hub", http.NoBody)
+
+	expectedFilters := domain.NearbyLocationsFilters{
+		LocationsFilters: domain.LocationsFilters{
+			CursorPaginationFilters: domain.CursorPaginationFilters{Limit: controllers.DefaultNearbyLimit},
+			Name:                    utils.ToPointer("Hub"),
+			Active:                  utils.ToPointer(true),
+		},
+		Latitude:     -34.6,
+		Longitude:    -58.38,
+		RadiusMeters: 2500,
+	}
+	s.locationServiceMock.On("GetNearbyLocations", mock.Anything, expectedFilters).Return([]domain.NearbyLocation{
+		{Location: domain.Location{ID: "1"}, DistanceMeters: 350.5},
+	}, nil).Once()
+
+	assert.Nil(s.T(), s.nearbyLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+
+	var response struct {
+		Data []domain.NearbyLocation `json:"data"`
+	}
+	if err := json.Unmarshal(s.recorder.Body.Bytes(), &response); err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), 350.5, response.Data[0].DistanceMeters)
+	assert.Equal(s.T(), "1", response.Data[0].ID)
+	s.assertMockExpectations()
+}
+
+func (s *LocationControllerSuite) Test_getNearbyLocations_Returns400OnInvalidCoordinates() {
+	for _, query := range []string{
+		"lng=-58.38&radius_m=2500",
+		"lat=91&lng=-58.38&radius_m=2500",
+		"lat=-34.6&lng=-58.38&radius_m=0",
+		"lat=-34.6&lng=-58.38&radius_m=2500&limit=5000",
+	} {
+		s.recorder = httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/v1/locations/nearby?"+query, http.NoBody)
+
+		assert.Nil(s.T(), s.nearbyLocationsEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+		assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code, query)
+	}
+	s.assertMockExpectations()
+}
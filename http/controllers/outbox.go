@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"net/http"
+)
+
+var ErrInvalidOutboxMessageID = errors.New("invalid 'messageID' path param, it must be a UUID")
+
+type OutboxController struct {
+	logger        monitor.AppLogger
+	outboxService services.IOutboxService
+}
+
+func NewOutboxController(outboxService services.IOutboxService) *OutboxController {
+	return &OutboxController{
+		outboxService: outboxService,
+		logger:        monitor.GetStdLogger("OutboxController"),
+	}
+}
+
+// Nada godoc
+// @Summary List parked outbox messages
+// @Description Get the oldest outbox messages that failed every publish attempt, along with the error of the last one. Each of them holds back the later messages of its aggregate until it is redriven or discarded
+// @Produce json
+// @Success 200 {object} []domain.OutboxMessage
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/outbox/parked [get]
+func (ct *OutboxController) ParkedMessagesEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/outbox/parked",
+		Handler:        ct.getParkedMessages,
+		RequiredScopes: []string{ScopeOutboxAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Redrive parked outbox message
+// @Description Make a parked outbox message pending again with its attempts reset, it is published before the messages of its aggregate it held back
+// @Param messageID path string true "Outbox message ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/outbox/parked/{messageID}/redrive [post]
+func (ct *OutboxController) RedriveParkedMessageEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/outbox/parked/:messageID/redrive",
+		Handler:        ct.redriveParkedMessage,
+		RequiredScopes: []string{ScopeOutboxAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Discard parked outbox message
+// @Description Delete a parked outbox message without publishing it, the messages of its aggregate it held back are published
+// @Param messageID path string true "Outbox message ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/outbox/parked/{messageID} [delete]
+func (ct *OutboxController) DiscardParkedMessageEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodDelete,
+		Path:           "/v1/outbox/parked/:messageID",
+		Handler:        ct.discardParkedMessage,
+		RequiredScopes: []string{ScopeOutboxAdmin},
+	}
+}
+
+func (ct *OutboxController) getParkedMessages(c echo.Context) error {
+	fnName := "OutboxController.getParkedMessages"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	messages, err := ct.outboxService.GetParkedMessages(appCtx)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get parked outbox messages", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get parked outbox messages", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(messages))
+}
+
+func (ct *OutboxController) redriveParkedMessage(c echo.Context) error {
+	fnName := "OutboxController.redriveParkedMessage"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "messageID", ErrInvalidOutboxMessageID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err = ct.outboxService.RedriveParkedMessage(appCtx, id); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to redrive parked outbox message", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to redrive parked outbox message", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (ct *OutboxController) discardParkedMessage(c echo.Context) error {
+	fnName := "OutboxController.discardParkedMessage"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "messageID", ErrInvalidOutboxMessageID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err = ct.outboxService.DiscardParkedMessage(appCtx, id); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to discard parked outbox message", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to discard parked outbox message", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,107 @@
+package controllers_test
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testOutboxMessageID = "8c6f2b1e-4d3a-4f5b-9e8d-7a6b5c4d3e2f"
+
+type OutboxControllerSuite struct {
+	suite.Suite
+	outboxServiceMock *mocks.IOutboxService
+	parkedMessagesEP  customHTTP.Endpoint
+	redriveEP         customHTTP.Endpoint
+	discardEP         customHTTP.Endpoint
+	echoRouter        *echo.Echo
+	recorder          *httptest.ResponseRecorder
+}
+
+func (s *OutboxControllerSuite) SetupSuite() {
+	outboxServiceMock := new(mocks.IOutboxService)
+	controller := controllers.NewOutboxController(outboxServiceMock)
+
+	s.parkedMessagesEP = controller.ParkedMessagesEndpoint()
+	s.redriveEP = controller.RedriveParkedMessageEndpoint()
+	s.discardEP = controller.DiscardParkedMessageEndpoint()
+	s.outboxServiceMock = outboxServiceMock
+
+	s.echoRouter = echo.New()
+}
+
+func (s *OutboxControllerSuite) SetupTest() {
+	s.outboxServiceMock.ExpectedCalls = nil
+	s.recorder = httptest.NewRecorder()
+}
+
+func (s *OutboxControllerSuite) assertMockExpectations() {
+	s.outboxServiceMock.AssertExpectations(s.T())
+}
+
+func TestOutboxControllerSuite(t *testing.T) {
+	suite.Run(t, new(OutboxControllerSuite))
+}
+
+func (s *OutboxControllerSuite) Test_getParkedMessages_Success() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/outbox/parked", http.NoBody)
+
+	s.outboxServiceMock.On("GetParkedMessages", mock.Anything).Return([]domain.OutboxMessage{{ID: testOutboxMessageID}}, nil).Once()
+
+	assert.Nil(s.T(), s.parkedMessagesEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), testOutboxMessageID)
+	s.assertMockExpectations()
+}
+
+func (s *OutboxControllerSuite) Test_redriveParkedMessage_Success() {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/outbox/parked/"+testOutboxMessageID+"/redrive", http.NoBody)
+
+	s.outboxServiceMock.On("RedriveParkedMessage", mock.Anything, testOutboxMessageID).Return(nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.redriveEP.Path)
+	echoCtx.SetParamNames("messageID")
+	echoCtx.SetParamValues(testOutboxMessageID)
+
+	assert.Nil(s.T(), s.redriveEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNoContent, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *OutboxControllerSuite) Test_discardParkedMessage_Returns404WhenNotParked() {
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/outbox/parked/"+testOutboxMessageID, http.NoBody)
+
+	s.outboxServiceMock.On("DiscardParkedMessage", mock.Anything, testOutboxMessageID).
+		Return(domain.NotFoundErr{Msg: "not parked", Resource: domain.ResourceOutboxMessage}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.discardEP.Path)
+	echoCtx.SetParamNames("messageID")
+	echoCtx.SetParamValues(testOutboxMessageID)
+
+	assert.Nil(s.T(), s.discardEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *OutboxControllerSuite) Test_discardParkedMessage_Returns400OnInvalidID() {
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/outbox/parked/nope", http.NoBody)
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.discardEP.Path)
+	echoCtx.SetParamNames("messageID")
+	echoCtx.SetParamValues("nope")
+
+	assert.Nil(s.T(), s.discardEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
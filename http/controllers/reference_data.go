@@ -0,0 +1,644 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"net/http"
+)
+
+type ReferenceDataController struct {
+	logger               monitor.AppLogger
+	referenceDataService services.IReferenceDataService
+	validator            *validator.Validate
+}
+
+func NewReferenceDataController(referenceDataService services.IReferenceDataService, validator *validator.Validate) *ReferenceDataController {
+	return &ReferenceDataController{
+		referenceDataService: referenceDataService,
+		logger:               monitor.GetStdLogger("ReferenceDataController"),
+		validator:            validator,
+	}
+}
+
+// Nada godoc
+// @Summary List suppliers
+// @Description Get all the suppliers
+// @Produce json
+// @Success 200 {object} []domain.Supplier
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/suppliers [get]
+func (ct *ReferenceDataController) SuppliersEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/suppliers",
+		Handler:        ct.getSuppliers,
+		RequiredScopes: []string{ScopeReferenceDataRead},
+	}
+}
+
+// Nada godoc
+// @Summary Create supplier
+// @Description Create a new supplier
+// @Produce json
+// @Param request body dto.SupplierRequest true "Supplier attributes"
+// @Success 200 {object} domain.Supplier
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/suppliers [post]
+func (ct *ReferenceDataController) CreateSupplierEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/suppliers",
+		Handler:        ct.createSupplier,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Get supplier details
+// @Description Get supplier details
+// @Produce json
+// @Param supplierID path int true "Supplier ID"
+// @Success 200 {object} domain.Supplier
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/suppliers/{supplierID} [get]
+func (ct *ReferenceDataController) SupplierDetailsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/suppliers/:supplierID",
+		Handler:        ct.getSupplierDetails,
+		RequiredScopes: []string{ScopeReferenceDataRead},
+	}
+}
+
+// Nada godoc
+// @Summary Update supplier
+// @Description Update an existing supplier
+// @Produce json
+// @Param supplierID path int true "Supplier ID"
+// @Param request body dto.SupplierRequest true "Supplier attributes"
+// @Success 200 {object} domain.Supplier
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/suppliers/{supplierID} [put]
+func (ct *ReferenceDataController) UpdateSupplierEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPut,
+		Path:           "/v1/suppliers/:supplierID",
+		Handler:        ct.updateSupplier,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Delete supplier
+// @Description Delete a supplier that is not in use
+// @Param supplierID path int true "Supplier ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/suppliers/{supplierID} [delete]
+func (ct *ReferenceDataController) DeleteSupplierEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodDelete,
+		Path:           "/v1/suppliers/:supplierID",
+		Handler:        ct.deleteSupplier,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary List location types
+// @Description Get all the location types
+// @Produce json
+// @Success 200 {object} []domain.LocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/location-types [get]
+func (ct *ReferenceDataController) LocationTypesEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/location-types",
+		Handler:        ct.getLocationTypes,
+		RequiredScopes: []string{ScopeReferenceDataRead},
+	}
+}
+
+// Nada godoc
+// @Summary Create location type
+// @Description Create a new location type
+// @Produce json
+// @Param request body dto.LocationTypeRequest true "Location type attributes"
+// @Success 200 {object} domain.LocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/location-types [post]
+func (ct *ReferenceDataController) CreateLocationTypeEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/location-types",
+		Handler:        ct.createLocationType,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Get location type details
+// @Description Get location type details
+// @Produce json
+// @Param locationTypeID path int true "Location type ID"
+// @Success 200 {object} domain.LocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/location-types/{locationTypeID} [get]
+func (ct *ReferenceDataController) LocationTypeDetailsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/location-types/:locationTypeID",
+		Handler:        ct.getLocationTypeDetails,
+		RequiredScopes: []string{ScopeReferenceDataRead},
+	}
+}
+
+// Nada godoc
+// @Summary Update location type
+// @Description Update an existing location type
+// @Produce json
+// @Param locationTypeID path int true "Location type ID"
+// @Param request body dto.LocationTypeRequest true "Location type attributes"
+// @Success 200 {object} domain.LocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/location-types/{locationTypeID} [put]
+func (ct *ReferenceDataController) UpdateLocationTypeEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPut,
+		Path:           "/v1/location-types/:locationTypeID",
+		Handler:        ct.updateLocationType,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Delete location type
+// @Description Delete a location type that is not in use
+// @Param locationTypeID path int true "Location type ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/location-types/{locationTypeID} [delete]
+func (ct *ReferenceDataController) DeleteLocationTypeEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodDelete,
+		Path:           "/v1/location-types/:locationTypeID",
+		Handler:        ct.deleteLocationType,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary List sub location types
+// @Description Get all the sub location types
+// @Produce json
+// @Success 200 {object} []domain.SubLocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/sub-location-types [get]
+func (ct *ReferenceDataController) SubLocationTypesEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/sub-location-types",
+		Handler:        ct.getSubLocationTypes,
+		RequiredScopes: []string{ScopeReferenceDataRead},
+	}
+}
+
+// Nada godoc
+// @Summary Create sub location type
+// @Description Create a new sub location type
+// @Produce json
+// @Param request body dto.SubLocationTypeRequest true "Sub location type attributes"
+// @Success 200 {object} domain.SubLocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/sub-location-types [post]
+func (ct *ReferenceDataController) CreateSubLocationTypeEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/sub-location-types",
+		Handler:        ct.createSubLocationType,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Get sub location type details
+// @Description Get sub location type details
+// @Produce json
+// @Param subLocationTypeID path int true "Sub location type ID"
+// @Success 200 {object} domain.SubLocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/sub-location-types/{subLocationTypeID} [get]
+func (ct *ReferenceDataController) SubLocationTypeDetailsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/sub-location-types/:subLocationTypeID",
+		Handler:        ct.getSubLocationTypeDetails,
+		RequiredScopes: []string{ScopeReferenceDataRead},
+	}
+}
+
+// Nada godoc
+// @Summary Update sub location type
+// @Description Update an existing sub location type
+// @Produce json
+// @Param subLocationTypeID path int true "Sub location type ID"
+// @Param request body dto.SubLocationTypeRequest true "Sub location type attributes"
+// @Success 200 {object} domain.SubLocationType
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/sub-location-types/{subLocationTypeID} [put]
+func (ct *ReferenceDataController) UpdateSubLocationTypeEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPut,
+		Path:           "/v1/sub-location-types/:subLocationTypeID",
+		Handler:        ct.updateSubLocationType,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Delete sub location type
+// @Description Delete a sub location type that is not in use
+// @Param subLocationTypeID path int true "Sub location type ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/sub-location-types/{subLocationTypeID} [delete]
+func (ct *ReferenceDataController) DeleteSubLocationTypeEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodDelete,
+		Path:           "/v1/sub-location-types/:subLocationTypeID",
+		Handler:        ct.deleteSubLocationType,
+		RequiredScopes: []string{ScopeReferenceDataWrite},
+	}
+}
+
+func (ct *ReferenceDataController) getSuppliers(c echo.Context) error {
+	fnName := "ReferenceDataController.getSuppliers"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	suppliers, err := ct.referenceDataService.GetSuppliers(appCtx)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get suppliers", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get suppliers", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(suppliers))
+}
+
+func (ct *ReferenceDataController) createSupplier(c echo.Context) error {
+	fnName := "ReferenceDataController.createSupplier"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	request, err := parseAndValidateBody[dto.SupplierRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	supplier, err := ct.referenceDataService.CreateSupplier(appCtx, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to create supplier", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create supplier", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(supplier))
+}
+
+func (ct *ReferenceDataController) getSupplierDetails(c echo.Context) error {
+	fnName := "ReferenceDataController.getSupplierDetails"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "supplierID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	supplier, err := ct.referenceDataService.GetSupplierByID(appCtx, id)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to retrieve supplier by ID", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to retrieve supplier", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(supplier))
+}
+
+func (ct *ReferenceDataController) updateSupplier(c echo.Context) error {
+	fnName := "ReferenceDataController.updateSupplier"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "supplierID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	request, err := parseAndValidateBody[dto.SupplierRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	supplier, err := ct.referenceDataService.UpdateSupplier(appCtx, id, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to update supplier", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to update supplier", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(supplier))
+}
+
+func (ct *ReferenceDataController) deleteSupplier(c echo.Context) error {
+	fnName := "ReferenceDataController.deleteSupplier"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "supplierID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err = ct.referenceDataService.DeleteSupplier(appCtx, id); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to delete supplier", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to delete supplier", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (ct *ReferenceDataController) getLocationTypes(c echo.Context) error {
+	fnName := "ReferenceDataController.getLocationTypes"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationTypes, err := ct.referenceDataService.GetLocationTypes(appCtx)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get location types", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get location types", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(locationTypes))
+}
+
+func (ct *ReferenceDataController) createLocationType(c echo.Context) error {
+	fnName := "ReferenceDataController.createLocationType"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	request, err := parseAndValidateBody[dto.LocationTypeRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	locationType, err := ct.referenceDataService.CreateLocationType(appCtx, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to create location type", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create location type", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(locationType))
+}
+
+func (ct *ReferenceDataController) getLocationTypeDetails(c echo.Context) error {
+	fnName := "ReferenceDataController.getLocationTypeDetails"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "locationTypeID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	locationType, err := ct.referenceDataService.GetLocationTypeByID(appCtx, id)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to retrieve location type by ID", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to retrieve location type", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(locationType))
+}
+
+func (ct *ReferenceDataController) updateLocationType(c echo.Context) error {
+	fnName := "ReferenceDataController.updateLocationType"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "locationTypeID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	request, err := parseAndValidateBody[dto.LocationTypeRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	locationType, err := ct.referenceDataService.UpdateLocationType(appCtx, id, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to update location type", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to update location type", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(locationType))
+}
+
+func (ct *ReferenceDataController) deleteLocationType(c echo.Context) error {
+	fnName := "ReferenceDataController.deleteLocationType"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "locationTypeID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err = ct.referenceDataService.DeleteLocationType(appCtx, id); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to delete location type", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to delete location type", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (ct *ReferenceDataController) getSubLocationTypes(c echo.Context) error {
+	fnName := "ReferenceDataController.getSubLocationTypes"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	subLocationTypes, err := ct.referenceDataService.GetSubLocationTypes(appCtx)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get sub location types", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get sub location types", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocationTypes))
+}
+
+func (ct *ReferenceDataController) createSubLocationType(c echo.Context) error {
+	fnName := "ReferenceDataController.createSubLocationType"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	request, err := parseAndValidateBody[dto.SubLocationTypeRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	subLocationType, err := ct.referenceDataService.CreateSubLocationType(appCtx, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to create sub location type", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create sub location type", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocationType))
+}
+
+func (ct *ReferenceDataController) getSubLocationTypeDetails(c echo.Context) error {
+	fnName := "ReferenceDataController.getSubLocationTypeDetails"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "subLocationTypeID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	subLocationType, err := ct.referenceDataService.GetSubLocationTypeByID(appCtx, id)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to retrieve sub location type by ID", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to retrieve sub location type", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocationType))
+}
+
+func (ct *ReferenceDataController) updateSubLocationType(c echo.Context) error {
+	fnName := "ReferenceDataController.updateSubLocationType"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "subLocationTypeID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	request, err := parseAndValidateBody[dto.SubLocationTypeRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	subLocationType, err := ct.referenceDataService.UpdateSubLocationType(appCtx, id, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to update sub location type", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to update sub location type", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocationType))
+}
+
+func (ct *ReferenceDataController) deleteSubLocationType(c echo.Context) error {
+	fnName := "ReferenceDataController.deleteSubLocationType"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getIntPathParam(c, "subLocationTypeID")
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err = ct.referenceDataService.DeleteSubLocationType(appCtx, id); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to delete sub location type", err)
+		err = notFoundFromUnknownReference(err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to delete sub location type", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// referenceDataStatusFromError answers 404 when the item addressed by the URL does not exist. Elsewhere an unknown
+// reference is a problem with the request body.
+// notFoundFromUnknownReference reports the unknown reference data items as missing, they are the resource of the
+// request instead of one referenced in its body
+func notFoundFromUnknownReference(err error) error {
+	var unknownErr domain.UnknownReferenceErr
+	if errors.As(err, &unknownErr) {
+		return domain.NotFoundErr{Msg: unknownErr.Msg, Resource: unknownErr.Resource}
+	}
+
+	return err
+}
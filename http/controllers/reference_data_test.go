@@ -0,0 +1,148 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ReferenceDataControllerSuite struct {
+	suite.Suite
+	referenceDataServiceMock *mocks.IReferenceDataService
+	getSuppliersEP           customHTTP.Endpoint
+	createSupplierEP         customHTTP.Endpoint
+	getSupplierDetailsEP     customHTTP.Endpoint
+	updateLocationTypeEP     customHTTP.Endpoint
+	deleteSubLocationTypeEP  customHTTP.Endpoint
+	echoRouter               *echo.Echo
+	recorder                 *httptest.ResponseRecorder
+}
+
+func (s *ReferenceDataControllerSuite) SetupSuite() {
+	referenceDataServiceMock := new(mocks.IReferenceDataService)
+	controller := controllers.NewReferenceDataController(referenceDataServiceMock, validator.New())
+
+	s.getSuppliersEP = controller.SuppliersEndpoint()
+	s.createSupplierEP = controller.CreateSupplierEndpoint()
+	s.getSupplierDetailsEP = controller.SupplierDetailsEndpoint()
+	s.updateLocationTypeEP = controller.UpdateLocationTypeEndpoint()
+	s.deleteSubLocationTypeEP = controller.DeleteSubLocationTypeEndpoint()
+	s.referenceDataServiceMock = referenceDataServiceMock
+
+	s.echoRouter = echo.New()
+}
+
+func (s *ReferenceDataControllerSuite) SetupTest() {
+	s.referenceDataServiceMock.ExpectedCalls = nil
+	s.recorder = httptest.NewRecorder()
+}
+
+func (s *ReferenceDataControllerSuite) assertMockExpectations() {
+	s.referenceDataServiceMock.AssertExpectations(s.T())
+}
+
+func TestReferenceDataControllerSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceDataControllerSuite))
+}
+
+func (s *ReferenceDataControllerSuite) Test_getSuppliers_Success() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/suppliers", http.NoBody)
+
+	s.referenceDataServiceMock.On("GetSuppliers", mock.Anything).Return([]domain.Supplier{{ID: 1, Name: "Supplier 1"}}, nil).Once()
+
+	assert.Nil(s.T(), s.getSuppliersEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+
+	var response struct {
+		Data []domain.Supplier `json:"data"`
+	}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	if err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Len(s.T(), response.Data, 1)
+	s.assertMockExpectations()
+}
+
+func (s *ReferenceDataControllerSuite) Test_createSupplier_Returns400OnInvalidBody() {
+	bodyBytes, _ := json.Marshal(dto.SupplierRequest{})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/suppliers", bytes.NewBuffer(bodyBytes))
+
+	assert.Nil(s.T(), s.createSupplierEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *ReferenceDataControllerSuite) Test_getSupplierDetails_Returns404WhenSupplierCannotBeFound() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/suppliers/99", http.NoBody)
+
+	s.referenceDataServiceMock.On("GetSupplierByID", mock.Anything, 99).
+		Return(domain.Supplier{}, domain.UnknownReferenceErr{Msg: "supplier with ID 99 does not exist"}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.getSupplierDetailsEP.Path)
+	echoCtx.SetParamNames("supplierID")
+	echoCtx.SetParamValues("99")
+
+	assert.Nil(s.T(), s.getSupplierDetailsEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *ReferenceDataControllerSuite) Test_getSupplierDetails_Returns400OnNonNumericID() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/suppliers/abc", http.NoBody)
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.getSupplierDetailsEP.Path)
+	echoCtx.SetParamNames("supplierID")
+	echoCtx.SetParamValues("abc")
+
+	assert.Nil(s.T(), s.getSupplierDetailsEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *ReferenceDataControllerSuite) Test_updateLocationType_Success() {
+	bodyBytes, _ := json.Marshal(dto.LocationTypeRequest{Type: "Hub"})
+	req, _ := http.NewRequest(http.MethodPut, "/v1/location-types/3", bytes.NewBuffer(bodyBytes))
+
+	s.referenceDataServiceMock.On("UpdateLocationType", mock.Anything, 3, dto.LocationTypeRequest{Type: "Hub"}).
+		Return(domain.LocationType{ID: 3, Type: "Hub"}, nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.updateLocationTypeEP.Path)
+	echoCtx.SetParamNames("locationTypeID")
+	echoCtx.SetParamValues("3")
+
+	assert.Nil(s.T(), s.updateLocationTypeEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *ReferenceDataControllerSuite) Test_deleteSubLocationType_Returns400WhenTypeIsInUse() {
+	req, _ := http.NewRequest(http.MethodDelete, "/v1/sub-location-types/2", http.NoBody)
+
+	s.referenceDataServiceMock.On("DeleteSubLocationType", mock.Anything, 2).Return(domain.BusinessErr{Msg: "in use"}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.deleteSubLocationTypeEP.Path)
+	echoCtx.SetParamNames("subLocationTypeID")
+	echoCtx.SetParamValues("2")
+
+	assert.Nil(s.T(), s.deleteSubLocationTypeEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
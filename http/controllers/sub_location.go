@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"go.opentelemetry.io/otel/codes"
+	"net/http"
+)
+
+var ErrNoSubLocationIDSend = errors.New("no subLocationID sent in URL")
+
+type SubLocationController struct {
+	logger          monitor.AppLogger
+	locationService services.ILocationService
+	validator       *validator.Validate
+}
+
+func NewSubLocationController(locService services.ILocationService, validator *validator.Validate) *SubLocationController {
+	return &SubLocationController{
+		locationService: locService,
+		logger:          monitor.GetStdLogger("SubLocationController"),
+		validator:       validator,
+	}
+}
+
+// Nada godoc
+// @Summary Retrieve paginated sub locations
+// @Description Get paginated sub locations of a location
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Param limit query int false "Pagination limit, default to 10000"
+// @Param cursor query string false "Cursor value, default to empty string"
+// @Param direction query string true "Indicates the cursor direction. Accepted values: 'next' or 'prev'"
+// @Success 200 {object} []domain.ExampleCursorPage
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID}/sub-locations [get]
+func (ct *SubLocationController) PaginatedSubLocationsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/locations/:locationID/sub-locations",
+		Handler:        ct.getPaginatedSubLocations,
+		RequiredScopes: []string{ScopeLocationsRead},
+	}
+}
+
+// Nada godoc
+// @Summary Create sub location
+// @Description Create a new sub location in an existing location
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Param request body dto.CreateSubLocationRequest true "Sub location attributes"
+// @Success 200 {object} domain.SubLocation
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID}/sub-locations [post]
+func (ct *SubLocationController) CreateSubLocationEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/locations/:locationID/sub-locations",
+		Handler:        ct.createSubLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Get sub location details
+// @Description Get sub location details
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Param subLocationID path string true "Sub location ID"
+// @Success 200 {object} domain.SubLocation
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID}/sub-locations/{subLocationID} [get]
+func (ct *SubLocationController) SubLocationDetailsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/locations/:locationID/sub-locations/:subLocationID",
+		Handler:        ct.getSubLocationDetails,
+		RequiredScopes: []string{ScopeLocationsRead},
+	}
+}
+
+// Nada godoc
+// @Summary Rename sub location
+// @Description Rename an existing sub location. The default sub location cannot be renamed
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Param subLocationID path string true "Sub location ID"
+// @Param request body dto.RenameSubLocationRequest true "Sub location name"
+// @Success 200 {object} domain.SubLocation
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID}/sub-locations/{subLocationID} [put]
+func (ct *SubLocationController) RenameSubLocationEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPut,
+		Path:           "/v1/locations/:locationID/sub-locations/:subLocationID",
+		Handler:        ct.renameSubLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
+	}
+}
+
+// Nada godoc
+// @Summary Deactivate sub location
+// @Description Deactivate an existing sub location. The default sub location cannot be deactivated
+// @Produce json
+// @Param locationID path string true "Location ID"
+// @Param subLocationID path string true "Sub location ID"
+// @Success 200 {object} domain.SubLocation
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/locations/{locationID}/sub-locations/{subLocationID}/deactivate [post]
+func (ct *SubLocationController) DeactivateSubLocationEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/locations/:locationID/sub-locations/:subLocationID/deactivate",
+		Handler:        ct.deactivateSubLocation,
+		RequiredScopes: []string{ScopeLocationsWrite},
+	}
+}
+
+func (ct *SubLocationController) getPaginatedSubLocations(c echo.Context) error {
+	fnName := "SubLocationController.getPaginatedSubLocations"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID := c.Param("locationID")
+	if locationID == "" {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
+	}
+
+	cursorPaginationFilters, err := buildCursorPaginationFilters(c.Request())
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "error building sub location filters", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	subLocationPage, err := ct.locationService.GetPaginatedSubLocations(appCtx, domain.SubLocationsFilters{
+		CursorPaginationFilters: cursorPaginationFilters,
+		LocationID:              locationID,
+	})
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get paginated sub locations", err)
+		span.SetStatus(codes.Error, err.Error())
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get paginated sub locations", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocationPage))
+}
+
+func (ct *SubLocationController) createSubLocation(c echo.Context) error {
+	fnName := "SubLocationController.createSubLocation"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID := c.Param("locationID")
+	if locationID == "" {
+		ct.logger.ErrorCtx(appCtx, fnName, ErrNoLocationIDSend.Error(), ErrNoLocationIDSend)
+		return respondWithError(c, http.StatusBadRequest, ErrNoLocationIDSend, ErrNoLocationIDSend.Error(), appCtx.GetCorrelationID())
+	}
+
+	createSubLocationRequest, err := parseAndValidateBody[dto.CreateSubLocationRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	subLocation, err := ct.locationService.CreateSubLocation(appCtx, locationID, createSubLocationRequest)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to create sub location", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create sub location", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocation))
+}
+
+func (ct *SubLocationController) getSubLocationDetails(c echo.Context) error {
+	fnName := "SubLocationController.getSubLocationDetails"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID, subLocationID, err := getSubLocationPathParams(c)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	subLocation, err := ct.locationService.GetSubLocationByID(appCtx, locationID, subLocationID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to retrieve sub location by ID", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to retrieve sub location", appCtx.GetCorrelationID())
+	}
+
+	if subLocation == nil {
+		notFoundErr := domain.NotFoundErr{
+			Msg:      fmt.Sprintf("sub location with ID %v not found in location %v", subLocationID, locationID),
+			Resource: domain.ResourceSubLocation,
+		}
+		ct.logger.ErrorCtx(appCtx, fnName, notFoundErr.Error(), notFoundErr)
+		return respondWithError(c, http.StatusNotFound, notFoundErr, "failed to retrieve sub location", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocation))
+}
+
+func (ct *SubLocationController) renameSubLocation(c echo.Context) error {
+	fnName := "SubLocationController.renameSubLocation"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID, subLocationID, err := getSubLocationPathParams(c)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	renameSubLocationRequest, err := parseAndValidateBody[dto.RenameSubLocationRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	subLocation, err := ct.locationService.RenameSubLocation(appCtx, locationID, subLocationID, renameSubLocationRequest)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to rename sub location", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to rename sub location", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocation))
+}
+
+func (ct *SubLocationController) deactivateSubLocation(c echo.Context) error {
+	fnName := "SubLocationController.deactivateSubLocation"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	locationID, subLocationID, err := getSubLocationPathParams(c)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	subLocation, err := ct.locationService.DeactivateSubLocation(appCtx, locationID, subLocationID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to deactivate sub location", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to deactivate sub location", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(subLocation))
+}
+
+func getSubLocationPathParams(c echo.Context) (locationID, subLocationID string, err error) {
+	locationID = c.Param("locationID")
+	if locationID == "" {
+		return "", "", ErrNoLocationIDSend
+	}
+
+	subLocationID = c.Param("subLocationID")
+	if subLocationID == "" {
+		return "", "", ErrNoSubLocationIDSend
+	}
+
+	return locationID, subLocationID, nil
+}
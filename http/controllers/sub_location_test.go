@@ -0,0 +1,256 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type SubLocationControllerSuite struct {
+	suite.Suite
+	locationServiceMock        *mocks.ILocationService
+	getPaginatedSubLocationsEP customHTTP.Endpoint
+	createSubLocationEP        customHTTP.Endpoint
+	getSubLocationDetailsEP    customHTTP.Endpoint
+	renameSubLocationEP        customHTTP.Endpoint
+	deactivateSubLocationEP    customHTTP.Endpoint
+	echoRouter                 *echo.Echo
+	recorder                   *httptest.ResponseRecorder
+}
+
+func (s *SubLocationControllerSuite) SetupSuite() {
+	locationServiceMock := new(mocks.ILocationService)
+	controller := controllers.NewSubLocationController(locationServiceMock, validator.New())
+
+	s.getPaginatedSubLocationsEP = controller.PaginatedSubLocationsEndpoint()
+	s.createSubLocationEP = controller.CreateSubLocationEndpoint()
+	s.getSubLocationDetailsEP = controller.SubLocationDetailsEndpoint()
+	s.renameSubLocationEP = controller.RenameSubLocationEndpoint()
+	s.deactivateSubLocationEP = controller.DeactivateSubLocationEndpoint()
+	s.locationServiceMock = locationServiceMock
+
+	s.echoRouter = echo.New()
+}
+
+func (s *SubLocationControllerSuite) SetupTest() {
+	s.locationServiceMock.ExpectedCalls = nil
+	s.recorder = httptest.NewRecorder()
+}
+
+func (s *SubLocationControllerSuite) assertMockExpectations() {
+	s.locationServiceMock.AssertExpectations(s.T())
+}
+
+func (s *SubLocationControllerSuite) newContext(req *http.Request, ep customHTTP.Endpoint, paramValues ...string) echo.Context {
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(ep.Path)
+	echoCtx.SetParamNames([]string{"locationID", "subLocationID"}[:len(paramValues)]...)
+	echoCtx.SetParamValues(paramValues...)
+
+	return echoCtx
+}
+
+func TestSubLocationControllerSuite(t *testing.T) {
+	suite.Run(t, new(SubLocationControllerSuite))
+}
+
+func (s *SubLocationControllerSuite) Test_getPaginatedSubLocations_Success() {
+	locationID := uuid.New().String()
+	req, _ := http.NewRequest(
+		http.MethodGet,
+		fmt.Sprintf("/v1/locations/%v/sub-locations?limit=%v&direction=%v", locationID, controllers.DefaultLimit, domain.NextPage),
+		http.NoBody,
+	)
+
+	s.locationServiceMock.On("GetPaginatedSubLocations", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		filters := args.Get(1).(domain.SubLocationsFilters)
+		assert.Equal(s.T(), locationID, filters.LocationID)
+		assert.Equal(s.T(), domain.NextPage, filters.CursorPaginationFilters.Direction)
+		assert.Equal(s.T(), controllers.DefaultLimit, filters.CursorPaginationFilters.Limit)
+	}).Return(
+		domain.CursorPage[domain.SubLocation]{
+			Limit: controllers.DefaultLimit,
+			Data:  []domain.SubLocation{{}},
+		}, nil,
+	).Once()
+
+	assert.Nil(s.T(), s.getPaginatedSubLocationsEP.Handler(s.newContext(req, s.getPaginatedSubLocationsEP, locationID)))
+
+	var response struct {
+		Data domain.CursorPage[domain.SubLocation] `json:"data"`
+	}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	if err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Len(s.T(), response.Data.Data, 1)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_getPaginatedSubLocations_Returns400OnInvalidDirection() {
+	locationID := uuid.New().String()
+	req, _ := http.NewRequest(
+		http.MethodGet,
+		fmt.Sprintf("/v1/locations/%v/sub-locations?direction=%v", locationID, "invalidDirection"),
+		http.NoBody,
+	)
+
+	assert.Nil(s.T(), s.getPaginatedSubLocationsEP.Handler(s.newContext(req, s.getPaginatedSubLocationsEP, locationID)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_createSubLocation_Success() {
+	locationID := uuid.New().String()
+	bodyBytes, _ := json.Marshal(dto.CreateSubLocationRequest{Name: "Storage", SubLocationTypeID: 1})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/locations/%v/sub-locations", locationID), bytes.NewBuffer(bodyBytes))
+
+	s.locationServiceMock.On("CreateSubLocation", mock.Anything, locationID, mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(2).(dto.CreateSubLocationRequest)
+		assert.Equal(s.T(), "Storage", request.Name)
+		assert.Equal(s.T(), 1, request.SubLocationTypeID)
+	}).Return(domain.SubLocation{ID: "1", LocationID: locationID}, nil).Once()
+
+	assert.Nil(s.T(), s.createSubLocationEP.Handler(s.newContext(req, s.createSubLocationEP, locationID)))
+
+	var response struct {
+		Data domain.SubLocation `json:"data"`
+	}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	if err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), "1", response.Data.ID)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_createSubLocation_Returns400OnInvalidBody() {
+	locationID := uuid.New().String()
+	bodyBytes, _ := json.Marshal(dto.CreateSubLocationRequest{Name: "Storage"})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/locations/%v/sub-locations", locationID), bytes.NewBuffer(bodyBytes))
+
+	assert.Nil(s.T(), s.createSubLocationEP.Handler(s.newContext(req, s.createSubLocationEP, locationID)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_createSubLocation_Returns409WhenNameIsInUse() {
+	locationID := uuid.New().String()
+	bodyBytes, _ := json.Marshal(dto.CreateSubLocationRequest{Name: "Storage", SubLocationTypeID: 1})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/locations/%v/sub-locations", locationID), bytes.NewBuffer(bodyBytes))
+
+	s.locationServiceMock.On("CreateSubLocation", mock.Anything, locationID, mock.Anything).
+		Return(domain.SubLocation{}, domain.NameAlreadyInUseErr{Msg: "in use", Resource: domain.ResourceSubLocation}).Once()
+
+	assert.Nil(s.T(), s.createSubLocationEP.Handler(s.newContext(req, s.createSubLocationEP, locationID)))
+	assert.Equal(s.T(), http.StatusConflict, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), `"code":"sub_location.name_conflict"`)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_getSubLocationDetails_Success() {
+	locationID, subLocationID := uuid.New().String(), uuid.New().String()
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/locations/%v/sub-locations/%v", locationID, subLocationID), http.NoBody)
+
+	s.locationServiceMock.On("GetSubLocationByID", mock.Anything, locationID, subLocationID).
+		Return(&domain.SubLocation{ID: subLocationID, LocationID: locationID}, nil).Once()
+
+	assert.Nil(s.T(), s.getSubLocationDetailsEP.Handler(s.newContext(req, s.getSubLocationDetailsEP, locationID, subLocationID)))
+
+	var response struct {
+		Data domain.SubLocation `json:"data"`
+	}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	if err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), subLocationID, response.Data.ID)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_getSubLocationDetails_Returns404WhenSubLocationCannotBeFound() {
+	locationID, subLocationID := uuid.New().String(), uuid.New().String()
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/locations/%v/sub-locations/%v", locationID, subLocationID), http.NoBody)
+
+	s.locationServiceMock.On("GetSubLocationByID", mock.Anything, locationID, subLocationID).Return(nil, nil).Once()
+
+	assert.Nil(s.T(), s.getSubLocationDetailsEP.Handler(s.newContext(req, s.getSubLocationDetailsEP, locationID, subLocationID)))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_renameSubLocation_Success() {
+	locationID, subLocationID := uuid.New().String(), uuid.New().String()
+	bodyBytes, _ := json.Marshal(dto.RenameSubLocationRequest{Name: "Backroom"})
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/locations/%v/sub-locations/%v", locationID, subLocationID), bytes.NewBuffer(bodyBytes))
+
+	s.locationServiceMock.On("RenameSubLocation", mock.Anything, locationID, subLocationID, dto.RenameSubLocationRequest{Name: "Backroom"}).
+		Return(domain.SubLocation{ID: subLocationID, Name: "Backroom"}, nil).Once()
+
+	assert.Nil(s.T(), s.renameSubLocationEP.Handler(s.newContext(req, s.renameSubLocationEP, locationID, subLocationID)))
+
+	var response struct {
+		Data domain.SubLocation `json:"data"`
+	}
+	err := json.Unmarshal(s.recorder.Body.Bytes(), &response)
+	if err != nil {
+		s.FailNow("could not unmarshal response body", err.Error())
+	}
+
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), "Backroom", response.Data.Name)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_renameSubLocation_Returns400OnInvalidBody() {
+	locationID, subLocationID := uuid.New().String(), uuid.New().String()
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/locations/%v/sub-locations/%v", locationID, subLocationID), bytes.NewBuffer([]byte("invalid body")))
+
+	assert.Nil(s.T(), s.renameSubLocationEP.Handler(s.newContext(req, s.renameSubLocationEP, locationID, subLocationID)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_deactivateSubLocation_Success() {
+	locationID, subLocationID := uuid.New().String(), uuid.New().String()
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/locations/%v/sub-locations/%v/deactivate", locationID, subLocationID), http.NoBody)
+
+	s.locationServiceMock.On("DeactivateSubLocation", mock.Anything, locationID, subLocationID).
+		Return(domain.SubLocation{ID: subLocationID, Active: false}, nil).Once()
+
+	assert.Nil(s.T(), s.deactivateSubLocationEP.Handler(s.newContext(req, s.deactivateSubLocationEP, locationID, subLocationID)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *SubLocationControllerSuite) Test_deactivateSubLocation_Returns400WhenSubLocationIsDefault() {
+	locationID, subLocationID := uuid.New().String(), uuid.New().String()
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/locations/%v/sub-locations/%v/deactivate", locationID, subLocationID), http.NoBody)
+
+	s.locationServiceMock.On("DeactivateSubLocation", mock.Anything, locationID, subLocationID).
+		Return(domain.SubLocation{}, domain.BusinessErr{Msg: "the default sub location cannot be modified"}).Once()
+
+	assert.Nil(s.T(), s.deactivateSubLocationEP.Handler(s.newContext(req, s.deactivateSubLocationEP, locationID, subLocationID)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
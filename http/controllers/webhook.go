@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/middleware"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"net/http"
+)
+
+const DeliveryStatusQP = "status"
+
+var (
+	ErrInvalidWebhookID         = errors.New("invalid 'webhookID' path param, it must be a UUID")
+	ErrInvalidWebhookDeliveryID = errors.New("invalid 'deliveryID' path param, it must be a UUID")
+	ErrInvalidDeliveryStatusQP  = errors.New("invalid status value, accepted values: '" + domain.WebhookDeliveryRetrying + "', '" +
+		domain.WebhookDeliveryFailed + "' or '" + domain.WebhookDeliverySucceeded + "'")
+)
+
+type WebhookController struct {
+	logger         monitor.AppLogger
+	webhookService services.IWebhookService
+	validator      *validator.Validate
+}
+
+func NewWebhookController(webhookService services.IWebhookService, validator *validator.Validate) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+		logger:         monitor.GetStdLogger("WebhookController"),
+		validator:      validator,
+	}
+}
+
+// Nada godoc
+// @Summary List webhooks
+// @Description Get the webhook subscriptions, callers restricted to a supplier only get the ones of that supplier. Secrets are never returned
+// @Produce json
+// @Success 200 {object} []domain.WebhookSubscription
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/webhooks [get]
+func (ct *WebhookController) WebhooksEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/webhooks",
+		Handler:        ct.getWebhooks,
+		RequiredScopes: []string{ScopeWebhooksAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Create webhook
+// @Description Subscribe a URL to location events. Each delivery is a POST signed in the X-Webhook-Signature header as 'sha256=<hex HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" with the secret>'. A subscription restricted to a supplier only receives the events of its locations. The URL must be an https URL of a public address, redirects are not followed
+// @Produce json
+// @Param request body dto.CreateWebhookRequest true "Webhook attributes"
+// @Success 200 {object} domain.WebhookSubscription
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/webhooks [post]
+func (ct *WebhookController) CreateWebhookEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/webhooks",
+		Handler:        ct.createWebhook,
+		RequiredScopes: []string{ScopeWebhooksAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Get webhook
+// @Description Get a webhook subscription by its ID
+// @Produce json
+// @Param webhookID path string true "Webhook ID"
+// @Success 200 {object} domain.WebhookSubscription
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/webhooks/{webhookID} [get]
+func (ct *WebhookController) WebhookDetailsEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/webhooks/:webhookID",
+		Handler:        ct.getWebhookDetails,
+		RequiredScopes: []string{ScopeWebhooksAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Update webhook
+// @Description Replace a webhook subscription, send the status 'paused' to stop its deliveries. The secret is only changed when it is sent
+// @Produce json
+// @Param webhookID path string true "Webhook ID"
+// @Param request body dto.UpdateWebhookRequest true "Webhook attributes"
+// @Success 200 {object} domain.WebhookSubscription
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/webhooks/{webhookID} [put]
+func (ct *WebhookController) UpdateWebhookEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPut,
+		Path:           "/v1/webhooks/:webhookID",
+		Handler:        ct.updateWebhook,
+		RequiredScopes: []string{ScopeWebhooksAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Delete webhook
+// @Description Delete a webhook subscription along with its delivery log
+// @Param webhookID path string true "Webhook ID"
+// @Success 204
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/webhooks/{webhookID} [delete]
+func (ct *WebhookController) DeleteWebhookEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodDelete,
+		Path:           "/v1/webhooks/:webhookID",
+		Handler:        ct.deleteWebhook,
+		RequiredScopes: []string{ScopeWebhooksAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary List webhook deliveries
+// @Description Get the newest entries of the delivery log of a webhook, the events that could not be delivered at once. They are retried in the background until they succeed or run out of attempts
+// @Produce json
+// @Param webhookID path string true "Webhook ID"
+// @Param status query string false "Optional filter by delivery status. Accepted values: 'retrying', 'failed' or 'succeeded'"
+// @Success 200 {object} []domain.WebhookDelivery
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/webhooks/{webhookID}/deliveries [get]
+func (ct *WebhookController) WebhookDeliveriesEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/v1/webhooks/:webhookID/deliveries",
+		Handler:        ct.getWebhookDeliveries,
+		RequiredScopes: []string{ScopeWebhooksAdmin},
+	}
+}
+
+// Nada godoc
+// @Summary Redeliver webhook event
+// @Description Send a logged event again, once and with a new signature. The delivery is returned with the outcome of the attempt
+// @Produce json
+// @Param webhookID path string true "Webhook ID"
+// @Param deliveryID path string true "Delivery ID"
+// @Success 200 {object} domain.WebhookDelivery
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v1/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver [post]
+func (ct *WebhookController) RedeliverWebhookEndpoint() customHTTP.Endpoint {
+	return customHTTP.Endpoint{
+		Method:         http.MethodPost,
+		Path:           "/v1/webhooks/:webhookID/deliveries/:deliveryID/redeliver",
+		Handler:        ct.redeliverWebhook,
+		RequiredScopes: []string{ScopeWebhooksAdmin},
+	}
+}
+
+func (ct *WebhookController) getWebhooks(c echo.Context) error {
+	fnName := "WebhookController.getWebhooks"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	webhooks, err := ct.webhookService.GetWebhooks(appCtx)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get webhooks", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get webhooks", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(webhooks))
+}
+
+func (ct *WebhookController) createWebhook(c echo.Context) error {
+	fnName := "WebhookController.createWebhook"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	request, err := parseAndValidateBody[dto.CreateWebhookRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	webhook, err := ct.webhookService.CreateWebhook(appCtx, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to create webhook", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to create webhook", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(webhook))
+}
+
+func (ct *WebhookController) getWebhookDetails(c echo.Context) error {
+	fnName := "WebhookController.getWebhookDetails"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "webhookID", ErrInvalidWebhookID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	webhook, err := ct.webhookService.GetWebhookByID(appCtx, id)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get webhook", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get webhook", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(webhook))
+}
+
+func (ct *WebhookController) updateWebhook(c echo.Context) error {
+	fnName := "WebhookController.updateWebhook"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "webhookID", ErrInvalidWebhookID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	request, err := parseAndValidateBody[dto.UpdateWebhookRequest](c.Request().Body, ct.validator)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to parse or validate request body", err)
+		return respondWithError(c, http.StatusBadRequest, err, "failed to parse or validate request body", appCtx.GetCorrelationID())
+	}
+
+	webhook, err := ct.webhookService.UpdateWebhook(appCtx, id, request)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to update webhook", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to update webhook", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(webhook))
+}
+
+func (ct *WebhookController) deleteWebhook(c echo.Context) error {
+	fnName := "WebhookController.deleteWebhook"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "webhookID", ErrInvalidWebhookID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	if err = ct.webhookService.DeleteWebhook(appCtx, id); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to delete webhook", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to delete webhook", appCtx.GetCorrelationID())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (ct *WebhookController) getWebhookDeliveries(c echo.Context) error {
+	fnName := "WebhookController.getWebhookDeliveries"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, err := getUUIDPathParam(c, "webhookID", ErrInvalidWebhookID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, err.Error(), appCtx.GetCorrelationID())
+	}
+
+	var status *string
+	if statusVal := c.QueryParam(DeliveryStatusQP); statusVal != "" {
+		if statusVal != domain.WebhookDeliveryRetrying && statusVal != domain.WebhookDeliveryFailed &&
+			statusVal != domain.WebhookDeliverySucceeded {
+			err = FieldErr{Field: DeliveryStatusQP, Err: ErrInvalidDeliveryStatusQP}
+			ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+			return respondWithError(c, http.StatusBadRequest, err, "invalid delivery filters", appCtx.GetCorrelationID())
+		}
+		status = &statusVal
+	}
+
+	deliveries, err := ct.webhookService.GetWebhookDeliveries(appCtx, id, status)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to get webhook deliveries", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to get webhook deliveries", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(deliveries))
+}
+
+func (ct *WebhookController) redeliverWebhook(c echo.Context) error {
+	fnName := "WebhookController.redeliverWebhook"
+	var appCtx monitor.ApplicationContext = middleware.GetAppContext(c)
+
+	appCtx, span := appCtx.StartSpan(fnName)
+	defer span.End()
+
+	id, idErr := getUUIDPathParam(c, "webhookID", ErrInvalidWebhookID)
+	deliveryID, deliveryIDErr := getUUIDPathParam(c, "deliveryID", ErrInvalidWebhookDeliveryID)
+	if err := errors.Join(idErr, deliveryIDErr); err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, err.Error(), err)
+		return respondWithError(c, http.StatusBadRequest, err, "invalid path params", appCtx.GetCorrelationID())
+	}
+
+	delivery, err := ct.webhookService.RedeliverWebhook(appCtx, id, deliveryID)
+	if err != nil {
+		ct.logger.ErrorCtx(appCtx, fnName, "failed to redeliver webhook event", err)
+		return respondWithError(c, httpStatusFromError(err), err, "failed to redeliver webhook event", appCtx.GetCorrelationID())
+	}
+
+	return c.JSON(http.StatusOK, buildSuccessResponse(delivery))
+}
+
+func getUUIDPathParam(c echo.Context, name string, invalidErr error) (string, error) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		return "", invalidErr
+	}
+
+	return id.String(), nil
+}
@@ -0,0 +1,176 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/http/controllers"
+	"go-service-template/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	testWebhookID         = "0f6b1d9e-7b1c-4c55-9a0e-2a9a3cbb6f10"
+	testWebhookDeliveryID = "8e3a53c4-6f0a-4b8e-8a1d-3f9f1f2b7c55"
+)
+
+type WebhookControllerSuite struct {
+	suite.Suite
+	webhookServiceMock  *mocks.IWebhookService
+	createWebhookEP     customHTTP.Endpoint
+	webhookDetailsEP    customHTTP.Endpoint
+	webhookDeliveriesEP customHTTP.Endpoint
+	redeliverWebhookEP  customHTTP.Endpoint
+	echoRouter          *echo.Echo
+	recorder            *httptest.ResponseRecorder
+}
+
+func (s *WebhookControllerSuite) SetupSuite() {
+	webhookServiceMock := new(mocks.IWebhookService)
+	controller := controllers.NewWebhookController(webhookServiceMock, validator.New())
+
+	s.createWebhookEP = controller.CreateWebhookEndpoint()
+	s.webhookDetailsEP = controller.WebhookDetailsEndpoint()
+	s.webhookDeliveriesEP = controller.WebhookDeliveriesEndpoint()
+	s.redeliverWebhookEP = controller.RedeliverWebhookEndpoint()
+	s.webhookServiceMock = webhookServiceMock
+
+	s.echoRouter = echo.New()
+}
+
+func (s *WebhookControllerSuite) SetupTest() {
+	s.webhookServiceMock.ExpectedCalls = nil
+	s.recorder = httptest.NewRecorder()
+}
+
+func (s *WebhookControllerSuite) assertMockExpectations() {
+	s.webhookServiceMock.AssertExpectations(s.T())
+}
+
+func TestWebhookControllerSuite(t *testing.T) {
+	suite.Run(t, new(WebhookControllerSuite))
+}
+
+func (s *WebhookControllerSuite) Test_createWebhook_DoesNotReturnSecret() {
+	request := dto.CreateWebhookRequest{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{domain.WebhookEventLocationCreated},
+		Secret:     "0123456789abcdef",
+	}
+	bodyBytes, _ := json.Marshal(request)
+	req, _ := http.NewRequest(http.MethodPost, "/v1/webhooks", bytes.NewBuffer(bodyBytes))
+
+	s.webhookServiceMock.On("CreateWebhook", mock.Anything, request).Return(domain.WebhookSubscription{
+		ID:         testWebhookID,
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+		Secret:     request.Secret,
+		Status:     domain.WebhookStatusActive,
+	}, nil).Once()
+
+	assert.Nil(s.T(), s.createWebhookEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), testWebhookID)
+	assert.NotContains(s.T(), s.recorder.Body.String(), request.Secret)
+	s.assertMockExpectations()
+}
+
+func (s *WebhookControllerSuite) Test_createWebhook_Returns400OnUnknownEventType() {
+	bodyBytes, _ := json.Marshal(dto.CreateWebhookRequest{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{"location.exploded"},
+		Secret:     "0123456789abcdef",
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/webhooks", bytes.NewBuffer(bodyBytes))
+
+	assert.Nil(s.T(), s.createWebhookEP.Handler(s.echoRouter.NewContext(req, s.recorder)))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *WebhookControllerSuite) Test_getWebhookDetails_Returns404WhenMissing() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/webhooks/"+testWebhookID, http.NoBody)
+
+	s.webhookServiceMock.On("GetWebhookByID", mock.Anything, testWebhookID).
+		Return(domain.WebhookSubscription{}, domain.NotFoundErr{Msg: "not found", Resource: domain.ResourceWebhook}).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.webhookDetailsEP.Path)
+	echoCtx.SetParamNames("webhookID")
+	echoCtx.SetParamValues(testWebhookID)
+
+	assert.Nil(s.T(), s.webhookDetailsEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusNotFound, s.recorder.Code)
+	s.assertMockExpectations()
+}
+
+func (s *WebhookControllerSuite) Test_getWebhookDeliveries_FiltersByStatus() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/webhooks/"+testWebhookID+"/deliveries?status=failed", http.NoBody)
+
+	status := domain.WebhookDeliveryFailed
+	s.webhookServiceMock.On("GetWebhookDeliveries", mock.Anything, testWebhookID, &status).
+		Return([]domain.WebhookDelivery{{ID: testWebhookDeliveryID, Status: status}}, nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.webhookDeliveriesEP.Path)
+	echoCtx.SetParamNames("webhookID")
+	echoCtx.SetParamValues(testWebhookID)
+
+	assert.Nil(s.T(), s.webhookDeliveriesEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), testWebhookDeliveryID)
+	s.assertMockExpectations()
+}
+
+func (s *WebhookControllerSuite) Test_getWebhookDeliveries_Returns400OnInvalidStatus() {
+	req, _ := http.NewRequest(http.MethodGet, "/v1/webhooks/"+testWebhookID+"/deliveries?status=pending", http.NoBody)
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.webhookDeliveriesEP.Path)
+	echoCtx.SetParamNames("webhookID")
+	echoCtx.SetParamValues(testWebhookID)
+
+	assert.Nil(s.T(), s.webhookDeliveriesEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), controllers.DeliveryStatusQP)
+	s.assertMockExpectations()
+}
+
+func (s *WebhookControllerSuite) Test_redeliverWebhook_Success() {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/webhooks/"+testWebhookID+"/deliveries/"+testWebhookDeliveryID+"/redeliver", http.NoBody)
+
+	s.webhookServiceMock.On("RedeliverWebhook", mock.Anything, testWebhookID, testWebhookDeliveryID).
+		Return(domain.WebhookDelivery{ID: testWebhookDeliveryID, Status: domain.WebhookDeliverySucceeded}, nil).Once()
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.redeliverWebhookEP.Path)
+	echoCtx.SetParamNames("webhookID", "deliveryID")
+	echoCtx.SetParamValues(testWebhookID, testWebhookDeliveryID)
+
+	assert.Nil(s.T(), s.redeliverWebhookEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), domain.WebhookDeliverySucceeded)
+	s.assertMockExpectations()
+}
+
+func (s *WebhookControllerSuite) Test_redeliverWebhook_Returns400OnInvalidDeliveryID() {
+	req, _ := http.NewRequest(http.MethodPost, "/v1/webhooks/"+testWebhookID+"/deliveries/abc/redeliver", http.NoBody)
+
+	echoCtx := s.echoRouter.NewContext(req, s.recorder)
+	echoCtx.SetPath(s.redeliverWebhookEP.Path)
+	echoCtx.SetParamNames("webhookID", "deliveryID")
+	echoCtx.SetParamValues(testWebhookID, "abc")
+
+	assert.Nil(s.T(), s.redeliverWebhookEP.Handler(echoCtx))
+	assert.Equal(s.T(), http.StatusBadRequest, s.recorder.Code)
+	s.assertMockExpectations()
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/monitor"
+	"net/http"
+)
+
+const (
+	APIKeyHeader        = "X-API-Key"
+	APIKeySubjectPrefix = "api-key:"
+	apiKeyAuthScheme    = "APIKey"
+)
+
+var ErrInvalidAPIKey = errors.New("the API key is not valid or was revoked")
+
+// APIKeyAuthenticator finds the active API key matching the key sent by a client, nil is returned for invalid keys
+type APIKeyAuthenticator interface {
+	Authenticate(ctx monitor.ApplicationContext, key string) (*domain.APIKey, error)
+}
+
+// HasAPIKey reports whether the request is authenticated with an API key instead of a bearer token
+func HasAPIKey(c echo.Context) bool {
+	return c.Request().Header.Get(APIKeyHeader) != ""
+}
+
+// CreateAPIKeyMiddleware authenticates the requests sent with the X-API-Key header, the scopes and supplier of the key
+// are stored as the principal of the request AppContext. Requests without the header are left to the bearer token
+// authentication, so it must run after the AppContext middleware and before the auth one.
+func CreateAPIKeyMiddleware(authenticator APIKeyAuthenticator) customHTTP.Middleware {
+	logger := monitor.GetStdLogger("APIKeyMiddleware")
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !HasAPIKey(c) {
+				return next(c)
+			}
+
+			fnName := "APIKeyMiddleware"
+			appCtx := GetAppContext(c)
+
+			apiKey, err := authenticator.Authenticate(appCtx, c.Request().Header.Get(APIKeyHeader))
+			if err != nil {
+				logger.ErrorCtx(appCtx, fnName, "failed to authenticate API key", err)
+				return writeAuthProblem(c, http.StatusInternalServerError, err, "failed to authenticate API key", appCtx.GetCorrelationID())
+			}
+			if apiKey == nil {
+				logger.WarnCtx(appCtx, fnName, "request rejected", monitor.LoggingParam{Name: "error", Value: ErrInvalidAPIKey.Error()})
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, apiKeyAuthScheme)
+				return writeAuthProblem(c, http.StatusUnauthorized, ErrInvalidAPIKey, "unauthenticated request", appCtx.GetCorrelationID())
+			}
+
+			appCtx = appCtx.WithPrincipal(APIKeyPrincipal(*apiKey))
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), AppContextKey, appCtx)))
+
+			return next(c)
+		}
+	}
+}
+
+// APIKeyPrincipal returns the principal of the requests authenticated with the API key, which has its scopes and
+// supplier
+func APIKeyPrincipal(apiKey domain.APIKey) monitor.Principal {
+	return monitor.Principal{
+		Subject:    APIKeySubjectPrefix + apiKey.ID,
+		Scopes:     apiKey.Scopes,
+		SupplierID: apiKey.SupplierID,
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubAPIKeyAuthenticator accepts a single key
+type stubAPIKeyAuthenticator struct {
+	key    string
+	apiKey domain.APIKey
+	err    error
+}
+
+func (a stubAPIKeyAuthenticator) Authenticate(_ monitor.ApplicationContext, key string) (*domain.APIKey, error) {
+	if a.err != nil || key != a.key {
+		return nil, a.err
+	}
+
+	return &a.apiKey, nil
+}
+
+type APIKeyMiddlewareSuite struct {
+	suite.Suite
+	echoRouter *echo.Echo
+	recorder   *httptest.ResponseRecorder
+}
+
+func (s *APIKeyMiddlewareSuite) SetupSuite() {
+	s.echoRouter = echo.New()
+}
+
+func (s *APIKeyMiddlewareSuite) SetupTest() {
+	s.recorder = httptest.NewRecorder()
+}
+
+func TestAPIKeyMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyMiddlewareSuite))
+}
+
+func (s *APIKeyMiddlewareSuite) serve(authenticator APIKeyAuthenticator, apiKey string) (monitor.Principal, bool, error) {
+	req, _ := http.NewRequest(http.MethodGet, "/test", http.NoBody)
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+
+	var principal monitor.Principal
+	var authenticated bool
+	handler := CreateAPIKeyMiddleware(authenticator)(func(c echo.Context) error {
+		principal, authenticated = GetAppContext(c).GetPrincipal()
+		return c.NoContent(http.StatusOK)
+	})
+
+	err := handler(s.echoRouter.NewContext(req, s.recorder))
+
+	return principal, authenticated, err
+}
+
+func (s *APIKeyMiddlewareSuite) Test_APIKeyMiddleware_SetsKeyPrincipal() {
+	supplierID := 2
+	authenticator := stubAPIKeyAuthenticator{
+		key:    "prefix.secret",
+		apiKey: domain.APIKey{ID: "id", Scopes: []string{"locations:read"}, SupplierID: &supplierID},
+	}
+
+	principal, authenticated, err := s.serve(authenticator, "prefix.secret")
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), authenticated)
+	assert.Equal(s.T(), monitor.Principal{
+		Subject:    APIKeySubjectPrefix + "id",
+		Scopes:     []string{"locations:read"},
+		SupplierID: &supplierID,
+	}, principal)
+}
+
+func (s *APIKeyMiddlewareSuite) Test_APIKeyMiddleware_RejectsInvalidKey() {
+	_, _, err := s.serve(stubAPIKeyAuthenticator{key: "prefix.secret"}, "prefix.other")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), ErrInvalidAPIKey.Error())
+}
+
+func (s *APIKeyMiddlewareSuite) Test_APIKeyMiddleware_Returns500OnAuthenticatorError() {
+	_, _, err := s.serve(stubAPIKeyAuthenticator{err: errors.New("db down")}, "prefix.secret")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusInternalServerError, s.recorder.Code)
+}
+
+func (s *APIKeyMiddlewareSuite) Test_APIKeyMiddleware_IgnoresRequestsWithoutKey() {
+	_, authenticated, err := s.serve(stubAPIKeyAuthenticator{}, "")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.False(s.T(), authenticated)
+}
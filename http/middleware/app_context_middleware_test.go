@@ -56,6 +56,22 @@ func (s *AppContextMiddlewareSuite) Test_AppContextMiddleware_UsesCorrelationIDH
 	assert.Equal(s.T(), "value", s.recorder.Body.String())
 }
 
+func (s *AppContextMiddlewareSuite) Test_AppContextMiddleware_DoesNotTakeActorFromHeaders() {
+	req, _ := http.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Add("Actor-Id", "jane.doe")
+
+	var actor string
+	wrappedTestHandler := s.appContextMiddleware(func(c echo.Context) error {
+		actor = GetAppContext(c).GetActor()
+		return c.NoContent(http.StatusOK)
+	})
+
+	err := wrappedTestHandler(s.echoRouter.NewContext(req, s.recorder))
+
+	assert.Nil(s.T(), err)
+	assert.Empty(s.T(), actor)
+}
+
 func CreateTestEndpoint() customHTTP.Handler {
 	return func(c echo.Context) error {
 		appCtx := GetAppContext(c)
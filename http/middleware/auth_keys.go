@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"go-service-template/config"
+	"math/big"
+	"os"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// verificationKey is a key able to verify the tokens signed with its algorithm
+type verificationKey struct {
+	algorithm string
+	key       any
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwk holds the members of RSA and symmetric JSON Web Keys, other key types are not supported
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	K         string `json:"k"`
+}
+
+// loadVerificationKeys reads the keys of the JWKS file and the static keys, indexed by key ID
+func loadVerificationKeys(cfg config.AuthConfig) (map[string]verificationKey, error) {
+	keys := make(map[string]verificationKey)
+
+	addKey := func(keyID string, key verificationKey) error {
+		if _, ok := keys[keyID]; ok {
+			return fmt.Errorf("the key ID '%v' is repeated", keyID)
+		}
+		keys[keyID] = key
+		return nil
+	}
+
+	if cfg.JWKSFile != "" {
+		content, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWKS file: %w", err)
+		}
+
+		var keySet jwks
+		if err = json.Unmarshal(content, &keySet); err != nil {
+			return nil, fmt.Errorf("error parsing JWKS file: %w", err)
+		}
+
+		for _, key := range keySet.Keys {
+			// Encryption keys cannot verify signatures
+			if key.Use != "" && key.Use != "sig" {
+				continue
+			}
+
+			verification, err := key.verificationKey()
+			if err != nil {
+				return nil, fmt.Errorf("invalid JWK '%v': %w", key.KeyID, err)
+			}
+			if err = addKey(key.KeyID, verification); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, staticKey := range cfg.StaticKeys {
+		verification, err := staticVerificationKey(staticKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid static key '%v': %w", staticKey.KeyID, err)
+		}
+		if err = addKey(staticKey.KeyID, verification); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no keys to verify the bearer tokens were configured")
+	}
+
+	return keys, nil
+}
+
+func (k jwk) verificationKey() (verificationKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		if k.Algorithm != "" && k.Algorithm != AlgorithmRS256 {
+			return verificationKey{}, fmt.Errorf("unsupported algorithm '%v'", k.Algorithm)
+		}
+
+		modulus, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("invalid modulus: %w", err)
+		}
+		exponent, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("invalid exponent: %w", err)
+		}
+
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(new(big.Int).SetBytes(exponent).Int64())}
+
+		return verificationKey{algorithm: AlgorithmRS256, key: publicKey}, nil
+	case "oct":
+		if k.Algorithm != "" && k.Algorithm != AlgorithmHS256 {
+			return verificationKey{}, fmt.Errorf("unsupported algorithm '%v'", k.Algorithm)
+		}
+
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return verificationKey{}, fmt.Errorf("invalid secret: %w", err)
+		}
+
+		return verificationKey{algorithm: AlgorithmHS256, key: secret}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type '%v'", k.KeyType)
+	}
+}
+
+func staticVerificationKey(staticKey config.StaticKeyConfig) (verificationKey, error) {
+	switch staticKey.Algorithm {
+	case AlgorithmHS256:
+		if staticKey.Secret == "" {
+			return verificationKey{}, errors.New("the secret is empty")
+		}
+
+		return verificationKey{algorithm: AlgorithmHS256, key: []byte(staticKey.Secret)}, nil
+	case AlgorithmRS256:
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(staticKey.PublicKey))
+		if err != nil {
+			return verificationKey{}, err
+		}
+
+		return verificationKey{algorithm: AlgorithmRS256, key: publicKey}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported algorithm '%v'", staticKey.Algorithm)
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"go-service-template/config"
+	customHTTP "go-service-template/http"
+	"go-service-template/monitor"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	AuthorizationHeader = "Authorization"
+	BearerScheme        = "Bearer"
+	TokenLeeway         = 30 * time.Second
+)
+
+var (
+	ErrMissingBearerToken = errors.New("the Authorization header with a bearer token is required")
+	ErrUnknownTokenKey    = errors.New("the token was not signed with a known key")
+	ErrMissingSubject     = errors.New("the token has no subject")
+	ErrNotAuthenticated   = errors.New("the request is not authenticated")
+)
+
+// tokenClaims accepts the scopes as a space separated scope claim, as in OAuth 2.0, or as an scp array. The
+// supplier_id claim restricts the caller to the locations of that supplier.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Scope      string   `json:"scope"`
+	Scp        []string `json:"scp"`
+	SupplierID *int     `json:"supplier_id"`
+}
+
+func (c tokenClaims) scopes() []string {
+	return append(strings.Fields(c.Scope), c.Scp...)
+}
+
+// TokenAuthenticator authenticates the value of an Authorization header holding a bearer JWT
+type TokenAuthenticator func(authorization string) (monitor.Principal, error)
+
+// NewTokenAuthenticator accepts the tokens signed with one of the configured keys, using RS256 or HS256. It is shared
+// by the HTTP and gRPC servers.
+func NewTokenAuthenticator(cfg config.AuthConfig) TokenAuthenticator {
+	keys, err := loadVerificationKeys(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmHS256}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(TokenLeeway),
+	}
+	if cfg.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(cfg.Audience))
+	}
+	parser := jwt.NewParser(parserOptions...)
+
+	return func(authorization string) (monitor.Principal, error) {
+		return authenticate(parser, keys, authorization)
+	}
+}
+
+// CreateAuthMiddleware authenticates the requests with a bearer JWT signed with one of the configured keys, using
+// RS256 or HS256. The subject and scopes of the token are stored as the principal of the request AppContext, so it
+// must run after the AppContext middleware. Requests accepted by the skipper are not authenticated.
+func CreateAuthMiddleware(cfg config.AuthConfig, skipper echoMiddleware.Skipper) customHTTP.Middleware {
+	authenticator := NewTokenAuthenticator(cfg)
+	logger := monitor.GetStdLogger("AuthMiddleware")
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			fnName := "AuthMiddleware"
+			appCtx := GetAppContext(c)
+
+			principal, err := authenticator(c.Request().Header.Get(AuthorizationHeader))
+			if err != nil {
+				logger.WarnCtx(appCtx, fnName, "request rejected", monitor.LoggingParam{Name: "error", Value: err.Error()})
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, BearerScheme)
+				return writeAuthProblem(c, http.StatusUnauthorized, err, "unauthenticated request", appCtx.GetCorrelationID())
+			}
+
+			appCtx = appCtx.WithPrincipal(principal)
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), AppContextKey, appCtx)))
+
+			return next(c)
+		}
+	}
+}
+
+// CreateScopeAuthorizer returns the web server Authorizer, it only lets through the principals with every required
+// scope of the endpoint
+func CreateScopeAuthorizer() customHTTP.Authorizer {
+	return func(requiredScopes []string) customHTTP.Middleware {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				appCtx := GetAppContext(c)
+
+				principal, ok := appCtx.GetPrincipal()
+				if !ok {
+					c.Response().Header().Set(echo.HeaderWWWAuthenticate, BearerScheme)
+					return writeAuthProblem(c, http.StatusUnauthorized, ErrNotAuthenticated, "unauthenticated request", appCtx.GetCorrelationID())
+				}
+
+				if !principal.HasScopes(requiredScopes...) {
+					err := fmt.Errorf("the scopes %v are required", strings.Join(requiredScopes, ", "))
+					return writeAuthProblem(c, http.StatusForbidden, err, "insufficient scopes", appCtx.GetCorrelationID())
+				}
+
+				return next(c)
+			}
+		}
+	}
+}
+
+func authenticate(parser *jwt.Parser, keys map[string]verificationKey, authorization string) (monitor.Principal, error) {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, BearerScheme) || token == "" {
+		return monitor.Principal{}, ErrMissingBearerToken
+	}
+
+	var claims tokenClaims
+	if _, err := parser.ParseWithClaims(token, &claims, func(token *jwt.Token) (any, error) {
+		return findVerificationKey(keys, token)
+	}); err != nil {
+		return monitor.Principal{}, err
+	}
+
+	if claims.Subject == "" {
+		return monitor.Principal{}, ErrMissingSubject
+	}
+
+	return monitor.Principal{Subject: claims.Subject, Scopes: claims.scopes(), SupplierID: claims.SupplierID}, nil
+}
+
+// findVerificationKey picks the key by the kid header, which can be omitted when a single key is configured. The key
+// algorithm must match the token one, so a public RSA key is never used as an HMAC secret.
+func findVerificationKey(keys map[string]verificationKey, token *jwt.Token) (any, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	key, ok := keys[keyID]
+	if !ok && keyID == "" && len(keys) == 1 {
+		for _, onlyKey := range keys {
+			key, ok = onlyKey, true
+		}
+	}
+
+	if !ok || key.algorithm != token.Method.Alg() {
+		return nil, ErrUnknownTokenKey
+	}
+
+	return key.key, nil
+}
+
+// writeAuthProblem rejects the request, the code of the problem is the one of the status
+func writeAuthProblem(c echo.Context, status int, err error, title, correlationID string) error {
+	return customHTTP.WriteProblem(c, customHTTP.NewProblem(c.Request(), status, "", title, err.Error(), correlationID))
+}
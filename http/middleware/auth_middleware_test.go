@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	customHTTP "go-service-template/http"
+	"go-service-template/monitor"
+	"go-service-template/utils"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "test-issuer"
+	testAudience = "test-audience"
+	testSecret   = "test-secret"
+)
+
+type AuthMiddlewareSuite struct {
+	suite.Suite
+	rsaKey         *rsa.PrivateKey
+	authMiddleware customHTTP.Middleware
+	authorizer     customHTTP.Authorizer
+	echoRouter     *echo.Echo
+	recorder       *httptest.ResponseRecorder
+}
+
+func (s *AuthMiddlewareSuite) SetupSuite() {
+	var err error
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	keySet, _ := json.Marshal(jwks{Keys: []jwk{{
+		KeyType:   "RSA",
+		KeyID:     "rsa-key",
+		Algorithm: AlgorithmRS256,
+		Use:       "sig",
+		N:         base64.RawURLEncoding.EncodeToString(s.rsaKey.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.rsaKey.E)).Bytes()),
+	}}})
+	jwksFile := filepath.Join(s.T().TempDir(), "jwks.json")
+	s.Require().NoError(os.WriteFile(jwksFile, keySet, 0o600))
+
+	s.authMiddleware = CreateAuthMiddleware(config.AuthConfig{
+		Issuer:     testIssuer,
+		Audience:   testAudience,
+		JWKSFile:   jwksFile,
+		StaticKeys: []config.StaticKeyConfig{{KeyID: "hmac-key", Algorithm: AlgorithmHS256, Secret: testSecret}},
+	}, func(c echo.Context) bool {
+		return c.Request().URL.Path == "/health"
+	})
+	s.authorizer = CreateScopeAuthorizer()
+	s.echoRouter = echo.New()
+}
+
+func (s *AuthMiddlewareSuite) SetupTest() {
+	s.recorder = httptest.NewRecorder()
+}
+
+func TestAuthMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(AuthMiddlewareSuite))
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_AcceptsHS256Token() {
+	token := s.signHS256("hmac-key", validClaims("jane.doe", "locations:read locations:write"))
+
+	principal, actor, err := s.serve(s.authMiddleware, "/test", token)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), monitor.Principal{Subject: "jane.doe", Scopes: []string{"locations:read", "locations:write"}}, principal)
+	assert.Equal(s.T(), "jane.doe", actor)
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_AcceptsRS256TokenFromJWKS() {
+	claims := validClaims("service-a", "")
+	claims["scp"] = []string{"locations:read"}
+	token := s.signRS256("rsa-key", claims)
+
+	principal, _, err := s.serve(s.authMiddleware, "/test", token)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), monitor.Principal{Subject: "service-a", Scopes: []string{"locations:read"}}, principal)
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_RestrictsPrincipalToSupplierClaim() {
+	claims := validClaims("supplier-portal", "locations:read")
+	claims["supplier_id"] = 7
+	token := s.signHS256("hmac-key", claims)
+
+	principal, _, err := s.serve(s.authMiddleware, "/test", token)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+	assert.Equal(s.T(), utils.ToPointer(7), principal.SupplierID)
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_RejectsMissingToken() {
+	_, _, err := s.serve(s.authMiddleware, "/test", "")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+	assert.Equal(s.T(), BearerScheme, s.recorder.Header().Get(echo.HeaderWWWAuthenticate))
+	assert.Contains(s.T(), s.recorder.Body.String(), ErrMissingBearerToken.Error())
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_RejectsExpiredToken() {
+	claims := validClaims("jane.doe", "locations:read")
+	claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+	_, _, err := s.serve(s.authMiddleware, "/test", s.signHS256("hmac-key", claims))
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_RejectsWrongAudience() {
+	claims := validClaims("jane.doe", "locations:read")
+	claims["aud"] = "other-service"
+
+	_, _, err := s.serve(s.authMiddleware, "/test", s.signHS256("hmac-key", claims))
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_RejectsKeyWithOtherAlgorithm() {
+	// HS256 token whose kid points to the RSA key
+	_, _, err := s.serve(s.authMiddleware, "/test", s.signHS256("rsa-key", validClaims("jane.doe", "locations:read")))
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), ErrUnknownTokenKey.Error())
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_RejectsTokenWithoutSubject() {
+	_, _, err := s.serve(s.authMiddleware, "/test", s.signHS256("hmac-key", validClaims("", "locations:read")))
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), ErrMissingSubject.Error())
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware_SkipsPublicPaths() {
+	_, _, err := s.serve(s.authMiddleware, "/health", "")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+}
+
+func (s *AuthMiddlewareSuite) Test_ScopeAuthorizer_AcceptsPrincipalWithRequiredScopes() {
+	token := s.signHS256("hmac-key", validClaims("jane.doe", "locations:read locations:write"))
+
+	_, _, err := s.serve(s.chain(s.authorizer([]string{"locations:write"})), "/test", token)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, s.recorder.Code)
+}
+
+func (s *AuthMiddlewareSuite) Test_ScopeAuthorizer_RejectsMissingScopes() {
+	token := s.signHS256("hmac-key", validClaims("jane.doe", "locations:read"))
+
+	_, _, err := s.serve(s.chain(s.authorizer([]string{"locations:write"})), "/test", token)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusForbidden, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), "insufficient scopes")
+}
+
+func (s *AuthMiddlewareSuite) Test_ScopeAuthorizer_RejectsUnauthenticatedRequest() {
+	_, _, err := s.serve(s.authorizer([]string{"locations:read"}), "/test", "")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Body.String(), ErrNotAuthenticated.Error())
+}
+
+// chain runs the auth middleware before the given one, as the web server does
+func (s *AuthMiddlewareSuite) chain(middleware customHTTP.Middleware) customHTTP.Middleware {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return s.authMiddleware(middleware(next))
+	}
+}
+
+func (s *AuthMiddlewareSuite) serve(middleware customHTTP.Middleware, path, token string) (monitor.Principal, string, error) {
+	req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+	if token != "" {
+		req.Header.Set(AuthorizationHeader, BearerScheme+" "+token)
+	}
+
+	var principal monitor.Principal
+	var actor string
+	handler := middleware(func(c echo.Context) error {
+		appCtx := GetAppContext(c)
+		principal, _ = appCtx.GetPrincipal()
+		actor = appCtx.GetActor()
+		return c.NoContent(http.StatusOK)
+	})
+
+	err := handler(s.echoRouter.NewContext(req, s.recorder))
+
+	return principal, actor, err
+}
+
+func (s *AuthMiddlewareSuite) signHS256(keyID string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = keyID
+
+	signed, err := token.SignedString([]byte(testSecret))
+	s.Require().NoError(err)
+
+	return signed
+}
+
+func (s *AuthMiddlewareSuite) signRS256(keyID string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+
+	signed, err := token.SignedString(s.rsaKey)
+	s.Require().NoError(err)
+
+	return signed
+}
+
+func validClaims(subject, scope string) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	if subject != "" {
+		claims["sub"] = subject
+	}
+	if scope != "" {
+		claims["scope"] = scope
+	}
+
+	return claims
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	customHTTP "go-service-template/http"
 	"net/http"
@@ -20,7 +21,8 @@ func CreateCorsMiddleware(allowedOrigins []string) customHTTP.Middleware {
 	return echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
 		AllowOrigins:     allowedOrigins,
 		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodHead},
-		AllowHeaders:     []string{"*"},
+		AllowHeaders:     []string{"*", echo.HeaderAuthorization}, // The wildcard does not cover the Authorization header
+		ExposeHeaders:    []string{"ETag", "Idempotent-Replayed"}, // Clients need the ETag to send If-Match on location updates
 		AllowCredentials: true,
 		MaxAge:           CorsMaxAge, // Maximum value not ignored by any of major browsers
 	})
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	customHTTP "go-service-template/http"
+	"net/http"
+)
+
+// CreateProblemErrorHandler reports the errors returned by the router, such as unknown routes or methods, as problem
+// responses like the ones of the endpoints
+func CreateProblemErrorHandler() echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		status := http.StatusInternalServerError
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			status = httpErr.Code
+		}
+
+		if c.Request().Method == http.MethodHead {
+			_ = c.NoContent(status)
+			return
+		}
+
+		problem := customHTTP.NewProblem(c.Request(), status, "", http.StatusText(status), "", GetAppContext(c).GetCorrelationID())
+		_ = customHTTP.WriteProblem(c, problem)
+	}
+}
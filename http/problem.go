@@ -0,0 +1,117 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const (
+	// MIMEApplicationProblemJSON is the content type of the error responses, defined by RFC 7807
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	problemTypePrefix = "urn:problem-type:"
+)
+
+// Codes of the problems that are not caused by a domain error, which carry their own code
+const (
+	CodeInvalidRequest        = "request.invalid"
+	CodeRouteNotFound         = "request.route_not_found"
+	CodeMethodNotAllowed      = "request.method_not_allowed"
+	CodeUnsupportedMediaType  = "request.unsupported_media_type"
+	CodePreconditionRequired  = "request.precondition_required"
+	CodeRequestTooLarge       = "request.too_large"
+	CodeUnauthenticated       = "auth.unauthenticated"
+	CodeForbidden             = "auth.forbidden"
+	CodeDependencyUnavailable = "dependency.unavailable"
+	CodeDependencyTimeout     = "dependency.timeout"
+	CodeInternalError         = "internal.error"
+)
+
+// Problem is an RFC 7807 problem details response. Code is a stable, machine-readable identifier of the problem that
+// clients can rely on, the type is built from it. The title only depends on the failed operation and the detail
+// explains this occurrence.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	Code          string         `json:"code"`
+	CorrelationID string         `json:"correlation_id"`
+	Errors        []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError is one of the errors found in the request. Pointer is the JSON pointer of an invalid body field and
+// Parameter the name of an invalid query param or header.
+type ProblemError struct {
+	Detail    string `json:"detail"`
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// NewProblem builds a problem for the request, the code defaults to the one of the status. The detail of the server
+// errors is replaced by a generic one, their errors can reveal queries and internal addresses and are only logged.
+func NewProblem(request *http.Request, status int, code, title, detail, correlationID string) Problem {
+	if code == "" {
+		code = CodeFromStatus(status)
+	}
+	if status >= http.StatusInternalServerError {
+		detail = serverErrorDetail(status)
+	}
+
+	return Problem{
+		Type:          problemTypePrefix + code,
+		Title:         title,
+		Status:        status,
+		Detail:        detail,
+		Instance:      request.URL.Path,
+		Code:          code,
+		CorrelationID: correlationID,
+	}
+}
+
+// CodeFromStatus returns the code of the problems without a more specific one
+func CodeFromStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeRouteNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusRequestEntityTooLarge:
+		return CodeRequestTooLarge
+	case http.StatusUnsupportedMediaType:
+		return CodeUnsupportedMediaType
+	case http.StatusPreconditionRequired:
+		return CodePreconditionRequired
+	case http.StatusServiceUnavailable:
+		return CodeDependencyUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeDependencyTimeout
+	default:
+		return CodeInternalError
+	}
+}
+
+func serverErrorDetail(status int) string {
+	switch status {
+	case http.StatusServiceUnavailable:
+		return "a dependency of the service is unavailable, the request can be retried later"
+	case http.StatusGatewayTimeout:
+		return "a dependency of the service took too long to respond, the request can be retried later"
+	default:
+		return "the request could not be processed, report the correlation ID if the problem persists"
+	}
+}
+
+// WriteProblem sends the problem with the problem+json content type
+func WriteProblem(c echo.Context, problem Problem) error {
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+
+	return c.JSON(problem.Status, problem)
+}
@@ -12,9 +12,12 @@ func CreateWebServer(
 	appConfig config.AppConfig,
 	webServerConfig config.WebServerConfig,
 	globalMiddleware []Middleware,
+	authorizer Authorizer,
+	errorHandler echo.HTTPErrorHandler,
 	endpoints []Endpoint,
 ) *http.Server {
 	router := echo.New()
+	router.HTTPErrorHandler = errorHandler
 
 	// Decorate router with Prometheus metrics
 	promMetrics := prometheus.NewPrometheus(appConfig.Name, nil)
@@ -23,9 +26,14 @@ func CreateWebServer(
 	// Register global middleware
 	router.Use(globalMiddleware...)
 
-	// Create each endpoint with their custom middlewares
+	// Create each endpoint with their custom middlewares, the required scopes are checked first
 	for _, endpoint := range endpoints {
-		router.Add(endpoint.Method, endpoint.Path, endpoint.Handler, endpoint.Middlewares...)
+		middlewares := endpoint.Middlewares
+		if len(endpoint.RequiredScopes) > 0 {
+			middlewares = append([]Middleware{authorizer(endpoint.RequiredScopes)}, middlewares...)
+		}
+
+		router.Add(endpoint.Method, endpoint.Path, endpoint.Handler, middlewares...)
 	}
 
 	readHeaderTimeout, err := time.ParseDuration(webServerConfig.ReadHeaderTimeout)
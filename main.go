@@ -3,6 +3,14 @@
 // @description Sample service that creates "locations"
 // @tag.name go-service-template
 // @tag.description API endpoints
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT bearer token, sent as 'Bearer <token>'
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key of a machine client
 package main
 
 import (
@@ -11,6 +19,7 @@ import (
 	"go-service-template/config"
 	_ "go-service-template/docs"
 	"go-service-template/eventhandler"
+	customGRPC "go-service-template/grpc"
 	customHTTP "go-service-template/http"
 	"go-service-template/http/controllers"
 	httpMiddleware "go-service-template/http/middleware"
@@ -23,16 +32,17 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
 	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
 	"github.com/ThreeDotsLabs/watermill/message"
 	watermillMiddleware "github.com/ThreeDotsLabs/watermill/message/router/middleware"
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"google.golang.org/grpc"
 )
 
 const ShutdownTimeSec = 30
@@ -49,69 +59,196 @@ func main() {
 
 	// Create support structures
 	customHTTPClient := customHTTP.CreateCustomHTTPClient(appCfg.HTTPClientConfig)
-	structValidator := validator.New()
+	structValidator := controllers.NewValidator()
 	subscriber, err := pubsub.CreateSubscriber(kafka.DefaultSaramaSubscriberConfig(), appCfg.KafkaConfig)
 	if err != nil {
 		panic(err)
 	}
-	publisher, err := pubsub.CreatePublisher(kafka.DefaultSaramaSyncPublisherConfig(), appCfg.KafkaConfig)
+	webhookSubscriber, err := pubsub.CreateSubscriber(kafka.DefaultSaramaSubscriberConfig(), appCfg.KafkaConfig.WithConsumerGroupSuffix("webhooks"))
+	if err != nil {
+		panic(err)
+	}
+	broadcastSubscriber, err := pubsub.CreateBroadcastSubscriber(kafka.DefaultSaramaSubscriberConfig(), appCfg.KafkaConfig)
+	if err != nil {
+		panic(err)
+	}
+	schemaRegistry := pubsub.NewSchemaRegistry(appCfg.KafkaConfig.SchemaRegistry, customHTTPClient)
+	publisher, err := pubsub.CreatePublisher(kafka.DefaultSaramaSyncPublisherConfig(), appCfg.KafkaConfig, schemaRegistry)
 	if err != nil {
 		panic(err)
 	}
 
 	// Create repositories
-	dalFactory := db.NewFactory(appCfg.DBConfig)
+	dalFactory := db.NewFactory(appCfg.DBConfig, appCfg.PaginationConfig)
 	googleMapsAPI := googleMapsRepo.NewGoogleMapsRepository(customHTTPClient)
 
 	// Create services
-	locationService := services.NewLocationService(dalFactory, googleMapsAPI, publisher)
+	referenceDataService := services.NewReferenceDataService(dalFactory, appCfg.ReferenceDataConfig)
+	locationService := services.NewLocationService(dalFactory, googleMapsAPI, referenceDataService, publisher)
+	idempotencyService := services.NewIdempotencyService(dalFactory, appCfg.IdempotencyConfig)
+	apiKeyService := services.NewAPIKeyService(dalFactory, referenceDataService, appCfg.APIKeyConfig)
+	locationChangeStream := services.NewLocationChangeStream(appCfg.LocationStreamConfig)
+	webhookHTTPClient := customHTTP.CreateExternalHTTPClient(
+		appCfg.HTTPClientConfig, appCfg.WebhookConfig.AllowLocalTargets, appCfg.WebhookConfig.MaxResponseBytes,
+	)
+	webhookService := services.NewWebhookService(dalFactory, referenceDataService, webhookHTTPClient, appCfg.WebhookConfig)
+	deadLetterService := services.NewDeadLetterService(dalFactory)
+	outboxService := services.NewOutboxService(dalFactory)
+	healthRegistry := services.NewHealthRegistry(appCfg.HealthConfig)
+	healthRegistry.RegisterReadinessCheck(services.NewDBHealthCheck(dalFactory))
+	healthRegistry.RegisterReadinessCheck(pubsub.NewBrokersHealthCheck(appCfg.KafkaConfig))
+
+	// Create outbox relay
+	outboxRelay := pubsub.NewOutboxRelay(dalFactory, publisher, appCfg.OutboxConfig)
+
+	// Create HTTP middlewares applied to specific endpoints
+	idempotencyMiddleware := controllers.NewIdempotencyMiddleware(idempotencyService)
 
 	// Create HTTP controllers
-	healthDBController := controllers.NewHealthController()
+	healthDBController := controllers.NewHealthController(healthRegistry)
 	swaggerController := controllers.NewSwaggerController()
 	locationsController := controllers.NewLocationController(locationService, structValidator)
+	locationStreamController := controllers.NewLocationStreamController(locationChangeStream, appCfg.LocationStreamConfig)
+	subLocationsController := controllers.NewSubLocationController(locationService, structValidator)
+	referenceDataController := controllers.NewReferenceDataController(referenceDataService, structValidator)
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService, structValidator)
+	webhookController := controllers.NewWebhookController(webhookService, structValidator)
+	deadLetterController := controllers.NewDeadLetterController(deadLetterService)
+	outboxController := controllers.NewOutboxController(outboxService)
 
 	// Create event handlers
 	newLocationHandler := eventhandler.CreateNewLocationHandler()
 	updatedLocationHandler := eventhandler.CreateUpdatedLocationHandler()
+	locationChangeHandlers := eventhandler.CreateLocationChangeHandlers(locationChangeStream, broadcastSubscriber)
+	webhookHandlers := eventhandler.CreateWebhookHandlers(webhookService, webhookSubscriber)
+	eventHandlers := slices.Concat([]pubsub.EventHandler{newLocationHandler, updatedLocationHandler}, locationChangeHandlers, webhookHandlers)
+	deadLetterHandlers := eventhandler.CreateDeadLetterHandlers(deadLetterService, pubsub.GetPoisonTopics(eventHandlers, appCfg.KafkaConfig))
 
 	webServer := customHTTP.CreateWebServer(
 		appCfg.AppConfig,
 		appCfg.WebServerConfig,
 		[]customHTTP.Middleware{ // Middlewares are run in the slice order
 			otelecho.Middleware(appCfg.AppConfig.Name, otelecho.WithSkipper(func(c echo.Context) bool {
-				ignoredPaths := []string{"/health", "/metrics"}
+				ignoredPaths := []string{"/health", "/health/live", "/health/ready", "/metrics"}
 				return utils.ListContains(ignoredPaths, c.Path())
 			})),
 			echoMiddleware.Logger(),
 			echoMiddleware.Recover(),
 			httpMiddleware.CreateCorsMiddleware(config.GetCorsOriginAddressByEnv(env)),
 			httpMiddleware.CreateAppContextMiddleware(),
+			httpMiddleware.CreateAPIKeyMiddleware(apiKeyService),
+			httpMiddleware.CreateAuthMiddleware(appCfg.AuthConfig, func(c echo.Context) bool {
+				// Requests sent with an API key were already authenticated
+				publicPaths := []string{"/health", "/health/live", "/health/ready", "/metrics", "/v1/swagger/*"}
+				return utils.ListContains(publicPaths, c.Path()) || httpMiddleware.HasAPIKey(c)
+			}),
 		},
+		httpMiddleware.CreateScopeAuthorizer(),
+		httpMiddleware.CreateProblemErrorHandler(),
 		[]customHTTP.Endpoint{
 			swaggerController.SwaggerEndpoint(),
 			healthDBController.HealthEndpoint(),
-			locationsController.CreateLocationEndpoint(),
+			healthDBController.LivenessEndpoint(),
+			healthDBController.ReadinessEndpoint(),
+			locationsController.CreateLocationEndpoint().With(idempotencyMiddleware),
+			locationsController.ImportLocationsEndpoint(),
 			locationsController.UpdateLocationEndpoint(),
+			locationsController.PatchLocationEndpoint(),
 			locationsController.PaginatedLocationsEndpoint(),
+			locationsController.NearbyLocationsEndpoint(),
+			locationsController.ExportLocationsEndpoint(),
+			locationStreamController.LocationChangesEndpoint(),
 			locationsController.LocationDetailsEndpoint(),
+			locationsController.DeleteLocationEndpoint(),
+			locationsController.RestoreLocationEndpoint(),
+			locationsController.LocationHistoryEndpoint(),
 			locationsController.CreateLocationMockEndpoint(),
+			subLocationsController.PaginatedSubLocationsEndpoint(),
+			subLocationsController.CreateSubLocationEndpoint(),
+			subLocationsController.SubLocationDetailsEndpoint(),
+			subLocationsController.RenameSubLocationEndpoint(),
+			subLocationsController.DeactivateSubLocationEndpoint(),
+			referenceDataController.SuppliersEndpoint(),
+			referenceDataController.CreateSupplierEndpoint(),
+			referenceDataController.SupplierDetailsEndpoint(),
+			referenceDataController.UpdateSupplierEndpoint(),
+			referenceDataController.DeleteSupplierEndpoint(),
+			referenceDataController.LocationTypesEndpoint(),
+			referenceDataController.CreateLocationTypeEndpoint(),
+			referenceDataController.LocationTypeDetailsEndpoint(),
+			referenceDataController.UpdateLocationTypeEndpoint(),
+			referenceDataController.DeleteLocationTypeEndpoint(),
+			referenceDataController.SubLocationTypesEndpoint(),
+			referenceDataController.CreateSubLocationTypeEndpoint(),
+			referenceDataController.SubLocationTypeDetailsEndpoint(),
+			referenceDataController.UpdateSubLocationTypeEndpoint(),
+			referenceDataController.DeleteSubLocationTypeEndpoint(),
+			apiKeyController.APIKeysEndpoint(),
+			apiKeyController.CreateAPIKeyEndpoint(),
+			apiKeyController.RotateAPIKeyEndpoint(),
+			apiKeyController.RevokeAPIKeyEndpoint(),
+			webhookController.WebhooksEndpoint(),
+			webhookController.CreateWebhookEndpoint(),
+			webhookController.WebhookDetailsEndpoint(),
+			webhookController.UpdateWebhookEndpoint(),
+			webhookController.DeleteWebhookEndpoint(),
+			webhookController.WebhookDeliveriesEndpoint(),
+			webhookController.RedeliverWebhookEndpoint(),
+			deadLetterController.DeadLettersEndpoint(),
+			deadLetterController.DeadLetterDetailsEndpoint(),
+			deadLetterController.RedriveDeadLetterEndpoint(),
+			outboxController.ParkedMessagesEndpoint(),
+			outboxController.RedriveParkedMessageEndpoint(),
+			outboxController.DiscardParkedMessageEndpoint(),
+		},
+	)
+
+	// Location change streams never end by themselves, they are closed when the web server starts shutting down
+	webServer.RegisterOnShutdown(locationChangeStream.Close)
+
+	grpcServer := customGRPC.CreateGRPCServer(
+		customGRPC.NewAuthenticator(httpMiddleware.NewTokenAuthenticator(appCfg.AuthConfig), apiKeyService),
+		[]customGRPC.Service{
+			customGRPC.NewLocationServer(locationService, structValidator).Service(),
 		},
 	)
 
 	eventRouter, err := pubsub.CreateRouter(
 		[]message.HandlerMiddleware{watermillMiddleware.Recoverer},
-		[]pubsub.EventHandler{newLocationHandler, updatedLocationHandler},
+		eventHandlers,
+		deadLetterHandlers,
 		subscriber,
+		publisher,
+		appCfg.KafkaConfig,
 	)
 	if err != nil {
 		panic(err)
 	}
+	healthRegistry.RegisterReadinessCheck(pubsub.NewRouterHealthCheck(eventRouter))
 
 	serverCtx, serverCtxCancelFn := context.WithCancel(context.Background())
 
 	// Prepare graceful shutdown handler
-	go handleGracefulShutdown(serverCtx, serverCtxCancelFn, webServer, eventRouter)
+	go handleGracefulShutdown(serverCtx, serverCtxCancelFn, healthRegistry, webServer, grpcServer, eventRouter)
+
+	// Start outbox relay in new goroutine, it stops when the server context is cancelled
+	go outboxRelay.Run(serverCtx)
+
+	// Start expired idempotency keys cleanup in new goroutine, it stops when the server context is cancelled
+	go idempotencyService.RunCleanup(serverCtx)
+
+	// Start API keys last used flush in new goroutine, it stops when the server context is cancelled
+	go apiKeyService.RunLastUsedFlush(serverCtx)
+
+	// Start webhook delivery retries in new goroutine, it stops when the server context is cancelled
+	go webhookService.RunDeliveryRetries(serverCtx)
+
+	// Start gRPC server in new goroutine, it stops with the graceful shutdown
+	go func() {
+		if grpcErr := customGRPC.ListenAndServe(grpcServer, appCfg.WebServerConfig.GRPCAddress); grpcErr != nil {
+			panic(grpcErr)
+		}
+	}()
 
 	// Start event handler in new goroutine
 	go func() {
@@ -131,7 +268,9 @@ func main() {
 func handleGracefulShutdown(
 	serverCtx context.Context,
 	serverCancelFn context.CancelFunc,
+	healthRegistry *services.HealthRegistry,
 	server *http.Server,
+	grpcServer *grpc.Server,
 	router *message.Router,
 ) {
 	fnName := "handleGracefulShutdown"
@@ -147,6 +286,9 @@ func handleGracefulShutdown(
 	<-c
 	shutdownLog.Warn(fnName, "", "Shutting down application")
 
+	// Stop receiving new traffic while the running requests and messages are drained
+	healthRegistry.MarkShuttingDown()
+
 	// Shutdown signal with grace period of 30 seconds
 	shutdownCtx, shutdownCancelFn := context.WithTimeout(serverCtx, ShutdownTimeSec*time.Second)
 	defer shutdownCancelFn()
@@ -160,6 +302,9 @@ func handleGracefulShutdown(
 		shutdownLog.Error(fnName, "", "failed to shutdown web server", err)
 	}
 
+	// Close gRPC server, waiting for the running calls
+	customGRPC.Shutdown(shutdownCtx, grpcServer)
+
 	// Close event router
 	if err := router.Close(); err != nil {
 		shutdownLog.Error(fnName, "", "failed to shutdown event router", err)
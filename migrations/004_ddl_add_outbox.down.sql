@@ -0,0 +1 @@
+DROP TABLE IF EXISTS location.outbox;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- outbox
+CREATE TABLE IF NOT EXISTS location.outbox (
+    id                      UUID            PRIMARY KEY,
+    sequence                BIGSERIAL       NOT NULL,
+    aggregate_id            VARCHAR         NOT NULL,
+    topic                   VARCHAR         NOT NULL,
+    payload                 BYTEA           NOT NULL,
+    metadata                JSONB           NOT NULL DEFAULT '{}'::jsonb,
+    created_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at            timestamptz     DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS outbox_sequence ON location.outbox USING btree (sequence);
+CREATE INDEX IF NOT EXISTS outbox_pending ON location.outbox USING btree (sequence) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_published_at ON location.outbox USING btree (published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS location.sub_locations_location_id_name;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS sub_locations_location_id_name ON location.sub_locations USING btree (location_id, name);
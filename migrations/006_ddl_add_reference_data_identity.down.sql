@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS location.sub_location_types_type;
+DROP INDEX IF EXISTS location.location_types_type;
+DROP INDEX IF EXISTS location.suppliers_name;
+
+ALTER TABLE location.sub_location_types ALTER COLUMN id DROP IDENTITY IF EXISTS;
+ALTER TABLE location.location_types ALTER COLUMN id DROP IDENTITY IF EXISTS;
+ALTER TABLE location.suppliers ALTER COLUMN id DROP IDENTITY IF EXISTS;
@@ -0,0 +1,13 @@
+-- reference data ids are generated by the database from now on
+ALTER TABLE location.suppliers ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY;
+ALTER TABLE location.location_types ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY;
+ALTER TABLE location.sub_location_types ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY;
+
+-- move the sequences past the static data
+SELECT setval(pg_get_serial_sequence('location.suppliers', 'id'), (SELECT COALESCE(MAX(id), 1) FROM location.suppliers));
+SELECT setval(pg_get_serial_sequence('location.location_types', 'id'), (SELECT COALESCE(MAX(id), 1) FROM location.location_types));
+SELECT setval(pg_get_serial_sequence('location.sub_location_types', 'id'), (SELECT COALESCE(MAX(id), 1) FROM location.sub_location_types));
+
+CREATE UNIQUE INDEX IF NOT EXISTS suppliers_name ON location.suppliers USING btree (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS location_types_type ON location.location_types USING btree (LOWER(type));
+CREATE UNIQUE INDEX IF NOT EXISTS sub_location_types_type ON location.sub_location_types USING btree (LOWER(type));
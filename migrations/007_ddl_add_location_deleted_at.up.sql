@@ -0,0 +1 @@
+ALTER TABLE location.locations ADD COLUMN IF NOT EXISTS deleted_at timestamptz DEFAULT NULL;
@@ -0,0 +1 @@
+ALTER TABLE location.locations DROP COLUMN IF EXISTS version;
@@ -0,0 +1,14 @@
+-- location_history
+CREATE TABLE IF NOT EXISTS location.location_history (
+    id                      BIGSERIAL       PRIMARY KEY,
+    location_id             UUID            NOT NULL REFERENCES location.locations (id),
+    operation               VARCHAR         NOT NULL,
+    before                  JSONB           NULL,
+    after                   JSONB           NULL,
+    changed_fields          TEXT[]          NOT NULL DEFAULT '{}',
+    correlation_id          VARCHAR         NULL,
+    actor                   VARCHAR         NULL,
+    changed_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS location_history_location_id ON location.location_history USING btree (location_id, id);
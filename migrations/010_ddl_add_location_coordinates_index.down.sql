@@ -0,0 +1 @@
+DROP INDEX IF EXISTS location.location_information_coordinates;
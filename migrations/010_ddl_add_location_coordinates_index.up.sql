@@ -0,0 +1,2 @@
+-- Supports the bounding box prefilter of the nearby locations search
+CREATE INDEX IF NOT EXISTS location_information_coordinates ON location.location_information USING btree (latitude, longitude);
@@ -0,0 +1,15 @@
+-- idempotency_keys
+CREATE TABLE IF NOT EXISTS location.idempotency_keys (
+    scope                   VARCHAR         NOT NULL,
+    key                     VARCHAR         NOT NULL,
+    request_hash            VARCHAR         NOT NULL,
+    status_code             INT             NULL,
+    response_headers        JSONB           NULL,
+    response_body           BYTEA           NULL,
+    created_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at            timestamptz     NULL,
+    expires_at              timestamptz     NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at ON location.idempotency_keys USING btree (expires_at);
@@ -0,0 +1,15 @@
+-- api_keys
+CREATE TABLE IF NOT EXISTS location.api_keys (
+    id                      UUID            PRIMARY KEY,
+    name                    VARCHAR         NOT NULL,
+    prefix                  VARCHAR         NOT NULL,
+    key_hash                VARCHAR         NOT NULL,
+    scopes                  VARCHAR[]       NOT NULL,
+    supplier_id             INTEGER         NULL REFERENCES location.suppliers (id),
+    created_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotated_at              timestamptz     NULL,
+    last_used_at            timestamptz     NULL,
+    revoked_at              timestamptz     NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_keys_prefix ON location.api_keys USING btree (prefix);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS location.webhook_deliveries;
+DROP TABLE IF EXISTS location.webhook_subscriptions;
@@ -0,0 +1,31 @@
+-- webhook_subscriptions
+CREATE TABLE IF NOT EXISTS location.webhook_subscriptions (
+    id                      UUID            PRIMARY KEY,
+    url                     VARCHAR         NOT NULL,
+    event_types             VARCHAR[]       NOT NULL,
+    secret                  VARCHAR         NOT NULL,
+    supplier_id             INTEGER         NULL REFERENCES location.suppliers (id),
+    status                  VARCHAR         NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused')),
+    created_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- webhook_deliveries, the delivery log of the events that could not be delivered
+CREATE TABLE IF NOT EXISTS location.webhook_deliveries (
+    id                      UUID            PRIMARY KEY,
+    subscription_id         UUID            NOT NULL REFERENCES location.webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id                VARCHAR         NOT NULL,
+    event_type              VARCHAR         NOT NULL,
+    payload                 JSONB           NOT NULL,
+    status                  VARCHAR         NOT NULL CHECK (status IN ('failed', 'succeeded')),
+    attempts                INTEGER         NOT NULL,
+    response_status         INTEGER         NULL,
+    last_error              VARCHAR         NULL,
+    created_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_attempt_at         timestamptz     NOT NULL,
+    delivered_at            timestamptz     NULL
+);
+
+-- An event redelivered by the broker updates its entry instead of adding another one
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_subscription_id_event_id ON location.webhook_deliveries USING btree (subscription_id, event_id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_id_created_at ON location.webhook_deliveries USING btree (subscription_id, created_at DESC);
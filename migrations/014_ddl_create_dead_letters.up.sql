@@ -0,0 +1,18 @@
+-- dead_letters, the messages the event handlers could not process after every retry
+CREATE TABLE IF NOT EXISTS location.dead_letters (
+    id                      UUID            PRIMARY KEY,
+    message_id              VARCHAR         NOT NULL,
+    handler                 VARCHAR         NOT NULL,
+    original_topic          VARCHAR         NOT NULL,
+    poison_topic            VARCHAR         NOT NULL,
+    error                   VARCHAR         NOT NULL,
+    attempts                INTEGER         NOT NULL,
+    correlation_id          VARCHAR         NOT NULL DEFAULT '',
+    payload                 BYTEA           NOT NULL,
+    metadata                JSONB           NOT NULL DEFAULT '{}',
+    failed_at               timestamptz     NOT NULL,
+    redriven_at             timestamptz     NULL,
+    created_at              timestamptz     NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS dead_letters_failed_at ON location.dead_letters USING btree (failed_at DESC);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS location.outbox_failed_at;
+DROP INDEX IF EXISTS location.outbox_pending;
+CREATE INDEX IF NOT EXISTS outbox_pending ON location.outbox USING btree (sequence) WHERE published_at IS NULL;
+
+ALTER TABLE location.outbox DROP COLUMN IF EXISTS failed_at;
+ALTER TABLE location.outbox DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE location.outbox DROP COLUMN IF EXISTS last_error;
+ALTER TABLE location.outbox DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,9 @@
+-- Failed publishes are retried with backoff, a message that keeps failing is parked so it stops blocking the relay
+ALTER TABLE location.outbox ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE location.outbox ADD COLUMN IF NOT EXISTS last_error VARCHAR NULL;
+ALTER TABLE location.outbox ADD COLUMN IF NOT EXISTS next_attempt_at timestamptz NULL;
+ALTER TABLE location.outbox ADD COLUMN IF NOT EXISTS failed_at timestamptz NULL;
+
+DROP INDEX IF EXISTS location.outbox_pending;
+CREATE INDEX IF NOT EXISTS outbox_pending ON location.outbox USING btree (sequence) WHERE published_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_failed_at ON location.outbox USING btree (failed_at) WHERE failed_at IS NOT NULL;
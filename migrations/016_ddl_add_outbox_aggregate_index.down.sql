@@ -0,0 +1 @@
+DROP INDEX IF EXISTS location.outbox_pending_aggregate;
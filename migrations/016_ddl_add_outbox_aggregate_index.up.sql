@@ -0,0 +1,2 @@
+-- The relay reads the oldest unpublished message of each aggregate
+CREATE INDEX IF NOT EXISTS outbox_pending_aggregate ON location.outbox USING btree (aggregate_id, sequence) WHERE published_at IS NULL;
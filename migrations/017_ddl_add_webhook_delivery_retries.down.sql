@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS location.webhook_deliveries_next_attempt_at;
+
+UPDATE location.webhook_deliveries SET status = 'failed' WHERE status = 'retrying';
+ALTER TABLE location.webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_status_check;
+ALTER TABLE location.webhook_deliveries ADD CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('failed', 'succeeded'));
+
+ALTER TABLE location.webhook_deliveries DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,7 @@
+-- Failed deliveries are retried in the background, next_attempt_at is when the next attempt is due
+ALTER TABLE location.webhook_deliveries ADD COLUMN IF NOT EXISTS next_attempt_at timestamptz NULL;
+
+ALTER TABLE location.webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_status_check;
+ALTER TABLE location.webhook_deliveries ADD CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('retrying', 'failed', 'succeeded'));
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_next_attempt_at ON location.webhook_deliveries USING btree (next_attempt_at) WHERE status = 'retrying';
@@ -12,6 +12,75 @@ type DatabaseFactory struct {
 	mock.Mock
 }
 
+// GetAPIKeyDB provides a mock function with given fields:
+func (_m *DatabaseFactory) GetAPIKeyDB() (repositories.APIKeyDB, error) {
+	ret := _m.Called()
+
+	var r0 repositories.APIKeyDB
+	if rf, ok := ret.Get(0).(func() repositories.APIKeyDB); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.APIKeyDB)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetterDB provides a mock function with given fields:
+func (_m *DatabaseFactory) GetDeadLetterDB() (repositories.DeadLetterDB, error) {
+	ret := _m.Called()
+
+	var r0 repositories.DeadLetterDB
+	if rf, ok := ret.Get(0).(func() repositories.DeadLetterDB); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.DeadLetterDB)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdempotencyDB provides a mock function with given fields:
+func (_m *DatabaseFactory) GetIdempotencyDB() (repositories.IdempotencyDB, error) {
+	ret := _m.Called()
+
+	var r0 repositories.IdempotencyDB
+	if rf, ok := ret.Get(0).(func() repositories.IdempotencyDB); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.IdempotencyDB)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLocationsDB provides a mock function with given fields:
 func (_m *DatabaseFactory) GetLocationsDB() (repositories.LocationsDB, error) {
 	ret := _m.Called()
@@ -35,6 +104,52 @@ func (_m *DatabaseFactory) GetLocationsDB() (repositories.LocationsDB, error) {
 	return r0, r1
 }
 
+// GetReferenceDataDB provides a mock function with given fields:
+func (_m *DatabaseFactory) GetReferenceDataDB() (repositories.ReferenceDataDB, error) {
+	ret := _m.Called()
+
+	var r0 repositories.ReferenceDataDB
+	if rf, ok := ret.Get(0).(func() repositories.ReferenceDataDB); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.ReferenceDataDB)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDB provides a mock function with given fields:
+func (_m *DatabaseFactory) GetWebhookDB() (repositories.WebhookDB, error) {
+	ret := _m.Called()
+
+	var r0 repositories.WebhookDB
+	if rf, ok := ret.Get(0).(func() repositories.WebhookDB); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repositories.WebhookDB)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewDatabaseFactory interface {
 	mock.TestingT
 	Cleanup(func())
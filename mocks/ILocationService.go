@@ -54,25 +54,151 @@ func (_m *ILocationService) CreateLocationMock(ctx monitor.ApplicationContext) e
 	return r0
 }
 
-// GetLocationByID provides a mock function with given fields: ctx, id
-func (_m *ILocationService) GetLocationByID(ctx monitor.ApplicationContext, id string) (*domain.Location, error) {
+// CreateSubLocation provides a mock function with given fields: ctx, locationID, newSubLocationData
+func (_m *ILocationService) CreateSubLocation(ctx monitor.ApplicationContext, locationID string, newSubLocationData dto.CreateSubLocationRequest) (domain.SubLocation, error) {
+	ret := _m.Called(ctx, locationID, newSubLocationData)
+
+	var r0 domain.SubLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, dto.CreateSubLocationRequest) (domain.SubLocation, error)); ok {
+		return rf(ctx, locationID, newSubLocationData)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, dto.CreateSubLocationRequest) domain.SubLocation); ok {
+		r0 = rf(ctx, locationID, newSubLocationData)
+	} else {
+		r0 = ret.Get(0).(domain.SubLocation)
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, dto.CreateSubLocationRequest) error); ok {
+		r1 = rf(ctx, locationID, newSubLocationData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateSubLocation provides a mock function with given fields: ctx, locationID, subLocationID
+func (_m *ILocationService) DeactivateSubLocation(ctx monitor.ApplicationContext, locationID string, subLocationID string) (domain.SubLocation, error) {
+	ret := _m.Called(ctx, locationID, subLocationID)
+
+	var r0 domain.SubLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string) (domain.SubLocation, error)); ok {
+		return rf(ctx, locationID, subLocationID)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string) domain.SubLocation); ok {
+		r0 = rf(ctx, locationID, subLocationID)
+	} else {
+		r0 = ret.Get(0).(domain.SubLocation)
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, string) error); ok {
+		r1 = rf(ctx, locationID, subLocationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLocation provides a mock function with given fields: ctx, id
+func (_m *ILocationService) DeleteLocation(ctx monitor.ApplicationContext, id string) error {
 	ret := _m.Called(ctx, id)
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportLocations provides a mock function with given fields: ctx, filters, fn
+func (_m *ILocationService) ExportLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters, fn func(domain.Location) error) error {
+	ret := _m.Called(ctx, filters, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.LocationsFilters, func(domain.Location) error) error); ok {
+		r0 = rf(ctx, filters, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLocationByID provides a mock function with given fields: ctx, id, includeDeleted
+func (_m *ILocationService) GetLocationByID(ctx monitor.ApplicationContext, id string, includeDeleted bool) (*domain.Location, error) {
+	ret := _m.Called(ctx, id, includeDeleted)
+
 	var r0 *domain.Location
 	var r1 error
-	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) (*domain.Location, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, bool) (*domain.Location, error)); ok {
+		return rf(ctx, id, includeDeleted)
 	}
-	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) *domain.Location); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, bool) *domain.Location); ok {
+		r0 = rf(ctx, id, includeDeleted)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Location)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, bool) error); ok {
+		r1 = rf(ctx, id, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLocationHistory provides a mock function with given fields: ctx, filters
+func (_m *ILocationService) GetLocationHistory(ctx monitor.ApplicationContext, filters domain.LocationHistoryFilters) (domain.CursorPage[domain.LocationHistoryEntry], error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 domain.CursorPage[domain.LocationHistoryEntry]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.LocationHistoryFilters) (domain.CursorPage[domain.LocationHistoryEntry], error)); ok {
+		return rf(ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.LocationHistoryFilters) domain.CursorPage[domain.LocationHistoryEntry]); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(domain.CursorPage[domain.LocationHistoryEntry])
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, domain.LocationHistoryFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNearbyLocations provides a mock function with given fields: ctx, filters
+func (_m *ILocationService) GetNearbyLocations(ctx monitor.ApplicationContext, filters domain.NearbyLocationsFilters) ([]domain.NearbyLocation, error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 []domain.NearbyLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.NearbyLocationsFilters) ([]domain.NearbyLocation, error)); ok {
+		return rf(ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.NearbyLocationsFilters) []domain.NearbyLocation); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NearbyLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, domain.NearbyLocationsFilters) error); ok {
+		r1 = rf(ctx, filters)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,23 +230,169 @@ func (_m *ILocationService) GetPaginatedLocations(ctx monitor.ApplicationContext
 	return r0, r1
 }
 
-// UpdateLocation provides a mock function with given fields: ctx, updatedLocationData
-func (_m *ILocationService) UpdateLocation(ctx monitor.ApplicationContext, updatedLocationData dto.UpdateLocationRequest) (domain.Location, error) {
-	ret := _m.Called(ctx, updatedLocationData)
+// GetPaginatedSubLocations provides a mock function with given fields: ctx, filters
+func (_m *ILocationService) GetPaginatedSubLocations(ctx monitor.ApplicationContext, filters domain.SubLocationsFilters) (domain.CursorPage[domain.SubLocation], error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 domain.CursorPage[domain.SubLocation]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.SubLocationsFilters) (domain.CursorPage[domain.SubLocation], error)); ok {
+		return rf(ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.SubLocationsFilters) domain.CursorPage[domain.SubLocation]); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(domain.CursorPage[domain.SubLocation])
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, domain.SubLocationsFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubLocationByID provides a mock function with given fields: ctx, locationID, subLocationID
+func (_m *ILocationService) GetSubLocationByID(ctx monitor.ApplicationContext, locationID string, subLocationID string) (*domain.SubLocation, error) {
+	ret := _m.Called(ctx, locationID, subLocationID)
+
+	var r0 *domain.SubLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string) (*domain.SubLocation, error)); ok {
+		return rf(ctx, locationID, subLocationID)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string) *domain.SubLocation); ok {
+		r0 = rf(ctx, locationID, subLocationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SubLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, string) error); ok {
+		r1 = rf(ctx, locationID, subLocationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportLocations provides a mock function with given fields: ctx, rows, dryRun
+func (_m *ILocationService) ImportLocations(ctx monitor.ApplicationContext, rows []dto.ImportLocationRow, dryRun bool) (domain.LocationImportReport, error) {
+	ret := _m.Called(ctx, rows, dryRun)
+
+	var r0 domain.LocationImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, []dto.ImportLocationRow, bool) (domain.LocationImportReport, error)); ok {
+		return rf(ctx, rows, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, []dto.ImportLocationRow, bool) domain.LocationImportReport); ok {
+		r0 = rf(ctx, rows, dryRun)
+	} else {
+		r0 = ret.Get(0).(domain.LocationImportReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, []dto.ImportLocationRow, bool) error); ok {
+		r1 = rf(ctx, rows, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchLocation provides a mock function with given fields: ctx, id, patch, expectedVersion
+func (_m *ILocationService) PatchLocation(ctx monitor.ApplicationContext, id string, patch dto.PatchLocationRequest, expectedVersion int) (domain.Location, error) {
+	ret := _m.Called(ctx, id, patch, expectedVersion)
+
+	var r0 domain.Location
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, dto.PatchLocationRequest, int) (domain.Location, error)); ok {
+		return rf(ctx, id, patch, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, dto.PatchLocationRequest, int) domain.Location); ok {
+		r0 = rf(ctx, id, patch, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(domain.Location)
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, dto.PatchLocationRequest, int) error); ok {
+		r1 = rf(ctx, id, patch, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenameSubLocation provides a mock function with given fields: ctx, locationID, subLocationID, renameData
+func (_m *ILocationService) RenameSubLocation(ctx monitor.ApplicationContext, locationID string, subLocationID string, renameData dto.RenameSubLocationRequest) (domain.SubLocation, error) {
+	ret := _m.Called(ctx, locationID, subLocationID, renameData)
+
+	var r0 domain.SubLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string, dto.RenameSubLocationRequest) (domain.SubLocation, error)); ok {
+		return rf(ctx, locationID, subLocationID, renameData)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string, dto.RenameSubLocationRequest) domain.SubLocation); ok {
+		r0 = rf(ctx, locationID, subLocationID, renameData)
+	} else {
+		r0 = ret.Get(0).(domain.SubLocation)
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, string, dto.RenameSubLocationRequest) error); ok {
+		r1 = rf(ctx, locationID, subLocationID, renameData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreLocation provides a mock function with given fields: ctx, id
+func (_m *ILocationService) RestoreLocation(ctx monitor.ApplicationContext, id string) (domain.Location, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 domain.Location
+	var r1 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) (domain.Location, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) domain.Location); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Location)
+	}
+
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLocation provides a mock function with given fields: ctx, updatedLocationData, expectedVersion
+func (_m *ILocationService) UpdateLocation(ctx monitor.ApplicationContext, updatedLocationData dto.UpdateLocationRequest, expectedVersion int) (domain.Location, error) {
+	ret := _m.Called(ctx, updatedLocationData, expectedVersion)
 
 	var r0 domain.Location
 	var r1 error
-	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, dto.UpdateLocationRequest) (domain.Location, error)); ok {
-		return rf(ctx, updatedLocationData)
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, dto.UpdateLocationRequest, int) (domain.Location, error)); ok {
+		return rf(ctx, updatedLocationData, expectedVersion)
 	}
-	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, dto.UpdateLocationRequest) domain.Location); ok {
-		r0 = rf(ctx, updatedLocationData)
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, dto.UpdateLocationRequest, int) domain.Location); ok {
+		r0 = rf(ctx, updatedLocationData, expectedVersion)
 	} else {
 		r0 = ret.Get(0).(domain.Location)
 	}
 
-	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, dto.UpdateLocationRequest) error); ok {
-		r1 = rf(ctx, updatedLocationData)
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, dto.UpdateLocationRequest, int) error); ok {
+		r1 = rf(ctx, updatedLocationData, expectedVersion)
 	} else {
 		r1 = ret.Error(1)
 	}
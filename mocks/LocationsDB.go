@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	"fmt"
 	domain "go-service-template/domain"
 	"go-service-template/monitor"
@@ -10,6 +11,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	sql "database/sql"
+
+	time "time"
 )
 
 // LocationsDB is an autogenerated mock type for the LocationsDB type
@@ -17,6 +20,27 @@ type LocationsDB struct {
 	mock.Mock
 }
 
+// AcquireOutboxLock provides a mock function with given fields: ctx
+func (_m *LocationsDB) AcquireOutboxLock(ctx monitor.ApplicationContext) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckLocationNameExistence provides a mock function with given fields: ctx, name
 func (_m *LocationsDB) CheckLocationNameExistence(ctx monitor.ApplicationContext, name string) (bool, error) {
 	ret := _m.Called(ctx, name)
@@ -38,6 +62,41 @@ func (_m *LocationsDB) CheckLocationNameExistence(ctx monitor.ApplicationContext
 	return r0, r1
 }
 
+// CheckSubLocationNameExistence provides a mock function with given fields: ctx, locationID, name
+func (_m *LocationsDB) CheckSubLocationNameExistence(ctx monitor.ApplicationContext, locationID string, name string) (bool, error) {
+	ret := _m.Called(ctx, locationID, name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string) bool); ok {
+		r0 = rf(ctx, locationID, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, string) error); ok {
+		r1 = rf(ctx, locationID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimOutboxMessages provides a mock function with given fields: ctx, ids, claimedUntil
+func (_m *LocationsDB) ClaimOutboxMessages(ctx monitor.ApplicationContext, ids []string, claimedUntil time.Time) error {
+	ret := _m.Called(ctx, ids, claimedUntil)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, []string, time.Time) error); ok {
+		r0 = rf(ctx, ids, claimedUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CommitTx provides a mock function with given fields:
 func (_m *LocationsDB) CommitTx() error {
 	ret := _m.Called()
@@ -66,6 +125,20 @@ func (_m *LocationsDB) CreateLocation(ctx monitor.ApplicationContext, location d
 	return r0
 }
 
+// CreateOutboxMessage provides a mock function with given fields: ctx, msg
+func (_m *LocationsDB) CreateOutboxMessage(ctx monitor.ApplicationContext, msg domain.OutboxMessage) error {
+	ret := _m.Called(ctx, msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.OutboxMessage) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateSubLocation provides a mock function with given fields: ctx, subLocation
 func (_m *LocationsDB) CreateSubLocation(ctx monitor.ApplicationContext, subLocation domain.SubLocation) error {
 	ret := _m.Called(ctx, subLocation)
@@ -80,6 +153,48 @@ func (_m *LocationsDB) CreateSubLocation(ctx monitor.ApplicationContext, subLoca
 	return r0
 }
 
+// DeleteParkedOutboxMessage provides a mock function with given fields: ctx, id
+func (_m *LocationsDB) DeleteParkedOutboxMessage(ctx monitor.ApplicationContext, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePublishedOutboxMessages provides a mock function with given fields: ctx, publishedBefore
+func (_m *LocationsDB) DeletePublishedOutboxMessages(ctx monitor.ApplicationContext, publishedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, publishedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, time.Time) int64); ok {
+		r0 = rf(ctx, publishedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, time.Time) error); ok {
+		r1 = rf(ctx, publishedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Exec provides a mock function with given fields: ctx, stmt, fields
 func (_m *LocationsDB) Exec(ctx monitor.ApplicationContext, stmt string, fields ...interface{}) (sql.Result, error) {
 	var _ca []interface{}
@@ -129,6 +244,50 @@ func (_m *LocationsDB) GetLocationByID(ctx monitor.ApplicationContext, id string
 	return r0, r1
 }
 
+// GetLocationHistory provides a mock function with given fields: ctx, filters
+func (_m *LocationsDB) GetLocationHistory(ctx monitor.ApplicationContext, filters domain.LocationHistoryFilters) (domain.CursorPage[domain.LocationHistoryEntry], error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 domain.CursorPage[domain.LocationHistoryEntry]
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.LocationHistoryFilters) domain.CursorPage[domain.LocationHistoryEntry]); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(domain.CursorPage[domain.LocationHistoryEntry])
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, domain.LocationHistoryFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNearbyLocations provides a mock function with given fields: ctx, filters
+func (_m *LocationsDB) GetNearbyLocations(ctx monitor.ApplicationContext, filters domain.NearbyLocationsFilters) ([]domain.NearbyLocation, error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 []domain.NearbyLocation
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.NearbyLocationsFilters) []domain.NearbyLocation); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NearbyLocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, domain.NearbyLocationsFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPaginatedLocations provides a mock function with given fields: ctx, filters
 func (_m *LocationsDB) GetPaginatedLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters) (domain.CursorPage[domain.Location], error) {
 	ret := _m.Called(ctx, filters)
@@ -150,6 +309,110 @@ func (_m *LocationsDB) GetPaginatedLocations(ctx monitor.ApplicationContext, fil
 	return r0, r1
 }
 
+// GetPaginatedSubLocations provides a mock function with given fields: ctx, filters
+func (_m *LocationsDB) GetPaginatedSubLocations(ctx monitor.ApplicationContext, filters domain.SubLocationsFilters) (domain.CursorPage[domain.SubLocation], error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 domain.CursorPage[domain.SubLocation]
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.SubLocationsFilters) domain.CursorPage[domain.SubLocation]); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(domain.CursorPage[domain.SubLocation])
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, domain.SubLocationsFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetParkedOutboxMessages provides a mock function with given fields: ctx, limit
+func (_m *LocationsDB) GetParkedOutboxMessages(ctx monitor.ApplicationContext, limit int) ([]domain.OutboxMessage, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []domain.OutboxMessage
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, int) []domain.OutboxMessage); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OutboxMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingOutboxMessages provides a mock function with given fields: ctx, limit
+func (_m *LocationsDB) GetPendingOutboxMessages(ctx monitor.ApplicationContext, limit int) ([]domain.OutboxMessage, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []domain.OutboxMessage
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, int) []domain.OutboxMessage); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OutboxMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubLocationByID provides a mock function with given fields: ctx, locationID, subLocationID
+func (_m *LocationsDB) GetSubLocationByID(ctx monitor.ApplicationContext, locationID string, subLocationID string) (*domain.SubLocation, error) {
+	ret := _m.Called(ctx, locationID, subLocationID)
+
+	var r0 *domain.SubLocation
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string) *domain.SubLocation); ok {
+		r0 = rf(ctx, locationID, subLocationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SubLocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string, string) error); ok {
+		r1 = rf(ctx, locationID, subLocationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkOutboxMessagesAsPublished provides a mock function with given fields: ctx, ids
+func (_m *LocationsDB) MarkOutboxMessagesAsPublished(ctx monitor.ApplicationContext, ids []string) error {
+	ret := _m.Called(ctx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, []string) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Ping provides a mock function with given fields:
 func (_m *LocationsDB) Ping() error {
 	ret := _m.Called()
@@ -164,6 +427,69 @@ func (_m *LocationsDB) Ping() error {
 	return r0
 }
 
+// PingContext provides a mock function with given fields: ctx
+func (_m *LocationsDB) PingContext(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordOutboxMessageFailure provides a mock function with given fields: ctx, id, lastError, nextAttemptAt, failed
+func (_m *LocationsDB) RecordOutboxMessageFailure(ctx monitor.ApplicationContext, id string, lastError string, nextAttemptAt time.Time, failed bool) error {
+	ret := _m.Called(ctx, id, lastError, nextAttemptAt, failed)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, string, time.Time, bool) error); ok {
+		r0 = rf(ctx, id, lastError, nextAttemptAt, failed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedriveParkedOutboxMessage provides a mock function with given fields: ctx, id
+func (_m *LocationsDB) RedriveParkedOutboxMessage(ctx monitor.ApplicationContext, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(monitor.ApplicationContext, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreLocation provides a mock function with given fields: ctx, id
+func (_m *LocationsDB) RestoreLocation(ctx monitor.ApplicationContext, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RollbackTx provides a mock function with given fields:
 func (_m *LocationsDB) RollbackTx() error {
 	ret := _m.Called()
@@ -178,6 +504,20 @@ func (_m *LocationsDB) RollbackTx() error {
 	return r0
 }
 
+// SoftDeleteLocation provides a mock function with given fields: ctx, id, deletedAt
+func (_m *LocationsDB) SoftDeleteLocation(ctx monitor.ApplicationContext, id string, deletedAt time.Time) error {
+	ret := _m.Called(ctx, id, deletedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, string, time.Time) error); ok {
+		r0 = rf(ctx, id, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StartTx provides a mock function with given fields: ctx
 func (_m *LocationsDB) StartTx(ctx monitor.ApplicationContext) error {
 	ret := _m.Called(ctx)
@@ -192,6 +532,20 @@ func (_m *LocationsDB) StartTx(ctx monitor.ApplicationContext) error {
 	return r0
 }
 
+// StreamLocations provides a mock function with given fields: ctx, filters, fn
+func (_m *LocationsDB) StreamLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters, fn func(domain.Location) error) error {
+	ret := _m.Called(ctx, filters, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.LocationsFilters, func(domain.Location) error) error); ok {
+		r0 = rf(ctx, filters, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateLocation provides a mock function with given fields: ctx, location
 func (_m *LocationsDB) UpdateLocation(ctx monitor.ApplicationContext, location domain.Location) error {
 	ret := _m.Called(ctx, location)
@@ -206,6 +560,20 @@ func (_m *LocationsDB) UpdateLocation(ctx monitor.ApplicationContext, location d
 	return r0
 }
 
+// UpdateSubLocation provides a mock function with given fields: ctx, subLocation
+func (_m *LocationsDB) UpdateSubLocation(ctx monitor.ApplicationContext, subLocation domain.SubLocation) error {
+	ret := _m.Called(ctx, subLocation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(monitor.ApplicationContext, domain.SubLocation) error); ok {
+		r0 = rf(ctx, subLocation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WithTx provides a mock function with given fields: ctx, fn
 func (_m *LocationsDB) WithTx(ctx monitor.ApplicationContext, fn func(monitor.ApplicationContext) error) error {
 	err := _m.StartTx(ctx)
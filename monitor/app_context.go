@@ -19,6 +19,9 @@ type KeyValuePair struct {
 type ApplicationContext interface {
 	context.Context
 	GetCorrelationID() string
+	GetActor() string
+	GetPrincipal() (Principal, bool)
+	GetTenantSupplierID() (int, bool)
 	StartSpan(name string, opts ...trace.SpanStartOption) (ApplicationContext, trace.Span)
 }
 
@@ -66,6 +69,39 @@ func (appCtx *AppContext) GetCorrelationID() string {
 	return baggage.FromContext(appCtx).Member(CorrelationIDField).Value()
 }
 
+// GetActor returns who is performing the operation, the subject of the authenticated principal, or an empty string
+// if the caller is not authenticated
+func (appCtx *AppContext) GetActor() string {
+	actor, _ := appCtx.Value(ActorContextKey).(string)
+
+	return actor
+}
+
+// WithPrincipal returns a copy of the context authenticated as the principal, which is also recorded as the actor
+func (appCtx *AppContext) WithPrincipal(principal Principal) *AppContext {
+	principalCtx := context.WithValue(appCtx.Context, PrincipalContextKey, principal)
+
+	return &AppContext{Context: context.WithValue(principalCtx, ActorContextKey, principal.Subject)}
+}
+
+// GetPrincipal returns the authenticated caller, the second value is false on unauthenticated contexts
+func (appCtx *AppContext) GetPrincipal() (Principal, bool) {
+	principal, ok := appCtx.Value(PrincipalContextKey).(Principal)
+
+	return principal, ok
+}
+
+// GetTenantSupplierID returns the supplier the caller is restricted to, the second value is false when the caller can
+// act on the locations of every supplier
+func (appCtx *AppContext) GetTenantSupplierID() (int, bool) {
+	principal, ok := appCtx.GetPrincipal()
+	if !ok || principal.SupplierID == nil {
+		return 0, false
+	}
+
+	return *principal.SupplierID, true
+}
+
 // StartSpan is a wrapper around tracer.Start() that returns an ApplicationContext object instead of a plain context
 func (appCtx *AppContext) StartSpan(name string, opts ...trace.SpanStartOption) (ApplicationContext, trace.Span) {
 	opts = append(opts,
@@ -5,6 +5,8 @@ type ContextKeyType string
 const (
 	CorrelationIDField                     = "correlation_id"
 	CorrelationIDContextKey ContextKeyType = "correlation_id"
+	ActorContextKey         ContextKeyType = "actor"
+	PrincipalContextKey     ContextKeyType = "principal"
 	AppVersionLogField                     = "app_version"
 	ObjectLogField                         = "object"
 	FunctionLogField                       = "function"
@@ -7,7 +7,7 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-var logger *otelzap.Logger
+var logger = otelzap.New(zap.NewNop())
 
 func NewGlobalLogger() {
 	env, _ := config.GetEnvironment()
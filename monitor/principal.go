@@ -0,0 +1,22 @@
+package monitor
+
+import "slices"
+
+// Principal is the authenticated caller of an operation. SupplierID is set when the caller can only act on the
+// locations of a supplier.
+type Principal struct {
+	Subject    string
+	Scopes     []string
+	SupplierID *int
+}
+
+// HasScopes reports whether the principal was granted every one of the scopes
+func (p Principal) HasScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(p.Scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,7 @@
+// Package proto embeds the protobuf files, so the schemas of the events can be registered in the schema registry
+package proto
+
+import "embed"
+
+//go:embed events/v1/*.proto
+var Files embed.FS
@@ -0,0 +1,201 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Shopify/sarama"
+	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+	CloudEventsSource      = "/go-service-template"
+
+	// Modes of the Kafka protocol binding. Binary mode sends the attributes as headers and the data as the payload,
+	// structured mode sends the whole event as the payload.
+	CloudEventsModeBinary     = "binary"
+	CloudEventsModeStructured = "structured"
+
+	CloudEventsHeaderPrefix    = "ce_"
+	ContentTypeHeader          = "content-type"
+	JSONContentType            = "application/json"
+	CloudEventsJSONContentType = "application/cloudevents+json"
+
+	// SchemaVersionExtension is the CloudEvents extension attribute with the schema version of the data
+	SchemaVersionExtension = "schemaversion"
+
+	// LegacySchemaVersion is the schema version of the events published without a CloudEvents envelope
+	LegacySchemaVersion = 1
+)
+
+var ErrInvalidCloudEventsMode = errors.New("invalid CloudEvents mode, accepted values: '" + CloudEventsModeBinary + "' or '" + CloudEventsModeStructured + "'")
+
+// CloudEvent is a CloudEvents 1.0 event with JSON data, as sent in structured mode
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	SchemaVersion   int             `json:"schemaversion,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// CreateCloudEventMessage builds a binary mode CloudEvent with the data as JSON. The publisher sends it in the mode
+// set in the Kafka config.
+func CreateCloudEventMessage(ctx monitor.ApplicationContext, key, eventType string, schemaVersion int, data any) (*message.Message, error) {
+	msg, err := CreateJSONMessage(ctx, key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Metadata.Set(CloudEventsHeaderPrefix+"specversion", CloudEventsSpecVersion)
+	msg.Metadata.Set(CloudEventsHeaderPrefix+"id", msg.UUID)
+	msg.Metadata.Set(CloudEventsHeaderPrefix+"source", CloudEventsSource)
+	msg.Metadata.Set(CloudEventsHeaderPrefix+"type", eventType)
+	msg.Metadata.Set(CloudEventsHeaderPrefix+"subject", key)
+	msg.Metadata.Set(CloudEventsHeaderPrefix+"time", time.Now().UTC().Format(time.RFC3339Nano))
+	msg.Metadata.Set(CloudEventsHeaderPrefix+SchemaVersionExtension, strconv.Itoa(schemaVersion))
+	msg.Metadata.Set(ContentTypeHeader, JSONContentType)
+
+	return msg, nil
+}
+
+// CreateCloudEventOutboxMessage builds the same message as CreateCloudEventMessage, but as an outbox row that will be
+// relayed to the given topic once the transaction that stores it commits
+func CreateCloudEventOutboxMessage(
+	ctx monitor.ApplicationContext,
+	topic, key, eventType string,
+	schemaVersion int,
+	data any,
+) (domain.OutboxMessage, error) {
+	msg, err := CreateCloudEventMessage(ctx, key, eventType, schemaVersion, data)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+
+	return createOutboxMessage(topic, key, msg), nil
+}
+
+// ReadCloudEvent reads the CloudEvent of a message sent in either mode. Messages published before the events were
+// wrapped in CloudEvents are read as an event of the legacy schema version, without type.
+func ReadCloudEvent(msg *message.Message) (CloudEvent, error) {
+	if msg.Metadata.Get(ContentTypeHeader) == CloudEventsJSONContentType {
+		var event CloudEvent
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return CloudEvent{}, fmt.Errorf("error unmarshaling structured CloudEvent: %w", err)
+		}
+		if event.SchemaVersion == 0 {
+			event.SchemaVersion = LegacySchemaVersion
+		}
+
+		return event, nil
+	}
+
+	if msg.Metadata.Get(CloudEventsHeaderPrefix+"specversion") == "" {
+		return CloudEvent{ID: msg.UUID, SchemaVersion: LegacySchemaVersion, Data: json.RawMessage(msg.Payload)}, nil
+	}
+
+	event := CloudEvent{
+		SpecVersion:     msg.Metadata.Get(CloudEventsHeaderPrefix + "specversion"),
+		ID:              msg.Metadata.Get(CloudEventsHeaderPrefix + "id"),
+		Source:          msg.Metadata.Get(CloudEventsHeaderPrefix + "source"),
+		Type:            msg.Metadata.Get(CloudEventsHeaderPrefix + "type"),
+		Subject:         msg.Metadata.Get(CloudEventsHeaderPrefix + "subject"),
+		DataContentType: msg.Metadata.Get(ContentTypeHeader),
+		SchemaVersion:   LegacySchemaVersion,
+		Data:            json.RawMessage(msg.Payload),
+	}
+
+	if timeVal := msg.Metadata.Get(CloudEventsHeaderPrefix + "time"); timeVal != "" {
+		eventTime, err := time.Parse(time.RFC3339Nano, timeVal)
+		if err != nil {
+			return CloudEvent{}, fmt.Errorf("error parsing CloudEvent time: %w", err)
+		}
+		event.Time = eventTime
+	}
+
+	if versionVal := msg.Metadata.Get(CloudEventsHeaderPrefix + SchemaVersionExtension); versionVal != "" {
+		schemaVersion, err := strconv.Atoi(versionVal)
+		if err != nil {
+			return CloudEvent{}, fmt.Errorf("error parsing CloudEvent schema version: %w", err)
+		}
+		event.SchemaVersion = schemaVersion
+	}
+
+	return event, nil
+}
+
+// ToStructuredCloudEvent converts a binary mode CloudEvent to structured mode, the rest of the metadata is kept.
+// Messages that are not binary mode CloudEvents with JSON data are returned as they are, Protobuf data is always sent
+// in binary mode.
+func ToStructuredCloudEvent(msg *message.Message) (*message.Message, error) {
+	if msg.Metadata.Get(CloudEventsHeaderPrefix+"specversion") == "" || msg.Metadata.Get(ContentTypeHeader) != JSONContentType {
+		return msg, nil
+	}
+
+	event, err := ReadCloudEvent(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	structuredMsg := message.NewMessage(msg.UUID, payload)
+	structuredMsg.SetContext(msg.Context())
+	for key, value := range msg.Metadata {
+		if !isCloudEventsHeader(key) {
+			structuredMsg.Metadata.Set(key, value)
+		}
+	}
+	structuredMsg.Metadata.Set(ContentTypeHeader, CloudEventsJSONContentType)
+
+	return structuredMsg, nil
+}
+
+func isCloudEventsHeader(key string) bool {
+	return key == ContentTypeHeader || strings.HasPrefix(key, CloudEventsHeaderPrefix)
+}
+
+// cloudEventsMarshaler sends the CloudEvents in the mode set in the Kafka config
+type cloudEventsMarshaler struct {
+	kafka.MarshalerUnmarshaler
+	mode string
+}
+
+// NewCloudEventsMarshaler wraps the marshaler so the binary mode CloudEvents are sent in the mode, the other messages
+// are marshaled as they are
+func NewCloudEventsMarshaler(marshaler kafka.MarshalerUnmarshaler, mode string) (kafka.MarshalerUnmarshaler, error) {
+	if mode == "" {
+		mode = CloudEventsModeBinary
+	}
+	if mode != CloudEventsModeBinary && mode != CloudEventsModeStructured {
+		return nil, ErrInvalidCloudEventsMode
+	}
+
+	return cloudEventsMarshaler{MarshalerUnmarshaler: marshaler, mode: mode}, nil
+}
+
+func (m cloudEventsMarshaler) Marshal(topic string, msg *message.Message) (*sarama.ProducerMessage, error) {
+	if m.mode == CloudEventsModeStructured {
+		structuredMsg, err := ToStructuredCloudEvent(msg)
+		if err != nil {
+			return nil, err
+		}
+		msg = structuredMsg
+	}
+
+	return m.MarshalerUnmarshaler.Marshal(topic, msg)
+}
@@ -0,0 +1,166 @@
+package pubsub_test
+
+import (
+	"encoding/json"
+	"github.com/Shopify/sarama"
+	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/pubsub"
+	"testing"
+)
+
+var testLocationEvent = domain.LocationEventV2{Location: domain.Location{ID: "location1", Name: "Location"}, Actor: "user"}
+
+type CloudEventsSuite struct {
+	suite.Suite
+}
+
+func TestCloudEventsSuite(t *testing.T) {
+	suite.Run(t, new(CloudEventsSuite))
+}
+
+func (s *CloudEventsSuite) Test_CreateCloudEventMessage_BinaryMode() {
+	msg, err := pubsub.CreateCloudEventMessage(testCtx, "location1", domain.LocationCreatedEventType, 2, testLocationEvent)
+	s.Require().NoError(err)
+
+	assert.Equal(s.T(), pubsub.CloudEventsSpecVersion, msg.Metadata.Get("ce_specversion"))
+	assert.Equal(s.T(), msg.UUID, msg.Metadata.Get("ce_id"))
+	assert.Equal(s.T(), domain.LocationCreatedEventType, msg.Metadata.Get("ce_type"))
+	assert.Equal(s.T(), "location1", msg.Metadata.Get("ce_subject"))
+	assert.Equal(s.T(), "2", msg.Metadata.Get("ce_schemaversion"))
+	assert.Equal(s.T(), pubsub.JSONContentType, msg.Metadata.Get(pubsub.ContentTypeHeader))
+	assert.Equal(s.T(), "location1", msg.Metadata.Get(pubsub.MessageKey))
+
+	event, err := pubsub.ReadCloudEvent(msg)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), domain.LocationCreatedEventType, event.Type)
+	assert.Equal(s.T(), 2, event.SchemaVersion)
+	assert.False(s.T(), event.Time.IsZero())
+	assertLocationEventData(s, event.Data)
+}
+
+func (s *CloudEventsSuite) Test_ToStructuredCloudEvent_KeepsAttributesAndMetadata() {
+	msg, err := pubsub.CreateCloudEventMessage(testCtx, "location1", domain.LocationCreatedEventType, 2, testLocationEvent)
+	s.Require().NoError(err)
+	binaryEvent, err := pubsub.ReadCloudEvent(msg)
+	s.Require().NoError(err)
+
+	structuredMsg, err := pubsub.ToStructuredCloudEvent(msg)
+	s.Require().NoError(err)
+
+	assert.Equal(s.T(), msg.UUID, structuredMsg.UUID)
+	assert.Equal(s.T(), pubsub.CloudEventsJSONContentType, structuredMsg.Metadata.Get(pubsub.ContentTypeHeader))
+	assert.Empty(s.T(), structuredMsg.Metadata.Get("ce_type"))
+	assert.Equal(s.T(), "location1", structuredMsg.Metadata.Get(pubsub.MessageKey))
+
+	var payload map[string]any
+	s.Require().NoError(json.Unmarshal(structuredMsg.Payload, &payload))
+	assert.Equal(s.T(), "1.0", payload["specversion"])
+	assert.Equal(s.T(), domain.LocationCreatedEventType, payload["type"])
+
+	structuredEvent, err := pubsub.ReadCloudEvent(structuredMsg)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), binaryEvent.ID, structuredEvent.ID)
+	assert.Equal(s.T(), binaryEvent.Type, structuredEvent.Type)
+	assert.Equal(s.T(), binaryEvent.SchemaVersion, structuredEvent.SchemaVersion)
+	assert.True(s.T(), binaryEvent.Time.Equal(structuredEvent.Time))
+	assertLocationEventData(s, structuredEvent.Data)
+}
+
+func (s *CloudEventsSuite) Test_ReadCloudEvent_LegacyMessage() {
+	msg, err := pubsub.CreateJSONMessage(testCtx, "location1", testLocationEvent.Location)
+	s.Require().NoError(err)
+
+	event, err := pubsub.ReadCloudEvent(msg)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), msg.UUID, event.ID)
+	assert.Empty(s.T(), event.Type)
+	assert.Equal(s.T(), pubsub.LegacySchemaVersion, event.SchemaVersion)
+	assert.JSONEq(s.T(), string(msg.Payload), string(event.Data))
+}
+
+func (s *CloudEventsSuite) Test_CloudEventsMarshaler_StructuredMode() {
+	marshaler, err := pubsub.NewCloudEventsMarshaler(kafka.NewWithPartitioningMarshaler(pubsub.GetMessageKeyFromMessage), pubsub.CloudEventsModeStructured)
+	s.Require().NoError(err)
+	msg, err := pubsub.CreateCloudEventMessage(testCtx, "location1", domain.LocationCreatedEventType, 2, testLocationEvent)
+	s.Require().NoError(err)
+
+	kafkaMsg, err := marshaler.Marshal(domain.LocationsNewTopic, msg)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), sarama.ByteEncoder("location1"), kafkaMsg.Key)
+	assert.Equal(s.T(), pubsub.CloudEventsJSONContentType, kafkaHeader(kafkaMsg, pubsub.ContentTypeHeader))
+	assert.Empty(s.T(), kafkaHeader(kafkaMsg, "ce_type"))
+
+	consumedMsg, err := marshaler.Unmarshal(&sarama.ConsumerMessage{Headers: consumerHeaders(kafkaMsg), Value: []byte(kafkaMsg.Value.(sarama.ByteEncoder))})
+	s.Require().NoError(err)
+	event, err := pubsub.ReadCloudEvent(consumedMsg)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), msg.UUID, consumedMsg.UUID)
+	assert.Equal(s.T(), domain.LocationCreatedEventType, event.Type)
+}
+
+func (s *CloudEventsSuite) Test_NewCloudEventsMarshaler_RejectsUnknownMode() {
+	_, err := pubsub.NewCloudEventsMarshaler(kafka.DefaultMarshaler{}, "compact")
+
+	assert.ErrorIs(s.T(), err, pubsub.ErrInvalidCloudEventsMode)
+}
+
+func (s *CloudEventsSuite) Test_UpcasterRegistry_UpcastsOneVersionAtATime() {
+	registry := pubsub.NewUpcasterRegistry().
+		Register(1, wrapData("v2"), domain.LocationCreatedEventType).
+		Register(2, wrapData("v3"), domain.LocationCreatedEventType)
+
+	data, err := registry.Upcast(pubsub.CloudEvent{Type: domain.LocationCreatedEventType, SchemaVersion: 1, Data: json.RawMessage(`1`)}, 3)
+
+	assert.Nil(s.T(), err)
+	assert.JSONEq(s.T(), `{"v3":{"v2":1}}`, string(data))
+}
+
+func (s *CloudEventsSuite) Test_UpcasterRegistry_RejectsUnknownVersions() {
+	registry := pubsub.NewUpcasterRegistry().Register(1, wrapData("v2"), domain.LocationCreatedEventType)
+
+	_, newerErr := registry.Upcast(pubsub.CloudEvent{Type: domain.LocationCreatedEventType, SchemaVersion: 3}, 2)
+	_, missingErr := registry.Upcast(pubsub.CloudEvent{Type: domain.LocationUpdatedEventType, SchemaVersion: 1}, 2)
+
+	assert.ErrorIs(s.T(), newerErr, pubsub.ErrUnsupportedSchemaVersion)
+	assert.ErrorIs(s.T(), missingErr, pubsub.ErrUnsupportedSchemaVersion)
+}
+
+func assertLocationEventData(s *CloudEventsSuite, data json.RawMessage) {
+	var locationEvent domain.LocationEventV2
+	s.Require().NoError(json.Unmarshal(data, &locationEvent))
+	assert.Equal(s.T(), testLocationEvent.Location.ID, locationEvent.Location.ID)
+	assert.Equal(s.T(), testLocationEvent.Actor, locationEvent.Actor)
+}
+
+func wrapData(field string) pubsub.Upcaster {
+	return func(data json.RawMessage) (json.RawMessage, error) {
+		return json.Marshal(map[string]json.RawMessage{field: data})
+	}
+}
+
+func kafkaHeader(kafkaMsg *sarama.ProducerMessage, key string) string {
+	for _, header := range kafkaMsg.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+func consumerHeaders(kafkaMsg *sarama.ProducerMessage) []*sarama.RecordHeader {
+	headers := make([]*sarama.RecordHeader, 0, len(kafkaMsg.Headers))
+	for i := range kafkaMsg.Headers {
+		headers = append(headers, &kafkaMsg.Headers[i])
+	}
+
+	return headers
+}
@@ -0,0 +1,84 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-service-template/config"
+	customHTTP "go-service-template/http"
+	"go-service-template/monitor"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// ConfluentSchemaRegistry is a client of the REST API of the Confluent schema registry, the registry checks the
+// compatibility of the schemas with the compatibility level of each subject
+type ConfluentSchemaRegistry struct {
+	url        string
+	basicAuth  *customHTTP.BasicAuth
+	httpClient customHTTP.CustomHTTPClient
+}
+
+type schemaRegistryError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func NewConfluentSchemaRegistry(cfg config.SchemaRegistryConfig, httpClient customHTTP.CustomHTTPClient) *ConfluentSchemaRegistry {
+	var basicAuth *customHTTP.BasicAuth
+	if cfg.Username != "" {
+		basicAuth = &customHTTP.BasicAuth{Username: cfg.Username, Password: cfg.Password}
+	}
+
+	return &ConfluentSchemaRegistry{
+		url:        strings.TrimSuffix(cfg.URL, "/"),
+		basicAuth:  basicAuth,
+		httpClient: httpClient,
+	}
+}
+
+func (r *ConfluentSchemaRegistry) Register(ctx monitor.ApplicationContext, subject string, schema Schema) (int, error) {
+	ctx, span := ctx.StartSpan("ConfluentSchemaRegistry.Register")
+	defer span.End()
+
+	response, err := r.httpClient.Do(ctx, customHTTP.RequestValues{
+		URL:       fmt.Sprintf("%s/subjects/%s/versions", r.url, url.PathEscape(subject)),
+		Method:    http.MethodPost,
+		Headers:   http.Header{"Content-Type": []string{schemaRegistryContentType}},
+		Body:      schema,
+		BasicAuth: r.basicAuth,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error registering schema of subject %s: %w", subject, err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		var registered struct {
+			ID int `json:"id"`
+		}
+		if err = json.Unmarshal(response.BodyPayload, &registered); err != nil {
+			return 0, fmt.Errorf("error reading schema ID of subject %s: %w", subject, err)
+		}
+
+		return registered.ID, nil
+	case http.StatusConflict:
+		return 0, fmt.Errorf("%w: subject %s: %s", ErrIncompatibleSchema, subject, registryErrorMessage(response))
+	case http.StatusUnprocessableEntity:
+		return 0, fmt.Errorf("%w: subject %s: %s", ErrInvalidSchema, subject, registryErrorMessage(response))
+	default:
+		return 0, fmt.Errorf("error registering schema of subject %s, the registry answered with status %v: %s",
+			subject, response.StatusCode, registryErrorMessage(response))
+	}
+}
+
+func registryErrorMessage(response customHTTP.CustomHTTPResponse) string {
+	var registryErr schemaRegistryError
+	if err := json.Unmarshal(response.BodyPayload, &registryErr); err != nil || registryErr.Message == "" {
+		return string(response.BodyPayload)
+	}
+
+	return registryErr.Message
+}
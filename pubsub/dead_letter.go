@@ -0,0 +1,140 @@
+package pubsub
+
+import (
+	"fmt"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"strconv"
+	"time"
+)
+
+// Metadata of the redriven messages
+const (
+	// RedrivenFromMetadataKey is set with the ID of the dead letter the message comes from
+	RedrivenFromMetadataKey = "redriven_from"
+	// RedriveHandlerMetadataKey is set with the name of the handler that failed the message, the rest of the handlers
+	// of the topic already processed it and skip it
+	RedriveHandlerMetadataKey = "redrive_handler"
+	// RedrivenMessageUUIDMetadataKey is set with the UUID of the message that failed, which the redriven message keeps
+	RedrivenMessageUUIDMetadataKey = "redriven_message_uuid"
+)
+
+// PoisonTopic returns the topic the dead letters of the handler are published to, the one set in its config or
+// "<topic>.poison.<handler>"
+func PoisonTopic(kafkaParams config.KafkaConfig, handlerName, topic string) string {
+	if poisonTopic := kafkaParams.GetHandlerConfig(handlerName).PoisonTopic; poisonTopic != "" {
+		return poisonTopic
+	}
+
+	return fmt.Sprintf("%s.poison.%s", topic, handlerName)
+}
+
+// DeadLetterMiddleware publishes the messages the handler failed to process to the poison topic, wrapped in a
+// DeadLetter envelope, and acks them so the partition moves on. When the envelope cannot be published the error is
+// returned and the message is redelivered, so it is never lost.
+func DeadLetterMiddleware(publisher message.Publisher, handlerName, topic, poisonTopic string) message.HandlerMiddleware {
+	logger := monitor.GetStdLogger("DeadLetterMiddleware")
+
+	return func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			fnName := "DeadLetterMiddleware"
+
+			events, err := h(msg)
+			if err == nil {
+				return events, nil
+			}
+
+			appCtx := monitor.CreateAppContextFromContext(msg.Context(), msg.Metadata.Get(monitor.CorrelationIDField))
+			deadLetter := CreateDeadLetter(msg, handlerName, topic, poisonTopic, err)
+
+			envelope, marshalErr := CreateJSONMessage(appCtx, msg.Metadata.Get(MessageKey), deadLetter)
+			if marshalErr != nil {
+				logger.ErrorCtx(appCtx, fnName, "failed to create dead letter envelope", marshalErr)
+				return nil, err
+			}
+			envelope.UUID = deadLetter.ID
+
+			if publishErr := publisher.Publish(poisonTopic, envelope); publishErr != nil {
+				logger.ErrorCtx(appCtx, fnName, "failed to publish dead letter, the message will be redelivered", publishErr)
+				return nil, err
+			}
+
+			logger.WarnCtx(appCtx, fnName, "message sent to the poison topic",
+				monitor.LoggingParam{Name: "message_id", Value: msg.UUID},
+				monitor.LoggingParam{Name: "handler", Value: handlerName},
+				monitor.LoggingParam{Name: "poison_topic", Value: poisonTopic},
+			)
+
+			return nil, nil
+		}
+	}
+}
+
+// RedriveFilterMiddleware acks without processing the messages redriven for another handler, so a redrive only runs
+// the handler that failed the message again
+func RedriveFilterMiddleware(handlerName string) message.HandlerMiddleware {
+	return func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			if redriveHandler := msg.Metadata.Get(RedriveHandlerMetadataKey); redriveHandler != "" && redriveHandler != handlerName {
+				return nil, nil
+			}
+
+			return h(msg)
+		}
+	}
+}
+
+// CreateDeadLetter builds the envelope of a message the handler failed to process, the attempts are the ones
+// recorded by RetryMiddleware
+func CreateDeadLetter(msg *message.Message, handlerName, topic, poisonTopic string, err error) domain.DeadLetter {
+	attempts, parseErr := strconv.Atoi(msg.Metadata.Get(AttemptsMetadataKey))
+	if parseErr != nil {
+		attempts = 1
+	}
+
+	metadata := make(map[string]string, len(msg.Metadata))
+	for key, value := range msg.Metadata {
+		if key != AttemptsMetadataKey {
+			metadata[key] = value
+		}
+	}
+
+	return domain.DeadLetter{
+		ID:            watermill.NewUUID(),
+		MessageID:     msg.UUID,
+		Handler:       handlerName,
+		OriginalTopic: topic,
+		PoisonTopic:   poisonTopic,
+		Error:         err.Error(),
+		Attempts:      attempts,
+		CorrelationID: msg.Metadata.Get(monitor.CorrelationIDField),
+		Payload:       msg.Payload,
+		Metadata:      metadata,
+		FailedAt:      time.Now().UTC(),
+	}
+}
+
+// CreateRedriveOutboxMessage builds the outbox row that publishes the message of the dead letter to its original
+// topic again, for the handler that failed it only. The row gets a new ID since the original message may still be in
+// the outbox, the message published keeps its UUID. The metadata is kept so the correlation ID and the message key,
+// which keeps the per aggregate ordering, are the same.
+func CreateRedriveOutboxMessage(deadLetter domain.DeadLetter) domain.OutboxMessage {
+	metadata := make(map[string]string, len(deadLetter.Metadata)+3)
+	for key, value := range deadLetter.Metadata {
+		metadata[key] = value
+	}
+	metadata[RedrivenFromMetadataKey] = deadLetter.ID
+	metadata[RedriveHandlerMetadataKey] = deadLetter.Handler
+	metadata[RedrivenMessageUUIDMetadataKey] = deadLetter.MessageID
+
+	return domain.OutboxMessage{
+		ID:          watermill.NewUUID(),
+		AggregateID: deadLetter.Metadata[MessageKey],
+		Topic:       deadLetter.OriginalTopic,
+		Payload:     deadLetter.Payload,
+		Metadata:    metadata,
+	}
+}
@@ -0,0 +1,163 @@
+package pubsub_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/mocks"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"testing"
+)
+
+const (
+	testHandlerName = "NewLocationWebhookHandler"
+	testPoisonTopic = domain.LocationsNewTopic + ".poison." + testHandlerName
+)
+
+var errHandlerFailed = errors.New("handler failed")
+
+type DeadLetterSuite struct {
+	suite.Suite
+	publisherMock *mocks.MockPublisher
+	retryConfig   config.HandlerConfig
+}
+
+func (s *DeadLetterSuite) SetupTest() {
+	s.publisherMock = new(mocks.MockPublisher)
+	s.retryConfig = config.HandlerConfig{MaxRetries: 2, InitialIntervalMs: 1, MaxIntervalMs: 2}
+}
+
+func TestDeadLetterSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterSuite))
+}
+
+func (s *DeadLetterSuite) Test_RetryMiddleware_RetriesUntilSuccess() {
+	attempts := 0
+	handler := pubsub.RetryMiddleware(s.retryConfig)(func(msg *message.Message) ([]*message.Message, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errHandlerFailed
+		}
+		return nil, nil
+	})
+
+	_, err := handler(buildHandlerMessage())
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 3, attempts)
+}
+
+func (s *DeadLetterSuite) Test_RetryMiddleware_RecordsAttemptsWhenRetriesRunOut() {
+	attempts := 0
+	handler := pubsub.RetryMiddleware(s.retryConfig)(func(msg *message.Message) ([]*message.Message, error) {
+		attempts++
+		return nil, errHandlerFailed
+	})
+	msg := buildHandlerMessage()
+
+	_, err := handler(msg)
+
+	assert.ErrorIs(s.T(), err, errHandlerFailed)
+	assert.Equal(s.T(), 3, attempts)
+	assert.Equal(s.T(), "3", msg.Metadata.Get(pubsub.AttemptsMetadataKey))
+}
+
+func (s *DeadLetterSuite) Test_DeadLetterMiddleware_PublishesEnvelopeAndAcks() {
+	var envelope domain.DeadLetter
+	s.publisherMock.On("Publish", testPoisonTopic, mock.MatchedBy(func(msg *message.Message) bool {
+		return msg.Metadata.Get(pubsub.MessageKey) == "location1" && json.Unmarshal(msg.Payload, &envelope) == nil
+	})).Return(nil).Once()
+
+	handler := pubsub.DeadLetterMiddleware(s.publisherMock, testHandlerName, domain.LocationsNewTopic, testPoisonTopic)(
+		pubsub.RetryMiddleware(s.retryConfig)(func(msg *message.Message) ([]*message.Message, error) {
+			return nil, errHandlerFailed
+		}),
+	)
+	msg := buildHandlerMessage()
+
+	_, err := handler(msg)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), msg.UUID, envelope.MessageID)
+	assert.Equal(s.T(), testHandlerName, envelope.Handler)
+	assert.Equal(s.T(), domain.LocationsNewTopic, envelope.OriginalTopic)
+	assert.Equal(s.T(), testPoisonTopic, envelope.PoisonTopic)
+	assert.Equal(s.T(), errHandlerFailed.Error(), envelope.Error)
+	assert.Equal(s.T(), 3, envelope.Attempts)
+	assert.Equal(s.T(), "correlation-id", envelope.CorrelationID)
+	assert.Equal(s.T(), []byte(msg.Payload), envelope.Payload)
+	assert.NotContains(s.T(), envelope.Metadata, pubsub.AttemptsMetadataKey)
+	s.publisherMock.AssertExpectations(s.T())
+}
+
+func (s *DeadLetterSuite) Test_DeadLetterMiddleware_ReturnsErrorWhenPublishFails() {
+	s.publisherMock.On("Publish", testPoisonTopic, mock.Anything).Return(errors.New("broker unavailable")).Once()
+
+	handler := pubsub.DeadLetterMiddleware(s.publisherMock, testHandlerName, domain.LocationsNewTopic, testPoisonTopic)(
+		func(msg *message.Message) ([]*message.Message, error) {
+			return nil, errHandlerFailed
+		},
+	)
+
+	_, err := handler(buildHandlerMessage())
+
+	assert.ErrorIs(s.T(), err, errHandlerFailed)
+	s.publisherMock.AssertExpectations(s.T())
+}
+
+func (s *DeadLetterSuite) Test_PoisonTopic() {
+	kafkaParams := config.KafkaConfig{Handlers: map[string]config.HandlerConfig{
+		"CustomHandler": {PoisonTopic: "custom.poison"},
+	}}
+
+	assert.Equal(s.T(), "custom.poison", pubsub.PoisonTopic(kafkaParams, "CustomHandler", domain.LocationsNewTopic))
+	assert.Equal(s.T(), testPoisonTopic, pubsub.PoisonTopic(kafkaParams, testHandlerName, domain.LocationsNewTopic))
+}
+
+func (s *DeadLetterSuite) Test_CreateRedriveOutboxMessage() {
+	deadLetter := pubsub.CreateDeadLetter(buildHandlerMessage(), testHandlerName, domain.LocationsNewTopic, testPoisonTopic, errHandlerFailed)
+
+	outboxMessage := pubsub.CreateRedriveOutboxMessage(deadLetter)
+
+	assert.NotEqual(s.T(), deadLetter.MessageID, outboxMessage.ID)
+	assert.Equal(s.T(), testHandlerName, outboxMessage.Metadata[pubsub.RedriveHandlerMetadataKey])
+	assert.Equal(s.T(), deadLetter.MessageID, pubsub.CreateMessageFromOutbox(testCtx, outboxMessage).UUID)
+	assert.Equal(s.T(), "location1", outboxMessage.AggregateID)
+	assert.Equal(s.T(), domain.LocationsNewTopic, outboxMessage.Topic)
+	assert.Equal(s.T(), deadLetter.Payload, outboxMessage.Payload)
+	assert.Equal(s.T(), "correlation-id", outboxMessage.Metadata[monitor.CorrelationIDField])
+	assert.Equal(s.T(), deadLetter.ID, outboxMessage.Metadata[pubsub.RedrivenFromMetadataKey])
+}
+
+func (s *DeadLetterSuite) Test_RedriveFilterMiddleware_SkipsMessagesRedrivenForAnotherHandler() {
+	calls := 0
+	handlerFunc := func(msg *message.Message) ([]*message.Message, error) {
+		calls++
+		return nil, nil
+	}
+	redrivenMsg := buildHandlerMessage()
+	redrivenMsg.Metadata.Set(pubsub.RedriveHandlerMetadataKey, testHandlerName)
+
+	_, err := pubsub.RedriveFilterMiddleware("OtherHandler")(handlerFunc)(redrivenMsg)
+	s.Require().NoError(err)
+	_, err = pubsub.RedriveFilterMiddleware(testHandlerName)(handlerFunc)(redrivenMsg)
+	s.Require().NoError(err)
+	_, err = pubsub.RedriveFilterMiddleware("OtherHandler")(handlerFunc)(buildHandlerMessage())
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, calls)
+}
+
+func buildHandlerMessage() *message.Message {
+	msg := message.NewMessage("b6a3c1d2-8f4e-4e1a-9c7b-1d2e3f4a5b6c", []byte(`{"id":"location1"}`))
+	msg.Metadata.Set(pubsub.MessageKey, "location1")
+	msg.Metadata.Set(monitor.CorrelationIDField, "correlation-id")
+
+	return msg
+}
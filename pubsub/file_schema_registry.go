@@ -0,0 +1,134 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-service-template/monitor"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const DefaultSchemaRegistryFilePath = "schema-registry.local.json"
+
+// FileSchemaRegistry keeps the schemas in a JSON file, standing in for the schema registry locally and in tests. Like
+// the default compatibility level of the Confluent registry, a new version must be able to read the data written with
+// the latest one.
+type FileSchemaRegistry struct {
+	path string
+	mu   sync.Mutex
+}
+
+type fileSchemaRegistryData struct {
+	Schemas []registeredSchema `json:"schemas"`
+}
+
+type registeredSchema struct {
+	ID         int    `json:"id"`
+	Subject    string `json:"subject"`
+	Version    int    `json:"version"`
+	Type       string `json:"schemaType"`
+	Schema     string `json:"schema"`
+	Descriptor []byte `json:"descriptor,omitempty"`
+}
+
+func NewFileSchemaRegistry(path string) *FileSchemaRegistry {
+	if path == "" {
+		path = DefaultSchemaRegistryFilePath
+	}
+
+	return &FileSchemaRegistry{path: path}
+}
+
+func (r *FileSchemaRegistry) Register(ctx monitor.ApplicationContext, subject string, schema Schema) (int, error) {
+	_, span := ctx.StartSpan("FileSchemaRegistry.Register")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+
+	var latest *registeredSchema
+	lastID := 0
+	for i, registered := range data.Schemas {
+		lastID = max(lastID, registered.ID)
+		if registered.Subject != subject {
+			continue
+		}
+		if registered.Type == schema.Type && registered.Schema == schema.Schema {
+			return registered.ID, nil
+		}
+		if latest == nil || registered.Version > latest.Version {
+			latest = &data.Schemas[i]
+		}
+	}
+
+	version := 1
+	if latest != nil {
+		if latest.Type != schema.Type {
+			return 0, fmt.Errorf("%w: subject %s has %s schemas", ErrIncompatibleSchema, subject, latest.Type)
+		}
+		if schema.Type == SchemaTypeProtobuf {
+			if err = CheckProtobufCompatibility(latest.Descriptor, schema.Descriptor); err != nil {
+				return 0, fmt.Errorf("subject %s: %w", subject, err)
+			}
+		}
+		version = latest.Version + 1
+	}
+
+	registered := registeredSchema{
+		ID:         lastID + 1,
+		Subject:    subject,
+		Version:    version,
+		Type:       schema.Type,
+		Schema:     schema.Schema,
+		Descriptor: schema.Descriptor,
+	}
+	data.Schemas = append(data.Schemas, registered)
+
+	if err = r.save(data); err != nil {
+		return 0, err
+	}
+
+	return registered.ID, nil
+}
+
+func (r *FileSchemaRegistry) load() (fileSchemaRegistryData, error) {
+	var data fileSchemaRegistryData
+
+	content, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return data, nil
+	}
+	if err != nil {
+		return data, fmt.Errorf("error reading schema registry file: %w", err)
+	}
+
+	if err = json.Unmarshal(content, &data); err != nil {
+		return data, fmt.Errorf("error unmarshaling schema registry file: %w", err)
+	}
+
+	return data, nil
+}
+
+func (r *FileSchemaRegistry) save(data fileSchemaRegistryData) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("error creating schema registry directory: %w", err)
+	}
+
+	if err = os.WriteFile(r.path, content, 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("error writing schema registry file: %w", err)
+	}
+
+	return nil
+}
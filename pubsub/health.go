@@ -0,0 +1,73 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/config"
+	"net"
+)
+
+var (
+	ErrRouterNotRunning = errors.New("the event router is not running")
+	ErrRouterClosed     = errors.New("the event router is closed")
+)
+
+// BrokersHealthCheck reports Kafka as reachable when a connection can be opened to any of the brokers, the clients
+// fail over to the reachable ones
+type BrokersHealthCheck struct {
+	brokers []string
+	dialer  net.Dialer
+}
+
+func NewBrokersHealthCheck(kafkaParams config.KafkaConfig) *BrokersHealthCheck {
+	return &BrokersHealthCheck{brokers: kafkaParams.Brokers}
+}
+
+func (c *BrokersHealthCheck) Name() string {
+	return "kafka"
+}
+
+func (c *BrokersHealthCheck) Check(ctx context.Context) error {
+	if len(c.brokers) == 0 {
+		return ErrBrokerSliceEmpty
+	}
+
+	errs := make([]error, 0, len(c.brokers))
+	for _, broker := range c.brokers {
+		conn, err := c.dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn.Close()
+		}
+
+		errs = append(errs, err)
+	}
+
+	return fmt.Errorf("no Kafka broker is reachable: %w", errors.Join(errs...))
+}
+
+// RouterHealthCheck reports whether the event router is consuming, it is down until the router started and once it
+// was closed
+type RouterHealthCheck struct {
+	router *message.Router
+}
+
+func NewRouterHealthCheck(router *message.Router) *RouterHealthCheck {
+	return &RouterHealthCheck{router: router}
+}
+
+func (c *RouterHealthCheck) Name() string {
+	return "event_router"
+}
+
+func (c *RouterHealthCheck) Check(_ context.Context) error {
+	if c.router.IsClosed() {
+		return ErrRouterClosed
+	}
+	if !c.router.IsRunning() {
+		return ErrRouterNotRunning
+	}
+
+	return nil
+}
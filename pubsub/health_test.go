@@ -0,0 +1,62 @@
+package pubsub_test
+
+import (
+	"context"
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/pubsub"
+	"net"
+	"testing"
+)
+
+type HealthCheckSuite struct {
+	suite.Suite
+}
+
+func TestHealthCheckSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckSuite))
+}
+
+func (s *HealthCheckSuite) Test_BrokersHealthCheck_UpWhenAnyBrokerIsReachable() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	unreachable, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	unreachableAddr := unreachable.Addr().String()
+	s.Require().NoError(unreachable.Close())
+
+	check := pubsub.NewBrokersHealthCheck(config.KafkaConfig{Brokers: []string{unreachableAddr, listener.Addr().String()}})
+
+	assert.Nil(s.T(), check.Check(context.Background()))
+}
+
+func (s *HealthCheckSuite) Test_BrokersHealthCheck_DownWhenNoBrokerIsReachable() {
+	unreachable, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	unreachableAddr := unreachable.Addr().String()
+	s.Require().NoError(unreachable.Close())
+
+	check := pubsub.NewBrokersHealthCheck(config.KafkaConfig{Brokers: []string{unreachableAddr}})
+
+	assert.ErrorContains(s.T(), check.Check(context.Background()), unreachableAddr)
+}
+
+func (s *HealthCheckSuite) Test_RouterHealthCheck_FollowsRouterState() {
+	router, err := message.NewRouter(message.RouterConfig{}, watermill.NopLogger{})
+	s.Require().NoError(err)
+	check := pubsub.NewRouterHealthCheck(router)
+
+	assert.ErrorIs(s.T(), check.Check(context.Background()), pubsub.ErrRouterNotRunning)
+
+	go func() { _ = router.Run(context.Background()) }()
+	<-router.Running()
+	assert.Nil(s.T(), check.Check(context.Background()))
+
+	s.Require().NoError(router.Close())
+	assert.ErrorIs(s.T(), check.Check(context.Background()), pubsub.ErrRouterClosed)
+}
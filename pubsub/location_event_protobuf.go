@@ -0,0 +1,111 @@
+package pubsub
+
+import (
+	"go-service-template/domain"
+	"go-service-template/pubsub/eventspb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LocationEventToProto maps the data of a location event to its Protobuf message. The fields are mapped one by one so
+// a field removed from either side does not compile, Test_LocationEventProtoFields catches the ones added to one side.
+func LocationEventToProto(event domain.LocationEventV2) *eventspb.LocationEvent {
+	location := event.Location
+	contact := location.Information.ContactInformation
+
+	return &eventspb.LocationEvent{
+		Location: &eventspb.Location{
+			Id:   location.ID,
+			Name: location.Name,
+			Information: &eventspb.LocationInformation{
+				Address:   location.Information.Address,
+				City:      location.Information.City,
+				State:     location.Information.State,
+				Zipcode:   location.Information.Zipcode,
+				Latitude:  location.Information.Latitude,
+				Longitude: location.Information.Longitude,
+				ContactInformation: &eventspb.ContactInformation{
+					ContactPerson: contact.ContactPerson,
+					PhoneNumber:   contact.PhoneNumber,
+					Email:         contact.Email,
+				},
+			},
+			LocationType: &eventspb.LocationType{
+				Id:   int32(location.LocationType.ID), //nolint:gosec
+				Type: location.LocationType.Type,
+			},
+			Supplier: &eventspb.Supplier{
+				Id:   int32(location.Supplier.ID), //nolint:gosec
+				Name: location.Supplier.Name,
+			},
+			Active:    location.Active,
+			DeletedAt: timestampToProto(location.DeletedAt),
+			Version:   int32(location.Version), //nolint:gosec
+			CreatedAt: timestamppb.New(location.CreatedAt),
+		},
+		Actor: event.Actor,
+	}
+}
+
+// LocationEventFromProto maps a location event message back to the data of the event
+func LocationEventFromProto(event *eventspb.LocationEvent) domain.LocationEventV2 {
+	location := event.GetLocation()
+	information := location.GetInformation()
+
+	var contactInformation domain.ContactInformation
+	if contact := information.GetContactInformation(); contact != nil {
+		contactInformation = domain.ContactInformation{
+			ContactPerson: contact.ContactPerson,
+			PhoneNumber:   contact.PhoneNumber,
+			Email:         contact.Email,
+		}
+	}
+
+	return domain.LocationEventV2{
+		Location: domain.Location{
+			ID:   location.GetId(),
+			Name: location.GetName(),
+			Information: domain.LocationInformation{
+				Address:            information.GetAddress(),
+				City:               information.GetCity(),
+				State:              information.GetState(),
+				Zipcode:            information.GetZipcode(),
+				Latitude:           information.GetLatitude(),
+				Longitude:          information.GetLongitude(),
+				ContactInformation: contactInformation,
+			},
+			LocationType: domain.LocationType{
+				ID:   int(location.GetLocationType().GetId()),
+				Type: location.GetLocationType().GetType(),
+			},
+			Supplier: domain.Supplier{
+				ID:   int(location.GetSupplier().GetId()),
+				Name: location.GetSupplier().GetName(),
+			},
+			Active:    location.GetActive(),
+			DeletedAt: timestampFromProto(location.GetDeletedAt()),
+			Version:   int(location.GetVersion()),
+			CreatedAt: location.GetCreatedAt().AsTime(),
+		},
+		Actor: event.GetActor(),
+	}
+}
+
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func timestampFromProto(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	asTime := t.AsTime()
+
+	return &asTime
+}
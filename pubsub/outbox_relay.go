@@ -0,0 +1,224 @@
+package pubsub
+
+import (
+	"context"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+const (
+	DefaultOutboxPollIntervalMs         = 500
+	DefaultOutboxBatchSize              = 100
+	DefaultOutboxCleanupIntervalMinutes = 60
+	DefaultOutboxRetentionHours         = 72
+	DefaultOutboxMaxAttempts            = 20
+	DefaultOutboxRetryIntervalMs        = 1000
+	DefaultOutboxMaxRetryIntervalMs     = 300000
+	DefaultOutboxClaimTimeoutMs         = 60000
+)
+
+// OutboxRelay moves the messages stored in the outbox table to the message broker. Messages are published with
+// at-least-once semantics: a message is only marked as published after the broker acknowledged it, so a crash
+// between both steps produces a redelivery instead of a lost event. Only the oldest unpublished message of each
+// aggregate is relayed, so a message that keeps failing to publish is parked after the max attempts along with the
+// messages of its aggregate that follow it, until it is redriven or discarded.
+type OutboxRelay struct {
+	logger           monitor.AppLogger
+	dbFactory        repositories.DatabaseFactory
+	publisher        message.Publisher
+	batchSize        int
+	pollInterval     time.Duration
+	cleanupInterval  time.Duration
+	retention        time.Duration
+	maxAttempts      int
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+	claimTimeout     time.Duration
+}
+
+func NewOutboxRelay(dbFactory repositories.DatabaseFactory, publisher message.Publisher, cfg config.OutboxConfig) *OutboxRelay {
+	pollIntervalMs := config.GetIntValueOrDefault(cfg.PollIntervalMs, DefaultOutboxPollIntervalMs)
+	cleanupIntervalMinutes := config.GetIntValueOrDefault(cfg.CleanupIntervalMinutes, DefaultOutboxCleanupIntervalMinutes)
+	retentionHours := config.GetIntValueOrDefault(cfg.RetentionHours, DefaultOutboxRetentionHours)
+	retryIntervalMs := config.GetIntValueOrDefault(cfg.RetryIntervalMs, DefaultOutboxRetryIntervalMs)
+	maxRetryIntervalMs := config.GetIntValueOrDefault(cfg.MaxRetryIntervalMs, DefaultOutboxMaxRetryIntervalMs)
+	claimTimeoutMs := config.GetIntValueOrDefault(cfg.ClaimTimeoutMs, DefaultOutboxClaimTimeoutMs)
+
+	return &OutboxRelay{
+		logger:           monitor.GetStdLogger("OutboxRelay"),
+		dbFactory:        dbFactory,
+		publisher:        publisher,
+		batchSize:        config.GetIntValueOrDefault(cfg.BatchSize, DefaultOutboxBatchSize),
+		pollInterval:     time.Duration(pollIntervalMs) * time.Millisecond,
+		cleanupInterval:  time.Duration(cleanupIntervalMinutes) * time.Minute,
+		retention:        time.Duration(retentionHours) * time.Hour,
+		maxAttempts:      config.GetIntValueOrDefault(cfg.MaxAttempts, DefaultOutboxMaxAttempts),
+		retryInterval:    time.Duration(retryIntervalMs) * time.Millisecond,
+		maxRetryInterval: time.Duration(maxRetryIntervalMs) * time.Millisecond,
+		claimTimeout:     time.Duration(claimTimeoutMs) * time.Millisecond,
+	}
+}
+
+// Run polls the outbox until the context is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	fnName := "OutboxRelay.Run"
+
+	pollTicker := time.NewTicker(r.pollInterval)
+	defer pollTicker.Stop()
+
+	cleanupTicker := time.NewTicker(r.cleanupInterval)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info(fnName, "", "stopping outbox relay")
+			return
+		case <-pollTicker.C:
+			appCtx := monitor.CreateAppContextFromContext(ctx, "")
+			if _, err := r.RelayPendingMessages(appCtx); err != nil {
+				r.logger.ErrorCtx(appCtx, fnName, "failed to relay outbox messages", err)
+			}
+		case <-cleanupTicker.C:
+			appCtx := monitor.CreateAppContextFromContext(ctx, "")
+			if _, err := r.DeletePublishedMessages(appCtx); err != nil {
+				r.logger.ErrorCtx(appCtx, fnName, "failed to delete published outbox messages", err)
+			}
+		}
+	}
+}
+
+// RelayPendingMessages publishes one batch of pending messages and returns how many of them were published.
+// The batch is claimed in a short transaction and published after it is committed, so a slow broker does not keep a
+// transaction open. The messages still unpublished when the claim expires are left to the next iteration.
+func (r *OutboxRelay) RelayPendingMessages(ctx monitor.ApplicationContext) (int, error) {
+	fnName := "OutboxRelay.RelayPendingMessages"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	db, err := r.dbFactory.GetLocationsDB()
+	if err != nil {
+		return 0, err
+	}
+
+	var pendingMessages []domain.OutboxMessage
+	claimedUntil := time.Now().Add(r.claimTimeout)
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		// Another instance is claiming messages
+		acquired, txErr := db.AcquireOutboxLock(ctx)
+		if txErr != nil || !acquired {
+			return txErr
+		}
+
+		if pendingMessages, txErr = db.GetPendingOutboxMessages(ctx, r.batchSize); txErr != nil || len(pendingMessages) == 0 {
+			return txErr
+		}
+
+		return db.ClaimOutboxMessages(ctx, outboxMessageIDs(pendingMessages), claimedUntil)
+	}); err != nil || len(pendingMessages) == 0 {
+		return 0, err
+	}
+
+	publishedIDs := make([]string, 0, len(pendingMessages))
+	failures := make(map[string]error)
+
+	for _, pendingMsg := range pendingMessages {
+		if time.Now().After(claimedUntil) {
+			break
+		}
+
+		msgCtx := monitor.CreateAppContextFromContext(ctx, pendingMsg.Metadata[monitor.CorrelationIDField])
+		if pubErr := r.publisher.Publish(pendingMsg.Topic, CreateMessageFromOutbox(msgCtx, pendingMsg)); pubErr != nil {
+			failures[pendingMsg.ID] = pubErr
+			continue
+		}
+
+		publishedIDs = append(publishedIDs, pendingMsg.ID)
+	}
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		now := time.Now()
+		for _, pendingMsg := range pendingMessages {
+			pubErr, failed := failures[pendingMsg.ID]
+			if !failed {
+				continue
+			}
+
+			msgCtx := monitor.CreateAppContextFromContext(ctx, pendingMsg.Metadata[monitor.CorrelationIDField])
+			if txErr := r.recordFailure(msgCtx, db, pendingMsg, pubErr, now); txErr != nil {
+				return txErr
+			}
+		}
+
+		if len(publishedIDs) == 0 {
+			return nil
+		}
+
+		return db.MarkOutboxMessagesAsPublished(ctx, publishedIDs)
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(publishedIDs), nil
+}
+
+func outboxMessageIDs(messages []domain.OutboxMessage) []string {
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+
+	return ids
+}
+
+// recordFailure schedules the next attempt to publish the message, or parks it once it ran out of attempts
+func (r *OutboxRelay) recordFailure(
+	ctx monitor.ApplicationContext,
+	db repositories.OutboxDB,
+	msg domain.OutboxMessage,
+	pubErr error,
+	now time.Time,
+) error {
+	fnName := "OutboxRelay.recordFailure"
+
+	attempts := msg.Attempts + 1
+	failed := attempts >= r.maxAttempts
+	logParams := []monitor.LoggingParam{
+		{Name: "outbox_message_id", Value: msg.ID},
+		{Name: "topic", Value: msg.Topic},
+		{Name: "attempt", Value: attempts},
+	}
+
+	if failed {
+		r.logger.ErrorCtx(ctx, fnName, "parking outbox message after its last failed publish", pubErr, logParams...)
+	} else {
+		r.logger.ErrorCtx(ctx, fnName, "failed to publish outbox message", pubErr, logParams...)
+	}
+
+	interval := r.retryInterval
+	for i := 1; i < attempts && interval < r.maxRetryInterval; i++ {
+		interval *= 2
+	}
+
+	return db.RecordOutboxMessageFailure(ctx, msg.ID, pubErr.Error(), now.Add(min(interval, r.maxRetryInterval)), failed)
+}
+
+// DeletePublishedMessages removes the messages published before the retention window
+func (r *OutboxRelay) DeletePublishedMessages(ctx monitor.ApplicationContext) (int64, error) {
+	ctx, span := ctx.StartSpan("OutboxRelay.DeletePublishedMessages")
+	defer span.End()
+
+	db, err := r.dbFactory.GetLocationsDB()
+	if err != nil {
+		return 0, err
+	}
+
+	return db.DeletePublishedOutboxMessages(ctx, time.Now().Add(-r.retention))
+}
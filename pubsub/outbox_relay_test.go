@@ -0,0 +1,208 @@
+package pubsub_test
+
+import (
+	"errors"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/mocks"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"testing"
+	"time"
+)
+
+var testCtx = monitor.CreateMockAppContext("")
+
+type OutboxRelaySuite struct {
+	suite.Suite
+	dbFactoryMock   *mocks.DatabaseFactory
+	locationsDBMock *mocks.LocationsDB
+	publisherMock   *mocks.MockPublisher
+	relay           *pubsub.OutboxRelay
+}
+
+func (s *OutboxRelaySuite) SetupSuite() {
+	s.dbFactoryMock = new(mocks.DatabaseFactory)
+	s.locationsDBMock = new(mocks.LocationsDB)
+	s.publisherMock = new(mocks.MockPublisher)
+	s.relay = pubsub.NewOutboxRelay(s.dbFactoryMock, s.publisherMock, config.OutboxConfig{})
+}
+
+func (s *OutboxRelaySuite) SetupTest() {
+	s.dbFactoryMock.ExpectedCalls = nil
+	s.locationsDBMock.ExpectedCalls = nil
+	s.locationsDBMock.Calls = nil
+	s.publisherMock.ExpectedCalls = nil
+	s.publisherMock.Calls = nil
+}
+
+func (s *OutboxRelaySuite) assertAllExpectations() {
+	s.dbFactoryMock.AssertExpectations(s.T())
+	s.locationsDBMock.AssertExpectations(s.T())
+	s.publisherMock.AssertExpectations(s.T())
+}
+
+func TestOutboxRelaySuite(t *testing.T) {
+	suite.Run(t, new(OutboxRelaySuite))
+}
+
+func (s *OutboxRelaySuite) Test_RelayPendingMessages_PublishesAndMarksMessages() {
+	pendingMessages := []domain.OutboxMessage{
+		buildOutboxMessage("location1", domain.LocationsNewTopic),
+		buildOutboxMessage("location2", domain.LocationsUpdatedTopic),
+	}
+	pendingIDs := []string{pendingMessages[0].ID, pendingMessages[1].ID}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Twice()
+	s.locationsDBMock.On("CommitTx").Return(nil).Twice()
+	s.locationsDBMock.On("AcquireOutboxLock", mock.Anything).Return(true, nil).Once()
+	s.locationsDBMock.On("GetPendingOutboxMessages", mock.Anything, pubsub.DefaultOutboxBatchSize).Return(pendingMessages, nil).Once()
+	s.locationsDBMock.On("ClaimOutboxMessages", mock.Anything, pendingIDs, mock.Anything).Return(nil).Once()
+
+	s.publisherMock.On("Publish", domain.LocationsNewTopic, mock.Anything).Run(func(args mock.Arguments) {
+		msg := args.Get(1).(*message.Message)
+		assert.Equal(s.T(), pendingMessages[0].ID, msg.UUID)
+		assert.Equal(s.T(), "location1", msg.Metadata.Get(pubsub.MessageKey))
+		s.locationsDBMock.AssertNumberOfCalls(s.T(), "CommitTx", 1)
+	}).Return(nil).Once()
+	s.publisherMock.On("Publish", domain.LocationsUpdatedTopic, mock.Anything).Return(nil).Once()
+
+	s.locationsDBMock.On("MarkOutboxMessagesAsPublished", mock.Anything, pendingIDs).Return(nil).Once()
+
+	published, err := s.relay.RelayPendingMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, published)
+	s.assertAllExpectations()
+}
+
+func (s *OutboxRelaySuite) Test_RelayPendingMessages_RecordsFailureAndPublishesOtherAggregates() {
+	pendingMessages := []domain.OutboxMessage{
+		buildOutboxMessage("location1", domain.LocationsNewTopic),
+		buildOutboxMessage("location2", domain.LocationsUpdatedTopic),
+	}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Twice()
+	s.locationsDBMock.On("CommitTx").Return(nil).Twice()
+	s.locationsDBMock.On("AcquireOutboxLock", mock.Anything).Return(true, nil).Once()
+	s.locationsDBMock.On("GetPendingOutboxMessages", mock.Anything, pubsub.DefaultOutboxBatchSize).Return(pendingMessages, nil).Once()
+	s.locationsDBMock.On("ClaimOutboxMessages", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	s.publisherMock.On("Publish", domain.LocationsNewTopic, mock.Anything).Return(errors.New("broker unavailable")).Once()
+	s.publisherMock.On("Publish", domain.LocationsUpdatedTopic, mock.Anything).Return(nil).Once()
+
+	s.locationsDBMock.On("RecordOutboxMessageFailure", mock.Anything, pendingMessages[0].ID, "broker unavailable", mock.Anything, false).Return(nil).Once()
+	s.locationsDBMock.On("MarkOutboxMessagesAsPublished", mock.Anything, []string{pendingMessages[1].ID}).Return(nil).Once()
+
+	published, err := s.relay.RelayPendingMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, published)
+	s.assertAllExpectations()
+}
+
+func (s *OutboxRelaySuite) Test_RelayPendingMessages_LeavesMessagesWhenClaimExpires() {
+	relay := pubsub.NewOutboxRelay(s.dbFactoryMock, s.publisherMock, config.OutboxConfig{ClaimTimeoutMs: 10})
+	pendingMessages := []domain.OutboxMessage{
+		buildOutboxMessage("location1", domain.LocationsNewTopic),
+		buildOutboxMessage("location2", domain.LocationsUpdatedTopic),
+	}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Twice()
+	s.locationsDBMock.On("CommitTx").Return(nil).Twice()
+	s.locationsDBMock.On("AcquireOutboxLock", mock.Anything).Return(true, nil).Once()
+	s.locationsDBMock.On("GetPendingOutboxMessages", mock.Anything, pubsub.DefaultOutboxBatchSize).Return(pendingMessages, nil).Once()
+	s.locationsDBMock.On("ClaimOutboxMessages", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	s.publisherMock.On("Publish", domain.LocationsNewTopic, mock.Anything).Run(func(mock.Arguments) {
+		time.Sleep(20 * time.Millisecond)
+	}).Return(nil).Once()
+
+	s.locationsDBMock.On("MarkOutboxMessagesAsPublished", mock.Anything, []string{pendingMessages[0].ID}).Return(nil).Once()
+
+	published, err := relay.RelayPendingMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, published)
+	s.publisherMock.AssertNotCalled(s.T(), "Publish", domain.LocationsUpdatedTopic, mock.Anything)
+	s.assertAllExpectations()
+}
+
+func (s *OutboxRelaySuite) Test_RelayPendingMessages_ParksMessageAfterMaxAttempts() {
+	failingMsg := buildOutboxMessage("location1", "unknown-topic")
+	failingMsg.Attempts = pubsub.DefaultOutboxMaxAttempts - 1
+	pendingMessages := []domain.OutboxMessage{failingMsg}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Twice()
+	s.locationsDBMock.On("CommitTx").Return(nil).Twice()
+	s.locationsDBMock.On("AcquireOutboxLock", mock.Anything).Return(true, nil).Once()
+	s.locationsDBMock.On("GetPendingOutboxMessages", mock.Anything, pubsub.DefaultOutboxBatchSize).Return(pendingMessages, nil).Once()
+	s.locationsDBMock.On("ClaimOutboxMessages", mock.Anything, []string{failingMsg.ID}, mock.Anything).Return(nil).Once()
+
+	s.publisherMock.On("Publish", "unknown-topic", mock.Anything).Return(errors.New("unknown topic")).Once()
+
+	s.locationsDBMock.On("RecordOutboxMessageFailure", mock.Anything, failingMsg.ID, "unknown topic", mock.Anything, true).Return(nil).Once()
+
+	published, err := s.relay.RelayPendingMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 0, published)
+	s.assertAllExpectations()
+}
+
+func (s *OutboxRelaySuite) Test_RelayPendingMessages_DoesNothingWithoutPendingMessages() {
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("AcquireOutboxLock", mock.Anything).Return(true, nil).Once()
+	s.locationsDBMock.On("GetPendingOutboxMessages", mock.Anything, pubsub.DefaultOutboxBatchSize).Return([]domain.OutboxMessage{}, nil).Once()
+
+	published, err := s.relay.RelayPendingMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 0, published)
+	s.assertAllExpectations()
+}
+
+func (s *OutboxRelaySuite) Test_RelayPendingMessages_DoesNothingIfLockIsHeldByAnotherInstance() {
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("AcquireOutboxLock", mock.Anything).Return(false, nil).Once()
+
+	published, err := s.relay.RelayPendingMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 0, published)
+	s.assertAllExpectations()
+}
+
+func (s *OutboxRelaySuite) Test_DeletePublishedMessages_Success() {
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("DeletePublishedOutboxMessages", mock.Anything, mock.Anything).Return(int64(5), nil).Once()
+
+	deleted, err := s.relay.DeletePublishedMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(5), deleted)
+	s.assertAllExpectations()
+}
+
+func buildOutboxMessage(aggregateID, topic string) domain.OutboxMessage {
+	return domain.OutboxMessage{
+		ID:          uuid.New().String(),
+		AggregateID: aggregateID,
+		Topic:       topic,
+		Payload:     []byte(`{}`),
+		Metadata:    map[string]string{pubsub.MessageKey: aggregateID},
+	}
+}
@@ -0,0 +1,237 @@
+package pubsub
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Shopify/sarama"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	protofiles "go-service-template/proto"
+	"go-service-template/pubsub/eventspb"
+
+	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+)
+
+// Formats of the data of the topics
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf"
+)
+
+const ProtobufContentType = "application/x-protobuf"
+
+// wireFormatMagicByte starts the data written in the wire format of the Confluent schema registry
+const wireFormatMagicByte = 0
+
+var (
+	ErrInvalidTopicFormat = errors.New("invalid topic format, accepted values: '" + FormatJSON + "' or '" + FormatProtobuf + "'")
+	ErrNoProtobufMessage  = errors.New("the topic has no Protobuf message")
+	ErrInvalidWireFormat  = errors.New("the data is not in the wire format of the schema registry")
+)
+
+// protobufTopics are the topics whose data can be sent as Protobuf
+var protobufTopics = map[string]protobufTopic{
+	domain.LocationsNewTopic:      locationEventTopic,
+	domain.LocationsUpdatedTopic:  locationEventTopic,
+	domain.LocationsDeletedTopic:  locationEventTopic,
+	domain.LocationsRestoredTopic: locationEventTopic,
+}
+
+var locationEventTopic = newProtobufTopic(&eventspb.LocationEvent{}, LocationEventToProto, LocationEventFromProto)
+
+// protobufTopic converts the JSON data of a topic to its Protobuf message and back. The data is read into its domain
+// type and mapped field by field, so the message and the type cannot drift apart silently.
+type protobufTopic struct {
+	message proto.Message
+	toProto func(payload []byte) (proto.Message, error)
+	toJSON  func(msg proto.Message) ([]byte, error)
+}
+
+func newProtobufTopic[T any, M proto.Message](message M, toProto func(T) M, fromProto func(M) T) protobufTopic {
+	return protobufTopic{
+		message: message,
+		toProto: func(payload []byte) (proto.Message, error) {
+			var data T
+			decoder := json.NewDecoder(bytes.NewReader(payload))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&data); err != nil {
+				return nil, err
+			}
+
+			return toProto(data), nil
+		},
+		toJSON: func(msg proto.Message) ([]byte, error) {
+			return json.Marshal(fromProto(msg.(M)))
+		},
+	}
+}
+
+// protobufMarshaler sends the JSON data of the Protobuf topics as Protobuf, in the wire format of the schema registry,
+// and turns the Protobuf data consumed back to JSON, so the handlers read every topic the same way
+type protobufMarshaler struct {
+	kafka.MarshalerUnmarshaler
+	schemaIDs map[string]int
+}
+
+// NewProtobufMarshaler wraps the marshaler so the data of the topics set to the Protobuf format is sent as Protobuf.
+// The schemas of the topics are registered in the registry, an error is returned when one of them is incompatible
+// with the version registered, so the service does not start publishing data its consumers cannot read.
+func NewProtobufMarshaler(
+	ctx monitor.ApplicationContext,
+	marshaler kafka.MarshalerUnmarshaler,
+	kafkaParams config.KafkaConfig,
+	registry SchemaRegistry,
+) (kafka.MarshalerUnmarshaler, error) {
+	schemaIDs := map[string]int{}
+	for _, topicFormat := range kafkaParams.TopicFormats {
+		switch topicFormat.Format {
+		case FormatJSON:
+			continue
+		case FormatProtobuf:
+		default:
+			return nil, fmt.Errorf("%w: topic %s", ErrInvalidTopicFormat, topicFormat.Topic)
+		}
+
+		protobufTopic, ok := protobufTopics[topicFormat.Topic]
+		if !ok {
+			return nil, fmt.Errorf("%w: topic %s", ErrNoProtobufMessage, topicFormat.Topic)
+		}
+
+		schema, err := ProtobufSchema(protobufTopic.message)
+		if err != nil {
+			return nil, err
+		}
+
+		if schemaIDs[topicFormat.Topic], err = registry.Register(ctx, TopicSubject(topicFormat.Topic), schema); err != nil {
+			return nil, err
+		}
+	}
+
+	return protobufMarshaler{MarshalerUnmarshaler: marshaler, schemaIDs: schemaIDs}, nil
+}
+
+// NewProtobufUnmarshaler wraps the unmarshaler so the Protobuf data consumed is turned to JSON
+func NewProtobufUnmarshaler(unmarshaler kafka.Unmarshaler) kafka.Unmarshaler {
+	return protobufUnmarshaler{Unmarshaler: unmarshaler}
+}
+
+// ProtobufSchema returns the schema of the file of the message, as the schema registry expects it
+func ProtobufSchema(msg proto.Message) (Schema, error) {
+	file := msg.ProtoReflect().Descriptor().ParentFile()
+
+	schema, err := protofiles.Files.ReadFile(file.Path())
+	if err != nil {
+		return Schema{}, fmt.Errorf("error reading schema of %s: %w", file.Path(), err)
+	}
+
+	descriptor, err := proto.Marshal(protodesc.ToFileDescriptorProto(file))
+	if err != nil {
+		return Schema{}, fmt.Errorf("error marshaling descriptor of %s: %w", file.Path(), err)
+	}
+
+	return Schema{Type: SchemaTypeProtobuf, Schema: string(schema), Descriptor: descriptor}, nil
+}
+
+func (m protobufMarshaler) Marshal(topic string, msg *message.Message) (*sarama.ProducerMessage, error) {
+	schemaID, ok := m.schemaIDs[topic]
+	if !ok || msg.Metadata.Get(ContentTypeHeader) != JSONContentType {
+		return m.MarshalerUnmarshaler.Marshal(topic, msg)
+	}
+
+	data, err := protobufTopics[topic].toProto(msg.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error converting data of topic %s to Protobuf: %w", topic, err)
+	}
+
+	payload, err := encodeWireFormat(schemaID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	protobufMsg := message.NewMessage(msg.UUID, payload)
+	protobufMsg.SetContext(msg.Context())
+	for key, value := range msg.Metadata {
+		protobufMsg.Metadata.Set(key, value)
+	}
+	protobufMsg.Metadata.Set(ContentTypeHeader, ProtobufContentType)
+
+	return m.MarshalerUnmarshaler.Marshal(topic, protobufMsg)
+}
+
+func (m protobufMarshaler) Unmarshal(kafkaMsg *sarama.ConsumerMessage) (*message.Message, error) {
+	return protobufUnmarshaler{Unmarshaler: m.MarshalerUnmarshaler}.Unmarshal(kafkaMsg)
+}
+
+type protobufUnmarshaler struct {
+	kafka.Unmarshaler
+}
+
+func (u protobufUnmarshaler) Unmarshal(kafkaMsg *sarama.ConsumerMessage) (*message.Message, error) {
+	msg, err := u.Unmarshaler.Unmarshal(kafkaMsg)
+	if err != nil || msg.Metadata.Get(ContentTypeHeader) != ProtobufContentType {
+		return msg, err
+	}
+
+	protobufTopic, ok := protobufTopics[kafkaMsg.Topic]
+	if !ok {
+		return nil, fmt.Errorf("%w: topic %s", ErrNoProtobufMessage, kafkaMsg.Topic)
+	}
+
+	data := protobufTopic.message.ProtoReflect().New().Interface()
+	if err = decodeWireFormat(msg.Payload, data); err != nil {
+		return nil, fmt.Errorf("error reading Protobuf data of topic %s: %w", kafkaMsg.Topic, err)
+	}
+
+	if msg.Payload, err = protobufTopic.toJSON(data); err != nil {
+		return nil, fmt.Errorf("error converting Protobuf data of topic %s to JSON: %w", kafkaMsg.Topic, err)
+	}
+	msg.Metadata.Set(ContentTypeHeader, JSONContentType)
+
+	return msg, nil
+}
+
+// encodeWireFormat writes the message after the magic byte, the schema ID and the indexes of the message in its file.
+// The messages sent are the first one of their file, written as a single 0.
+func encodeWireFormat(schemaID int, msg proto.Message) ([]byte, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 6, 6+len(data))
+	payload[0] = wireFormatMagicByte
+	binary.BigEndian.PutUint32(payload[1:5], uint32(schemaID)) //nolint:gosec
+	payload[5] = 0
+
+	return append(payload, data...), nil
+}
+
+// decodeWireFormat reads the message of the data written in the wire format. The schema ID is not checked, the
+// compatibility of the schemas registered guarantees the message can read the data of the previous versions.
+func decodeWireFormat(payload []byte, msg proto.Message) error {
+	if len(payload) < 6 || payload[0] != wireFormatMagicByte {
+		return ErrInvalidWireFormat
+	}
+
+	data := payload[5:]
+	indexCount, n := binary.Varint(data)
+	if n <= 0 || indexCount < 0 {
+		return ErrInvalidWireFormat
+	}
+	data = data[n:]
+	for range indexCount {
+		if _, n = binary.Varint(data); n <= 0 {
+			return ErrInvalidWireFormat
+		}
+		data = data[n:]
+	}
+
+	return proto.Unmarshal(data, msg)
+}
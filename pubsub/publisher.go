@@ -1,9 +1,11 @@
 package pubsub
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/Shopify/sarama"
 	"go-service-template/config"
+	"go-service-template/domain"
 	"go-service-template/monitor"
 
 	"github.com/ThreeDotsLabs/watermill"
@@ -13,15 +15,27 @@ import (
 
 const MessageKey = "message_key"
 
-func CreatePublisher(kafkaCfg *sarama.Config, kafkaParams config.KafkaConfig) (message.Publisher, error) {
+// CreatePublisher creates the publisher of the topics, the schemas of the Protobuf topics are registered in the
+// registry first and an error is returned when one of them is incompatible with the version registered
+func CreatePublisher(kafkaCfg *sarama.Config, kafkaParams config.KafkaConfig, schemaRegistry SchemaRegistry) (message.Publisher, error) {
 	if len(kafkaParams.Brokers) == 0 {
 		return nil, ErrBrokerSliceEmpty
 	}
 
+	marshaler, err := NewCloudEventsMarshaler(kafka.NewWithPartitioningMarshaler(GetMessageKeyFromMessage), kafkaParams.CloudEventsMode)
+	if err != nil {
+		return nil, err
+	}
+
+	marshaler, err = NewProtobufMarshaler(monitor.CreateAppContextFromContext(context.Background(), ""), marshaler, kafkaParams, schemaRegistry)
+	if err != nil {
+		return nil, err
+	}
+
 	return kafka.NewPublisher(
 		kafka.PublisherConfig{
 			Brokers:               kafkaParams.Brokers,
-			Marshaler:             kafka.NewWithPartitioningMarshaler(GetMessageKeyFromMessage),
+			Marshaler:             marshaler,
 			OverwriteSaramaConfig: kafkaCfg,
 			OTELEnabled:           true,
 		},
@@ -43,6 +57,46 @@ func CreateJSONMessage(ctx monitor.ApplicationContext, key string, payload any)
 	return msg, nil
 }
 
+// CreateJSONOutboxMessage builds the same message as CreateJSONMessage, but as an outbox row that will be relayed
+// to the given topic once the transaction that stores it commits
+func CreateJSONOutboxMessage(ctx monitor.ApplicationContext, topic, key string, payload any) (domain.OutboxMessage, error) {
+	msg, err := CreateJSONMessage(ctx, key, payload)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+
+	return createOutboxMessage(topic, key, msg), nil
+}
+
+func createOutboxMessage(topic, key string, msg *message.Message) domain.OutboxMessage {
+	return domain.OutboxMessage{
+		ID:          msg.UUID,
+		AggregateID: key,
+		Topic:       topic,
+		Payload:     msg.Payload,
+		Metadata:    msg.Metadata,
+	}
+}
+
+// CreateMessageFromOutbox rebuilds the broker message stored in an outbox row. The message UUID is kept so consumers
+// can deduplicate the redeliveries that at-least-once delivery can produce
+func CreateMessageFromOutbox(ctx monitor.ApplicationContext, outboxMsg domain.OutboxMessage) *message.Message {
+	// Redriven messages keep the UUID of the message that failed, the outbox row has its own ID
+	uuid := outboxMsg.ID
+	if redrivenUUID := outboxMsg.Metadata[RedrivenMessageUUIDMetadataKey]; redrivenUUID != "" {
+		uuid = redrivenUUID
+	}
+
+	msg := message.NewMessage(uuid, outboxMsg.Payload)
+	msg.SetContext(ctx)
+
+	for key, value := range outboxMsg.Metadata {
+		msg.Metadata.Set(key, value)
+	}
+
+	return msg
+}
+
 func GetMessageKeyFromMessage(_ string, msg *message.Message) (string, error) {
 	return msg.Metadata.Get(MessageKey), nil
 }
@@ -0,0 +1,70 @@
+package pubsub
+
+import (
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/config"
+	"go-service-template/monitor"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultHandlerMaxRetries        = 3
+	DefaultHandlerInitialIntervalMs = 500
+	DefaultHandlerMaxIntervalMs     = 10000
+
+	// AttemptsMetadataKey is set on a message the handler failed to process, with the number of attempts it made
+	AttemptsMetadataKey = "handler_attempts"
+)
+
+// RetryMiddleware runs the handler again when it fails, waiting twice as long after each failure up to the max
+// interval. The last error is returned once the retries run out or the message context is done.
+func RetryMiddleware(cfg config.HandlerConfig) message.HandlerMiddleware {
+	maxRetries := config.GetIntValueOrDefault(cfg.MaxRetries, DefaultHandlerMaxRetries)
+	initialIntervalMs := config.GetIntValueOrDefault(cfg.InitialIntervalMs, DefaultHandlerInitialIntervalMs)
+	maxIntervalMs := config.GetIntValueOrDefault(cfg.MaxIntervalMs, DefaultHandlerMaxIntervalMs)
+
+	initialInterval := time.Duration(initialIntervalMs) * time.Millisecond
+	maxInterval := time.Duration(maxIntervalMs) * time.Millisecond
+	logger := monitor.GetStdLogger("RetryMiddleware")
+
+	return func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			fnName := "RetryMiddleware"
+
+			interval := initialInterval
+			for attempt := 1; ; attempt++ {
+				events, err := h(msg)
+				if err == nil {
+					return events, nil
+				}
+
+				appCtx := monitor.CreateAppContextFromContext(msg.Context(), msg.Metadata.Get(monitor.CorrelationIDField))
+				logger.WarnCtx(appCtx, fnName, "event handler failed",
+					monitor.LoggingParam{Name: "message_id", Value: msg.UUID},
+					monitor.LoggingParam{Name: "attempt", Value: attempt},
+					monitor.LoggingParam{Name: "error", Value: err.Error()},
+				)
+
+				if attempt > maxRetries || !waitForRetry(msg, interval) {
+					msg.Metadata.Set(AttemptsMetadataKey, strconv.Itoa(attempt))
+					return nil, err
+				}
+				interval = min(interval*2, maxInterval)
+			}
+		}
+	}
+}
+
+// waitForRetry waits for the interval, it returns false when the message context is done first
+func waitForRetry(msg *message.Message, interval time.Duration) bool {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-msg.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -3,6 +3,7 @@ package pubsub
 import (
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
+	"go-service-template/config"
 )
 
 type EventHandler interface {
@@ -10,10 +11,25 @@ type EventHandler interface {
 	GetData() (name, topic string)
 }
 
+// SubscriberEventHandler is an EventHandler that consumes its topic with its own subscriber instead of the one of the
+// router
+type SubscriberEventHandler interface {
+	EventHandler
+	GetSubscriber() message.Subscriber
+}
+
+// CreateRouter registers the handlers with the retry and dead letter middlewares configured for them in the Kafka
+// config: a message that still fails after the retries is sent to the poison topic of its handler instead of being
+// redelivered forever, and a redriven message is only processed by the handler that failed it. The dead letter
+// handlers consume the poison topics, they are only retried since a failure to store a dead letter must not produce
+// another one.
 func CreateRouter(
 	middleware []message.HandlerMiddleware,
 	handlers []EventHandler,
+	deadLetterHandlers []EventHandler,
 	subscriber message.Subscriber,
+	publisher message.Publisher,
+	kafkaParams config.KafkaConfig,
 ) (*message.Router, error) {
 	logger := watermill.NewStdLogger(false, false)
 
@@ -23,20 +39,53 @@ func CreateRouter(
 		return nil, err
 	}
 
-	// Apply middleware
-	router.AddMiddleware(middleware...)
-
 	// Register event handlers
 	for _, handler := range handlers {
 		handlerName, topic := handler.GetData()
 
-		router.AddNoPublisherHandler(
-			handlerName,
-			topic,
-			subscriber,
-			handler.Process,
+		addHandler(router, handler, subscriber).AddMiddleware(
+			RedriveFilterMiddleware(handlerName),
+			DeadLetterMiddleware(publisher, handlerName, topic, PoisonTopic(kafkaParams, handlerName, topic)),
+			RetryMiddleware(kafkaParams.GetHandlerConfig(handlerName)),
 		)
 	}
 
+	for _, handler := range deadLetterHandlers {
+		handlerName, _ := handler.GetData()
+
+		addHandler(router, handler, subscriber).AddMiddleware(RetryMiddleware(kafkaParams.GetHandlerConfig(handlerName)))
+	}
+
+	// Middlewares run in the order they are added, the ones of the router are added last so they run inside the
+	// retries, e.g. a recovered panic counts as a failed attempt
+	router.AddMiddleware(middleware...)
+
 	return router, nil
 }
+
+// GetPoisonTopics returns the poison topic of each handler keyed by the handler name
+func GetPoisonTopics(handlers []EventHandler, kafkaParams config.KafkaConfig) map[string]string {
+	poisonTopics := make(map[string]string, len(handlers))
+	for _, handler := range handlers {
+		handlerName, topic := handler.GetData()
+		poisonTopics[handlerName] = PoisonTopic(kafkaParams, handlerName, topic)
+	}
+
+	return poisonTopics
+}
+
+func addHandler(router *message.Router, handler EventHandler, subscriber message.Subscriber) *message.Handler {
+	handlerName, topic := handler.GetData()
+
+	handlerSubscriber := subscriber
+	if subscriberHandler, ok := handler.(SubscriberEventHandler); ok {
+		handlerSubscriber = subscriberHandler.GetSubscriber()
+	}
+
+	return router.AddNoPublisherHandler(
+		handlerName,
+		topic,
+		handlerSubscriber,
+		handler.Process,
+	)
+}
@@ -0,0 +1,115 @@
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"go-service-template/config"
+	customHTTP "go-service-template/http"
+	"go-service-template/monitor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const SchemaTypeProtobuf = "PROTOBUF"
+
+var (
+	ErrIncompatibleSchema = errors.New("the schema is incompatible with the latest version of its subject")
+	ErrInvalidSchema      = errors.New("invalid schema")
+)
+
+// Schema is a schema as the Confluent schema registry API sends it
+type Schema struct {
+	Type   string `json:"schemaType"`
+	Schema string `json:"schema"`
+	// Descriptor is the serialized FileDescriptorProto of a Protobuf schema. It is only kept by the file registry, which
+	// checks the compatibility of the versions with it instead of parsing the schema.
+	Descriptor []byte `json:"-"`
+}
+
+// SchemaRegistry registers the schemas of the topics, compatible with the Confluent schema registry
+type SchemaRegistry interface {
+	// Register returns the ID of the schema in the subject, it is registered when it is not yet. ErrIncompatibleSchema
+	// is returned when it breaks the compatibility of the subject.
+	Register(ctx monitor.ApplicationContext, subject string, schema Schema) (int, error)
+}
+
+// NewSchemaRegistry returns a client of the registry at the URL of the config, or a file registry when it is not set
+func NewSchemaRegistry(cfg config.SchemaRegistryConfig, httpClient customHTTP.CustomHTTPClient) SchemaRegistry {
+	if cfg.URL == "" {
+		return NewFileSchemaRegistry(cfg.FilePath)
+	}
+
+	return NewConfluentSchemaRegistry(cfg, httpClient)
+}
+
+// TopicSubject is the subject of the schema of the data of the topic, following the TopicNameStrategy
+func TopicSubject(topic string) string {
+	return topic + "-value"
+}
+
+// CheckProtobufCompatibility checks that the data written with the previous Protobuf schema can be read with the next
+// one: the messages of the previous schema must still exist, and the fields kept must keep their type and label.
+// Fields can be added and removed, removed field numbers must not be reused for another type.
+func CheckProtobufCompatibility(previous, next []byte) error {
+	previousMessages, err := protobufMessages(previous)
+	if err != nil {
+		return err
+	}
+	nextMessages, err := protobufMessages(next)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for name, previousMessage := range previousMessages {
+		nextMessage, ok := nextMessages[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("message %s was removed", name))
+			continue
+		}
+
+		nextFields := make(map[int32]*descriptorpb.FieldDescriptorProto, len(nextMessage.GetField()))
+		for _, field := range nextMessage.GetField() {
+			nextFields[field.GetNumber()] = field
+		}
+
+		for _, previousField := range previousMessage.GetField() {
+			nextField, ok := nextFields[previousField.GetNumber()]
+			if !ok {
+				continue
+			}
+			if previousField.GetType() != nextField.GetType() ||
+				previousField.GetTypeName() != nextField.GetTypeName() ||
+				(previousField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) != (nextField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) {
+				errs = append(errs, fmt.Errorf("field %v of message %s changed its type", previousField.GetNumber(), name))
+			}
+		}
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: %w", ErrIncompatibleSchema, err)
+	}
+
+	return nil
+}
+
+// protobufMessages returns the messages of the serialized FileDescriptorProto, nested ones included, by full name
+func protobufMessages(descriptor []byte) (map[string]*descriptorpb.DescriptorProto, error) {
+	var file descriptorpb.FileDescriptorProto
+	if err := proto.Unmarshal(descriptor, &file); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	messages := map[string]*descriptorpb.DescriptorProto{}
+	var addMessages func(prefix string, descriptors []*descriptorpb.DescriptorProto)
+	addMessages = func(prefix string, descriptors []*descriptorpb.DescriptorProto) {
+		for _, message := range descriptors {
+			name := prefix + "." + message.GetName()
+			messages[name] = message
+			addMessages(name, message.GetNestedType())
+		}
+	}
+	addMessages(file.GetPackage(), file.GetMessageType())
+
+	return messages, nil
+}
@@ -0,0 +1,236 @@
+package pubsub_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"github.com/Shopify/sarama"
+	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	customHTTP "go-service-template/http"
+	"go-service-template/mocks"
+	"go-service-template/pubsub"
+	"go-service-template/pubsub/eventspb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var protobufKafkaConfig = config.KafkaConfig{
+	TopicFormats: []config.TopicFormat{{Topic: domain.LocationsNewTopic, Format: pubsub.FormatProtobuf}},
+}
+
+type SchemaRegistrySuite struct {
+	suite.Suite
+	registry *pubsub.FileSchemaRegistry
+	schema   pubsub.Schema
+}
+
+func TestSchemaRegistrySuite(t *testing.T) {
+	suite.Run(t, new(SchemaRegistrySuite))
+}
+
+func (s *SchemaRegistrySuite) SetupTest() {
+	var err error
+	s.registry = pubsub.NewFileSchemaRegistry(filepath.Join(s.T().TempDir(), "registry", "schemas.json"))
+	s.schema, err = pubsub.ProtobufSchema(&eventspb.LocationEvent{})
+	s.Require().NoError(err)
+}
+
+func (s *SchemaRegistrySuite) Test_FileSchemaRegistry_ReturnsSameIDForSameSchema() {
+	subject := pubsub.TopicSubject(domain.LocationsNewTopic)
+
+	id, err := s.registry.Register(testCtx, subject, s.schema)
+	s.Require().NoError(err)
+	sameID, err := s.registry.Register(testCtx, subject, s.schema)
+	s.Require().NoError(err)
+	otherID, err := s.registry.Register(testCtx, pubsub.TopicSubject(domain.LocationsUpdatedTopic), s.schema)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, id)
+	assert.Equal(s.T(), id, sameID)
+	assert.Equal(s.T(), 2, otherID)
+}
+
+func (s *SchemaRegistrySuite) Test_FileSchemaRegistry_AcceptsAddedFields() {
+	subject := pubsub.TopicSubject(domain.LocationsNewTopic)
+	_, err := s.registry.Register(testCtx, subject, s.schema)
+	s.Require().NoError(err)
+
+	id, err := s.registry.Register(testCtx, subject, s.changeLocationField("source", 10, descriptorpb.FieldDescriptorProto_TYPE_STRING))
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, id)
+}
+
+func (s *SchemaRegistrySuite) Test_FileSchemaRegistry_RejectsIncompatibleSchema() {
+	subject := pubsub.TopicSubject(domain.LocationsNewTopic)
+	_, err := s.registry.Register(testCtx, subject, s.schema)
+	s.Require().NoError(err)
+
+	_, err = s.registry.Register(testCtx, subject, s.changeLocationField("name", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32))
+
+	assert.ErrorIs(s.T(), err, pubsub.ErrIncompatibleSchema)
+}
+
+func (s *SchemaRegistrySuite) Test_ConfluentSchemaRegistry_Register() {
+	httpClient := mocks.NewCustomHTTPClient(s.T())
+	registry := pubsub.NewConfluentSchemaRegistry(config.SchemaRegistryConfig{URL: "http://registry/", Username: "user", Password: "secret"}, httpClient)
+	httpClient.On("Do", mock.Anything, mock.MatchedBy(func(request customHTTP.RequestValues) bool {
+		return request.URL == "http://registry/subjects/go-service-template.locations.new-value/versions" &&
+			request.Method == http.MethodPost && request.BasicAuth.Username == "user"
+	})).Return(customHTTP.CustomHTTPResponse{StatusCode: http.StatusOK, BodyPayload: []byte(`{"id":7}`)}, nil)
+
+	id, err := registry.Register(testCtx, pubsub.TopicSubject(domain.LocationsNewTopic), s.schema)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 7, id)
+}
+
+func (s *SchemaRegistrySuite) Test_ConfluentSchemaRegistry_Register_Incompatible() {
+	httpClient := mocks.NewCustomHTTPClient(s.T())
+	registry := pubsub.NewConfluentSchemaRegistry(config.SchemaRegistryConfig{URL: "http://registry"}, httpClient)
+	httpClient.On("Do", mock.Anything, mock.Anything).Return(customHTTP.CustomHTTPResponse{
+		StatusCode:  http.StatusConflict,
+		BodyPayload: []byte(`{"error_code":409,"message":"Schema being registered is incompatible with an earlier schema"}`),
+	}, nil)
+
+	_, err := registry.Register(testCtx, pubsub.TopicSubject(domain.LocationsNewTopic), s.schema)
+
+	assert.ErrorIs(s.T(), err, pubsub.ErrIncompatibleSchema)
+	assert.ErrorContains(s.T(), err, "incompatible with an earlier schema")
+}
+
+func (s *SchemaRegistrySuite) Test_ProtobufMarshaler_RoundTrip() {
+	marshaler, err := pubsub.NewProtobufMarshaler(testCtx, kafka.NewWithPartitioningMarshaler(pubsub.GetMessageKeyFromMessage), protobufKafkaConfig, s.registry)
+	s.Require().NoError(err)
+	locationEvent := testLocationEvent
+	locationEvent.Location.CreatedAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	msg, err := pubsub.CreateCloudEventMessage(testCtx, "location1", domain.LocationCreatedEventType, 2, locationEvent)
+	s.Require().NoError(err)
+
+	kafkaMsg, err := marshaler.Marshal(domain.LocationsNewTopic, msg)
+	s.Require().NoError(err)
+	value := []byte(kafkaMsg.Value.(sarama.ByteEncoder))
+
+	assert.Equal(s.T(), pubsub.ProtobufContentType, kafkaHeader(kafkaMsg, pubsub.ContentTypeHeader))
+	assert.Equal(s.T(), domain.LocationCreatedEventType, kafkaHeader(kafkaMsg, "ce_type"))
+	assert.Equal(s.T(), byte(0), value[0])
+	assert.Equal(s.T(), uint32(1), binary.BigEndian.Uint32(value[1:5]))
+
+	consumedMsg, err := pubsub.NewProtobufUnmarshaler(kafka.DefaultMarshaler{}).Unmarshal(&sarama.ConsumerMessage{
+		Topic:   domain.LocationsNewTopic,
+		Headers: consumerHeaders(kafkaMsg),
+		Value:   value,
+	})
+	s.Require().NoError(err)
+	event, err := pubsub.ReadCloudEvent(consumedMsg)
+	s.Require().NoError(err)
+	var consumedEvent domain.LocationEventV2
+
+	assert.Equal(s.T(), pubsub.JSONContentType, consumedMsg.Metadata.Get(pubsub.ContentTypeHeader))
+	s.Require().NoError(json.Unmarshal(event.Data, &consumedEvent))
+	assert.Equal(s.T(), locationEvent.Actor, consumedEvent.Actor)
+	assert.Equal(s.T(), locationEvent.Location.ID, consumedEvent.Location.ID)
+	assert.True(s.T(), locationEvent.Location.CreatedAt.Equal(consumedEvent.Location.CreatedAt))
+}
+
+func (s *SchemaRegistrySuite) Test_ProtobufMarshaler_RejectsUnknownFields() {
+	marshaler, err := pubsub.NewProtobufMarshaler(testCtx, kafka.DefaultMarshaler{}, protobufKafkaConfig, s.registry)
+	s.Require().NoError(err)
+	msg, err := pubsub.CreateCloudEventMessage(testCtx, "location1", domain.LocationCreatedEventType, 2, map[string]any{"unknown": true})
+	s.Require().NoError(err)
+
+	_, err = marshaler.Marshal(domain.LocationsNewTopic, msg)
+
+	assert.NotNil(s.T(), err)
+}
+
+func (s *SchemaRegistrySuite) Test_NewProtobufMarshaler_RejectsIncompatibleSchema() {
+	_, err := s.registry.Register(testCtx, pubsub.TopicSubject(domain.LocationsNewTopic), s.changeLocationField("name", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32))
+	s.Require().NoError(err)
+
+	_, err = pubsub.NewProtobufMarshaler(testCtx, kafka.DefaultMarshaler{}, protobufKafkaConfig, s.registry)
+
+	assert.ErrorIs(s.T(), err, pubsub.ErrIncompatibleSchema)
+}
+
+func (s *SchemaRegistrySuite) Test_NewProtobufMarshaler_RejectsTopicWithoutMessage() {
+	kafkaCfg := config.KafkaConfig{TopicFormats: []config.TopicFormat{{Topic: domain.SubLocationsNewTopic, Format: pubsub.FormatProtobuf}}}
+
+	_, err := pubsub.NewProtobufMarshaler(testCtx, kafka.DefaultMarshaler{}, kafkaCfg, s.registry)
+
+	assert.ErrorIs(s.T(), err, pubsub.ErrNoProtobufMessage)
+}
+
+func (s *SchemaRegistrySuite) Test_LocationEventProtoFields() {
+	s.assertProtoFields(reflect.TypeOf(domain.LocationEventV2{}), (&eventspb.LocationEvent{}).ProtoReflect().Descriptor())
+}
+
+// assertProtoFields checks that the JSON fields of the struct are the fields of the Protobuf message, down through the
+// nested messages
+func (s *SchemaRegistrySuite) assertProtoFields(structType reflect.Type, descriptor protoreflect.MessageDescriptor) {
+	var jsonFields, protoFields []string
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		jsonFields = append(jsonFields, name)
+
+		protoField := descriptor.Fields().ByName(protoreflect.Name(name))
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if protoField != nil && protoField.Message() != nil && fieldType.Kind() == reflect.Struct && fieldType != reflect.TypeOf(time.Time{}) {
+			s.assertProtoFields(fieldType, protoField.Message())
+		}
+	}
+	for i := 0; i < descriptor.Fields().Len(); i++ {
+		protoFields = append(protoFields, string(descriptor.Fields().Get(i).Name()))
+	}
+
+	assert.ElementsMatch(s.T(), jsonFields, protoFields, "fields of %s and %s", structType.Name(), descriptor.FullName())
+}
+
+// changeLocationField returns the schema with the field of the Location message replaced, or added when the number is
+// not used
+func (s *SchemaRegistrySuite) changeLocationField(name string, number int32, fieldType descriptorpb.FieldDescriptorProto_Type) pubsub.Schema {
+	var file descriptorpb.FileDescriptorProto
+	s.Require().NoError(proto.Unmarshal(s.schema.Descriptor, &file))
+
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:   fieldType.Enum(),
+	}
+	for _, message := range file.GetMessageType() {
+		if message.GetName() != "Location" {
+			continue
+		}
+		fields := message.GetField()[:0]
+		for _, existing := range message.GetField() {
+			if existing.GetNumber() != number {
+				fields = append(fields, existing)
+			}
+		}
+		message.Field = append(fields, field)
+	}
+
+	descriptor, err := proto.Marshal(&file)
+	s.Require().NoError(err)
+
+	return pubsub.Schema{Type: pubsub.SchemaTypeProtobuf, Schema: s.schema.Schema + "// " + name, Descriptor: descriptor}
+}
@@ -20,7 +20,7 @@ func CreateSubscriber(kafkaCfg *sarama.Config, kafkaParams config.KafkaConfig) (
 	return kafka.NewSubscriber(
 		kafka.SubscriberConfig{
 			Brokers:               kafkaParams.Brokers,
-			Unmarshaler:           kafka.DefaultMarshaler{},
+			Unmarshaler:           NewProtobufUnmarshaler(kafka.DefaultMarshaler{}),
 			OverwriteSaramaConfig: kafkaCfg,
 			ConsumerGroup:         kafkaParams.ConsumerGroup,
 			OTELEnabled:           true,
@@ -28,3 +28,25 @@ func CreateSubscriber(kafkaCfg *sarama.Config, kafkaParams config.KafkaConfig) (
 		watermill.NewStdLogger(true, true),
 	)
 }
+
+// CreateBroadcastSubscriber creates a subscriber without consumer group, so every instance receives every message of
+// the topics instead of sharing their partitions. It starts from the newest messages, it is meant for state that
+// lives in memory and is not rebuilt from the history of the topics.
+func CreateBroadcastSubscriber(kafkaCfg *sarama.Config, kafkaParams config.KafkaConfig) (message.Subscriber, error) {
+	if len(kafkaParams.Brokers) == 0 {
+		return nil, ErrBrokerSliceEmpty
+	}
+
+	kafkaCfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	kafkaCfg.Admin.Retry.Max = kafkaParams.MaxRetries
+
+	return kafka.NewSubscriber(
+		kafka.SubscriberConfig{
+			Brokers:               kafkaParams.Brokers,
+			Unmarshaler:           NewProtobufUnmarshaler(kafka.DefaultMarshaler{}),
+			OverwriteSaramaConfig: kafkaCfg,
+			OTELEnabled:           true,
+		},
+		watermill.NewStdLogger(true, true),
+	)
+}
@@ -0,0 +1,55 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+
+// Upcaster converts the data of an event from its schema version to the next one
+type Upcaster func(data json.RawMessage) (json.RawMessage, error)
+
+type upcasterKey struct {
+	eventType string
+	version   int
+}
+
+// UpcasterRegistry brings the data of the events consumed to the schema version the handlers read, so the events
+// published with older versions, still in the topics or redriven from the dead letters, are read the same way
+type UpcasterRegistry struct {
+	upcasters map[upcasterKey]Upcaster
+}
+
+func NewUpcasterRegistry() *UpcasterRegistry {
+	return &UpcasterRegistry{upcasters: map[upcasterKey]Upcaster{}}
+}
+
+// Register sets the upcaster from the schema version to the next one for each of the event types
+func (r *UpcasterRegistry) Register(fromVersion int, upcaster Upcaster, eventTypes ...string) *UpcasterRegistry {
+	for _, eventType := range eventTypes {
+		r.upcasters[upcasterKey{eventType: eventType, version: fromVersion}] = upcaster
+	}
+
+	return r
+}
+
+// Upcast returns the data of the event in the schema version, running the upcasters of its type one version at a
+// time. ErrUnsupportedSchemaVersion is returned when the event is newer than the version or an upcaster is missing.
+func (r *UpcasterRegistry) Upcast(event CloudEvent, version int) (json.RawMessage, error) {
+	data := event.Data
+	for current := event.SchemaVersion; current != version; current++ {
+		upcaster, ok := r.upcasters[upcasterKey{eventType: event.Type, version: current}]
+		if current > version || !ok {
+			return nil, fmt.Errorf("%w: cannot read version %v of %q as version %v", ErrUnsupportedSchemaVersion, event.SchemaVersion, event.Type, version)
+		}
+
+		var err error
+		if data, err = upcaster(data); err != nil {
+			return nil, fmt.Errorf("error upcasting %q from version %v: %w", event.Type, current, err)
+		}
+	}
+
+	return data, nil
+}
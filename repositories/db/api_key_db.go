@@ -0,0 +1,165 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+	"time"
+)
+
+// APIKeyRepository stores the API keys of the machine clients
+type APIKeyRepository struct {
+	*TxDBContext
+}
+
+func (dal *APIKeyRepository) CreateAPIKey(ctx monitor.ApplicationContext, key domain.APIKey) error {
+	ctx, span := ctx.StartSpan("APIKeyRepository.CreateAPIKey")
+	defer span.End()
+
+	_, err := dal.Exec(
+		ctx,
+		InsertAPIKey,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		pq.Array(key.Scopes),
+		key.SupplierID,
+		key.CreatedAt,
+	)
+
+	return err
+}
+
+// GetAPIKeys returns the keys of the supplier of the caller when it is restricted to one
+func (dal *APIKeyRepository) GetAPIKeys(ctx monitor.ApplicationContext) ([]domain.APIKey, error) {
+	ctx, span := ctx.StartSpan("APIKeyRepository.GetAPIKeys")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetAPIKeys, tenantSupplierID(ctx))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]domain.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rowsErr(rows)
+}
+
+// GetAPIKeyByPrefix returns nil when no key has the prefix, revoked keys are returned too
+func (dal *APIKeyRepository) GetAPIKeyByPrefix(ctx monitor.ApplicationContext, prefix string) (*domain.APIKey, error) {
+	ctx, span := ctx.StartSpan("APIKeyRepository.GetAPIKeyByPrefix")
+	defer span.End()
+
+	key, err := scanAPIKey(dal.queryRow(ctx, GetAPIKeyByPrefix, prefix))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// RotateAPIKey replaces the prefix and hash of the key and returns the updated key, or nil when the key does not exist,
+// was revoked or belongs to another supplier than the one of the caller
+func (dal *APIKeyRepository) RotateAPIKey(
+	ctx monitor.ApplicationContext,
+	id, prefix, hash string,
+	rotatedAt time.Time,
+) (*domain.APIKey, error) {
+	ctx, span := ctx.StartSpan("APIKeyRepository.RotateAPIKey")
+	defer span.End()
+
+	key, err := scanAPIKey(dal.queryRow(ctx, RotateAPIKey, prefix, hash, rotatedAt, id, tenantSupplierID(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// RevokeAPIKey returns false when the key does not exist, was already revoked or belongs to another supplier than the
+// one of the caller
+func (dal *APIKeyRepository) RevokeAPIKey(ctx monitor.ApplicationContext, id string, revokedAt time.Time) (bool, error) {
+	ctx, span := ctx.StartSpan("APIKeyRepository.RevokeAPIKey")
+	defer span.End()
+
+	res, err := dal.Exec(ctx, RevokeAPIKey, revokedAt, id, tenantSupplierID(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows > 0, nil
+}
+
+// UpdateAPIKeysLastUsed stores the last used timestamp of every key in a single statement
+func (dal *APIKeyRepository) UpdateAPIKeysLastUsed(ctx monitor.ApplicationContext, lastUsed map[string]time.Time) error {
+	ctx, span := ctx.StartSpan("APIKeyRepository.UpdateAPIKeysLastUsed")
+	defer span.End()
+
+	ids := make([]string, 0, len(lastUsed))
+	timestamps := make([]string, 0, len(lastUsed))
+	for id, usedAt := range lastUsed {
+		ids = append(ids, id)
+		timestamps = append(timestamps, usedAt.UTC().Format(time.RFC3339Nano))
+	}
+
+	_, err := dal.Exec(ctx, UpdateAPIKeysLastUsed, pq.Array(ids), pq.Array(timestamps))
+
+	return err
+}
+
+func scanAPIKey(scanner rowScanner) (domain.APIKey, error) {
+	var key domain.APIKey
+	var supplierID sql.NullInt64
+
+	if err := scanner.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		pq.Array(&key.Scopes),
+		&supplierID,
+		&key.CreatedAt,
+		&key.RotatedAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	); err != nil {
+		return key, err
+	}
+
+	if supplierID.Valid {
+		id := int(supplierID.Int64)
+		key.SupplierID = &id
+	}
+
+	return key, nil
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/utils"
+	"log"
+	"testing"
+	"time"
+)
+
+var testAPIKey = domain.APIKey{
+	ID:         "5b0cbb42-3f57-4f3e-9f0c-8d3c6f1a1e0b",
+	Name:       "batch job",
+	Prefix:     "0a1b2c3d4e5f6a7b",
+	Hash:       "hash",
+	Scopes:     []string{"locations:read", "locations:write"},
+	SupplierID: utils.ToPointer(2),
+	CreatedAt:  time.Now(),
+}
+
+var apiKeyColumns = []string{
+	"id", "name", "prefix", "key_hash", "scopes", "supplier_id", "created_at", "rotated_at", "last_used_at", "revoked_at",
+}
+
+type APIKeyDALSuite struct {
+	suite.Suite
+	repo    *APIKeyRepository
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func (s *APIKeyDALSuite) SetupTest() {
+	db, sqmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("An error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.db = db
+	s.sqlMock = sqmock
+	s.repo = &APIKeyRepository{
+		TxDBContext: CreateTxDBContext(db),
+	}
+}
+
+func TestAPIKeyDALSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyDALSuite))
+}
+
+func (s *APIKeyDALSuite) Test_CreateAPIKey_Success() {
+	s.sqlMock.ExpectPrepare(InsertAPIKey).ExpectExec().WithArgs(
+		testAPIKey.ID,
+		testAPIKey.Name,
+		testAPIKey.Prefix,
+		testAPIKey.Hash,
+		pq.Array(testAPIKey.Scopes),
+		testAPIKey.SupplierID,
+		testAPIKey.CreatedAt,
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.repo.CreateAPIKey(mockCtx, testAPIKey)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *APIKeyDALSuite) Test_GetAPIKeyByPrefix_ReturnsKey() {
+	lastUsedAt := time.Now()
+
+	s.sqlMock.ExpectQuery(GetAPIKeyByPrefix).WithArgs(testAPIKey.Prefix).WillReturnRows(
+		sqlmock.NewRows(apiKeyColumns).AddRow(
+			testAPIKey.ID, testAPIKey.Name, testAPIKey.Prefix, testAPIKey.Hash, "{locations:read,locations:write}", 2,
+			testAPIKey.CreatedAt, nil, lastUsedAt, nil,
+		),
+	)
+
+	key, err := s.repo.GetAPIKeyByPrefix(mockCtx, testAPIKey.Prefix)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), testAPIKey.Scopes, key.Scopes)
+	assert.Equal(s.T(), testAPIKey.SupplierID, key.SupplierID)
+	assert.Equal(s.T(), &lastUsedAt, key.LastUsedAt)
+	assert.Nil(s.T(), key.RevokedAt)
+}
+
+func (s *APIKeyDALSuite) Test_GetAPIKeyByPrefix_ReturnsNilWhenMissing() {
+	s.sqlMock.ExpectQuery(GetAPIKeyByPrefix).WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+	key, err := s.repo.GetAPIKeyByPrefix(mockCtx, "unknown")
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), key)
+}
+
+func (s *APIKeyDALSuite) Test_RotateAPIKey_ReturnsNilWhenKeyIsNotActive() {
+	rotatedAt := time.Now()
+
+	s.sqlMock.ExpectQuery(RotateAPIKey).WithArgs("newprefix", "newhash", rotatedAt, testAPIKey.ID, nil).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+	key, err := s.repo.RotateAPIKey(mockCtx, testAPIKey.ID, "newprefix", "newhash", rotatedAt)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), key)
+}
+
+func (s *APIKeyDALSuite) Test_RevokeAPIKey_ReturnsFalseWhenAlreadyRevoked() {
+	revokedAt := time.Now()
+
+	s.sqlMock.ExpectPrepare(RevokeAPIKey).ExpectExec().WithArgs(revokedAt, testAPIKey.ID, nil).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	revoked, err := s.repo.RevokeAPIKey(mockCtx, testAPIKey.ID, revokedAt)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), revoked)
+}
+
+func (s *APIKeyDALSuite) Test_RevokeAPIKey_ScopedToTenant() {
+	supplierCtx := mockCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", SupplierID: utils.ToPointer(2)})
+	revokedAt := time.Now()
+
+	s.sqlMock.ExpectPrepare(RevokeAPIKey).ExpectExec().WithArgs(revokedAt, testAPIKey.ID, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	revoked, err := s.repo.RevokeAPIKey(supplierCtx, testAPIKey.ID, revokedAt)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), revoked)
+}
+
+func (s *APIKeyDALSuite) Test_GetAPIKeys_ScopedToTenant() {
+	supplierCtx := mockCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", SupplierID: utils.ToPointer(2)})
+
+	s.sqlMock.ExpectQuery(GetAPIKeys).WithArgs(2).WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+	keys, err := s.repo.GetAPIKeys(supplierCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Empty(s.T(), keys)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *APIKeyDALSuite) Test_UpdateAPIKeysLastUsed_Success() {
+	usedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	s.sqlMock.ExpectPrepare(UpdateAPIKeysLastUsed).ExpectExec().WithArgs(
+		pq.Array([]string{testAPIKey.ID}), pq.Array([]string{"2024-05-01T10:00:00Z"}),
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := s.repo.UpdateAPIKeysLastUsed(mockCtx, map[string]time.Time{testAPIKey.ID: usedAt})
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
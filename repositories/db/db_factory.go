@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"go-service-template/config"
+	"go-service-template/domain"
 	"go-service-template/repositories"
 	"net/url"
 	"regexp"
@@ -26,9 +27,14 @@ const (
 
 type Factory struct {
 	locationsDBConnection *sql.DB
+	cursorCodec           domain.CursorCodec
 }
 
-func NewFactory(dbConfig config.DBConfig) *Factory {
+func NewFactory(dbConfig config.DBConfig, paginationConfig config.PaginationConfig) *Factory {
+	if paginationConfig.CursorSigningKey == "" {
+		panic(errors.New("the cursor signing key is empty"))
+	}
+
 	conn, err := connectDB(dbConfig.LocationsDatabaseConnection, dbConfig)
 	if err != nil {
 		panic(err)
@@ -36,6 +42,7 @@ func NewFactory(dbConfig config.DBConfig) *Factory {
 
 	return &Factory{
 		locationsDBConnection: conn,
+		cursorCodec:           domain.NewCursorCodec(paginationConfig.CursorSigningKey),
 	}
 }
 
@@ -47,6 +54,57 @@ func (df *Factory) GetLocationsDB() (repositories.LocationsDB, error) {
 	return &LocationsRepository{
 		TxDBContext:  CreateTxDBContext(df.locationsDBConnection),
 		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		cursorCodec:  df.cursorCodec,
+	}, nil
+}
+
+func (df *Factory) GetReferenceDataDB() (repositories.ReferenceDataDB, error) {
+	if df.locationsDBConnection == nil {
+		return nil, errors.New("could not create ReferenceDataDBDal because the DB connection does not exist")
+	}
+
+	return &ReferenceDataRepository{
+		TxDBContext: CreateTxDBContext(df.locationsDBConnection),
+	}, nil
+}
+
+func (df *Factory) GetIdempotencyDB() (repositories.IdempotencyDB, error) {
+	if df.locationsDBConnection == nil {
+		return nil, errors.New("could not create IdempotencyDBDal because the DB connection does not exist")
+	}
+
+	return &IdempotencyRepository{
+		TxDBContext: CreateTxDBContext(df.locationsDBConnection),
+	}, nil
+}
+
+func (df *Factory) GetAPIKeyDB() (repositories.APIKeyDB, error) {
+	if df.locationsDBConnection == nil {
+		return nil, errors.New("could not create APIKeyDBDal because the DB connection does not exist")
+	}
+
+	return &APIKeyRepository{
+		TxDBContext: CreateTxDBContext(df.locationsDBConnection),
+	}, nil
+}
+
+func (df *Factory) GetWebhookDB() (repositories.WebhookDB, error) {
+	if df.locationsDBConnection == nil {
+		return nil, errors.New("could not create WebhookDBDal because the DB connection does not exist")
+	}
+
+	return &WebhookRepository{
+		TxDBContext: CreateTxDBContext(df.locationsDBConnection),
+	}, nil
+}
+
+func (df *Factory) GetDeadLetterDB() (repositories.DeadLetterDB, error) {
+	if df.locationsDBConnection == nil {
+		return nil, errors.New("could not create DeadLetterDBDal because the DB connection does not exist")
+	}
+
+	return &DeadLetterRepository{
+		TxDBContext: CreateTxDBContext(df.locationsDBConnection),
 	}, nil
 }
 
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+	"time"
+)
+
+// DeadLetterRepository stores the messages the event handlers could not process
+type DeadLetterRepository struct {
+	*TxDBContext
+}
+
+func (dal *DeadLetterRepository) CreateDeadLetter(ctx monitor.ApplicationContext, deadLetter domain.DeadLetter) error {
+	ctx, span := ctx.StartSpan("DeadLetterRepository.CreateDeadLetter")
+	defer span.End()
+
+	metadata, err := json.Marshal(deadLetter.Metadata)
+	if err != nil {
+		return fmt.Errorf("error marshaling dead letter metadata: %w", err)
+	}
+
+	_, err = dal.Exec(
+		ctx,
+		InsertDeadLetter,
+		deadLetter.ID,
+		deadLetter.MessageID,
+		deadLetter.Handler,
+		deadLetter.OriginalTopic,
+		deadLetter.PoisonTopic,
+		deadLetter.Error,
+		deadLetter.Attempts,
+		deadLetter.CorrelationID,
+		deadLetter.Payload,
+		metadata,
+		deadLetter.FailedAt,
+	)
+
+	return err
+}
+
+// GetDeadLetters returns the newest dead letters that match the filters
+func (dal *DeadLetterRepository) GetDeadLetters(ctx monitor.ApplicationContext, filters domain.DeadLetterFilters) ([]domain.DeadLetter, error) {
+	ctx, span := ctx.StartSpan("DeadLetterRepository.GetDeadLetters")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetDeadLetters, filters.Handler, filters.Redriven, filters.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	deadLetters := make([]domain.DeadLetter, 0)
+	for rows.Next() {
+		deadLetter, err := scanDeadLetter(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, rowsErr(rows)
+}
+
+// GetDeadLetterByID returns nil when the dead letter does not exist
+func (dal *DeadLetterRepository) GetDeadLetterByID(ctx monitor.ApplicationContext, id string) (*domain.DeadLetter, error) {
+	ctx, span := ctx.StartSpan("DeadLetterRepository.GetDeadLetterByID")
+	defer span.End()
+
+	deadLetter, err := scanDeadLetter(dal.queryRow(ctx, GetDeadLetterByID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &deadLetter, nil
+}
+
+// MarkDeadLetterAsRedriven returns false when the dead letter does not exist or was already redriven
+func (dal *DeadLetterRepository) MarkDeadLetterAsRedriven(ctx monitor.ApplicationContext, id string, redrivenAt time.Time) (bool, error) {
+	ctx, span := ctx.StartSpan("DeadLetterRepository.MarkDeadLetterAsRedriven")
+	defer span.End()
+
+	res, err := dal.Exec(ctx, MarkDeadLetterAsRedriven, redrivenAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows > 0, nil
+}
+
+func scanDeadLetter(scanner rowScanner) (domain.DeadLetter, error) {
+	var deadLetter domain.DeadLetter
+	var metadata []byte
+
+	if err := scanner.Scan(
+		&deadLetter.ID,
+		&deadLetter.MessageID,
+		&deadLetter.Handler,
+		&deadLetter.OriginalTopic,
+		&deadLetter.PoisonTopic,
+		&deadLetter.Error,
+		&deadLetter.Attempts,
+		&deadLetter.CorrelationID,
+		&deadLetter.Payload,
+		&metadata,
+		&deadLetter.FailedAt,
+		&deadLetter.RedrivenAt,
+	); err != nil {
+		return deadLetter, err
+	}
+
+	if err := json.Unmarshal(metadata, &deadLetter.Metadata); err != nil {
+		return deadLetter, fmt.Errorf("error unmarshaling metadata of dead letter %v: %w", deadLetter.ID, err)
+	}
+
+	return deadLetter, nil
+}
+
+func (dal *DeadLetterRepository) CreateOutboxMessage(ctx monitor.ApplicationContext, msg domain.OutboxMessage) error {
+	ctx, span := ctx.StartSpan("DeadLetterRepository.CreateOutboxMessage")
+	defer span.End()
+
+	return insertOutboxMessage(ctx, dal.TxDBContext, msg)
+}
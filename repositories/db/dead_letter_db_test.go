@@ -0,0 +1,151 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/utils"
+	"log"
+	"testing"
+	"time"
+)
+
+var testDeadLetter = domain.DeadLetter{
+	ID:            "5d0f1a8e-3c52-4d55-9f1e-2b7f3c9a6e21",
+	MessageID:     "b6a3c1d2-8f4e-4e1a-9c7b-1d2e3f4a5b6c",
+	Handler:       "NewLocationWebhookHandler",
+	OriginalTopic: domain.LocationsNewTopic,
+	PoisonTopic:   domain.LocationsNewTopic + ".poison.NewLocationWebhookHandler",
+	Error:         "webhook delivery failed",
+	Attempts:      6,
+	CorrelationID: "correlation-id",
+	Payload:       []byte(`{"id":"location1"}`),
+	Metadata:      map[string]string{"key": "location1"},
+	FailedAt:      time.Now(),
+}
+
+var deadLetterColumns = []string{
+	"id", "message_id", "handler", "original_topic", "poison_topic", "error", "attempts", "correlation_id", "payload",
+	"metadata", "failed_at", "redriven_at",
+}
+
+type DeadLetterDALSuite struct {
+	suite.Suite
+	repo    *DeadLetterRepository
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func (s *DeadLetterDALSuite) SetupTest() {
+	db, sqmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("An error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.db = db
+	s.sqlMock = sqmock
+	s.repo = &DeadLetterRepository{
+		TxDBContext: CreateTxDBContext(db),
+	}
+}
+
+func TestDeadLetterDALSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterDALSuite))
+}
+
+func (s *DeadLetterDALSuite) Test_CreateDeadLetter_Success() {
+	s.sqlMock.ExpectPrepare(InsertDeadLetter).ExpectExec().WithArgs(
+		testDeadLetter.ID,
+		testDeadLetter.MessageID,
+		testDeadLetter.Handler,
+		testDeadLetter.OriginalTopic,
+		testDeadLetter.PoisonTopic,
+		testDeadLetter.Error,
+		testDeadLetter.Attempts,
+		testDeadLetter.CorrelationID,
+		testDeadLetter.Payload,
+		[]byte(`{"key":"location1"}`),
+		testDeadLetter.FailedAt,
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.repo.CreateDeadLetter(mockCtx, testDeadLetter)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *DeadLetterDALSuite) Test_GetDeadLetters_FiltersByHandlerAndRedriven() {
+	handler := testDeadLetter.Handler
+	redriven := false
+
+	s.sqlMock.ExpectQuery(GetDeadLetters).WithArgs(&handler, &redriven, 100).WillReturnRows(
+		sqlmock.NewRows(deadLetterColumns).AddRow(
+			testDeadLetter.ID, testDeadLetter.MessageID, testDeadLetter.Handler, testDeadLetter.OriginalTopic,
+			testDeadLetter.PoisonTopic, testDeadLetter.Error, testDeadLetter.Attempts, testDeadLetter.CorrelationID,
+			testDeadLetter.Payload, []byte(`{"key":"location1"}`), testDeadLetter.FailedAt, nil,
+		),
+	)
+
+	deadLetters, err := s.repo.GetDeadLetters(mockCtx, domain.DeadLetterFilters{Handler: &handler, Redriven: &redriven, Limit: 100})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []domain.DeadLetter{testDeadLetter}, deadLetters)
+}
+
+func (s *DeadLetterDALSuite) Test_GetDeadLetterByID_ReturnsNilWhenMissing() {
+	s.sqlMock.ExpectQuery(GetDeadLetterByID).WithArgs(testDeadLetter.ID).WillReturnError(sql.ErrNoRows)
+
+	deadLetter, err := s.repo.GetDeadLetterByID(mockCtx, testDeadLetter.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), deadLetter)
+}
+
+func (s *DeadLetterDALSuite) Test_GetDeadLetterByID_ReturnsRedrivenAt() {
+	redrivenAt := time.Now()
+
+	s.sqlMock.ExpectQuery(GetDeadLetterByID).WithArgs(testDeadLetter.ID).WillReturnRows(
+		sqlmock.NewRows(deadLetterColumns).AddRow(
+			testDeadLetter.ID, testDeadLetter.MessageID, testDeadLetter.Handler, testDeadLetter.OriginalTopic,
+			testDeadLetter.PoisonTopic, testDeadLetter.Error, testDeadLetter.Attempts, testDeadLetter.CorrelationID,
+			testDeadLetter.Payload, []byte(`{}`), testDeadLetter.FailedAt, redrivenAt,
+		),
+	)
+
+	deadLetter, err := s.repo.GetDeadLetterByID(mockCtx, testDeadLetter.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), utils.ToPointer(redrivenAt), deadLetter.RedrivenAt)
+}
+
+func (s *DeadLetterDALSuite) Test_MarkDeadLetterAsRedriven_ReturnsFalseWhenAlreadyRedriven() {
+	redrivenAt := time.Now()
+
+	s.sqlMock.ExpectPrepare(MarkDeadLetterAsRedriven).ExpectExec().WithArgs(redrivenAt, testDeadLetter.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	marked, err := s.repo.MarkDeadLetterAsRedriven(mockCtx, testDeadLetter.ID, redrivenAt)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), marked)
+}
+
+func (s *DeadLetterDALSuite) Test_CreateOutboxMessage_Success() {
+	s.sqlMock.ExpectPrepare(InsertOutboxMessage).ExpectExec().WithArgs(
+		testOutboxMessage.ID,
+		testOutboxMessage.AggregateID,
+		testOutboxMessage.Topic,
+		testOutboxMessage.Payload,
+		[]byte(`{"correlation_id":"corrID"}`),
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.repo.CreateOutboxMessage(mockCtx, testOutboxMessage)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
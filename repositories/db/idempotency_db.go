@@ -0,0 +1,113 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+	"time"
+)
+
+// IdempotencyRepository stores the idempotency keys of the requests and the responses replayed for them
+type IdempotencyRepository struct {
+	*TxDBContext
+}
+
+// ReserveIdempotencyKey stores a new key without response. It returns false when a key that has not expired already
+// exists for the scope, in which case nothing is changed.
+func (dal *IdempotencyRepository) ReserveIdempotencyKey(ctx monitor.ApplicationContext, key domain.IdempotencyKey) (bool, error) {
+	ctx, span := ctx.StartSpan("IdempotencyRepository.ReserveIdempotencyKey")
+	defer span.End()
+
+	var reservedKey string
+
+	err := dal.queryRow(ctx, ReserveIdempotencyKey, key.Scope, key.Key, key.RequestHash, key.ExpiresAt).
+		Scan(&reservedKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dal *IdempotencyRepository) GetIdempotencyKey(ctx monitor.ApplicationContext, scope, key string) (*domain.IdempotencyKey, error) {
+	ctx, span := ctx.StartSpan("IdempotencyRepository.GetIdempotencyKey")
+	defer span.End()
+
+	var idempotencyKey domain.IdempotencyKey
+	var statusCode sql.NullInt64
+	var headers, body []byte
+
+	if err := dal.queryRow(ctx, GetIdempotencyKey, scope, key).Scan(
+		&idempotencyKey.Scope,
+		&idempotencyKey.Key,
+		&idempotencyKey.RequestHash,
+		&statusCode,
+		&headers,
+		&body,
+		&idempotencyKey.ExpiresAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if statusCode.Valid {
+		idempotencyKey.Response = &domain.IdempotentResponse{StatusCode: int(statusCode.Int64), Body: body}
+		if err := json.Unmarshal(headers, &idempotencyKey.Response.Headers); err != nil {
+			return nil, fmt.Errorf("error unmarshaling headers of idempotency key %v: %w", key, err)
+		}
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (dal *IdempotencyRepository) CompleteIdempotencyKey(
+	ctx monitor.ApplicationContext,
+	scope, key string,
+	response domain.IdempotentResponse,
+) error {
+	ctx, span := ctx.StartSpan("IdempotencyRepository.CompleteIdempotencyKey")
+	defer span.End()
+
+	headers, err := json.Marshal(response.Headers)
+	if err != nil {
+		return fmt.Errorf("error marshaling idempotent response headers: %w", err)
+	}
+
+	_, err = dal.Exec(ctx, CompleteIdempotencyKey, response.StatusCode, headers, response.Body, scope, key)
+
+	return err
+}
+
+func (dal *IdempotencyRepository) DeleteIdempotencyKey(ctx monitor.ApplicationContext, scope, key string) error {
+	ctx, span := ctx.StartSpan("IdempotencyRepository.DeleteIdempotencyKey")
+	defer span.End()
+
+	_, err := dal.Exec(ctx, DeleteIdempotencyKey, scope, key)
+
+	return err
+}
+
+func (dal *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx monitor.ApplicationContext, expiredBefore time.Time) (int64, error) {
+	ctx, span := ctx.StartSpan("IdempotencyRepository.DeleteExpiredIdempotencyKeys")
+	defer span.End()
+
+	res, err := dal.Exec(ctx, DeleteExpiredIdempotencyKeys, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
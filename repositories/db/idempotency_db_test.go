@@ -0,0 +1,138 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"log"
+	"testing"
+	"time"
+)
+
+var testIdempotencyKey = domain.IdempotencyKey{
+	Scope:       "POST /v1/locations",
+	Key:         "key",
+	RequestHash: "hash",
+	ExpiresAt:   time.Now().Add(time.Hour),
+}
+
+type IdempotencyDALSuite struct {
+	suite.Suite
+	repo    *IdempotencyRepository
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func (s *IdempotencyDALSuite) SetupTest() {
+	db, sqmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("An error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.db = db
+	s.sqlMock = sqmock
+	s.repo = &IdempotencyRepository{
+		TxDBContext: CreateTxDBContext(db),
+	}
+}
+
+func TestIdempotencyDALSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyDALSuite))
+}
+
+func (s *IdempotencyDALSuite) Test_ReserveIdempotencyKey_ReservesNewKey() {
+	s.sqlMock.ExpectQuery(ReserveIdempotencyKey).WithArgs(
+		testIdempotencyKey.Scope, testIdempotencyKey.Key, testIdempotencyKey.RequestHash, testIdempotencyKey.ExpiresAt,
+	).WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow(testIdempotencyKey.Key))
+
+	reserved, err := s.repo.ReserveIdempotencyKey(mockCtx, testIdempotencyKey)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), reserved)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *IdempotencyDALSuite) Test_ReserveIdempotencyKey_ReturnsFalseWhenKeyIsInUse() {
+	s.sqlMock.ExpectQuery(ReserveIdempotencyKey).WithArgs(
+		testIdempotencyKey.Scope, testIdempotencyKey.Key, testIdempotencyKey.RequestHash, testIdempotencyKey.ExpiresAt,
+	).WillReturnRows(sqlmock.NewRows([]string{"key"}))
+
+	reserved, err := s.repo.ReserveIdempotencyKey(mockCtx, testIdempotencyKey)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), reserved)
+}
+
+func (s *IdempotencyDALSuite) Test_GetIdempotencyKey_ReturnsStoredResponse() {
+	s.sqlMock.ExpectQuery(GetIdempotencyKey).WithArgs(testIdempotencyKey.Scope, testIdempotencyKey.Key).WillReturnRows(
+		sqlmock.NewRows([]string{"scope", "key", "request_hash", "status_code", "response_headers", "response_body", "expires_at"}).AddRow(
+			testIdempotencyKey.Scope, testIdempotencyKey.Key, "hash", 201, []byte(`{"Content-Type":"application/json"}`), []byte(`{}`),
+			testIdempotencyKey.ExpiresAt,
+		),
+	)
+
+	key, err := s.repo.GetIdempotencyKey(mockCtx, testIdempotencyKey.Scope, testIdempotencyKey.Key)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "hash", key.RequestHash)
+	assert.Equal(s.T(), &domain.IdempotentResponse{
+		StatusCode: 201,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       []byte(`{}`),
+	}, key.Response)
+}
+
+func (s *IdempotencyDALSuite) Test_GetIdempotencyKey_ReturnsNoResponseWhileInProgress() {
+	s.sqlMock.ExpectQuery(GetIdempotencyKey).WithArgs(testIdempotencyKey.Scope, testIdempotencyKey.Key).WillReturnRows(
+		sqlmock.NewRows([]string{"scope", "key", "request_hash", "status_code", "response_headers", "response_body", "expires_at"}).AddRow(
+			testIdempotencyKey.Scope, testIdempotencyKey.Key, "hash", nil, nil, nil, testIdempotencyKey.ExpiresAt,
+		),
+	)
+
+	key, err := s.repo.GetIdempotencyKey(mockCtx, testIdempotencyKey.Scope, testIdempotencyKey.Key)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), key.Response)
+}
+
+func (s *IdempotencyDALSuite) Test_GetIdempotencyKey_ReturnsNilWhenMissing() {
+	s.sqlMock.ExpectQuery(GetIdempotencyKey).WithArgs(testIdempotencyKey.Scope, testIdempotencyKey.Key).WillReturnError(sql.ErrNoRows)
+
+	key, err := s.repo.GetIdempotencyKey(mockCtx, testIdempotencyKey.Scope, testIdempotencyKey.Key)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), key)
+}
+
+func (s *IdempotencyDALSuite) Test_CompleteIdempotencyKey_Success() {
+	response := domain.IdempotentResponse{StatusCode: 201, Headers: map[string]string{"ETag": `"1"`}, Body: []byte(`{}`)}
+
+	s.sqlMock.ExpectPrepare(CompleteIdempotencyKey).ExpectExec().WithArgs(
+		201, []byte(`{"ETag":"\"1\""}`), []byte(`{}`), testIdempotencyKey.Scope, testIdempotencyKey.Key,
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := s.repo.CompleteIdempotencyKey(mockCtx, testIdempotencyKey.Scope, testIdempotencyKey.Key, response)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *IdempotencyDALSuite) Test_DeleteExpiredIdempotencyKeys_Success() {
+	expiredBefore := time.Now()
+
+	s.sqlMock.ExpectPrepare(DeleteExpiredIdempotencyKeys).ExpectExec().WithArgs(expiredBefore).WillReturnResult(sqlmock.NewResult(0, 4))
+
+	deleted, err := s.repo.DeleteExpiredIdempotencyKeys(mockCtx, expiredBefore)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(4), deleted)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
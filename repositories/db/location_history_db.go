@@ -0,0 +1,158 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/lib/pq"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// createLocationHistoryEntry records a change made to a location. It is called by the methods that write a location
+// so the entry is stored in the same transaction as the change.
+func (dal *LocationsRepository) createLocationHistoryEntry(
+	ctx monitor.ApplicationContext,
+	locationID, operation string,
+	before, after *domain.Location,
+) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.createLocationHistoryEntry")
+	defer span.End()
+
+	beforeJSON, err := marshalLocationSnapshot(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := marshalLocationSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = dal.Exec(
+		ctx,
+		InsertLocationHistory,
+		locationID,
+		operation,
+		beforeJSON,
+		afterJSON,
+		pq.Array(domain.LocationChangedFields(before, after)),
+		nullIfEmpty(ctx.GetCorrelationID()),
+		nullIfEmpty(ctx.GetActor()),
+	)
+
+	return err
+}
+
+// nolint
+func (dal *LocationsRepository) GetLocationHistory(
+	ctx monitor.ApplicationContext,
+	filters domain.LocationHistoryFilters,
+) (domain.CursorPage[domain.LocationHistoryEntry], error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.GetLocationHistory")
+	defer span.End()
+
+	var result domain.CursorPage[domain.LocationHistoryEntry]
+
+	baseSelectQuery := dal.queryBuilder.Select(
+		"h.id",
+		"h.location_id",
+		"h.operation",
+		"h.before",
+		"h.after",
+		"h.changed_fields",
+		"h.correlation_id",
+		"h.actor",
+		"h.changed_at",
+	).From("location.location_history h").Where("h.location_id = ?", filters.LocationID)
+
+	// History entries are always sorted by ID, which is also the tie-breaker
+	filters.Sort = nil
+	baseSelectQuery, err := dal.paginate(baseSelectQuery, filters.CursorPaginationFilters, filters.CursorScope(), nil, "h.id")
+	if err != nil {
+		return result, err
+	}
+
+	selectQueryStr, args, err := baseSelectQuery.ToSql()
+	if err != nil {
+		return result, fmt.Errorf("error when building GetLocationHistory query: %w", err)
+	}
+
+	rows, err := dal.query(ctx, selectQueryStr, args...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.LocationHistoryEntry, 0)
+	for rows.Next() {
+		var entry domain.LocationHistoryEntry
+		var before, after []byte
+		var correlationID, actor sql.NullString
+
+		if err = rows.Scan(
+			&entry.ID,
+			&entry.LocationID,
+			&entry.Operation,
+			&before,
+			&after,
+			pq.Array(&entry.ChangedFields),
+			&correlationID,
+			&actor,
+			&entry.ChangedAt,
+		); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return result, err
+		}
+
+		if entry.Before, err = unmarshalLocationSnapshot(before); err != nil {
+			return result, err
+		}
+		if entry.After, err = unmarshalLocationSnapshot(after); err != nil {
+			return result, err
+		}
+		entry.CorrelationID = correlationID.String
+		entry.Actor = actor.String
+
+		entries = append(entries, entry)
+	}
+
+	if err = rowsErr(rows); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	return domain.BuildCursorPage(entries, filters.CursorPaginationFilters, filters.CursorScope(), dal.cursorCodec)
+}
+
+func marshalLocationSnapshot(location *domain.Location) ([]byte, error) {
+	if location == nil {
+		return nil, nil
+	}
+
+	snapshot, err := json.Marshal(location)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling location snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func unmarshalLocationSnapshot(snapshot []byte) (*domain.Location, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	var location domain.Location
+	if err := json.Unmarshal(snapshot, &location); err != nil {
+		return nil, fmt.Errorf("error unmarshaling location snapshot: %w", err)
+	}
+
+	return &location, nil
+}
+
+func nullIfEmpty(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
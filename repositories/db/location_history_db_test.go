@@ -0,0 +1,52 @@
+package db
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go-service-template/domain"
+	"time"
+)
+
+func (s *LocationsDALSuite) Test_GetLocationHistory_SuccessOnNextDirection() {
+	locationID := uuid.New().String()
+	filters := domain.LocationHistoryFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{Direction: domain.NextPage, Limit: 10},
+		LocationID:              locationID,
+	}
+	filters.Cursor = s.encodeCursor(nil, filters.CursorScope(), "4")
+
+	expectedQuery := "SELECT h.id, h.location_id, h.operation, h.before, h.after, h.changed_fields, h.correlation_id, h.actor, h.changed_at " +
+		"FROM location.location_history h WHERE h.location_id = $1 AND ((h.id > $2)) ORDER BY h.id ASC LIMIT 11"
+
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(locationID, "4").WillReturnRows(
+		sqlmock.NewRows(
+			[]string{"h.id", "h.location_id", "h.operation", "h.before", "h.after", "h.changed_fields", "h.correlation_id", "h.actor", "h.changed_at"},
+		).AddRow(
+			5, locationID, domain.LocationUpdatedOperation, []byte(`{"name":"Old"}`), []byte(`{"name":"New"}`), "{name}", "corrID", nil, time.Now(),
+		),
+	)
+
+	page, err := s.repo.GetLocationHistory(mockCtx, filters)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), page.Data, 1)
+	assert.Equal(s.T(), "Old", page.Data[0].Before.Name)
+	assert.Equal(s.T(), "New", page.Data[0].After.Name)
+	assert.Equal(s.T(), []string{"name"}, page.Data[0].ChangedFields)
+	assert.Equal(s.T(), "", page.Data[0].Actor)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetLocationHistory_FailsOnInvalidCursor() {
+	filters := domain.LocationHistoryFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{Cursor: "abc", Direction: domain.NextPage, Limit: 10},
+		LocationID:              uuid.New().String(),
+	}
+
+	_, err := s.repo.GetLocationHistory(mockCtx, filters)
+
+	assert.IsType(s.T(), domain.BusinessErr{}, err)
+}
@@ -8,10 +8,20 @@ import (
 	"go-service-template/domain"
 	"go-service-template/monitor"
 	"go.opentelemetry.io/otel/codes"
+	"time"
 )
 
+var locationSortColumns = map[string]string{
+	domain.SortByName:      "l.name",
+	domain.SortByCreatedAt: "l.created_at",
+	domain.SortByCity:      "li.city",
+	domain.SortByState:     "li.state",
+	domain.SortByZipcode:   "li.zipcode",
+}
+
 type LocationsRepository struct {
 	queryBuilder sq.StatementBuilderType
+	cursorCodec  domain.CursorCodec
 	*TxDBContext
 }
 
@@ -27,6 +37,7 @@ func (dal *LocationsRepository) CreateLocation(ctx monitor.ApplicationContext, l
 		location.LocationType.ID,
 		location.Supplier.ID,
 		location.Active,
+		location.CreatedAt,
 	)
 	if err != nil {
 		return err
@@ -51,14 +62,19 @@ func (dal *LocationsRepository) CreateLocation(ctx monitor.ApplicationContext, l
 		return err
 	}
 
-	return nil
+	return dal.createLocationHistoryEntry(ctx, location.ID, domain.LocationCreatedOperation, nil, &location)
 }
 
 func (dal *LocationsRepository) UpdateLocation(ctx monitor.ApplicationContext, location domain.Location) error {
 	ctx, span := ctx.StartSpan("LocationsRepository.UpdateLocation")
 	defer span.End()
 
-	_, err := dal.Exec(
+	before, err := dal.getLocationForHistory(ctx, location.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = dal.Exec(
 		ctx,
 		UpdateLocation,
 		location.Name,
@@ -66,6 +82,7 @@ func (dal *LocationsRepository) UpdateLocation(ctx monitor.ApplicationContext, l
 		location.Supplier.ID,
 		location.Active,
 		location.ID,
+		tenantSupplierID(ctx),
 	)
 	if err != nil {
 		return err
@@ -89,7 +106,12 @@ func (dal *LocationsRepository) UpdateLocation(ctx monitor.ApplicationContext, l
 		return err
 	}
 
-	return nil
+	after := location
+	after.DeletedAt = before.DeletedAt
+	after.Version = before.Version + 1
+	after.CreatedAt = before.CreatedAt
+
+	return dal.createLocationHistoryEntry(ctx, location.ID, domain.LocationUpdatedOperation, before, &after)
 }
 
 func (dal *LocationsRepository) CreateSubLocation(ctx monitor.ApplicationContext, subLocation domain.SubLocation) error {
@@ -113,7 +135,60 @@ func (dal *LocationsRepository) GetLocationByID(ctx monitor.ApplicationContext,
 	ctx, span := ctx.StartSpan("LocationsRepository.GetLocationByID")
 	defer span.End()
 
-	return dal.parseLocationFromRow(dal.getDBReader().QueryRowContext(ctx, GetLocationByID, id))
+	return dal.parseLocationFromRow(dal.queryRow(ctx, GetLocationByID, id, tenantSupplierID(ctx)))
+}
+
+func (dal *LocationsRepository) SoftDeleteLocation(ctx monitor.ApplicationContext, id string, deletedAt time.Time) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.SoftDeleteLocation")
+	defer span.End()
+
+	before, err := dal.getLocationForHistory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = dal.Exec(ctx, SoftDeleteLocation, deletedAt, id, tenantSupplierID(ctx)); err != nil {
+		return err
+	}
+
+	after := *before
+	after.DeletedAt = &deletedAt
+	after.Version++
+
+	return dal.createLocationHistoryEntry(ctx, id, domain.LocationDeletedOperation, before, &after)
+}
+
+func (dal *LocationsRepository) RestoreLocation(ctx monitor.ApplicationContext, id string) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.RestoreLocation")
+	defer span.End()
+
+	before, err := dal.getLocationForHistory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = dal.Exec(ctx, RestoreLocation, id, tenantSupplierID(ctx)); err != nil {
+		return err
+	}
+
+	after := *before
+	after.DeletedAt = nil
+	after.Version++
+
+	return dal.createLocationHistoryEntry(ctx, id, domain.LocationRestoredOperation, before, &after)
+}
+
+// getLocationForHistory reads the current state of a location before it is changed
+func (dal *LocationsRepository) getLocationForHistory(ctx monitor.ApplicationContext, id string) (*domain.Location, error) {
+	location, err := dal.GetLocationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error reading location %v before changing it: %w", id, err)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("location %v does not exist", id)
+	}
+
+	return location, nil
 }
 
 func (dal *LocationsRepository) CheckLocationNameExistence(ctx monitor.ApplicationContext, name string) (bool, error) {
@@ -122,7 +197,7 @@ func (dal *LocationsRepository) CheckLocationNameExistence(ctx monitor.Applicati
 
 	var locationID string
 
-	if err := dal.getDBReader().QueryRowContext(ctx, CheckLocationNameExistence, name).Scan(&locationID); err != nil {
+	if err := dal.queryRow(ctx, CheckLocationNameExistence, name).Scan(&locationID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
 		}
@@ -140,8 +215,159 @@ func (dal *LocationsRepository) GetPaginatedLocations(ctx monitor.ApplicationCon
 
 	var result domain.CursorPage[domain.Location]
 
+	if len(filters.Sort) == 0 {
+		filters.Sort = domain.DefaultLocationSortOrder
+	}
+
 	// Build base query
-	baseSelectQuery := dal.queryBuilder.Select(
+	baseSelectQuery, err := dal.paginate(
+		applyLocationsFilters(dal.selectLocations(ctx), filters), filters.CursorPaginationFilters, filters.CursorScope(), locationSortColumns, "l.id",
+	)
+	if err != nil {
+		return result, err
+	}
+
+	selectQueryStr, args, err := baseSelectQuery.ToSql()
+	if err != nil {
+		return result, fmt.Errorf("error when building GetPaginatedLocations query: %w", err)
+	}
+
+	rows, err := dal.query(ctx, selectQueryStr, args...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+	defer rows.Close()
+
+	locations := make([]domain.Location, 0)
+	for rows.Next() {
+		var location domain.Location
+		if err := rows.Scan(locationScanDestinations(&location)...); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return result, err
+		}
+		locations = append(locations, location)
+	}
+
+	if err = rowsErr(rows); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	return domain.BuildCursorPage(locations, filters.CursorPaginationFilters, filters.CursorScope(), dal.cursorCodec)
+}
+
+// GetNearbyLocations returns the locations within the radius ordered by great-circle distance. A bounding box on the
+// coordinates discards far away rows before the exact distance is computed.
+func (dal *LocationsRepository) GetNearbyLocations(
+	ctx monitor.ApplicationContext,
+	filters domain.NearbyLocationsFilters,
+) ([]domain.NearbyLocation, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.GetNearbyLocations")
+	defer span.End()
+
+	distanceArgs := []any{filters.Latitude, filters.Latitude, filters.Longitude}
+
+	query := applyLocationsFilters(dal.selectLocations(ctx), filters.LocationsFilters).
+		Column(sq.Alias(sq.Expr(haversineDistanceExpr, distanceArgs...), "distance_m"))
+
+	box := domain.NewBoundingBox(filters.Latitude, filters.Longitude, filters.RadiusMeters)
+	query = query.Where(sq.Expr("li.latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude))
+	if !box.AllLongitudes {
+		query = query.Where(sq.Expr("li.longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude))
+	}
+
+	query = query.Where(sq.Expr(haversineDistanceExpr+" <= ?", append(distanceArgs, filters.RadiusMeters)...)).
+		OrderBy("distance_m ASC", "l.name ASC").
+		Limit(uint64(filters.Limit))
+
+	selectQueryStr, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error when building GetNearbyLocations query: %w", err)
+	}
+
+	rows, err := dal.query(ctx, selectQueryStr, args...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make([]domain.NearbyLocation, 0)
+	for rows.Next() {
+		var location domain.NearbyLocation
+		if err = rows.Scan(append(locationScanDestinations(&location.Location), &location.DistanceMeters)...); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+
+	if err = rowsErr(rows); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// StreamLocations calls fn with every location matching the filters, in the order of filters.Sort. Rows are read one
+// at a time from the database cursor, so the result set is never held in memory. Pagination filters other than the
+// sort are ignored and an error returned by fn stops the iteration.
+func (dal *LocationsRepository) StreamLocations(
+	ctx monitor.ApplicationContext,
+	filters domain.LocationsFilters,
+	fn func(location domain.Location) error,
+) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.StreamLocations")
+	defer span.End()
+
+	if len(filters.Sort) == 0 {
+		filters.Sort = domain.DefaultLocationSortOrder
+	}
+
+	keyset, err := buildKeyset(filters.Sort, locationSortColumns, "l.id")
+	if err != nil {
+		return err
+	}
+
+	selectQueryStr, args, err := applyLocationsFilters(dal.selectLocations(ctx), filters).
+		OrderBy(keysetOrderBy(keyset, false)...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error when building StreamLocations query: %w", err)
+	}
+
+	rows, err := dal.query(ctx, selectQueryStr, args...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var location domain.Location
+		if err = rows.Scan(locationScanDestinations(&location)...); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+
+		if err = fn(location); err != nil {
+			return err
+		}
+	}
+
+	if err = rowsErr(rows); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// selectLocations only selects the locations the caller can see
+func (dal *LocationsRepository) selectLocations(ctx monitor.ApplicationContext) sq.SelectBuilder {
+	query := dal.queryBuilder.Select(
 		"l.id",
 		"l.name",
 		"l.active",
@@ -159,6 +385,9 @@ func (dal *LocationsRepository) GetPaginatedLocations(ctx monitor.ApplicationCon
 		"li.email",
 		"li.latitude",
 		"li.longitude",
+		"l.deleted_at",
+		"l.version",
+		"l.created_at",
 	).From("location.locations l").InnerJoin(
 		"location.location_information li on l.id = li.location_id",
 	).InnerJoin(
@@ -167,78 +396,66 @@ func (dal *LocationsRepository) GetPaginatedLocations(ctx monitor.ApplicationCon
 		"location.suppliers s on s.id = l.supplier_id",
 	)
 
-	// Add filters
+	return applyTenantFilter(ctx, query)
+}
+
+func applyLocationsFilters(query sq.SelectBuilder, filters domain.LocationsFilters) sq.SelectBuilder {
+	if !filters.IncludeDeleted {
+		query = query.Where("l.deleted_at IS NULL")
+	}
+
 	if filters.Name != nil {
 		filterClause := "l.name ILIKE CONCAT ('%',?::text,'%')"
-		baseSelectQuery = baseSelectQuery.Where(filterClause, *filters.Name)
+		query = query.Where(filterClause, *filters.Name)
 	}
 
-	// Pagination filters
-	if filters.CursorPaginationFilters.Cursor == "" {
-		baseSelectQuery = baseSelectQuery.OrderBy("l.name ASC").Limit(uint64(filters.Limit) + 1)
-	} else {
-		var paginationClause, orderClause string
+	if filters.City != nil {
+		query = query.Where("li.city = ?", *filters.City)
+	}
 
-		if filters.CursorPaginationFilters.Direction == domain.NextPage {
-			paginationClause = "l.name > ?"
-			orderClause = "l.name ASC"
-		} else {
-			paginationClause = "l.name < ?"
-			orderClause = "l.name DESC"
-		}
-		baseSelectQuery = baseSelectQuery.Where(paginationClause, filters.Cursor).OrderBy(orderClause).Limit(uint64(filters.Limit) + 1)
+	if filters.State != nil {
+		query = query.Where("li.state = ?", *filters.State)
 	}
 
-	selectQueryStr, args, err := baseSelectQuery.ToSql()
-	if err != nil {
-		return result, fmt.Errorf("error when building GetPaginatedLocations query: %w", err)
+	if filters.Zipcode != nil {
+		query = query.Where("li.zipcode = ?", *filters.Zipcode)
 	}
 
-	rows, err := dal.getDBReader().QueryContext(ctx, selectQueryStr, args...)
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
-		return result, err
+	if len(filters.LocationTypeIDs) > 0 {
+		query = query.Where(sq.Eq{"l.location_type_id": filters.LocationTypeIDs})
 	}
-	defer rows.Close()
 
-	locations := make([]domain.Location, 0)
-	for rows.Next() {
-		var location domain.Location
-		if err := rows.Scan(
-			&location.ID,
-			&location.Name,
-			&location.Active,
-			&location.Supplier.ID,
-			&location.Supplier.Name,
-			&location.LocationType.ID,
-			&location.LocationType.Type,
-			&location.Information.ID,
-			&location.Information.Address,
-			&location.Information.City,
-			&location.Information.State,
-			&location.Information.Zipcode,
-			&location.Information.ContactInformation.ContactPerson,
-			&location.Information.ContactInformation.PhoneNumber,
-			&location.Information.ContactInformation.Email,
-			&location.Information.Latitude,
-			&location.Information.Longitude,
-		); err != nil {
-			span.SetStatus(codes.Error, err.Error())
-			return result, err
-		}
-		locations = append(locations, location)
+	if len(filters.SupplierIDs) > 0 {
+		query = query.Where(sq.Eq{"l.supplier_id": filters.SupplierIDs})
 	}
 
-	result = domain.BuildCursorPage(locations, filters.CursorPaginationFilters)
+	if filters.Active != nil {
+		query = query.Where("l.active = ?", *filters.Active)
+	}
 
-	return result, nil
-}
+	if filters.CreatedFrom != nil {
+		query = query.Where(sq.GtOrEq{"l.created_at": *filters.CreatedFrom})
+	}
 
-// nolint
-func (dal *LocationsRepository) parseLocationFromRow(row *sql.Row) (*domain.Location, error) {
-	var location domain.Location
+	if filters.CreatedTo != nil {
+		query = query.Where(sq.Lt{"l.created_at": *filters.CreatedTo})
+	}
+
+	// updated_at is only set by the first update, until then the creation is the last modification
+	if filters.UpdatedFrom != nil {
+		query = query.Where(sq.GtOrEq{"COALESCE(l.updated_at, l.created_at)": *filters.UpdatedFrom})
+	}
 
-	if err := row.Scan(
+	if filters.UpdatedTo != nil {
+		query = query.Where(sq.Lt{"COALESCE(l.updated_at, l.created_at)": *filters.UpdatedTo})
+	}
+
+	return query
+}
+
+// locationScanDestinations matches the columns of selectLocations and GetLocationByID
+func locationScanDestinations(location *domain.Location) []any {
+	return []any{
 		&location.ID,
 		&location.Name,
 		&location.Active,
@@ -256,7 +473,17 @@ func (dal *LocationsRepository) parseLocationFromRow(row *sql.Row) (*domain.Loca
 		&location.Information.ContactInformation.Email,
 		&location.Information.Latitude,
 		&location.Information.Longitude,
-	); err != nil {
+		&location.DeletedAt,
+		&location.Version,
+		&location.CreatedAt,
+	}
+}
+
+// nolint
+func (dal *LocationsRepository) parseLocationFromRow(row rowScanner) (*domain.Location, error) {
+	var location domain.Location
+
+	if err := row.Scan(locationScanDestinations(&location)...); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
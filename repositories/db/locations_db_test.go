@@ -2,9 +2,13 @@ package db
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go-service-template/domain"
@@ -12,6 +16,7 @@ import (
 	"go-service-template/utils"
 	"log"
 	"testing"
+	"time"
 )
 
 var (
@@ -75,6 +80,7 @@ func (s *LocationsDALSuite) SetupTest() {
 	s.repo = &LocationsRepository{
 		TxDBContext:  CreateTxDBContext(db),
 		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		cursorCodec:  domain.NewCursorCodec("test-key"),
 	}
 }
 
@@ -82,6 +88,57 @@ func TestLocationsDALSuiteSuite(t *testing.T) {
 	suite.Run(t, new(LocationsDALSuite))
 }
 
+func (s *LocationsDALSuite) expectLocationRead(location domain.Location) {
+	s.sqlMock.ExpectQuery(GetLocationByID).WithArgs(location.ID, nil).WillReturnRows(
+		sqlmock.NewRows(
+			[]string{
+				"l.id", "l.name", "l.active",
+				"s.id", "s.name",
+				"lt.id", "lt.type",
+				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at",
+			},
+		).AddRow(
+			location.ID, location.Name, location.Active,
+			location.Supplier.ID, location.Supplier.Name,
+			location.LocationType.ID, location.LocationType.Type,
+			location.Information.ID, location.Information.Address, location.Information.City, location.Information.State,
+			location.Information.Zipcode, location.Information.ContactInformation.ContactPerson,
+			location.Information.ContactInformation.PhoneNumber, location.Information.ContactInformation.Email,
+			location.Information.Latitude, location.Information.Longitude,
+			location.DeletedAt, location.Version, location.CreatedAt,
+		),
+	)
+}
+
+func (s *LocationsDALSuite) encodeCursor(order domain.SortOrder, scope, id string, values ...any) string {
+	cursor, err := s.repo.cursorCodec.Encode(domain.Cursor{Sort: order.String(), Scope: scope, Values: values, ID: id})
+	if err != nil {
+		s.FailNow("could not encode cursor", err.Error())
+	}
+
+	return cursor
+}
+
+func (s *LocationsDALSuite) decodeCursor(order domain.SortOrder, scope string, token *string) domain.Cursor {
+	if token == nil {
+		s.FailNow("expected a cursor")
+	}
+
+	cursor, err := s.repo.cursorCodec.Decode(*token, order, scope)
+	if err != nil {
+		s.FailNow("could not decode cursor", err.Error())
+	}
+
+	return cursor
+}
+
+func (s *LocationsDALSuite) expectLocationHistoryEntry(locationID, operation string) {
+	s.sqlMock.ExpectPrepare(InsertLocationHistory).ExpectExec().WithArgs(
+		locationID, operation, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil,
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
 func (s *LocationsDALSuite) Test_CreateLocation_Success() {
 	s.sqlMock.ExpectPrepare(InsertLocation).ExpectExec().WithArgs(
 		testLocation.ID,
@@ -89,6 +146,7 @@ func (s *LocationsDALSuite) Test_CreateLocation_Success() {
 		testLocation.LocationType.ID,
 		testLocation.Supplier.ID,
 		testLocation.Active,
+		testLocation.CreatedAt,
 	).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	s.sqlMock.ExpectPrepare(InsertLocationInformation).ExpectExec().WithArgs(
@@ -105,6 +163,8 @@ func (s *LocationsDALSuite) Test_CreateLocation_Success() {
 		testLocation.Information.Longitude,
 	).WillReturnResult(sqlmock.NewResult(1, 1))
 
+	s.expectLocationHistoryEntry(testLocation.ID, domain.LocationCreatedOperation)
+
 	err := s.repo.CreateLocation(mockCtx, testLocation)
 
 	assert.Nil(s.T(), err)
@@ -113,6 +173,26 @@ func (s *LocationsDALSuite) Test_CreateLocation_Success() {
 	}
 }
 
+func (s *LocationsDALSuite) Test_CreateLocation_ReturnsNameAlreadyInUseErrOnUniqueViolation() {
+	s.sqlMock.ExpectPrepare(InsertLocation).ExpectExec().WithArgs(
+		testLocation.ID,
+		testLocation.Name,
+		testLocation.LocationType.ID,
+		testLocation.Supplier.ID,
+		testLocation.Active,
+		testLocation.CreatedAt,
+	).WillReturnError(&pq.Error{Code: sqlStateUniqueViolation, Constraint: "locations_name"})
+
+	err := s.repo.CreateLocation(mockCtx, testLocation)
+
+	var nameErr domain.NameAlreadyInUseErr
+	assert.ErrorAs(s.T(), err, &nameErr)
+	assert.Equal(s.T(), domain.ResourceLocation, nameErr.Resource)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func (s *LocationsDALSuite) Test_CreateSubLocation_Success() {
 	s.sqlMock.ExpectPrepare(InsertSubLocation).ExpectExec().WithArgs(
 		subLocation.ID,
@@ -131,12 +211,15 @@ func (s *LocationsDALSuite) Test_CreateSubLocation_Success() {
 }
 
 func (s *LocationsDALSuite) Test_UpdateLocation_Success() {
+	s.expectLocationRead(testLocation)
+
 	s.sqlMock.ExpectPrepare(UpdateLocation).ExpectExec().WithArgs(
 		testLocation.Name,
 		testLocation.LocationType.ID,
 		testLocation.Supplier.ID,
 		testLocation.Active,
 		testLocation.ID,
+		nil,
 	).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	s.sqlMock.ExpectPrepare(UpdateLocationInformation).ExpectExec().WithArgs(
@@ -152,6 +235,8 @@ func (s *LocationsDALSuite) Test_UpdateLocation_Success() {
 		testLocation.Information.ID,
 	).WillReturnResult(sqlmock.NewResult(1, 1))
 
+	s.expectLocationHistoryEntry(testLocation.ID, domain.LocationUpdatedOperation)
+
 	err := s.repo.UpdateLocation(mockCtx, testLocation)
 
 	assert.Nil(s.T(), err)
@@ -163,19 +248,21 @@ func (s *LocationsDALSuite) Test_UpdateLocation_Success() {
 func (s *LocationsDALSuite) Test_GetLocationByID_Success() {
 	locationID := uuid.New().String()
 
-	s.sqlMock.ExpectQuery(GetLocationByID).WithArgs(locationID).WillReturnRows(
+	s.sqlMock.ExpectQuery(GetLocationByID).WithArgs(locationID, nil).WillReturnRows(
 		sqlmock.NewRows(
 			[]string{
 				"l.id", "l.name", "l.active",
 				"s.id", "s.name",
 				"lt.id", "lt.type",
 				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at",
 			},
 		).AddRow(
 			locationID, "locName", true,
 			1, "supplierName",
 			2, "locationType",
 			"locInfID", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 90.0, -90.0,
+			nil, 1, time.Now(),
 		),
 	)
 
@@ -191,6 +278,37 @@ func (s *LocationsDALSuite) Test_GetLocationByID_Success() {
 	}
 }
 
+func (s *LocationsDALSuite) Test_SoftDeleteLocation_Success() {
+	deletedAt := time.Now().UTC()
+
+	s.expectLocationRead(testLocation)
+	s.sqlMock.ExpectPrepare(SoftDeleteLocation).ExpectExec().WithArgs(deletedAt, testLocation.ID, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.expectLocationHistoryEntry(testLocation.ID, domain.LocationDeletedOperation)
+
+	err := s.repo.SoftDeleteLocation(mockCtx, testLocation.ID, deletedAt)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_RestoreLocation_Success() {
+	deletedLocation := testLocation
+	deletedLocation.DeletedAt = utils.ToPointer(time.Now())
+
+	s.expectLocationRead(deletedLocation)
+	s.sqlMock.ExpectPrepare(RestoreLocation).ExpectExec().WithArgs(testLocation.ID, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.expectLocationHistoryEntry(testLocation.ID, domain.LocationRestoredOperation)
+
+	err := s.repo.RestoreLocation(mockCtx, testLocation.ID)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func (s *LocationsDALSuite) Test_CheckLocationNameExistence_ReturnsTrueIfNameExists() {
 	s.sqlMock.ExpectQuery(CheckLocationNameExistence).WithArgs(existingName).WillReturnRows(
 		sqlmock.NewRows([]string{"id"}).AddRow(uuid.New().String()),
@@ -222,12 +340,13 @@ func (s *LocationsDALSuite) Test_CheckLocationNameExistence_ReturnsFalseIfNameDo
 func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnNextDirection() {
 	filters := domain.LocationsFilters{
 		CursorPaginationFilters: domain.CursorPaginationFilters{
-			Cursor:    "val",
 			Direction: domain.NextPage,
 			Limit:     10,
+			Sort:      domain.SortOrder{{Field: domain.SortByCreatedAt, Descending: true}, {Field: domain.SortByName}},
 		},
 		Name: utils.ToPointer[string]("name"),
 	}
+	filters.Cursor = s.encodeCursor(filters.Sort, filters.CursorScope(), "prevID", "2024-01-01T00:00:00Z", "prevName")
 
 	expectedQuery := `SELECT 
     	l.id, 
@@ -246,27 +365,35 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnNextDirection()
     	li.phone_number, 
     	li.email, 
     	li.latitude, 
-    	li.longitude
+    	li.longitude, 
+    	l.deleted_at, 
+    	l.version, 
+    	l.created_at
 	FROM location.locations l 
 	    INNER JOIN location.location_information li on l.id = li.location_id 
 	    INNER JOIN location.location_types lt on l.location_type_id = lt.id 
 	    INNER JOIN location.suppliers s on s.id = l.supplier_id 
-	  WHERE l.name ILIKE CONCAT ('%',$1::text,'%') 
-	  AND l.name > $2 ORDER BY l.name ASC LIMIT 11`
+	  WHERE l.deleted_at IS NULL AND l.name ILIKE CONCAT ('%',$1::text,'%') 
+	  AND ((l.created_at < $2) OR (l.created_at = $3 AND l.name > $4) OR (l.created_at = $5 AND l.name = $6 AND l.id > $7)) 
+	  ORDER BY l.created_at DESC, l.name ASC, l.id ASC LIMIT 11`
 
-	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(*filters.Name, filters.Cursor).WillReturnRows(
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(
+		*filters.Name, "2024-01-01T00:00:00Z", "2024-01-01T00:00:00Z", "prevName", "2024-01-01T00:00:00Z", "prevName", "prevID",
+	).WillReturnRows(
 		sqlmock.NewRows(
 			[]string{
 				"l.id", "l.name", "l.active",
 				"s.id", "s.name",
 				"lt.id", "lt.type",
 				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at",
 			},
 		).AddRow(
 			"uuid", "locName", true,
 			1, "supplierName",
 			2, "locationType",
 			"locInfID", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 90.0, -90.0,
+			nil, 1, time.Now(),
 		),
 	)
 
@@ -274,7 +401,7 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnNextDirection()
 
 	assert.Nil(s.T(), err)
 	assert.Nil(s.T(), resp.NextPage)
-	assert.Equal(s.T(), "locName", *resp.PreviousPage)
+	assert.Equal(s.T(), "uuid", s.decodeCursor(filters.Sort, filters.CursorScope(), resp.PreviousPage).ID)
 	assert.Len(s.T(), resp.Data, 1)
 	assert.Equal(s.T(), filters.Limit, resp.Limit)
 	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
@@ -285,12 +412,13 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnNextDirection()
 func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnPrevDirection() {
 	filters := domain.LocationsFilters{
 		CursorPaginationFilters: domain.CursorPaginationFilters{
-			Cursor:    "val",
 			Direction: domain.PreviousPage,
 			Limit:     10,
+			Sort:      domain.DefaultLocationSortOrder,
 		},
 		Name: utils.ToPointer[string]("name"),
 	}
+	filters.Cursor = s.encodeCursor(filters.Sort, filters.CursorScope(), "nextID", "nextName")
 
 	expectedQuery := `SELECT 
     	l.id, 
@@ -309,34 +437,39 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnPrevDirection()
     	li.phone_number, 
     	li.email, 
     	li.latitude, 
-    	li.longitude
+    	li.longitude, 
+    	l.deleted_at, 
+    	l.version, 
+    	l.created_at
 	FROM location.locations l 
 	    INNER JOIN location.location_information li on l.id = li.location_id 
 	    INNER JOIN location.location_types lt on l.location_type_id = lt.id 
 	    INNER JOIN location.suppliers s on s.id = l.supplier_id 
-	  WHERE l.name ILIKE CONCAT ('%',$1::text,'%') 
-	  AND l.name < $2 ORDER BY l.name DESC LIMIT 11`
+	  WHERE l.deleted_at IS NULL AND l.name ILIKE CONCAT ('%',$1::text,'%') 
+	  AND ((l.name < $2) OR (l.name = $3 AND l.id < $4)) ORDER BY l.name DESC, l.id DESC LIMIT 11`
 
-	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(*filters.Name, filters.CursorPaginationFilters.Cursor).WillReturnRows(
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(*filters.Name, "nextName", "nextName", "nextID").WillReturnRows(
 		sqlmock.NewRows(
 			[]string{
 				"l.id", "l.name", "l.active",
 				"s.id", "s.name",
 				"lt.id", "lt.type",
 				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at",
 			},
 		).AddRow(
 			"uuid", "locName", true,
 			1, "supplierName",
 			2, "locationType",
 			"locInfID", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 90.0, -90.0,
+			nil, 1, time.Now(),
 		),
 	)
 
 	resp, err := s.repo.GetPaginatedLocations(mockCtx, filters)
 
 	assert.Nil(s.T(), err)
-	assert.Equal(s.T(), "locName", *resp.NextPage)
+	assert.Equal(s.T(), []any{"locName"}, s.decodeCursor(filters.Sort, filters.CursorScope(), resp.NextPage).Values)
 	assert.Nil(s.T(), resp.PreviousPage)
 	assert.Len(s.T(), resp.Data, 1)
 	assert.Equal(s.T(), filters.Limit, resp.Limit)
@@ -345,6 +478,78 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnPrevDirection()
 	}
 }
 
+func (s *LocationsDALSuite) Test_GetPaginatedLocations_AppliesAttributeFilters() {
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedTo := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	filters := domain.LocationsFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{
+			Cursor:    "",
+			Direction: domain.NextPage,
+			Limit:     10,
+		},
+		City:            utils.ToPointer[string]("city"),
+		State:           utils.ToPointer[string]("state"),
+		Zipcode:         utils.ToPointer[string]("zipcode"),
+		LocationTypeIDs: []int{1, 2},
+		SupplierIDs:     []int{3},
+		Active:          utils.ToPointer[bool](true),
+		CreatedFrom:     &createdFrom,
+		UpdatedTo:       &updatedTo,
+		IncludeDeleted:  true,
+	}
+
+	expectedQuery := `SELECT 
+    	l.id, 
+    	l.name, 
+    	l.active, 
+    	s.id, 
+    	s.name, 
+    	lt.id, 
+    	lt.type, 
+    	li.id, 
+    	li.address, 
+    	li.city, 
+    	li.state, 
+    	li.zipcode, 
+    	li.contact_person, 
+    	li.phone_number, 
+    	li.email, 
+    	li.latitude, 
+    	li.longitude, 
+    	l.deleted_at, 
+    	l.version, 
+    	l.created_at
+	FROM location.locations l 
+	    INNER JOIN location.location_information li on l.id = li.location_id 
+	    INNER JOIN location.location_types lt on l.location_type_id = lt.id 
+	    INNER JOIN location.suppliers s on s.id = l.supplier_id 
+	  WHERE li.city = $1 AND li.state = $2 AND li.zipcode = $3 AND l.location_type_id IN ($4,$5) 
+	  AND l.supplier_id IN ($6) AND l.active = $7 AND l.created_at >= $8 AND COALESCE(l.updated_at, l.created_at) < $9 
+	  ORDER BY l.name ASC, l.id ASC LIMIT 11`
+
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(
+		*filters.City, *filters.State, *filters.Zipcode, 1, 2, 3, true, createdFrom, updatedTo,
+	).WillReturnRows(
+		sqlmock.NewRows(
+			[]string{
+				"l.id", "l.name", "l.active",
+				"s.id", "s.name",
+				"lt.id", "lt.type",
+				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at",
+			},
+		),
+	)
+
+	resp, err := s.repo.GetPaginatedLocations(mockCtx, filters)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), resp.Data, 0)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnEmptyCursor() {
 	filters := domain.LocationsFilters{
 		CursorPaginationFilters: domain.CursorPaginationFilters{
@@ -372,13 +577,16 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnEmptyCursor() {
     	li.phone_number, 
     	li.email, 
     	li.latitude, 
-    	li.longitude
+    	li.longitude, 
+    	l.deleted_at, 
+    	l.version, 
+    	l.created_at
 	FROM location.locations l 
 	    INNER JOIN location.location_information li on l.id = li.location_id 
 	    INNER JOIN location.location_types lt on l.location_type_id = lt.id 
 	    INNER JOIN location.suppliers s on s.id = l.supplier_id 
-	  WHERE l.name ILIKE CONCAT ('%',$1::text,'%') 
-		ORDER BY l.name ASC LIMIT 11`
+	  WHERE l.deleted_at IS NULL AND l.name ILIKE CONCAT ('%',$1::text,'%') 
+		ORDER BY l.name ASC, l.id ASC LIMIT 11`
 
 	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(*filters.Name).WillReturnRows(
 		sqlmock.NewRows(
@@ -387,12 +595,14 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnEmptyCursor() {
 				"s.id", "s.name",
 				"lt.id", "lt.type",
 				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at",
 			},
 		).AddRow(
 			"uuid", "locName", true,
 			1, "supplierName",
 			2, "locationType",
 			"locInfID", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 90.0, -90.0,
+			nil, 1, time.Now(),
 		),
 	)
 
@@ -407,3 +617,291 @@ func (s *LocationsDALSuite) Test_GetPaginatedLocations_SuccessOnEmptyCursor() {
 		s.T().Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func (s *LocationsDALSuite) Test_GetPaginatedLocations_FailsOnRowError() {
+	filters := domain.LocationsFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{Direction: domain.NextPage, Limit: 10},
+		Name:                    utils.ToPointer[string]("name"),
+	}
+	rowErr := &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"}
+
+	expectedQuery := `SELECT 
+    	l.id, 
+    	l.name, 
+    	l.active, 
+    	s.id, 
+    	s.name, 
+    	lt.id, 
+    	lt.type, 
+    	li.id, 
+    	li.address, 
+    	li.city, 
+    	li.state, 
+    	li.zipcode, 
+    	li.contact_person, 
+    	li.phone_number, 
+    	li.email, 
+    	li.latitude, 
+    	li.longitude, 
+    	l.deleted_at, 
+    	l.version, 
+    	l.created_at
+	FROM location.locations l 
+	    INNER JOIN location.location_information li on l.id = li.location_id 
+	    INNER JOIN location.location_types lt on l.location_type_id = lt.id 
+	    INNER JOIN location.suppliers s on s.id = l.supplier_id 
+	  WHERE l.deleted_at IS NULL AND l.name ILIKE CONCAT ('%',$1::text,'%') 
+		ORDER BY l.name ASC, l.id ASC LIMIT 11`
+
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(*filters.Name).WillReturnRows(
+		sqlmock.NewRows(
+			[]string{
+				"l.id", "l.name", "l.active",
+				"s.id", "s.name",
+				"lt.id", "lt.type",
+				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at",
+			},
+		).AddRow(
+			"uuid1", "locName1", true,
+			1, "supplierName",
+			2, "locationType",
+			"locInfID1", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 90.0, -90.0,
+			nil, 1, time.Now(),
+		).AddRow(
+			"uuid2", "locName2", true,
+			1, "supplierName",
+			2, "locationType",
+			"locInfID2", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 90.0, -90.0,
+			nil, 1, time.Now(),
+		).RowError(1, rowErr),
+	)
+
+	resp, err := s.repo.GetPaginatedLocations(mockCtx, filters)
+
+	assert.IsType(s.T(), domain.TimeoutErr{}, err)
+	assert.Empty(s.T(), resp.Data)
+	assert.Nil(s.T(), resp.NextPage)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetNearbyLocations_Success() {
+	filters := domain.NearbyLocationsFilters{
+		LocationsFilters: domain.LocationsFilters{
+			CursorPaginationFilters: domain.CursorPaginationFilters{Limit: 5},
+			Active:                  utils.ToPointer(true),
+		},
+		Latitude:     0,
+		Longitude:    0,
+		RadiusMeters: 1000,
+	}
+	box := domain.NewBoundingBox(filters.Latitude, filters.Longitude, filters.RadiusMeters)
+	distance := func(first, second, third int) string {
+		return fmt.Sprintf(`(2 * 6371000 * ASIN(LEAST(1, SQRT(
+			POWER(SIN(RADIANS(li.latitude::float8 - $%v::float8) / 2), 2) +
+			COS(RADIANS($%v::float8)) * COS(RADIANS(li.latitude::float8)) *
+			POWER(SIN(RADIANS(li.longitude::float8 - $%v::float8) / 2), 2)
+		))))`, first, second, third)
+	}
+
+	expectedQuery := `SELECT l.id, l.name, l.active, s.id, s.name, lt.id, lt.type, li.id, li.address, li.city, li.state, li.zipcode, 
+		li.contact_person, li.phone_number, li.email, li.latitude, li.longitude, l.deleted_at, l.version, l.created_at, (` + distance(1, 2, 3) + `) AS distance_m 
+		FROM location.locations l 
+		INNER JOIN location.location_information li on l.id = li.location_id 
+		INNER JOIN location.location_types lt on l.location_type_id = lt.id 
+		INNER JOIN location.suppliers s on s.id = l.supplier_id 
+		WHERE l.deleted_at IS NULL AND l.active = $4 AND li.latitude BETWEEN $5 AND $6 AND li.longitude BETWEEN $7 AND $8 
+		AND ` + distance(9, 10, 11) + ` <= $12 ORDER BY distance_m ASC, l.name ASC LIMIT 5`
+
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(
+		0.0, 0.0, 0.0, true, box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude, 0.0, 0.0, 0.0, 1000.0,
+	).WillReturnRows(
+		sqlmock.NewRows(
+			[]string{
+				"l.id", "l.name", "l.active",
+				"s.id", "s.name",
+				"lt.id", "lt.type",
+				"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+				"l.deleted_at", "l.version", "l.created_at", "distance_m",
+			},
+		).AddRow(
+			"uuid", "locName", true,
+			1, "supplierName",
+			2, "locationType",
+			"locInfID", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 0.001, 0.001,
+			nil, 1, time.Now(), 157.2,
+		),
+	)
+
+	locations, err := s.repo.GetNearbyLocations(mockCtx, filters)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), locations, 1)
+	assert.Equal(s.T(), "uuid", locations[0].ID)
+	assert.Equal(s.T(), 157.2, locations[0].DistanceMeters)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) expectStreamLocationsQuery(whereAndOrder string, args ...driver.Value) *sqlmock.ExpectedQuery {
+	expectedQuery := `SELECT l.id, l.name, l.active, s.id, s.name, lt.id, lt.type, li.id, li.address, li.city, li.state, li.zipcode, 
+		li.contact_person, li.phone_number, li.email, li.latitude, li.longitude, l.deleted_at, l.version, l.created_at 
+		FROM location.locations l 
+		INNER JOIN location.location_information li on l.id = li.location_id 
+		INNER JOIN location.location_types lt on l.location_type_id = lt.id 
+		INNER JOIN location.suppliers s on s.id = l.supplier_id 
+		` + whereAndOrder
+
+	return s.sqlMock.ExpectQuery(expectedQuery).WithArgs(args...)
+}
+
+func streamedLocationRows(ids ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows(
+		[]string{
+			"l.id", "l.name", "l.active",
+			"s.id", "s.name",
+			"lt.id", "lt.type",
+			"li.id", "li.address", "li.city", "li.state", "li.zipcode", "li.contact_person", "li.phone_number", "li.email", "li.latitude", "li.longitude",
+			"l.deleted_at", "l.version", "l.created_at",
+		},
+	)
+	for _, id := range ids {
+		rows.AddRow(
+			id, "locName "+id, true,
+			1, "supplierName",
+			2, "locationType",
+			"locInfID", "address", "city", "state", "zipcode", "contactPerson", "phone", "email", 0.001, 0.001,
+			nil, 1, time.Now(),
+		)
+	}
+
+	return rows
+}
+
+func (s *LocationsDALSuite) Test_StreamLocations_CallsFnForEveryRowInSortOrder() {
+	filters := domain.LocationsFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{
+			Limit:  1,
+			Cursor: "ignored",
+			Sort:   domain.SortOrder{{Field: domain.SortByCity, Descending: true}},
+		},
+		State: utils.ToPointer("FL"),
+	}
+
+	s.expectStreamLocationsQuery(
+		"WHERE l.deleted_at IS NULL AND li.state = $1 ORDER BY li.city DESC, l.id ASC", "FL",
+	).WillReturnRows(streamedLocationRows("first", "second", "third"))
+
+	var ids []string
+	err := s.repo.StreamLocations(mockCtx, filters, func(location domain.Location) error {
+		ids = append(ids, location.ID)
+		return nil
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"first", "second", "third"}, ids)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_StreamLocations_StopsWhenFnFails() {
+	fnErr := errors.New("client went away")
+
+	s.expectStreamLocationsQuery(
+		"WHERE l.deleted_at IS NULL ORDER BY l.name ASC, l.id ASC",
+	).WillReturnRows(streamedLocationRows("first", "second"))
+
+	calls := 0
+	err := s.repo.StreamLocations(mockCtx, domain.LocationsFilters{}, func(location domain.Location) error {
+		calls++
+		return fnErr
+	})
+
+	assert.ErrorIs(s.T(), err, fnErr)
+	assert.Equal(s.T(), 1, calls)
+}
+
+func (s *LocationsDALSuite) Test_GetLocationByID_ReturnsTimeoutErrOnCancelledQuery() {
+	locationID := uuid.New().String()
+
+	s.sqlMock.ExpectQuery(GetLocationByID).WithArgs(locationID, nil).
+		WillReturnError(&pq.Error{Code: sqlStateQueryCanceled, Message: "canceling statement due to statement timeout"})
+
+	location, err := s.repo.GetLocationByID(mockCtx, locationID)
+
+	assert.ErrorAs(s.T(), err, &domain.TimeoutErr{})
+	assert.Nil(s.T(), location)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetLocationByID_ReturnsNilForLocationOfOtherSupplier() {
+	locationID := uuid.New().String()
+	supplierCtx := mockCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", SupplierID: utils.ToPointer(7)})
+
+	s.sqlMock.ExpectQuery(GetLocationByID).WithArgs(locationID, 7).WillReturnRows(
+		sqlmock.NewRows([]string{"l.id"}),
+	)
+
+	location, err := s.repo.GetLocationByID(supplierCtx, locationID)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), location)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetPaginatedLocations_OnlyReturnsLocationsOfTenantSupplier() {
+	filters := domain.LocationsFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{
+			Direction: domain.NextPage,
+			Limit:     10,
+			Sort:      domain.DefaultLocationSortOrder,
+		},
+	}
+	supplierCtx := mockCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", SupplierID: utils.ToPointer(7)})
+
+	expectedQuery := `SELECT 
+    	l.id, 
+    	l.name, 
+    	l.active, 
+    	s.id, 
+    	s.name, 
+    	lt.id, 
+    	lt.type, 
+    	li.id, 
+    	li.address, 
+    	li.city, 
+    	li.state, 
+    	li.zipcode, 
+    	li.contact_person, 
+    	li.phone_number, 
+    	li.email, 
+    	li.latitude, 
+    	li.longitude, 
+    	l.deleted_at, 
+    	l.version, 
+    	l.created_at
+	FROM location.locations l 
+	    INNER JOIN location.location_information li on l.id = li.location_id 
+	    INNER JOIN location.location_types lt on l.location_type_id = lt.id 
+	    INNER JOIN location.suppliers s on s.id = l.supplier_id 
+	  WHERE l.supplier_id = $1 AND l.deleted_at IS NULL 
+	  ORDER BY l.name ASC, l.id ASC LIMIT 11`
+
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"l.id"}))
+
+	resp, err := s.repo.GetPaginatedLocations(supplierCtx, filters)
+
+	assert.Nil(s.T(), err)
+	assert.Empty(s.T(), resp.Data)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
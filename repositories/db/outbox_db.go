@@ -0,0 +1,234 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/lib/pq"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+	"time"
+)
+
+func (dal *LocationsRepository) CreateOutboxMessage(ctx monitor.ApplicationContext, msg domain.OutboxMessage) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.CreateOutboxMessage")
+	defer span.End()
+
+	return insertOutboxMessage(ctx, dal.TxDBContext, msg)
+}
+
+// insertOutboxMessage writes the message with the transaction of the repository when there is one, so the message is
+// only published when the changes it announces are committed
+func insertOutboxMessage(ctx monitor.ApplicationContext, dal *TxDBContext, msg domain.OutboxMessage) error {
+	metadata, err := json.Marshal(msg.Metadata)
+	if err != nil {
+		return fmt.Errorf("error marshaling outbox message metadata: %w", err)
+	}
+
+	_, err = dal.Exec(
+		ctx,
+		InsertOutboxMessage,
+		msg.ID,
+		msg.AggregateID,
+		msg.Topic,
+		msg.Payload,
+		metadata,
+	)
+
+	return err
+}
+
+// AcquireOutboxLock takes a transaction scoped advisory lock so only one relay at a time reads the outbox. This keeps
+// the per aggregate ordering when several instances of the service are running. It must be called inside a transaction.
+func (dal *LocationsRepository) AcquireOutboxLock(ctx monitor.ApplicationContext) (bool, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.AcquireOutboxLock")
+	defer span.End()
+
+	var acquired bool
+
+	if err := dal.queryRow(ctx, AcquireOutboxLock).Scan(&acquired); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+
+	return acquired, nil
+}
+
+func (dal *LocationsRepository) GetPendingOutboxMessages(ctx monitor.ApplicationContext, limit int) ([]domain.OutboxMessage, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.GetPendingOutboxMessages")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetPendingOutboxMessages, limit)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]domain.OutboxMessage, 0)
+	for rows.Next() {
+		var msg domain.OutboxMessage
+		var metadata []byte
+
+		if err = rows.Scan(
+			&msg.ID,
+			&msg.Sequence,
+			&msg.AggregateID,
+			&msg.Topic,
+			&msg.Payload,
+			&metadata,
+			&msg.CreatedAt,
+			&msg.Attempts,
+			&msg.NextAttemptAt,
+		); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		if err = json.Unmarshal(metadata, &msg.Metadata); err != nil {
+			return nil, fmt.Errorf("error unmarshaling metadata of outbox message %v: %w", msg.ID, err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if err = rowsErr(rows); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// ClaimOutboxMessages keeps the messages from being pending until claimedUntil, so they can be published outside the
+// transaction that read them without another relay taking them
+func (dal *LocationsRepository) ClaimOutboxMessages(ctx monitor.ApplicationContext, ids []string, claimedUntil time.Time) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.ClaimOutboxMessages")
+	defer span.End()
+
+	_, err := dal.Exec(ctx, ClaimOutboxMessages, pq.Array(ids), claimedUntil)
+
+	return err
+}
+
+func (dal *LocationsRepository) MarkOutboxMessagesAsPublished(ctx monitor.ApplicationContext, ids []string) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.MarkOutboxMessagesAsPublished")
+	defer span.End()
+
+	_, err := dal.Exec(ctx, MarkOutboxMessagesAsPublished, pq.Array(ids))
+
+	return err
+}
+
+// RecordOutboxMessageFailure counts a failed publish of the message, which is retried after nextAttemptAt or parked
+// when failed is true
+func (dal *LocationsRepository) RecordOutboxMessageFailure(
+	ctx monitor.ApplicationContext,
+	id string,
+	lastError string,
+	nextAttemptAt time.Time,
+	failed bool,
+) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.RecordOutboxMessageFailure")
+	defer span.End()
+
+	_, err := dal.Exec(ctx, RecordOutboxMessageFailure, id, lastError, nextAttemptAt, failed)
+
+	return err
+}
+
+// GetParkedOutboxMessages returns the oldest parked messages, each one holds back the messages of its aggregate
+func (dal *LocationsRepository) GetParkedOutboxMessages(ctx monitor.ApplicationContext, limit int) ([]domain.OutboxMessage, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.GetParkedOutboxMessages")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetParkedOutboxMessages, limit)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]domain.OutboxMessage, 0)
+	for rows.Next() {
+		var msg domain.OutboxMessage
+		var metadata []byte
+
+		if err = rows.Scan(
+			&msg.ID,
+			&msg.Sequence,
+			&msg.AggregateID,
+			&msg.Topic,
+			&msg.Payload,
+			&metadata,
+			&msg.CreatedAt,
+			&msg.Attempts,
+			&msg.LastError,
+			&msg.FailedAt,
+		); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		if err = json.Unmarshal(metadata, &msg.Metadata); err != nil {
+			return nil, fmt.Errorf("error unmarshaling metadata of outbox message %v: %w", msg.ID, err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if err = rowsErr(rows); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// RedriveParkedOutboxMessage makes the message pending again, it returns false when the message is not parked
+func (dal *LocationsRepository) RedriveParkedOutboxMessage(ctx monitor.ApplicationContext, id string) (bool, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.RedriveParkedOutboxMessage")
+	defer span.End()
+
+	res, err := dal.Exec(ctx, RedriveParkedOutboxMessage, id)
+	if err != nil {
+		return false, err
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows > 0, nil
+}
+
+// DeleteParkedOutboxMessage discards the message, it returns false when the message is not parked
+func (dal *LocationsRepository) DeleteParkedOutboxMessage(ctx monitor.ApplicationContext, id string) (bool, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.DeleteParkedOutboxMessage")
+	defer span.End()
+
+	res, err := dal.Exec(ctx, DeleteParkedOutboxMessage, id)
+	if err != nil {
+		return false, err
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows > 0, nil
+}
+
+func (dal *LocationsRepository) DeletePublishedOutboxMessages(ctx monitor.ApplicationContext, publishedBefore time.Time) (int64, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.DeletePublishedOutboxMessages")
+	defer span.End()
+
+	res, err := dal.Exec(ctx, DeletePublishedOutboxMessages, publishedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
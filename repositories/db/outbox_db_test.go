@@ -0,0 +1,219 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"log"
+	"testing"
+	"time"
+)
+
+var testOutboxMessage = domain.OutboxMessage{
+	ID:          uuid.New().String(),
+	AggregateID: uuid.New().String(),
+	Topic:       domain.LocationsNewTopic,
+	Payload:     []byte(`{"name":"location"}`),
+	Metadata:    map[string]string{"correlation_id": "corrID"},
+}
+
+type OutboxDALSuite struct {
+	suite.Suite
+	repo    *LocationsRepository
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func (s *OutboxDALSuite) SetupTest() {
+	db, sqmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("An error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.db = db
+	s.sqlMock = sqmock
+	s.repo = &LocationsRepository{
+		TxDBContext:  CreateTxDBContext(db),
+		queryBuilder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		cursorCodec:  domain.NewCursorCodec("test-key"),
+	}
+}
+
+func TestOutboxDALSuite(t *testing.T) {
+	suite.Run(t, new(OutboxDALSuite))
+}
+
+func (s *OutboxDALSuite) Test_CreateOutboxMessage_Success() {
+	s.sqlMock.ExpectPrepare(InsertOutboxMessage).ExpectExec().WithArgs(
+		testOutboxMessage.ID,
+		testOutboxMessage.AggregateID,
+		testOutboxMessage.Topic,
+		testOutboxMessage.Payload,
+		[]byte(`{"correlation_id":"corrID"}`),
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.repo.CreateOutboxMessage(mockCtx, testOutboxMessage)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_AcquireOutboxLock_Success() {
+	s.sqlMock.ExpectQuery(AcquireOutboxLock).WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+
+	acquired, err := s.repo.AcquireOutboxLock(mockCtx)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), acquired)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_GetPendingOutboxMessages_Success() {
+	createdAt := time.Now()
+
+	s.sqlMock.ExpectQuery(GetPendingOutboxMessages).WithArgs(10).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "sequence", "aggregate_id", "topic", "payload", "metadata", "created_at", "attempts", "next_attempt_at"}).AddRow(
+			testOutboxMessage.ID,
+			1,
+			testOutboxMessage.AggregateID,
+			testOutboxMessage.Topic,
+			testOutboxMessage.Payload,
+			[]byte(`{"correlation_id":"corrID"}`),
+			createdAt,
+			2,
+			createdAt,
+		),
+	)
+
+	messages, err := s.repo.GetPendingOutboxMessages(mockCtx, 10)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), messages, 1)
+	assert.Equal(s.T(), testOutboxMessage.ID, messages[0].ID)
+	assert.Equal(s.T(), int64(1), messages[0].Sequence)
+	assert.Equal(s.T(), testOutboxMessage.Metadata, messages[0].Metadata)
+	assert.Equal(s.T(), 2, messages[0].Attempts)
+	assert.Equal(s.T(), createdAt, *messages[0].NextAttemptAt)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_ClaimOutboxMessages_Success() {
+	ids := []string{uuid.New().String(), uuid.New().String()}
+	claimedUntil := time.Now()
+
+	s.sqlMock.ExpectPrepare(ClaimOutboxMessages).ExpectExec().WithArgs(pq.Array(ids), claimedUntil).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := s.repo.ClaimOutboxMessages(mockCtx, ids, claimedUntil)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_MarkOutboxMessagesAsPublished_Success() {
+	ids := []string{uuid.New().String(), uuid.New().String()}
+
+	s.sqlMock.ExpectPrepare(MarkOutboxMessagesAsPublished).ExpectExec().WithArgs(pq.Array(ids)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := s.repo.MarkOutboxMessagesAsPublished(mockCtx, ids)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_RecordOutboxMessageFailure_Success() {
+	nextAttemptAt := time.Now()
+
+	s.sqlMock.ExpectPrepare(RecordOutboxMessageFailure).ExpectExec().WithArgs(testOutboxMessage.ID, "broker unavailable", nextAttemptAt, true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := s.repo.RecordOutboxMessageFailure(mockCtx, testOutboxMessage.ID, "broker unavailable", nextAttemptAt, true)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_DeletePublishedOutboxMessages_Success() {
+	publishedBefore := time.Now()
+
+	s.sqlMock.ExpectPrepare(DeletePublishedOutboxMessages).ExpectExec().WithArgs(publishedBefore).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := s.repo.DeletePublishedOutboxMessages(mockCtx, publishedBefore)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(3), deleted)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_GetParkedOutboxMessages_Success() {
+	failedAt := time.Now()
+
+	s.sqlMock.ExpectQuery(GetParkedOutboxMessages).WithArgs(10).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "sequence", "aggregate_id", "topic", "payload", "metadata", "created_at", "attempts", "last_error", "failed_at"}).AddRow(
+			testOutboxMessage.ID,
+			1,
+			testOutboxMessage.AggregateID,
+			testOutboxMessage.Topic,
+			testOutboxMessage.Payload,
+			[]byte(`{"correlation_id":"corrID"}`),
+			failedAt,
+			20,
+			"unknown topic",
+			failedAt,
+		),
+	)
+
+	messages, err := s.repo.GetParkedOutboxMessages(mockCtx, 10)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), messages, 1)
+	assert.Equal(s.T(), testOutboxMessage.ID, messages[0].ID)
+	assert.Equal(s.T(), 20, messages[0].Attempts)
+	assert.Equal(s.T(), "unknown topic", *messages[0].LastError)
+	assert.Equal(s.T(), failedAt, *messages[0].FailedAt)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_RedriveParkedOutboxMessage_Success() {
+	s.sqlMock.ExpectPrepare(RedriveParkedOutboxMessage).ExpectExec().WithArgs(testOutboxMessage.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	redriven, err := s.repo.RedriveParkedOutboxMessage(mockCtx, testOutboxMessage.ID)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), redriven)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *OutboxDALSuite) Test_DeleteParkedOutboxMessage_ReturnsFalseWhenNotParked() {
+	s.sqlMock.ExpectPrepare(DeleteParkedOutboxMessage).ExpectExec().WithArgs(testOutboxMessage.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	deleted, err := s.repo.DeleteParkedOutboxMessage(mockCtx, testOutboxMessage.ID)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), deleted)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"go-service-template/domain"
+)
+
+// keysetColumn is a column of the keyset used to paginate, in the order of the sort
+type keysetColumn struct {
+	name       string
+	descending bool
+}
+
+// paginate applies the keyset predicate of the cursor, the order and the limit of a cursor page. The cursor must have
+// been issued for the scope of the search filters. sortColumns maps each sortable field to its column and idColumn
+// holds the unique identifier used as tie-breaker. One element more
+// than the limit is read, so BuildCursorPage knows whether there is another page.
+func (dal *LocationsRepository) paginate(
+	query sq.SelectBuilder,
+	filters domain.CursorPaginationFilters,
+	scope string,
+	sortColumns map[string]string,
+	idColumn string,
+) (sq.SelectBuilder, error) {
+	keyset, err := buildKeyset(filters.Sort, sortColumns, idColumn)
+	if err != nil {
+		return query, err
+	}
+
+	backwards := false
+	if filters.Cursor != "" {
+		cursor, err := dal.cursorCodec.Decode(filters.Cursor, filters.Sort, scope)
+		if err != nil {
+			return query, err
+		}
+
+		backwards = filters.Direction == domain.PreviousPage
+		query = query.Where(keysetPredicate(keyset, append(cursor.Values, cursor.ID), backwards))
+	}
+
+	return query.OrderBy(keysetOrderBy(keyset, backwards)...).Limit(uint64(filters.Limit) + 1), nil
+}
+
+// buildKeyset maps a sort order to its columns, followed by idColumn as tie-breaker
+func buildKeyset(sort domain.SortOrder, sortColumns map[string]string, idColumn string) ([]keysetColumn, error) {
+	keyset := make([]keysetColumn, 0, len(sort)+1)
+	for _, field := range sort {
+		column, ok := sortColumns[field.Field]
+		if !ok {
+			return nil, domain.BusinessErr{Msg: fmt.Sprintf("sorting by '%v' is not supported", field.Field)}
+		}
+		keyset = append(keyset, keysetColumn{name: column, descending: field.Descending})
+	}
+
+	return append(keyset, keysetColumn{name: idColumn}), nil
+}
+
+// keysetOrderBy returns the ORDER BY clauses of the keyset, with every direction flipped when going backwards
+func keysetOrderBy(keyset []keysetColumn, backwards bool) []string {
+	orderBy := make([]string, 0, len(keyset))
+	for _, column := range keyset {
+		if column.descending != backwards {
+			orderBy = append(orderBy, column.name+" DESC")
+		} else {
+			orderBy = append(orderBy, column.name+" ASC")
+		}
+	}
+
+	return orderBy
+}
+
+// keysetPredicate selects the rows after the cursor values in the keyset order, or before them when going backwards:
+// (a > x) OR (a = x AND b > y) OR ... Row comparisons cannot be used since every column has its own direction.
+func keysetPredicate(keyset []keysetColumn, values []any, backwards bool) sq.Or {
+	predicate := sq.Or{}
+
+	for i, column := range keyset {
+		conditions := sq.And{}
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, sq.Expr(keyset[j].name+" = ?", values[j]))
+		}
+
+		operator := ">"
+		if column.descending != backwards {
+			operator = "<"
+		}
+		conditions = append(conditions, sq.Expr(fmt.Sprintf("%v %v ?", column.name, operator), values[i]))
+
+		predicate = append(predicate, conditions)
+	}
+
+	return predicate
+}
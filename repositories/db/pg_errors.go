@@ -0,0 +1,114 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"go-service-template/domain"
+	"strings"
+)
+
+// SQLSTATE codes translated into domain errors, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	sqlStateUniqueViolation      = "23505"
+	sqlStateForeignKeyViolation  = "23503"
+	sqlStateSerializationFailure = "40001"
+	sqlStateQueryCanceled        = "57014"
+)
+
+// nameConstraints are the unique indexes on the names, by the resource they belong to
+var nameConstraints = map[string]string{
+	"locations_name":                 domain.ResourceLocation,
+	"sub_locations_location_id_name": domain.ResourceSubLocation,
+	"suppliers_name":                 domain.ResourceSupplier,
+	"location_types_type":            domain.ResourceLocationType,
+	"sub_location_types_type":        domain.ResourceSubLocationType,
+}
+
+// foreignKeyConstraints are the foreign keys, by the resource they refer to
+var foreignKeyConstraints = map[string]string{
+	"locations_location_type_id_fkey":         domain.ResourceLocationType,
+	"locations_supplier_id_fkey":              domain.ResourceSupplier,
+	"location_information_location_id_fkey":   domain.ResourceLocation,
+	"sub_locations_location_id_fkey":          domain.ResourceLocation,
+	"sub_locations_sub_location_type_id_fkey": domain.ResourceSubLocationType,
+	"location_history_location_id_fkey":       domain.ResourceLocation,
+	"api_keys_supplier_id_fkey":               domain.ResourceSupplier,
+	"webhook_subscriptions_supplier_id_fkey":  domain.ResourceSupplier,
+	"webhook_deliveries_subscription_id_fkey": domain.ResourceWebhook,
+}
+
+// translateError converts the Postgres errors the caller can act on into domain errors. It returns nil for any other
+// error, which the caller keeps as is.
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return nil
+	}
+
+	switch pqErr.Code {
+	case sqlStateUniqueViolation:
+		resource, ok := nameConstraints[pqErr.Constraint]
+		if !ok {
+			return domain.ConflictErr{Msg: "the change conflicts with existing data"}
+		}
+
+		return domain.NameAlreadyInUseErr{
+			Msg:      fmt.Sprintf("%v name is already in use", domain.DescribeResource(resource)),
+			Resource: resource,
+		}
+	case sqlStateForeignKeyViolation:
+		resource := foreignKeyConstraints[pqErr.Constraint]
+		// Deleting a referenced row reports the same code as referencing a missing one
+		if strings.Contains(pqErr.Detail, "is still referenced") {
+			return domain.BusinessErr{
+				Msg:      fmt.Sprintf("%v is still in use and cannot be deleted", describeConstraintResource(resource)),
+				Resource: resource,
+			}
+		}
+
+		return domain.UnknownReferenceErr{
+			Msg:      fmt.Sprintf("referenced %v does not exist", describeConstraintResource(resource)),
+			Resource: resource,
+		}
+	case sqlStateSerializationFailure:
+		return domain.ConflictErr{Msg: "the change conflicted with a concurrent one, it can be retried"}
+	case sqlStateQueryCanceled:
+		return domain.TimeoutErr{Msg: "the database query was cancelled because it took too long"}
+	default:
+		return nil
+	}
+}
+
+func describeConstraintResource(resource string) string {
+	if resource == "" {
+		return "resource"
+	}
+
+	return domain.DescribeResource(resource)
+}
+
+// translatingRow translates the errors of sql.Row, which are only returned when it is scanned
+type translatingRow struct {
+	row *sql.Row
+}
+
+func (r translatingRow) Scan(dest ...any) error {
+	return translatedOrOriginal(r.row.Scan(dest...))
+}
+
+// rowsErr returns the error found while iterating the rows, translated into a domain error. Queries cancelled after
+// returning the first rows, such as streams that hit the statement timeout, fail here.
+func rowsErr(rows *sql.Rows) error {
+	return translatedOrOriginal(rows.Err())
+}
+
+// translatedOrOriginal returns the domain error the Postgres error translates into, or the error itself
+func translatedOrOriginal(err error) error {
+	if translatedErr := translateError(err); translatedErr != nil {
+		return translatedErr
+	}
+
+	return err
+}
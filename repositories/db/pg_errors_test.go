@@ -0,0 +1,76 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go-service-template/domain"
+	"testing"
+)
+
+func Test_translateError_convertsUniqueViolationOnNameIntoNameAlreadyInUseErr(t *testing.T) {
+	err := translateError(fmt.Errorf("error creating sub location: %w", &pq.Error{
+		Code:       sqlStateUniqueViolation,
+		Constraint: "sub_locations_location_id_name",
+	}))
+
+	assert.Equal(t, domain.NameAlreadyInUseErr{
+		Msg:      "sub location name is already in use",
+		Resource: domain.ResourceSubLocation,
+	}, err)
+}
+
+func Test_translateError_convertsUniqueViolationOnOtherConstraintIntoConflictErr(t *testing.T) {
+	err := translateError(&pq.Error{Code: sqlStateUniqueViolation, Constraint: "api_keys_prefix"})
+
+	assert.ErrorAs(t, err, &domain.ConflictErr{})
+}
+
+func Test_translateError_convertsForeignKeyViolationIntoUnknownReferenceErr(t *testing.T) {
+	err := translateError(&pq.Error{
+		Code:       sqlStateForeignKeyViolation,
+		Constraint: "locations_supplier_id_fkey",
+		Detail:     `Key (supplier_id)=(99) is not present in table "suppliers".`,
+	})
+
+	assert.Equal(t, domain.UnknownReferenceErr{
+		Msg:      "referenced supplier does not exist",
+		Resource: domain.ResourceSupplier,
+	}, err)
+}
+
+func Test_translateError_convertsForeignKeyViolationOnDeleteIntoBusinessErr(t *testing.T) {
+	err := translateError(&pq.Error{
+		Code:       sqlStateForeignKeyViolation,
+		Constraint: "locations_supplier_id_fkey",
+		Detail:     `Key (id)=(1) is still referenced from table "locations".`,
+	})
+
+	assert.Equal(t, domain.BusinessErr{
+		Msg:      "supplier is still in use and cannot be deleted",
+		Resource: domain.ResourceSupplier,
+	}, err)
+}
+
+func Test_translateError_convertsSerializationFailureIntoConflictErr(t *testing.T) {
+	err := translateError(&pq.Error{Code: sqlStateSerializationFailure})
+
+	assert.ErrorAs(t, err, &domain.ConflictErr{})
+}
+
+func Test_translateError_convertsQueryCanceledIntoTimeoutErr(t *testing.T) {
+	err := translateError(&pq.Error{Code: sqlStateQueryCanceled})
+
+	assert.ErrorAs(t, err, &domain.TimeoutErr{})
+}
+
+func Test_translateError_ignoresOtherErrors(t *testing.T) {
+	pqErr := &pq.Error{Code: "42P01"}
+	otherErr := errors.New("some err")
+
+	assert.Nil(t, translateError(pqErr))
+	assert.Nil(t, translateError(otherErr))
+	assert.Equal(t, pqErr, translatedOrOriginal(pqErr))
+	assert.Equal(t, otherErr, translatedOrOriginal(otherErr))
+}
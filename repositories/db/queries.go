@@ -20,8 +20,9 @@ const (
                                 name,
                                 location_type_id,
                                 supplier_id,
-                                active
-							) VALUES ($1,$2,$3,$4,$5);`
+                                active,
+                                created_at
+							) VALUES ($1,$2,$3,$4,$5,$6);`
 
 	InsertSubLocation = `INSERT INTO location.sub_locations (
 									id,
@@ -36,8 +37,9 @@ const (
 								location_type_id = $2,
 								supplier_id = $3,
 								active = $4,
+								version = version + 1,
 								updated_at= CURRENT_TIMESTAMP
-							WHERE id = $5;`
+							WHERE id = $5 AND ($6::int IS NULL OR supplier_id = $6);`
 
 	UpdateLocationInformation = `UPDATE location.location_information SET
 								address = $1,
@@ -51,6 +53,8 @@ const (
 								longitude = $9
 							WHERE id = $10;`
 
+	// The queries on a single location take the supplier of the tenant as last argument, NULL when the caller can act on
+	// every supplier
 	GetLocationByID = `SELECT
 							l.id,
 							l.name,
@@ -68,13 +72,507 @@ const (
 							li.phone_number,
 							li.email,
 							li.latitude,
-							li.longitude
+							li.longitude,
+							l.deleted_at,
+							l.version,
+							l.created_at
 						FROM location.locations l
 						JOIN location.location_information li on l.id = li.location_id
 						JOIN location.location_types lt on l.location_type_id = lt.id
 						JOIN location.suppliers s on s.id = l.supplier_id
-						WHERE l.id = $1
+						WHERE l.id = $1 AND ($2::int IS NULL OR l.supplier_id = $2)
 						LIMIT 1 FOR UPDATE`
 
+	SoftDeleteLocation = `UPDATE location.locations SET
+								deleted_at = $1,
+								version = version + 1,
+								updated_at = CURRENT_TIMESTAMP
+							WHERE id = $2 AND ($3::int IS NULL OR supplier_id = $3);`
+
+	RestoreLocation = `UPDATE location.locations SET
+								deleted_at = NULL,
+								version = version + 1,
+								updated_at = CURRENT_TIMESTAMP
+							WHERE id = $1 AND ($2::int IS NULL OR supplier_id = $2);`
+
+	// Great-circle distance in meters from the location to a point, the radius matches domain.EarthRadiusMeters.
+	// Arguments: latitude, latitude, longitude.
+	// LEAST keeps rounding errors from pushing the ASIN argument above 1.
+	haversineDistanceExpr = `(2 * 6371000 * ASIN(LEAST(1, SQRT(
+								POWER(SIN(RADIANS(li.latitude::float8 - ?::float8) / 2), 2) +
+								COS(RADIANS(?::float8)) * COS(RADIANS(li.latitude::float8)) *
+								POWER(SIN(RADIANS(li.longitude::float8 - ?::float8) / 2), 2)
+							))))`
+
 	CheckLocationNameExistence = `SELECT id FROM location.locations WHERE LOWER(name) = LOWER($1)`
+
+	UpdateSubLocation = `UPDATE location.sub_locations SET
+								name = $1,
+								sub_location_type_id = $2,
+								active = $3,
+								updated_at = CURRENT_TIMESTAMP
+							WHERE id = $4;`
+
+	GetSubLocationByID = `SELECT
+							sl.id,
+							sl.name,
+							sl.active,
+							sl.location_id,
+							slt.id,
+							slt.type
+						FROM location.sub_locations sl
+						JOIN location.sub_location_types slt on sl.sub_location_type_id = slt.id
+						WHERE sl.location_id = $1 AND sl.id = $2 AND ($3::int IS NULL OR EXISTS (
+							SELECT 1 FROM location.locations l WHERE l.id = sl.location_id AND l.supplier_id = $3
+						))
+						LIMIT 1 FOR UPDATE`
+
+	CheckSubLocationNameExistence = `SELECT id FROM location.sub_locations WHERE location_id = $1 AND LOWER(name) = LOWER($2)`
+
+	InsertOutboxMessage = `INSERT INTO location.outbox (
+									id,
+									aggregate_id,
+									topic,
+									payload,
+									metadata
+								) VALUES ($1,$2,$3,$4,$5);`
+
+	AcquireOutboxLock = `SELECT pg_try_advisory_xact_lock(hashtext('location.outbox'))`
+
+	// Only the oldest unpublished message of each aggregate is pending, so a message waiting for its next attempt or
+	// parked holds back the messages that follow it
+	GetPendingOutboxMessages = `SELECT
+									id,
+									sequence,
+									aggregate_id,
+									topic,
+									payload,
+									metadata,
+									created_at,
+									attempts,
+									next_attempt_at
+								FROM (
+									SELECT DISTINCT ON (aggregate_id)
+										id,
+										sequence,
+										aggregate_id,
+										topic,
+										payload,
+										metadata,
+										created_at,
+										attempts,
+										next_attempt_at,
+										failed_at
+									FROM location.outbox
+									WHERE published_at IS NULL
+									ORDER BY aggregate_id, sequence ASC
+								) AS heads
+								WHERE failed_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= CURRENT_TIMESTAMP)
+								ORDER BY sequence ASC
+								LIMIT $1`
+
+	// The claimed messages are not pending until claimedUntil, when they are taken again if they were not published
+	ClaimOutboxMessages = `UPDATE location.outbox SET
+								next_attempt_at = $2
+							WHERE id = ANY($1);`
+
+	MarkOutboxMessagesAsPublished = `UPDATE location.outbox SET
+										published_at = CURRENT_TIMESTAMP
+									WHERE id = ANY($1);`
+
+	// The message is parked when failed is true, it is no longer returned as pending
+	RecordOutboxMessageFailure = `UPDATE location.outbox SET
+										attempts = attempts + 1,
+										last_error = $2,
+										next_attempt_at = $3,
+										failed_at = CASE WHEN $4 THEN CURRENT_TIMESTAMP ELSE NULL END
+									WHERE id = $1;`
+
+	GetParkedOutboxMessages = `SELECT
+									id,
+									sequence,
+									aggregate_id,
+									topic,
+									payload,
+									metadata,
+									created_at,
+									attempts,
+									last_error,
+									failed_at
+								FROM location.outbox
+								WHERE failed_at IS NOT NULL AND published_at IS NULL
+								ORDER BY sequence ASC
+								LIMIT $1`
+
+	// The redriven message is pending again with its attempts reset
+	RedriveParkedOutboxMessage = `UPDATE location.outbox SET
+										attempts = 0,
+										last_error = NULL,
+										next_attempt_at = NULL,
+										failed_at = NULL
+									WHERE id = $1 AND failed_at IS NOT NULL AND published_at IS NULL;`
+
+	DeleteParkedOutboxMessage = `DELETE FROM location.outbox
+									WHERE id = $1 AND failed_at IS NOT NULL AND published_at IS NULL;`
+
+	DeletePublishedOutboxMessages = `DELETE FROM location.outbox
+									WHERE published_at IS NOT NULL AND published_at < $1;`
+
+	// An expired key is taken over as if it did not exist. No row is returned when the key is in use.
+	ReserveIdempotencyKey = `INSERT INTO location.idempotency_keys (
+									scope,
+									key,
+									request_hash,
+									expires_at
+								) VALUES ($1,$2,$3,$4)
+								ON CONFLICT (scope, key) DO UPDATE SET
+									request_hash = EXCLUDED.request_hash,
+									status_code = NULL,
+									response_headers = NULL,
+									response_body = NULL,
+									created_at = CURRENT_TIMESTAMP,
+									completed_at = NULL,
+									expires_at = EXCLUDED.expires_at
+								WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+								RETURNING key;`
+
+	GetIdempotencyKey = `SELECT
+							scope,
+							key,
+							request_hash,
+							status_code,
+							response_headers,
+							response_body,
+							expires_at
+						FROM location.idempotency_keys
+						WHERE scope = $1 AND key = $2`
+
+	CompleteIdempotencyKey = `UPDATE location.idempotency_keys SET
+									status_code = $1,
+									response_headers = $2,
+									response_body = $3,
+									completed_at = CURRENT_TIMESTAMP
+								WHERE scope = $4 AND key = $5;`
+
+	DeleteIdempotencyKey = `DELETE FROM location.idempotency_keys WHERE scope = $1 AND key = $2;`
+
+	DeleteExpiredIdempotencyKeys = `DELETE FROM location.idempotency_keys WHERE expires_at < $1;`
+
+	InsertAPIKey = `INSERT INTO location.api_keys (
+						id,
+						name,
+						prefix,
+						key_hash,
+						scopes,
+						supplier_id,
+						created_at
+					) VALUES ($1,$2,$3,$4,$5,$6,$7);`
+
+	GetAPIKeys = `SELECT
+					id,
+					name,
+					prefix,
+					key_hash,
+					scopes,
+					supplier_id,
+					created_at,
+					rotated_at,
+					last_used_at,
+					revoked_at
+				FROM location.api_keys
+				WHERE ($1::int IS NULL OR supplier_id = $1)
+				ORDER BY created_at, id`
+
+	GetAPIKeyByPrefix = `SELECT
+							id,
+							name,
+							prefix,
+							key_hash,
+							scopes,
+							supplier_id,
+							created_at,
+							rotated_at,
+							last_used_at,
+							revoked_at
+						FROM location.api_keys
+						WHERE prefix = $1`
+
+	// Revoked keys cannot be rotated
+	RotateAPIKey = `UPDATE location.api_keys SET
+						prefix = $1,
+						key_hash = $2,
+						rotated_at = $3
+					WHERE id = $4 AND revoked_at IS NULL AND ($5::int IS NULL OR supplier_id = $5)
+					RETURNING
+						id,
+						name,
+						prefix,
+						key_hash,
+						scopes,
+						supplier_id,
+						created_at,
+						rotated_at,
+						last_used_at,
+						revoked_at`
+
+	RevokeAPIKey = `UPDATE location.api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL AND ($3::int IS NULL OR supplier_id = $3);`
+
+	// Timestamps recorded by other instances are not moved backwards
+	UpdateAPIKeysLastUsed = `UPDATE location.api_keys k SET last_used_at = u.last_used_at
+								FROM unnest($1::uuid[], $2::timestamptz[]) AS u(id, last_used_at)
+								WHERE k.id = u.id AND (k.last_used_at IS NULL OR k.last_used_at < u.last_used_at);`
+
+	InsertWebhookSubscription = `INSERT INTO location.webhook_subscriptions (
+									id,
+									url,
+									event_types,
+									secret,
+									supplier_id,
+									status,
+									created_at,
+									updated_at
+								) VALUES ($1,$2,$3,$4,$5,$6,$7,$8);`
+
+	GetWebhookSubscriptions = `SELECT
+									id,
+									url,
+									event_types,
+									secret,
+									supplier_id,
+									status,
+									created_at,
+									updated_at
+								FROM location.webhook_subscriptions
+								WHERE ($1::int IS NULL OR supplier_id = $1)
+								ORDER BY created_at, id`
+
+	GetWebhookSubscriptionByID = `SELECT
+									id,
+									url,
+									event_types,
+									secret,
+									supplier_id,
+									status,
+									created_at,
+									updated_at
+								FROM location.webhook_subscriptions
+								WHERE id = $1 AND ($2::int IS NULL OR supplier_id = $2)`
+
+	// Subscriptions without supplier receive the events of every location
+	GetActiveWebhookSubscriptions = `SELECT
+										id,
+										url,
+										event_types,
+										secret,
+										supplier_id,
+										status,
+										created_at,
+										updated_at
+									FROM location.webhook_subscriptions
+									WHERE status = 'active' AND $1 = ANY(event_types) AND (supplier_id IS NULL OR supplier_id = $2)
+									ORDER BY created_at, id`
+
+	UpdateWebhookSubscription = `UPDATE location.webhook_subscriptions SET
+									url = $1,
+									event_types = $2,
+									secret = $3,
+									supplier_id = $4,
+									status = $5,
+									updated_at = $6
+								WHERE id = $7 AND ($8::int IS NULL OR supplier_id = $8);`
+
+	DeleteWebhookSubscription = `DELETE FROM location.webhook_subscriptions
+									WHERE id = $1 AND ($2::int IS NULL OR supplier_id = $2);`
+
+	// An event that failed again after being redelivered by the broker keeps its entry and adds its attempts
+	UpsertWebhookDelivery = `INSERT INTO location.webhook_deliveries (
+								id,
+								subscription_id,
+								event_id,
+								event_type,
+								payload,
+								status,
+								attempts,
+								response_status,
+								last_error,
+								created_at,
+								last_attempt_at,
+								next_attempt_at
+							) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+							ON CONFLICT (subscription_id, event_id) DO UPDATE SET
+								status = EXCLUDED.status,
+								attempts = location.webhook_deliveries.attempts + EXCLUDED.attempts,
+								response_status = EXCLUDED.response_status,
+								last_error = EXCLUDED.last_error,
+								last_attempt_at = EXCLUDED.last_attempt_at,
+								next_attempt_at = EXCLUDED.next_attempt_at;`
+
+	GetWebhookDeliveries = `SELECT
+								id,
+								subscription_id,
+								event_id,
+								event_type,
+								payload,
+								status,
+								attempts,
+								response_status,
+								last_error,
+								created_at,
+								last_attempt_at,
+								delivered_at,
+								next_attempt_at
+							FROM location.webhook_deliveries
+							WHERE subscription_id = $1 AND ($2::varchar IS NULL OR status = $2)
+							ORDER BY created_at DESC, id
+							LIMIT $3`
+
+	GetWebhookDeliveryByID = `SELECT
+								id,
+								subscription_id,
+								event_id,
+								event_type,
+								payload,
+								status,
+								attempts,
+								response_status,
+								last_error,
+								created_at,
+								last_attempt_at,
+								delivered_at,
+								next_attempt_at
+							FROM location.webhook_deliveries
+							WHERE subscription_id = $1 AND id = $2`
+
+	UpdateWebhookDelivery = `UPDATE location.webhook_deliveries SET
+								status = $1,
+								attempts = $2,
+								response_status = $3,
+								last_error = $4,
+								last_attempt_at = $5,
+								delivered_at = $6,
+								next_attempt_at = $7
+							WHERE id = $8;`
+
+	// ClaimDueWebhookDeliveries moves the next attempt of the due retries to the end of the claim, so other instances
+	// skip them while they are sent
+	ClaimDueWebhookDeliveries = `UPDATE location.webhook_deliveries SET
+									next_attempt_at = $1
+								WHERE id IN (
+									SELECT id
+									FROM location.webhook_deliveries
+									WHERE status = 'retrying' AND next_attempt_at <= CURRENT_TIMESTAMP
+									ORDER BY next_attempt_at
+									LIMIT $2
+									FOR UPDATE SKIP LOCKED
+								)
+								RETURNING
+									id,
+									subscription_id,
+									event_id,
+									event_type,
+									payload,
+									status,
+									attempts,
+									response_status,
+									last_error,
+									created_at,
+									last_attempt_at,
+									delivered_at,
+									next_attempt_at;`
+
+	// InsertDeadLetter ignores the envelopes the broker redelivers
+	InsertDeadLetter = `INSERT INTO location.dead_letters (
+								id,
+								message_id,
+								handler,
+								original_topic,
+								poison_topic,
+								error,
+								attempts,
+								correlation_id,
+								payload,
+								metadata,
+								failed_at
+							) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+							ON CONFLICT (id) DO NOTHING;`
+
+	GetDeadLetters = `SELECT
+								id,
+								message_id,
+								handler,
+								original_topic,
+								poison_topic,
+								error,
+								attempts,
+								correlation_id,
+								payload,
+								metadata,
+								failed_at,
+								redriven_at
+							FROM location.dead_letters
+							WHERE ($1::varchar IS NULL OR handler = $1)
+								AND ($2::boolean IS NULL OR (redriven_at IS NOT NULL) = $2)
+							ORDER BY failed_at DESC, id
+							LIMIT $3`
+
+	GetDeadLetterByID = `SELECT
+								id,
+								message_id,
+								handler,
+								original_topic,
+								poison_topic,
+								error,
+								attempts,
+								correlation_id,
+								payload,
+								metadata,
+								failed_at,
+								redriven_at
+							FROM location.dead_letters
+							WHERE id = $1`
+
+	MarkDeadLetterAsRedriven = `UPDATE location.dead_letters SET redriven_at = $1 WHERE id = $2 AND redriven_at IS NULL;`
+
+	InsertLocationHistory = `INSERT INTO location.location_history (
+									location_id,
+									operation,
+									before,
+									after,
+									changed_fields,
+									correlation_id,
+									actor
+								) VALUES ($1,$2,$3,$4,$5,$6,$7);`
+
+	GetSuppliers = `SELECT id, name FROM location.suppliers ORDER BY id`
+
+	InsertSupplier = `INSERT INTO location.suppliers (name) VALUES ($1) RETURNING id`
+
+	UpdateSupplier = `UPDATE location.suppliers SET name = $1 WHERE id = $2;`
+
+	// Suppliers referenced by a location are kept
+	DeleteSupplier = `DELETE FROM location.suppliers s
+						WHERE s.id = $1
+						AND NOT EXISTS (SELECT 1 FROM location.locations l WHERE l.supplier_id = s.id);`
+
+	GetLocationTypes = `SELECT id, type FROM location.location_types ORDER BY id`
+
+	InsertLocationType = `INSERT INTO location.location_types (type) VALUES ($1) RETURNING id`
+
+	UpdateLocationType = `UPDATE location.location_types SET type = $1 WHERE id = $2;`
+
+	// Location types referenced by a location are kept
+	DeleteLocationType = `DELETE FROM location.location_types lt
+							WHERE lt.id = $1
+							AND NOT EXISTS (SELECT 1 FROM location.locations l WHERE l.location_type_id = lt.id);`
+
+	GetSubLocationTypes = `SELECT id, type FROM location.sub_location_types ORDER BY id`
+
+	InsertSubLocationType = `INSERT INTO location.sub_location_types (type) VALUES ($1) RETURNING id`
+
+	UpdateSubLocationType = `UPDATE location.sub_location_types SET type = $1 WHERE id = $2;`
+
+	// Sub location types referenced by a sub location are kept
+	DeleteSubLocationType = `DELETE FROM location.sub_location_types slt
+								WHERE slt.id = $1
+								AND NOT EXISTS (SELECT 1 FROM location.sub_locations sl WHERE sl.sub_location_type_id = slt.id);`
 )
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type ReferenceDataRepository struct {
+	*TxDBContext
+}
+
+func (dal *ReferenceDataRepository) GetSuppliers(ctx monitor.ApplicationContext) ([]domain.Supplier, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.GetSuppliers")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetSuppliers)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]domain.Supplier, 0)
+	for rows.Next() {
+		var supplier domain.Supplier
+		if err = rows.Scan(&supplier.ID, &supplier.Name); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	return suppliers, rowsErr(rows)
+}
+
+func (dal *ReferenceDataRepository) CreateSupplier(ctx monitor.ApplicationContext, supplier domain.Supplier) (int, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.CreateSupplier")
+	defer span.End()
+
+	var id int
+	if err := dal.queryRow(ctx, InsertSupplier, supplier.Name).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (dal *ReferenceDataRepository) UpdateSupplier(ctx monitor.ApplicationContext, supplier domain.Supplier) (bool, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.UpdateSupplier")
+	defer span.End()
+
+	return dal.execAffectingRows(ctx, UpdateSupplier, supplier.Name, supplier.ID)
+}
+
+func (dal *ReferenceDataRepository) DeleteSupplier(ctx monitor.ApplicationContext, id int) (bool, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.DeleteSupplier")
+	defer span.End()
+
+	return dal.execAffectingRows(ctx, DeleteSupplier, id)
+}
+
+func (dal *ReferenceDataRepository) GetLocationTypes(ctx monitor.ApplicationContext) ([]domain.LocationType, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.GetLocationTypes")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetLocationTypes)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	locationTypes := make([]domain.LocationType, 0)
+	for rows.Next() {
+		var locationType domain.LocationType
+		if err = rows.Scan(&locationType.ID, &locationType.Type); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		locationTypes = append(locationTypes, locationType)
+	}
+
+	return locationTypes, rowsErr(rows)
+}
+
+func (dal *ReferenceDataRepository) CreateLocationType(ctx monitor.ApplicationContext, locationType domain.LocationType) (int, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.CreateLocationType")
+	defer span.End()
+
+	var id int
+	if err := dal.queryRow(ctx, InsertLocationType, locationType.Type).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (dal *ReferenceDataRepository) UpdateLocationType(ctx monitor.ApplicationContext, locationType domain.LocationType) (bool, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.UpdateLocationType")
+	defer span.End()
+
+	return dal.execAffectingRows(ctx, UpdateLocationType, locationType.Type, locationType.ID)
+}
+
+func (dal *ReferenceDataRepository) DeleteLocationType(ctx monitor.ApplicationContext, id int) (bool, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.DeleteLocationType")
+	defer span.End()
+
+	return dal.execAffectingRows(ctx, DeleteLocationType, id)
+}
+
+func (dal *ReferenceDataRepository) GetSubLocationTypes(ctx monitor.ApplicationContext) ([]domain.SubLocationType, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.GetSubLocationTypes")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetSubLocationTypes)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	subLocationTypes := make([]domain.SubLocationType, 0)
+	for rows.Next() {
+		var subLocationType domain.SubLocationType
+		if err = rows.Scan(&subLocationType.ID, &subLocationType.Type); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		subLocationTypes = append(subLocationTypes, subLocationType)
+	}
+
+	return subLocationTypes, rowsErr(rows)
+}
+
+func (dal *ReferenceDataRepository) CreateSubLocationType(ctx monitor.ApplicationContext, subLocationType domain.SubLocationType) (int, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.CreateSubLocationType")
+	defer span.End()
+
+	var id int
+	if err := dal.queryRow(ctx, InsertSubLocationType, subLocationType.Type).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (dal *ReferenceDataRepository) UpdateSubLocationType(ctx monitor.ApplicationContext, subLocationType domain.SubLocationType) (bool, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.UpdateSubLocationType")
+	defer span.End()
+
+	return dal.execAffectingRows(ctx, UpdateSubLocationType, subLocationType.Type, subLocationType.ID)
+}
+
+func (dal *ReferenceDataRepository) DeleteSubLocationType(ctx monitor.ApplicationContext, id int) (bool, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataRepository.DeleteSubLocationType")
+	defer span.End()
+
+	return dal.execAffectingRows(ctx, DeleteSubLocationType, id)
+}
+
+// execAffectingRows returns whether the statement changed any row
+func (dal *ReferenceDataRepository) execAffectingRows(ctx monitor.ApplicationContext, stmt string, args ...interface{}) (bool, error) {
+	res, err := dal.Exec(ctx, stmt, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows > 0, nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"log"
+	"testing"
+)
+
+type ReferenceDataDALSuite struct {
+	suite.Suite
+	repo    *ReferenceDataRepository
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func (s *ReferenceDataDALSuite) SetupTest() {
+	db, sqmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("An error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.db = db
+	s.sqlMock = sqmock
+	s.repo = &ReferenceDataRepository{
+		TxDBContext: CreateTxDBContext(db),
+	}
+}
+
+func TestReferenceDataDALSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceDataDALSuite))
+}
+
+func (s *ReferenceDataDALSuite) Test_GetSuppliers_Success() {
+	s.sqlMock.ExpectQuery(GetSuppliers).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Supplier 1").AddRow(2, "Supplier 2"),
+	)
+
+	suppliers, err := s.repo.GetSuppliers(mockCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []domain.Supplier{{ID: 1, Name: "Supplier 1"}, {ID: 2, Name: "Supplier 2"}}, suppliers)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ReferenceDataDALSuite) Test_CreateSupplier_Success() {
+	s.sqlMock.ExpectQuery(InsertSupplier).WithArgs("Supplier 9").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+
+	id, err := s.repo.CreateSupplier(mockCtx, domain.Supplier{Name: "Supplier 9"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 9, id)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ReferenceDataDALSuite) Test_UpdateLocationType_ReturnsFalseIfNoRowWasUpdated() {
+	s.sqlMock.ExpectPrepare(UpdateLocationType).ExpectExec().WithArgs("Recon", 99).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	updated, err := s.repo.UpdateLocationType(mockCtx, domain.LocationType{ID: 99, Type: "Recon"})
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), updated)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *ReferenceDataDALSuite) Test_DeleteSubLocationType_Success() {
+	s.sqlMock.ExpectPrepare(DeleteSubLocationType).ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	deleted, err := s.repo.DeleteSubLocationType(mockCtx, 2)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), deleted)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (dal *LocationsRepository) UpdateSubLocation(ctx monitor.ApplicationContext, subLocation domain.SubLocation) error {
+	ctx, span := ctx.StartSpan("LocationsRepository.UpdateSubLocation")
+	defer span.End()
+
+	_, err := dal.Exec(
+		ctx,
+		UpdateSubLocation,
+		subLocation.Name,
+		subLocation.SubLocationType.ID,
+		subLocation.Active,
+		subLocation.ID,
+	)
+
+	return err
+}
+
+func (dal *LocationsRepository) GetSubLocationByID(ctx monitor.ApplicationContext, locationID, subLocationID string) (*domain.SubLocation, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.GetSubLocationByID")
+	defer span.End()
+
+	var subLocation domain.SubLocation
+
+	if err := dal.queryRow(ctx, GetSubLocationByID, locationID, subLocationID, tenantSupplierID(ctx)).Scan(
+		&subLocation.ID,
+		&subLocation.Name,
+		&subLocation.Active,
+		&subLocation.LocationID,
+		&subLocation.SubLocationType.ID,
+		&subLocation.SubLocationType.Type,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &subLocation, nil
+}
+
+func (dal *LocationsRepository) CheckSubLocationNameExistence(ctx monitor.ApplicationContext, locationID, name string) (bool, error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.CheckSubLocationNameExistence")
+	defer span.End()
+
+	var subLocationID string
+
+	if err := dal.queryRow(ctx, CheckSubLocationNameExistence, locationID, name).Scan(&subLocationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dal *LocationsRepository) GetPaginatedSubLocations(
+	ctx monitor.ApplicationContext,
+	filters domain.SubLocationsFilters,
+) (domain.CursorPage[domain.SubLocation], error) {
+	ctx, span := ctx.StartSpan("LocationsRepository.GetPaginatedSubLocations")
+	defer span.End()
+
+	var result domain.CursorPage[domain.SubLocation]
+
+	// Build base query
+	baseSelectQuery := dal.queryBuilder.Select(
+		"sl.id",
+		"sl.name",
+		"sl.active",
+		"sl.location_id",
+		"slt.id",
+		"slt.type",
+	).From("location.sub_locations sl").InnerJoin(
+		"location.sub_location_types slt on sl.sub_location_type_id = slt.id",
+	).Where("sl.location_id = ?", filters.LocationID)
+	if supplierID, ok := ctx.GetTenantSupplierID(); ok {
+		baseSelectQuery = baseSelectQuery.Where(
+			"EXISTS (SELECT 1 FROM location.locations l WHERE l.id = sl.location_id AND l.supplier_id = ?)", supplierID,
+		)
+	}
+
+	// Sub locations are always sorted by name
+	filters.Sort = domain.SortOrder{{Field: domain.SortByName}}
+	baseSelectQuery, err := dal.paginate(
+		baseSelectQuery, filters.CursorPaginationFilters, filters.CursorScope(), map[string]string{domain.SortByName: "sl.name"}, "sl.id",
+	)
+	if err != nil {
+		return result, err
+	}
+
+	selectQueryStr, args, err := baseSelectQuery.ToSql()
+	if err != nil {
+		return result, fmt.Errorf("error when building GetPaginatedSubLocations query: %w", err)
+	}
+
+	rows, err := dal.query(ctx, selectQueryStr, args...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+	defer rows.Close()
+
+	subLocations := make([]domain.SubLocation, 0)
+	for rows.Next() {
+		var subLocation domain.SubLocation
+		if err = rows.Scan(
+			&subLocation.ID,
+			&subLocation.Name,
+			&subLocation.Active,
+			&subLocation.LocationID,
+			&subLocation.SubLocationType.ID,
+			&subLocation.SubLocationType.Type,
+		); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return result, err
+		}
+		subLocations = append(subLocations, subLocation)
+	}
+
+	if err = rowsErr(rows); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	return domain.BuildCursorPage(subLocations, filters.CursorPaginationFilters, filters.CursorScope(), dal.cursorCodec)
+}
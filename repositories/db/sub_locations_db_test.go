@@ -0,0 +1,164 @@
+package db
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go-service-template/domain"
+)
+
+var testSubLocation = domain.SubLocation{
+	ID:              uuid.New().String(),
+	Name:            "Storage",
+	SubLocationType: domain.SubLocationType{ID: 1, Type: "Zone"},
+	Active:          true,
+	LocationID:      uuid.New().String(),
+}
+
+func (s *LocationsDALSuite) Test_UpdateSubLocation_Success() {
+	s.sqlMock.ExpectPrepare(UpdateSubLocation).ExpectExec().WithArgs(
+		testSubLocation.Name,
+		testSubLocation.SubLocationType.ID,
+		testSubLocation.Active,
+		testSubLocation.ID,
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.repo.UpdateSubLocation(mockCtx, testSubLocation)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetSubLocationByID_Success() {
+	s.sqlMock.ExpectQuery(GetSubLocationByID).WithArgs(testSubLocation.LocationID, testSubLocation.ID, nil).WillReturnRows(
+		sqlmock.NewRows([]string{"sl.id", "sl.name", "sl.active", "sl.location_id", "slt.id", "slt.type"}).AddRow(
+			testSubLocation.ID,
+			testSubLocation.Name,
+			testSubLocation.Active,
+			testSubLocation.LocationID,
+			testSubLocation.SubLocationType.ID,
+			testSubLocation.SubLocationType.Type,
+		),
+	)
+
+	subLocation, err := s.repo.GetSubLocationByID(mockCtx, testSubLocation.LocationID, testSubLocation.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), testSubLocation, *subLocation)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetSubLocationByID_ReturnsNilIfSubLocationDoesNotExist() {
+	s.sqlMock.ExpectQuery(GetSubLocationByID).WithArgs(testSubLocation.LocationID, testSubLocation.ID, nil).WillReturnRows(
+		sqlmock.NewRows([]string{"sl.id", "sl.name", "sl.active", "sl.location_id", "slt.id", "slt.type"}),
+	)
+
+	subLocation, err := s.repo.GetSubLocationByID(mockCtx, testSubLocation.LocationID, testSubLocation.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), subLocation)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_CheckSubLocationNameExistence_ReturnsTrueIfNameExists() {
+	s.sqlMock.ExpectQuery(CheckSubLocationNameExistence).WithArgs(testSubLocation.LocationID, testSubLocation.Name).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow(testSubLocation.ID),
+	)
+
+	exists, err := s.repo.CheckSubLocationNameExistence(mockCtx, testSubLocation.LocationID, testSubLocation.Name)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), exists)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetPaginatedSubLocations_SuccessOnNextDirection() {
+	filters := domain.SubLocationsFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{
+			Direction: domain.NextPage,
+			Limit:     10,
+		},
+		LocationID: testSubLocation.LocationID,
+	}
+	nameOrder := domain.SortOrder{{Field: domain.SortByName}}
+	filters.Cursor = s.encodeCursor(nameOrder, filters.CursorScope(), "prevID", "prevName")
+
+	expectedQuery := `SELECT sl.id, sl.name, sl.active, sl.location_id, slt.id, slt.type
+	FROM location.sub_locations sl
+		INNER JOIN location.sub_location_types slt on sl.sub_location_type_id = slt.id
+	WHERE sl.location_id = $1 AND ((sl.name > $2) OR (sl.name = $3 AND sl.id > $4)) ORDER BY sl.name ASC, sl.id ASC LIMIT 11`
+
+	s.sqlMock.ExpectQuery(expectedQuery).WithArgs(filters.LocationID, "prevName", "prevName", "prevID").WillReturnRows(
+		sqlmock.NewRows([]string{"sl.id", "sl.name", "sl.active", "sl.location_id", "slt.id", "slt.type"}).AddRow(
+			testSubLocation.ID,
+			testSubLocation.Name,
+			testSubLocation.Active,
+			testSubLocation.LocationID,
+			testSubLocation.SubLocationType.ID,
+			testSubLocation.SubLocationType.Type,
+		),
+	)
+
+	resp, err := s.repo.GetPaginatedSubLocations(mockCtx, filters)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), resp.NextPage)
+	assert.Equal(s.T(), testSubLocation.ID, s.decodeCursor(nameOrder, filters.CursorScope(), resp.PreviousPage).ID)
+	assert.Len(s.T(), resp.Data, 1)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *LocationsDALSuite) Test_GetPaginatedSubLocations_FailsOnRowError() {
+	filters := domain.SubLocationsFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{Direction: domain.NextPage, Limit: 10},
+		LocationID:              testSubLocation.LocationID,
+	}
+	rowErr := errors.New("connection reset")
+
+	s.sqlMock.ExpectQuery(`SELECT sl.id, sl.name, sl.active, sl.location_id, slt.id, slt.type
+	FROM location.sub_locations sl
+		INNER JOIN location.sub_location_types slt on sl.sub_location_type_id = slt.id
+	WHERE sl.location_id = $1 ORDER BY sl.name ASC, sl.id ASC LIMIT 11`).WithArgs(filters.LocationID).WillReturnRows(
+		sqlmock.NewRows([]string{"sl.id", "sl.name", "sl.active", "sl.location_id", "slt.id", "slt.type"}).AddRow(
+			testSubLocation.ID,
+			testSubLocation.Name,
+			testSubLocation.Active,
+			testSubLocation.LocationID,
+			testSubLocation.SubLocationType.ID,
+			testSubLocation.SubLocationType.Type,
+		).RowError(0, rowErr),
+	)
+
+	_, err := s.repo.GetPaginatedSubLocations(mockCtx, filters)
+
+	assert.ErrorIs(s.T(), err, rowErr)
+}
+
+func (s *LocationsDALSuite) Test_GetPaginatedSubLocations_RejectsCursorOfAnotherLocation() {
+	nameOrder := domain.SortOrder{{Field: domain.SortByName}}
+	otherLocation := domain.SubLocationsFilters{LocationID: "other-location"}
+	filters := domain.SubLocationsFilters{
+		CursorPaginationFilters: domain.CursorPaginationFilters{
+			Cursor:    s.encodeCursor(nameOrder, otherLocation.CursorScope(), "prevID", "prevName"),
+			Direction: domain.NextPage,
+			Limit:     10,
+		},
+		LocationID: testSubLocation.LocationID,
+	}
+
+	_, err := s.repo.GetPaginatedSubLocations(mockCtx, filters)
+
+	assert.ErrorAs(s.T(), err, &domain.BusinessErr{})
+	assert.Nil(s.T(), s.sqlMock.ExpectationsWereMet())
+}
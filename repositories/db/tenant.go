@@ -0,0 +1,27 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"go-service-template/monitor"
+)
+
+// tenantSupplierID returns the supplier the caller is restricted to, nil when it can act on every supplier. It is
+// passed as the last argument of the queries on a single location.
+func tenantSupplierID(ctx monitor.ApplicationContext) *int {
+	supplierID, ok := ctx.GetTenantSupplierID()
+	if !ok {
+		return nil
+	}
+
+	return &supplierID
+}
+
+// applyTenantFilter restricts the locations to the supplier of the caller, locations of other suppliers are treated
+// as if they did not exist
+func applyTenantFilter(ctx monitor.ApplicationContext, query sq.SelectBuilder) sq.SelectBuilder {
+	if supplierID, ok := ctx.GetTenantSupplierID(); ok {
+		query = query.Where(sq.Eq{"l.supplier_id": supplierID})
+	}
+
+	return query
+}
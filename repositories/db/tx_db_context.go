@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/pkg/errors"
@@ -8,6 +9,11 @@ import (
 	"go-service-template/repositories"
 )
 
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 type TxDBContext struct {
 	db *sql.DB
 	tx *sql.Tx
@@ -49,6 +55,10 @@ func (txDb *TxDBContext) Exec(ctx monitor.ApplicationContext, query string, args
 
 	res, err = prepStmt.ExecContext(ctx, args...)
 	if err != nil {
+		if translatedErr := translateError(err); translatedErr != nil {
+			return nil, translatedErr
+		}
+
 		return nil, fmt.Errorf("error executing query '%v'. Error: %w", query, err)
 	}
 
@@ -66,6 +76,11 @@ func (txDb *TxDBContext) CommitTx() error {
 	}
 
 	if err := txDb.tx.Commit(); err != nil {
+		// Serialization failures of the transaction are reported on commit
+		if translatedErr := translateError(err); translatedErr != nil {
+			return translatedErr
+		}
+
 		return fmt.Errorf("tx commit failed: %w", err)
 	}
 
@@ -96,6 +111,15 @@ func (txDb *TxDBContext) Ping() error {
 	return nil
 }
 
+// PingContext pings the DB until the context is done, so a DB that does not answer cannot block the caller
+func (txDb *TxDBContext) PingContext(ctx context.Context) error {
+	if err := txDb.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("error pinging DB: %w", err)
+	}
+
+	return nil
+}
+
 func (txDb *TxDBContext) getDBReader() repositories.DBReader {
 	if txDb.tx != nil {
 		return txDb.tx
@@ -104,6 +128,22 @@ func (txDb *TxDBContext) getDBReader() repositories.DBReader {
 	return txDb.db
 }
 
+// query runs the query in the transaction, if any, with the Postgres errors translated into domain errors
+func (txDb *TxDBContext) query(ctx monitor.ApplicationContext, query string, args ...any) (*sql.Rows, error) {
+	rows, err := txDb.getDBReader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, translatedOrOriginal(err)
+	}
+
+	return rows, nil
+}
+
+// queryRow runs the query in the transaction, if any, the Postgres errors returned on scan are translated into
+// domain errors
+func (txDb *TxDBContext) queryRow(ctx monitor.ApplicationContext, query string, args ...any) rowScanner {
+	return translatingRow{row: txDb.getDBReader().QueryRowContext(ctx, query, args...)}
+}
+
 func (txDb *TxDBContext) WithTx(ctx monitor.ApplicationContext, fn func(fnCtx monitor.ApplicationContext) error) error {
 	var err error
 
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -8,6 +9,7 @@ import (
 	"go-service-template/monitor"
 	"log"
 	"testing"
+	"time"
 )
 
 var mockContext = monitor.CreateMockAppContext("")
@@ -71,3 +73,13 @@ func (s *TxDBContextSuite) Test_WithTx_SuccessfullyWrapsTxExecutionOnFailure() {
 		s.T().Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func (s *TxDBContextSuite) Test_PingContext_FailsWhenContextIsDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.sqlMock.ExpectPing().WillDelayFor(time.Second)
+
+	err := s.dbContext.PingContext(ctx)
+
+	assert.ErrorIs(s.T(), err, context.Canceled)
+}
@@ -0,0 +1,332 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go.opentelemetry.io/otel/codes"
+	"time"
+)
+
+// WebhookRepository stores the webhook subscriptions and their deliveries
+type WebhookRepository struct {
+	*TxDBContext
+}
+
+func (dal *WebhookRepository) CreateWebhookSubscription(ctx monitor.ApplicationContext, subscription domain.WebhookSubscription) error {
+	ctx, span := ctx.StartSpan("WebhookRepository.CreateWebhookSubscription")
+	defer span.End()
+
+	_, err := dal.Exec(
+		ctx,
+		InsertWebhookSubscription,
+		subscription.ID,
+		subscription.URL,
+		pq.Array(subscription.EventTypes),
+		subscription.Secret,
+		subscription.SupplierID,
+		subscription.Status,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetWebhookSubscriptions returns the subscriptions the caller can manage, the ones of its supplier when it is
+// restricted to one
+func (dal *WebhookRepository) GetWebhookSubscriptions(ctx monitor.ApplicationContext) ([]domain.WebhookSubscription, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.GetWebhookSubscriptions")
+	defer span.End()
+
+	return dal.queryWebhookSubscriptions(ctx, GetWebhookSubscriptions, tenantSupplierID(ctx))
+}
+
+// GetWebhookSubscriptionByID returns nil when the subscription does not exist or belongs to another supplier
+func (dal *WebhookRepository) GetWebhookSubscriptionByID(ctx monitor.ApplicationContext, id string) (*domain.WebhookSubscription, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.GetWebhookSubscriptionByID")
+	defer span.End()
+
+	subscription, err := scanWebhookSubscription(dal.queryRow(ctx, GetWebhookSubscriptionByID, id, tenantSupplierID(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// GetActiveWebhookSubscriptions returns the active subscriptions to the event type that receive the events of the
+// supplier
+func (dal *WebhookRepository) GetActiveWebhookSubscriptions(
+	ctx monitor.ApplicationContext,
+	eventType string,
+	supplierID int,
+) ([]domain.WebhookSubscription, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.GetActiveWebhookSubscriptions")
+	defer span.End()
+
+	return dal.queryWebhookSubscriptions(ctx, GetActiveWebhookSubscriptions, eventType, supplierID)
+}
+
+// UpdateWebhookSubscription returns false when the subscription does not exist or belongs to another supplier
+func (dal *WebhookRepository) UpdateWebhookSubscription(ctx monitor.ApplicationContext, subscription domain.WebhookSubscription) (bool, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.UpdateWebhookSubscription")
+	defer span.End()
+
+	res, err := dal.Exec(
+		ctx,
+		UpdateWebhookSubscription,
+		subscription.URL,
+		pq.Array(subscription.EventTypes),
+		subscription.Secret,
+		subscription.SupplierID,
+		subscription.Status,
+		subscription.UpdatedAt,
+		subscription.ID,
+		tenantSupplierID(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows > 0, nil
+}
+
+// DeleteWebhookSubscription deletes the subscription along with its delivery log. It returns false when the
+// subscription does not exist or belongs to another supplier.
+func (dal *WebhookRepository) DeleteWebhookSubscription(ctx monitor.ApplicationContext, id string) (bool, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.DeleteWebhookSubscription")
+	defer span.End()
+
+	res, err := dal.Exec(ctx, DeleteWebhookSubscription, id, tenantSupplierID(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRows > 0, nil
+}
+
+// UpsertWebhookDelivery adds the delivery to the log, a delivery of the same event to the subscription adds its
+// attempts to the existing entry
+func (dal *WebhookRepository) UpsertWebhookDelivery(ctx monitor.ApplicationContext, delivery domain.WebhookDelivery) error {
+	ctx, span := ctx.StartSpan("WebhookRepository.UpsertWebhookDelivery")
+	defer span.End()
+
+	_, err := dal.Exec(
+		ctx,
+		UpsertWebhookDelivery,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		[]byte(delivery.Payload),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.CreatedAt,
+		delivery.LastAttemptAt,
+		delivery.NextAttemptAt,
+	)
+
+	return err
+}
+
+// GetWebhookDeliveries returns the newest deliveries of the subscription, optionally only the ones with the status
+func (dal *WebhookRepository) GetWebhookDeliveries(
+	ctx monitor.ApplicationContext,
+	subscriptionID string,
+	status *string,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.GetWebhookDeliveries")
+	defer span.End()
+
+	rows, err := dal.query(ctx, GetWebhookDeliveries, subscriptionID, status, limit)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rowsErr(rows)
+}
+
+// GetWebhookDeliveryByID returns nil when the subscription has no delivery with the ID
+func (dal *WebhookRepository) GetWebhookDeliveryByID(ctx monitor.ApplicationContext, subscriptionID, id string) (*domain.WebhookDelivery, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.GetWebhookDeliveryByID")
+	defer span.End()
+
+	delivery, err := scanWebhookDelivery(dal.queryRow(ctx, GetWebhookDeliveryByID, subscriptionID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// UpdateWebhookDelivery stores the outcome of a retry or a redelivery
+func (dal *WebhookRepository) UpdateWebhookDelivery(ctx monitor.ApplicationContext, delivery domain.WebhookDelivery) error {
+	ctx, span := ctx.StartSpan("WebhookRepository.UpdateWebhookDelivery")
+	defer span.End()
+
+	_, err := dal.Exec(
+		ctx,
+		UpdateWebhookDelivery,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.LastAttemptAt,
+		delivery.DeliveredAt,
+		delivery.NextAttemptAt,
+		delivery.ID,
+	)
+
+	return err
+}
+
+// ClaimDueWebhookDeliveries returns the retries whose next attempt is due, they are not due again until claimedUntil
+// so they are only sent by one instance at a time
+func (dal *WebhookRepository) ClaimDueWebhookDeliveries(
+	ctx monitor.ApplicationContext,
+	claimedUntil time.Time,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	ctx, span := ctx.StartSpan("WebhookRepository.ClaimDueWebhookDeliveries")
+	defer span.End()
+
+	rows, err := dal.query(ctx, ClaimDueWebhookDeliveries, claimedUntil, limit)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rowsErr(rows)
+}
+
+func (dal *WebhookRepository) queryWebhookSubscriptions(
+	ctx monitor.ApplicationContext,
+	query string,
+	args ...any,
+) ([]domain.WebhookSubscription, error) {
+	rows, err := dal.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]domain.WebhookSubscription, 0)
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rowsErr(rows)
+}
+
+func scanWebhookSubscription(scanner rowScanner) (domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	var supplierID sql.NullInt64
+
+	if err := scanner.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		pq.Array(&subscription.EventTypes),
+		&subscription.Secret,
+		&supplierID,
+		&subscription.Status,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	); err != nil {
+		return subscription, err
+	}
+
+	if supplierID.Valid {
+		id := int(supplierID.Int64)
+		subscription.SupplierID = &id
+	}
+
+	return subscription, nil
+}
+
+func scanWebhookDelivery(scanner rowScanner) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload []byte
+	var responseStatus sql.NullInt64
+
+	if err := scanner.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&responseStatus,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.LastAttemptAt,
+		&delivery.DeliveredAt,
+		&delivery.NextAttemptAt,
+	); err != nil {
+		return delivery, err
+	}
+
+	delivery.Payload = payload
+	if responseStatus.Valid {
+		status := int(responseStatus.Int64)
+		delivery.ResponseStatus = &status
+	}
+
+	return delivery, nil
+}
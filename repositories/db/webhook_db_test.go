@@ -0,0 +1,166 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/utils"
+	"log"
+	"testing"
+	"time"
+)
+
+var testWebhook = domain.WebhookSubscription{
+	ID:         "0f6b1d9e-7b1c-4c55-9a0e-2a9a3cbb6f10",
+	URL:        "https://partner.example.com/hooks",
+	EventTypes: []string{domain.WebhookEventLocationCreated, domain.WebhookEventLocationUpdated},
+	Secret:     "0123456789abcdef",
+	SupplierID: utils.ToPointer(2),
+	Status:     domain.WebhookStatusActive,
+	CreatedAt:  time.Now(),
+	UpdatedAt:  time.Now(),
+}
+
+var webhookColumns = []string{"id", "url", "event_types", "secret", "supplier_id", "status", "created_at", "updated_at"}
+
+var webhookDeliveryColumns = []string{
+	"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "response_status", "last_error",
+	"created_at", "last_attempt_at", "delivered_at", "next_attempt_at",
+}
+
+type WebhookDALSuite struct {
+	suite.Suite
+	repo    *WebhookRepository
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+}
+
+func (s *WebhookDALSuite) SetupTest() {
+	db, sqmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		log.Fatalf("An error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	s.db = db
+	s.sqlMock = sqmock
+	s.repo = &WebhookRepository{
+		TxDBContext: CreateTxDBContext(db),
+	}
+}
+
+func TestWebhookDALSuite(t *testing.T) {
+	suite.Run(t, new(WebhookDALSuite))
+}
+
+func (s *WebhookDALSuite) Test_CreateWebhookSubscription_Success() {
+	s.sqlMock.ExpectPrepare(InsertWebhookSubscription).ExpectExec().WithArgs(
+		testWebhook.ID,
+		testWebhook.URL,
+		pq.Array(testWebhook.EventTypes),
+		testWebhook.Secret,
+		testWebhook.SupplierID,
+		testWebhook.Status,
+		testWebhook.CreatedAt,
+		testWebhook.UpdatedAt,
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := s.repo.CreateWebhookSubscription(mockCtx, testWebhook)
+
+	assert.Nil(s.T(), err)
+	if err = s.sqlMock.ExpectationsWereMet(); err != nil {
+		s.T().Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func (s *WebhookDALSuite) Test_GetWebhookSubscriptionByID_ScopedToTenant() {
+	supplierCtx := mockCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", SupplierID: utils.ToPointer(2)})
+
+	s.sqlMock.ExpectQuery(GetWebhookSubscriptionByID).WithArgs(testWebhook.ID, 2).WillReturnRows(
+		sqlmock.NewRows(webhookColumns).AddRow(
+			testWebhook.ID, testWebhook.URL, "{location.created,location.updated}", testWebhook.Secret, 2,
+			testWebhook.Status, testWebhook.CreatedAt, testWebhook.UpdatedAt,
+		),
+	)
+
+	webhook, err := s.repo.GetWebhookSubscriptionByID(supplierCtx, testWebhook.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), testWebhook, *webhook)
+}
+
+func (s *WebhookDALSuite) Test_GetWebhookSubscriptionByID_ReturnsNilWhenMissing() {
+	s.sqlMock.ExpectQuery(GetWebhookSubscriptionByID).WithArgs(testWebhook.ID, nil).WillReturnError(sql.ErrNoRows)
+
+	webhook, err := s.repo.GetWebhookSubscriptionByID(mockCtx, testWebhook.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), webhook)
+}
+
+func (s *WebhookDALSuite) Test_GetActiveWebhookSubscriptions_Success() {
+	s.sqlMock.ExpectQuery(GetActiveWebhookSubscriptions).WithArgs(domain.WebhookEventLocationCreated, 2).WillReturnRows(
+		sqlmock.NewRows(webhookColumns).
+			AddRow(testWebhook.ID, testWebhook.URL, "{location.created}", testWebhook.Secret, 2, testWebhook.Status, testWebhook.CreatedAt, testWebhook.UpdatedAt).
+			AddRow("other", "https://all.example.com", "{location.created}", "secret", nil, testWebhook.Status, testWebhook.CreatedAt, testWebhook.UpdatedAt),
+	)
+
+	webhooks, err := s.repo.GetActiveWebhookSubscriptions(mockCtx, domain.WebhookEventLocationCreated, 2)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), webhooks, 2)
+	assert.Equal(s.T(), testWebhook.SupplierID, webhooks[0].SupplierID)
+	assert.Nil(s.T(), webhooks[1].SupplierID)
+}
+
+func (s *WebhookDALSuite) Test_DeleteWebhookSubscription_ReturnsFalseWhenMissing() {
+	s.sqlMock.ExpectPrepare(DeleteWebhookSubscription).ExpectExec().WithArgs(testWebhook.ID, nil).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	deleted, err := s.repo.DeleteWebhookSubscription(mockCtx, testWebhook.ID)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), deleted)
+}
+
+func (s *WebhookDALSuite) Test_GetWebhookDeliveries_FiltersByStatus() {
+	status := domain.WebhookDeliveryFailed
+	createdAt := time.Now()
+
+	s.sqlMock.ExpectQuery(GetWebhookDeliveries).WithArgs(testWebhook.ID, &status, 100).WillReturnRows(
+		sqlmock.NewRows(webhookDeliveryColumns).AddRow(
+			"delivery", testWebhook.ID, "event", domain.WebhookEventLocationCreated, []byte(`{"id":"event"}`), status, 5,
+			503, "the webhook endpoint answered with status 503", createdAt, createdAt, nil, nil,
+		),
+	)
+
+	deliveries, err := s.repo.GetWebhookDeliveries(mockCtx, testWebhook.ID, &status, 100)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), deliveries, 1)
+	assert.Equal(s.T(), utils.ToPointer(503), deliveries[0].ResponseStatus)
+	assert.JSONEq(s.T(), `{"id":"event"}`, string(deliveries[0].Payload))
+	assert.Nil(s.T(), deliveries[0].DeliveredAt)
+}
+
+func (s *WebhookDALSuite) Test_ClaimDueWebhookDeliveries_ReturnsClaimedRetries() {
+	createdAt := time.Now()
+	claimedUntil := createdAt.Add(5 * time.Minute)
+
+	s.sqlMock.ExpectQuery(ClaimDueWebhookDeliveries).WithArgs(claimedUntil, 50).WillReturnRows(
+		sqlmock.NewRows(webhookDeliveryColumns).AddRow(
+			"delivery", testWebhook.ID, "event", domain.WebhookEventLocationCreated, []byte(`{"id":"event"}`),
+			domain.WebhookDeliveryRetrying, 2, nil, "connection refused", createdAt, createdAt, nil, claimedUntil,
+		),
+	)
+
+	deliveries, err := s.repo.ClaimDueWebhookDeliveries(mockCtx, claimedUntil, 50)
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), deliveries, 1)
+	assert.Equal(s.T(), domain.WebhookDeliveryRetrying, deliveries[0].Status)
+	assert.Equal(s.T(), &claimedUntil, deliveries[0].NextAttemptAt)
+	assert.Nil(s.T(), s.sqlMock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package googlemaps
 import (
 	"encoding/json"
 	"errors"
+	"go-service-template/domain"
 	"go-service-template/domain/googlemaps"
 	customHTTP "go-service-template/http"
 	"go-service-template/monitor"
@@ -13,6 +14,7 @@ import (
 const (
 	premisePlaceType   = "premise"
 	defaultTimeoutSecs = 5
+	dependencyName     = "Google Maps API"
 )
 
 var ErrGenericGoogleErr = errors.New("error from Google Maps API")
@@ -42,7 +44,7 @@ func (r *Repository) ValidateAddress(ctx monitor.ApplicationContext, request goo
 
 	res, err := r.httpClient.DoWithRetry(ctx, requestValues, defaultTimeoutSecs*time.Second, customHTTP.DefaultRetryAmount, time.Second, []int{})
 	if err != nil {
-		return nil, err
+		return nil, domain.DependencyErr{Dependency: dependencyName, Err: err}
 	}
 
 	r.logger.InfoCtx(ctx, fnName,
@@ -68,12 +70,12 @@ func (r *Repository) ValidateAddress(ctx monitor.ApplicationContext, request goo
 			Value: string(res.BodyPayload),
 		})
 
-		return nil, ErrGenericGoogleErr
+		return nil, domain.DependencyErr{Dependency: dependencyName, Err: ErrGenericGoogleErr}
 	}
 
 	var response googlemaps.AddressValidationResponse
 	if err = json.Unmarshal(res.BodyPayload, &response); err != nil {
-		return nil, err
+		return nil, domain.DependencyErr{Dependency: dependencyName, Err: err}
 	}
 
 	// If no matches were found, return nil
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
 	"go-service-template/domain/googlemaps"
 	customHTTP "go-service-template/http"
 	"go-service-template/mocks"
@@ -187,6 +188,7 @@ func (s *GoogleMapsRepositorySuite) Test_ValidateAddress_Error() {
 	res, err := s.googleMapsRepository.ValidateAddress(mockCtx, mockRequest)
 
 	assert.Nil(s.T(), res)
-	assert.NotNil(s.T(), err)
+	assert.ErrorAs(s.T(), err, &domain.DependencyErr{})
+	assert.ErrorIs(s.T(), err, googleMapsRepo.ErrGenericGoogleErr)
 	s.assertMockExpectations()
 }
@@ -6,6 +6,7 @@ import (
 	"go-service-template/domain"
 	"go-service-template/domain/googlemaps"
 	"go-service-template/monitor"
+	"time"
 )
 
 type DBReader interface {
@@ -26,14 +27,103 @@ type LocationsDB interface {
 	QueryExecutor
 	CreateLocation(ctx monitor.ApplicationContext, location domain.Location) error
 	UpdateLocation(ctx monitor.ApplicationContext, location domain.Location) error
+	SoftDeleteLocation(ctx monitor.ApplicationContext, id string, deletedAt time.Time) error
+	RestoreLocation(ctx monitor.ApplicationContext, id string) error
 	CreateSubLocation(ctx monitor.ApplicationContext, subLocation domain.SubLocation) error
+	UpdateSubLocation(ctx monitor.ApplicationContext, subLocation domain.SubLocation) error
+	GetSubLocationByID(ctx monitor.ApplicationContext, locationID, subLocationID string) (*domain.SubLocation, error)
+	CheckSubLocationNameExistence(ctx monitor.ApplicationContext, locationID, name string) (bool, error)
+	GetPaginatedSubLocations(ctx monitor.ApplicationContext, filters domain.SubLocationsFilters) (domain.CursorPage[domain.SubLocation], error)
 	GetLocationByID(ctx monitor.ApplicationContext, id string) (*domain.Location, error)
 	CheckLocationNameExistence(ctx monitor.ApplicationContext, name string) (bool, error)
 	GetPaginatedLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters) (domain.CursorPage[domain.Location], error)
+	GetNearbyLocations(ctx monitor.ApplicationContext, filters domain.NearbyLocationsFilters) ([]domain.NearbyLocation, error)
+	StreamLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters, fn func(location domain.Location) error) error
+	GetLocationHistory(ctx monitor.ApplicationContext, filters domain.LocationHistoryFilters) (domain.CursorPage[domain.LocationHistoryEntry], error)
+	PingContext(ctx context.Context) error
+	OutboxDB
+}
+
+type OutboxDB interface {
+	CreateOutboxMessage(ctx monitor.ApplicationContext, msg domain.OutboxMessage) error
+	AcquireOutboxLock(ctx monitor.ApplicationContext) (bool, error)
+	GetPendingOutboxMessages(ctx monitor.ApplicationContext, limit int) ([]domain.OutboxMessage, error)
+	ClaimOutboxMessages(ctx monitor.ApplicationContext, ids []string, claimedUntil time.Time) error
+	MarkOutboxMessagesAsPublished(ctx monitor.ApplicationContext, ids []string) error
+	RecordOutboxMessageFailure(ctx monitor.ApplicationContext, id string, lastError string, nextAttemptAt time.Time, failed bool) error
+	DeletePublishedOutboxMessages(ctx monitor.ApplicationContext, publishedBefore time.Time) (int64, error)
+	GetParkedOutboxMessages(ctx monitor.ApplicationContext, limit int) ([]domain.OutboxMessage, error)
+	RedriveParkedOutboxMessage(ctx monitor.ApplicationContext, id string) (bool, error)
+	DeleteParkedOutboxMessage(ctx monitor.ApplicationContext, id string) (bool, error)
+}
+
+type IdempotencyDB interface {
+	QueryExecutor
+	ReserveIdempotencyKey(ctx monitor.ApplicationContext, key domain.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx monitor.ApplicationContext, scope, key string) (*domain.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx monitor.ApplicationContext, scope, key string, response domain.IdempotentResponse) error
+	DeleteIdempotencyKey(ctx monitor.ApplicationContext, scope, key string) error
+	DeleteExpiredIdempotencyKeys(ctx monitor.ApplicationContext, expiredBefore time.Time) (int64, error)
+}
+
+type APIKeyDB interface {
+	QueryExecutor
+	CreateAPIKey(ctx monitor.ApplicationContext, key domain.APIKey) error
+	GetAPIKeys(ctx monitor.ApplicationContext) ([]domain.APIKey, error)
+	GetAPIKeyByPrefix(ctx monitor.ApplicationContext, prefix string) (*domain.APIKey, error)
+	RotateAPIKey(ctx monitor.ApplicationContext, id, prefix, hash string, rotatedAt time.Time) (*domain.APIKey, error)
+	RevokeAPIKey(ctx monitor.ApplicationContext, id string, revokedAt time.Time) (bool, error)
+	UpdateAPIKeysLastUsed(ctx monitor.ApplicationContext, lastUsed map[string]time.Time) error
+}
+
+type WebhookDB interface {
+	QueryExecutor
+	CreateWebhookSubscription(ctx monitor.ApplicationContext, subscription domain.WebhookSubscription) error
+	GetWebhookSubscriptions(ctx monitor.ApplicationContext) ([]domain.WebhookSubscription, error)
+	GetWebhookSubscriptionByID(ctx monitor.ApplicationContext, id string) (*domain.WebhookSubscription, error)
+	GetActiveWebhookSubscriptions(ctx monitor.ApplicationContext, eventType string, supplierID int) ([]domain.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx monitor.ApplicationContext, subscription domain.WebhookSubscription) (bool, error)
+	DeleteWebhookSubscription(ctx monitor.ApplicationContext, id string) (bool, error)
+	UpsertWebhookDelivery(ctx monitor.ApplicationContext, delivery domain.WebhookDelivery) error
+	GetWebhookDeliveries(ctx monitor.ApplicationContext, subscriptionID string, status *string, limit int) ([]domain.WebhookDelivery, error)
+	GetWebhookDeliveryByID(ctx monitor.ApplicationContext, subscriptionID, id string) (*domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx monitor.ApplicationContext, delivery domain.WebhookDelivery) error
+	ClaimDueWebhookDeliveries(ctx monitor.ApplicationContext, claimedUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+}
+
+type DeadLetterDB interface {
+	QueryExecutor
+	CreateDeadLetter(ctx monitor.ApplicationContext, deadLetter domain.DeadLetter) error
+	GetDeadLetters(ctx monitor.ApplicationContext, filters domain.DeadLetterFilters) ([]domain.DeadLetter, error)
+	GetDeadLetterByID(ctx monitor.ApplicationContext, id string) (*domain.DeadLetter, error)
+	MarkDeadLetterAsRedriven(ctx monitor.ApplicationContext, id string, redrivenAt time.Time) (bool, error)
+	// CreateOutboxMessage writes the redriven message in the transaction that marks its dead letter
+	CreateOutboxMessage(ctx monitor.ApplicationContext, msg domain.OutboxMessage) error
+}
+
+type ReferenceDataDB interface {
+	QueryExecutor
+	GetSuppliers(ctx monitor.ApplicationContext) ([]domain.Supplier, error)
+	CreateSupplier(ctx monitor.ApplicationContext, supplier domain.Supplier) (int, error)
+	UpdateSupplier(ctx monitor.ApplicationContext, supplier domain.Supplier) (bool, error)
+	DeleteSupplier(ctx monitor.ApplicationContext, id int) (bool, error)
+	GetLocationTypes(ctx monitor.ApplicationContext) ([]domain.LocationType, error)
+	CreateLocationType(ctx monitor.ApplicationContext, locationType domain.LocationType) (int, error)
+	UpdateLocationType(ctx monitor.ApplicationContext, locationType domain.LocationType) (bool, error)
+	DeleteLocationType(ctx monitor.ApplicationContext, id int) (bool, error)
+	GetSubLocationTypes(ctx monitor.ApplicationContext) ([]domain.SubLocationType, error)
+	CreateSubLocationType(ctx monitor.ApplicationContext, subLocationType domain.SubLocationType) (int, error)
+	UpdateSubLocationType(ctx monitor.ApplicationContext, subLocationType domain.SubLocationType) (bool, error)
+	DeleteSubLocationType(ctx monitor.ApplicationContext, id int) (bool, error)
 }
 
 type DatabaseFactory interface {
 	GetLocationsDB() (LocationsDB, error)
+	GetReferenceDataDB() (ReferenceDataDB, error)
+	GetIdempotencyDB() (IdempotencyDB, error)
+	GetAPIKeyDB() (APIKeyDB, error)
+	GetWebhookDB() (WebhookDB, error)
+	GetDeadLetterDB() (DeadLetterDB, error)
 }
 
 type GoogleMapsAPI interface {
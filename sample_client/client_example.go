@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"github.com/golang-jwt/jwt/v5"
 	"go-service-template/config"
 	customHTTP "go-service-template/http"
 	"go-service-template/monitor"
@@ -48,6 +49,33 @@ func registerSyncTraceProvider(collectorEndpoint string) {
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 }
 
+// signLocalToken signs a bearer token with the first HS256 static key of the config, valid for the local environment
+func signLocalToken(authCfg config.AuthConfig) string {
+	for _, key := range authCfg.StaticKeys {
+		if key.Algorithm != jwt.SigningMethodHS256.Alg() {
+			continue
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":   "mock-client",
+			"iss":   authCfg.Issuer,
+			"aud":   authCfg.Audience,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "locations:read locations:write",
+		})
+		token.Header["kid"] = key.KeyID
+
+		signed, err := token.SignedString([]byte(key.Secret))
+		if err != nil {
+			panic(err)
+		}
+
+		return signed
+	}
+
+	panic("no HS256 static key configured")
+}
+
 func main() {
 	_, _ = config.GetEnvironment()
 	appCfg, err := config.LoadConfig()
@@ -91,6 +119,7 @@ func main() {
 
 	header := http.Header{}
 	header.Set("Correlation-Id", appCtx.GetCorrelationID())
+	header.Set("Authorization", "Bearer "+signLocalToken(appCfg.AuthConfig))
 
 	// Send first request
 	_, err = customHTTPClient.Do(appCtx, customHTTP.RequestValues{
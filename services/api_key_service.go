@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultAPIKeyCacheTTLSeconds              = 60
+	DefaultAPIKeyLastUsedFlushIntervalSeconds = 60
+	apiKeyPrefixBytes                         = 8
+	apiKeySecretBytes                         = 32
+	apiKeySeparator                           = "."
+)
+
+// APIKeyService manages the API keys of the machine clients and authenticates the requests sent with them. Keys are
+// sent as "<prefix>.<secret>", the prefix finds the stored key and the hash of the whole key is compared in constant
+// time. Authenticated keys are cached by prefix and their last used timestamps are flushed to the DB periodically.
+type APIKeyService struct {
+	logger        monitor.AppLogger
+	dbFactory     repositories.DatabaseFactory
+	referenceData IReferenceDataService
+	cacheTTL      time.Duration
+	flushInterval time.Duration
+
+	cacheMu sync.RWMutex
+	cache   map[string]cachedAPIKey
+
+	lastUsedMu sync.Mutex
+	lastUsed   map[string]time.Time
+}
+
+type cachedAPIKey struct {
+	key       domain.APIKey
+	expiresAt time.Time
+}
+
+func NewAPIKeyService(
+	dbFactory repositories.DatabaseFactory,
+	referenceData IReferenceDataService,
+	cfg config.APIKeyConfig,
+) *APIKeyService {
+	cacheTTLSeconds := config.GetIntValueOrDefault(cfg.CacheTTLSeconds, DefaultAPIKeyCacheTTLSeconds)
+	flushIntervalSeconds := config.GetIntValueOrDefault(cfg.LastUsedFlushIntervalSeconds, DefaultAPIKeyLastUsedFlushIntervalSeconds)
+
+	return &APIKeyService{
+		logger:        monitor.GetStdLogger("APIKeyService"),
+		dbFactory:     dbFactory,
+		referenceData: referenceData,
+		cacheTTL:      time.Duration(cacheTTLSeconds) * time.Second,
+		flushInterval: time.Duration(flushIntervalSeconds) * time.Second,
+		cache:         make(map[string]cachedAPIKey),
+		lastUsed:      make(map[string]time.Time),
+	}
+}
+
+// CreateAPIKey stores a new key, the returned key is the only time it can be read. The key cannot be granted more
+// than the caller has: the scopes must be granted to the caller and the key of a caller restricted to a supplier is
+// restricted to the same one.
+func (s *APIKeyService) CreateAPIKey(ctx monitor.ApplicationContext, data dto.CreateAPIKeyRequest) (domain.IssuedAPIKey, error) {
+	fnName := "APIKeyService.CreateAPIKey"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	supplierID, err := getTenantSupplierID(ctx, s.referenceData, data.SupplierID)
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	if err = checkGrantedScopes(ctx, data.Scopes); err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	prefix, key, err := generateAPIKey()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.IssuedAPIKey{}, err
+	}
+
+	issued := domain.IssuedAPIKey{
+		APIKey: domain.APIKey{
+			ID:         uuid.New().String(),
+			Name:       data.Name,
+			Prefix:     prefix,
+			Hash:       hashAPIKey(key),
+			Scopes:     data.Scopes,
+			SupplierID: supplierID,
+			CreatedAt:  time.Now().UTC(),
+		},
+		Key: key,
+	}
+
+	db, err := s.dbFactory.GetAPIKeyDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.IssuedAPIKey{}, err
+	}
+
+	if err = db.CreateAPIKey(ctx, issued.APIKey); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to create API key", err)
+		return domain.IssuedAPIKey{}, err
+	}
+
+	return issued, nil
+}
+
+func (s *APIKeyService) GetAPIKeys(ctx monitor.ApplicationContext) ([]domain.APIKey, error) {
+	ctx, span := ctx.StartSpan("APIKeyService.GetAPIKeys")
+	defer span.End()
+
+	db, err := s.dbFactory.GetAPIKeyDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetAPIKeys(ctx)
+}
+
+// RotateAPIKey replaces the key with a new one keeping its name, scopes and supplier. The previous key stops working
+// right away in this instance and once its cache entry expires in the rest.
+func (s *APIKeyService) RotateAPIKey(ctx monitor.ApplicationContext, id string) (domain.IssuedAPIKey, error) {
+	fnName := "APIKeyService.RotateAPIKey"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("api_key_id", id)))
+	defer span.End()
+
+	prefix, key, err := generateAPIKey()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.IssuedAPIKey{}, err
+	}
+
+	db, err := s.dbFactory.GetAPIKeyDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.IssuedAPIKey{}, err
+	}
+
+	rotated, err := db.RotateAPIKey(ctx, id, prefix, hashAPIKey(key), time.Now().UTC())
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to rotate API key", err)
+		return domain.IssuedAPIKey{}, err
+	}
+	if rotated == nil {
+		return domain.IssuedAPIKey{}, domain.NotFoundErr{Msg: fmt.Sprintf("active API key with ID %v does not exist", id), Resource: domain.ResourceAPIKey}
+	}
+
+	s.invalidateCache()
+
+	return domain.IssuedAPIKey{APIKey: *rotated, Key: key}, nil
+}
+
+// RevokeAPIKey disables the key, revoked keys are kept to show when they were last used
+func (s *APIKeyService) RevokeAPIKey(ctx monitor.ApplicationContext, id string) error {
+	fnName := "APIKeyService.RevokeAPIKey"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("api_key_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetAPIKeyDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	revoked, err := db.RevokeAPIKey(ctx, id, time.Now().UTC())
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to revoke API key", err)
+		return err
+	}
+	if !revoked {
+		return domain.NotFoundErr{Msg: fmt.Sprintf("active API key with ID %v does not exist", id), Resource: domain.ResourceAPIKey}
+	}
+
+	s.invalidateCache()
+
+	return nil
+}
+
+// checkGrantedScopes rejects the scopes the caller was not granted, so a key cannot be used to gain more access
+func checkGrantedScopes(ctx monitor.ApplicationContext, scopes []string) error {
+	principal, _ := ctx.GetPrincipal()
+	for _, scope := range scopes {
+		if !principal.HasScopes(scope) {
+			return domain.BusinessErr{Msg: fmt.Sprintf("the scope %v cannot be granted, the caller does not have it", scope), Resource: domain.ResourceAPIKey}
+		}
+	}
+
+	return nil
+}
+
+// Authenticate returns the active key matching the given one, or nil when the key is unknown, malformed or revoked
+func (s *APIKeyService) Authenticate(ctx monitor.ApplicationContext, key string) (*domain.APIKey, error) {
+	fnName := "APIKeyService.Authenticate"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	prefix, _, found := strings.Cut(key, apiKeySeparator)
+	if !found || prefix == "" {
+		return nil, nil
+	}
+
+	storedKey, err := s.findByPrefix(ctx, prefix)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to get API key", err)
+		return nil, err
+	}
+
+	if storedKey == nil || storedKey.IsRevoked() ||
+		subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(storedKey.Hash)) != 1 {
+		return nil, nil
+	}
+
+	span.SetAttributes(attribute.String("api_key_id", storedKey.ID))
+	s.recordUse(storedKey.ID)
+
+	return storedKey, nil
+}
+
+// FlushLastUsed writes the last used timestamps recorded since the previous flush
+func (s *APIKeyService) FlushLastUsed(ctx monitor.ApplicationContext) error {
+	ctx, span := ctx.StartSpan("APIKeyService.FlushLastUsed")
+	defer span.End()
+
+	s.lastUsedMu.Lock()
+	lastUsed := s.lastUsed
+	s.lastUsed = make(map[string]time.Time)
+	s.lastUsedMu.Unlock()
+
+	if len(lastUsed) == 0 {
+		return nil
+	}
+
+	db, err := s.dbFactory.GetAPIKeyDB()
+	if err == nil {
+		err = db.UpdateAPIKeysLastUsed(ctx, lastUsed)
+	}
+	if err != nil {
+		// Put the timestamps back so the next flush retries them, unless a newer use was recorded meanwhile
+		for id, usedAt := range lastUsed {
+			s.recordUseAt(id, usedAt)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RunLastUsedFlush flushes the last used timestamps periodically until the context is cancelled, then flushes the
+// pending ones a last time
+func (s *APIKeyService) RunLastUsedFlush(ctx context.Context) {
+	fnName := "APIKeyService.RunLastUsedFlush"
+
+	flushTicker := time.NewTicker(s.flushInterval)
+	defer flushTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info(fnName, "", "stopping API keys last used flush")
+			appCtx := monitor.CreateAppContextFromContext(context.WithoutCancel(ctx), "")
+			if err := s.FlushLastUsed(appCtx); err != nil {
+				s.logger.ErrorCtx(appCtx, fnName, "failed to flush API keys last used timestamps", err)
+			}
+			return
+		case <-flushTicker.C:
+			appCtx := monitor.CreateAppContextFromContext(ctx, "")
+			if err := s.FlushLastUsed(appCtx); err != nil {
+				s.logger.ErrorCtx(appCtx, fnName, "failed to flush API keys last used timestamps", err)
+			}
+		}
+	}
+}
+
+func (s *APIKeyService) findByPrefix(ctx monitor.ApplicationContext, prefix string) (*domain.APIKey, error) {
+	s.cacheMu.RLock()
+	cached, ok := s.cache[prefix]
+	s.cacheMu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return &cached.key, nil
+	}
+
+	db, err := s.dbFactory.GetAPIKeyDB()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := db.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil || key == nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache[prefix] = cachedAPIKey{key: *key, expiresAt: time.Now().Add(s.cacheTTL)}
+	s.cacheMu.Unlock()
+
+	return key, nil
+}
+
+func (s *APIKeyService) invalidateCache() {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	s.cache = make(map[string]cachedAPIKey)
+}
+
+func (s *APIKeyService) recordUse(id string) {
+	s.recordUseAt(id, time.Now().UTC())
+}
+
+func (s *APIKeyService) recordUseAt(id string, usedAt time.Time) {
+	s.lastUsedMu.Lock()
+	defer s.lastUsedMu.Unlock()
+
+	if previous, ok := s.lastUsed[id]; !ok || previous.Before(usedAt) {
+		s.lastUsed[id] = usedAt
+	}
+}
+
+// generateAPIKey returns a random key and its prefix
+func generateAPIKey() (string, string, error) {
+	prefix := make([]byte, apiKeyPrefixBytes)
+	secret := make([]byte, apiKeySecretBytes)
+
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", fmt.Errorf("error generating API key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("error generating API key: %w", err)
+	}
+
+	encodedPrefix := hex.EncodeToString(prefix)
+
+	return encodedPrefix, encodedPrefix + apiKeySeparator + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashAPIKey uses SHA-256 without salt, the keys are random so they cannot be guessed from a precomputed table
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
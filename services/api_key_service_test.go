@@ -0,0 +1,239 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/mocks"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"go-service-template/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+// apiKeyAdminCtx is the context of an admin that can grant the scopes of the keys created in the tests
+var apiKeyAdminCtx = testCtx.WithPrincipal(monitor.Principal{Subject: "admin", Scopes: []string{"locations:read", "api-keys:admin"}})
+
+type APIKeyServiceSuite struct {
+	suite.Suite
+	dbFactoryMock     *mocks.DatabaseFactory
+	apiKeyDBMock      *mocks.APIKeyDB
+	referenceDataMock *mocks.IReferenceDataService
+	apiKeyService     *services.APIKeyService
+}
+
+func (s *APIKeyServiceSuite) SetupTest() {
+	s.dbFactoryMock = new(mocks.DatabaseFactory)
+	s.apiKeyDBMock = new(mocks.APIKeyDB)
+	s.referenceDataMock = new(mocks.IReferenceDataService)
+	s.dbFactoryMock.On("GetAPIKeyDB").Return(s.apiKeyDBMock, nil)
+
+	// The service is created for every test so the cache and last used timestamps start empty
+	s.apiKeyService = services.NewAPIKeyService(s.dbFactoryMock, s.referenceDataMock, config.APIKeyConfig{})
+}
+
+func (s *APIKeyServiceSuite) assertAllExpectations() {
+	s.apiKeyDBMock.AssertExpectations(s.T())
+	s.referenceDataMock.AssertExpectations(s.T())
+}
+
+// createKey creates a key through the service and returns it along with the key stored in the DB
+func (s *APIKeyServiceSuite) createKey(request dto.CreateAPIKeyRequest) (domain.IssuedAPIKey, domain.APIKey) {
+	var stored domain.APIKey
+	s.apiKeyDBMock.On("CreateAPIKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(domain.APIKey)
+	}).Return(nil).Once()
+
+	issued, err := s.apiKeyService.CreateAPIKey(apiKeyAdminCtx, request)
+	s.Require().NoError(err)
+
+	return issued, stored
+}
+
+func TestAPIKeyServiceSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyServiceSuite))
+}
+
+func (s *APIKeyServiceSuite) Test_CreateAPIKey_StoresHashOnly() {
+	issued, stored := s.createKey(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+
+	assert.True(s.T(), strings.HasPrefix(issued.Key, stored.Prefix+"."))
+	assert.NotEmpty(s.T(), stored.Hash)
+	assert.NotContains(s.T(), stored.Hash, issued.Key)
+	assert.Equal(s.T(), []string{"locations:read"}, stored.Scopes)
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_CreateAPIKey_FailsWithUnknownSupplier() {
+	s.referenceDataMock.On("GetSupplierByID", mock.Anything, 99).
+		Return(domain.Supplier{}, domain.UnknownReferenceErr{Msg: "supplier with ID 99 does not exist"}).Once()
+
+	_, err := s.apiKeyService.CreateAPIKey(apiKeyAdminCtx, dto.CreateAPIKeyRequest{
+		Name:       "supplier portal",
+		Scopes:     []string{"locations:read"},
+		SupplierID: utils.ToPointer(99),
+	})
+
+	assert.ErrorAs(s.T(), err, &domain.UnknownReferenceErr{})
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_CreateAPIKey_RestrictsKeyToSupplierOfCaller() {
+	supplierCtx := testCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", Scopes: []string{"locations:read"}, SupplierID: utils.ToPointer(7)})
+	var stored domain.APIKey
+	s.apiKeyDBMock.On("CreateAPIKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(domain.APIKey)
+	}).Return(nil).Once()
+
+	_, err := s.apiKeyService.CreateAPIKey(supplierCtx, dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), utils.ToPointer(7), stored.SupplierID)
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_CreateAPIKey_FailsWithSupplierOfAnotherTenant() {
+	supplierCtx := testCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", Scopes: []string{"locations:read"}, SupplierID: utils.ToPointer(7)})
+
+	_, err := s.apiKeyService.CreateAPIKey(supplierCtx, dto.CreateAPIKeyRequest{
+		Name:       "batch job",
+		Scopes:     []string{"locations:read"},
+		SupplierID: utils.ToPointer(8),
+	})
+
+	assert.ErrorAs(s.T(), err, &domain.UnknownReferenceErr{})
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_CreateAPIKey_FailsWithScopeNotGrantedToCaller() {
+	_, err := s.apiKeyService.CreateAPIKey(apiKeyAdminCtx, dto.CreateAPIKeyRequest{
+		Name:   "batch job",
+		Scopes: []string{"locations:read", "locations:write"},
+	})
+
+	assert.ErrorAs(s.T(), err, &domain.BusinessErr{})
+	assert.ErrorContains(s.T(), err, "locations:write")
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_Authenticate_AcceptsKeyAndCachesIt() {
+	issued, stored := s.createKey(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+	s.apiKeyDBMock.On("GetAPIKeyByPrefix", mock.Anything, stored.Prefix).Return(&stored, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		apiKey, err := s.apiKeyService.Authenticate(testCtx, issued.Key)
+
+		assert.Nil(s.T(), err)
+		assert.Equal(s.T(), stored.ID, apiKey.ID)
+	}
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_Authenticate_RejectsWrongSecret() {
+	issued, stored := s.createKey(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+	s.apiKeyDBMock.On("GetAPIKeyByPrefix", mock.Anything, stored.Prefix).Return(&stored, nil).Once()
+
+	apiKey, err := s.apiKeyService.Authenticate(testCtx, issued.Key+"x")
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), apiKey)
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_Authenticate_RejectsRevokedKey() {
+	issued, stored := s.createKey(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+	stored.RevokedAt = utils.ToPointer(time.Now())
+	s.apiKeyDBMock.On("GetAPIKeyByPrefix", mock.Anything, stored.Prefix).Return(&stored, nil).Once()
+
+	apiKey, err := s.apiKeyService.Authenticate(testCtx, issued.Key)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), apiKey)
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_Authenticate_RejectsMalformedKey() {
+	apiKey, err := s.apiKeyService.Authenticate(testCtx, "no-separator")
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), apiKey)
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_RevokeAPIKey_DropsCachedKeys() {
+	issued, stored := s.createKey(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+	s.apiKeyDBMock.On("GetAPIKeyByPrefix", mock.Anything, stored.Prefix).Return(&stored, nil).Once()
+	_, _ = s.apiKeyService.Authenticate(testCtx, issued.Key)
+
+	s.apiKeyDBMock.On("RevokeAPIKey", mock.Anything, stored.ID, mock.Anything).Return(true, nil).Once()
+	assert.Nil(s.T(), s.apiKeyService.RevokeAPIKey(testCtx, stored.ID))
+
+	revoked := stored
+	revoked.RevokedAt = utils.ToPointer(time.Now())
+	s.apiKeyDBMock.On("GetAPIKeyByPrefix", mock.Anything, stored.Prefix).Return(&revoked, nil).Once()
+
+	apiKey, err := s.apiKeyService.Authenticate(testCtx, issued.Key)
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), apiKey)
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_RevokeAPIKey_FailsWhenKeyIsNotActive() {
+	s.apiKeyDBMock.On("RevokeAPIKey", mock.Anything, "id", mock.Anything).Return(false, nil).Once()
+
+	err := s.apiKeyService.RevokeAPIKey(testCtx, "id")
+
+	assert.ErrorAs(s.T(), err, &domain.NotFoundErr{})
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_RotateAPIKey_ReturnsNewKey() {
+	s.apiKeyDBMock.On("RotateAPIKey", mock.Anything, "id", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ monitor.ApplicationContext, id, prefix, hash string, rotatedAt time.Time) *domain.APIKey {
+			return &domain.APIKey{ID: id, Prefix: prefix, Hash: hash, RotatedAt: &rotatedAt}
+		}, nil).Once()
+
+	rotated, err := s.apiKeyService.RotateAPIKey(testCtx, "id")
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), strings.HasPrefix(rotated.Key, rotated.Prefix+"."))
+	assert.NotNil(s.T(), rotated.RotatedAt)
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_FlushLastUsed_WritesUsedKeysOnce() {
+	issued, stored := s.createKey(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+	s.apiKeyDBMock.On("GetAPIKeyByPrefix", mock.Anything, stored.Prefix).Return(&stored, nil).Once()
+	_, _ = s.apiKeyService.Authenticate(testCtx, issued.Key)
+
+	s.apiKeyDBMock.On("UpdateAPIKeysLastUsed", mock.Anything, mock.MatchedBy(func(lastUsed map[string]time.Time) bool {
+		_, ok := lastUsed[stored.ID]
+		return len(lastUsed) == 1 && ok
+	})).Return(nil).Once()
+
+	assert.Nil(s.T(), s.apiKeyService.FlushLastUsed(testCtx))
+	// Nothing was used since the previous flush
+	assert.Nil(s.T(), s.apiKeyService.FlushLastUsed(testCtx))
+	s.assertAllExpectations()
+}
+
+func (s *APIKeyServiceSuite) Test_FlushLastUsed_KeepsTimestampsOnError() {
+	issued, stored := s.createKey(dto.CreateAPIKeyRequest{Name: "batch job", Scopes: []string{"locations:read"}})
+	s.apiKeyDBMock.On("GetAPIKeyByPrefix", mock.Anything, stored.Prefix).Return(&stored, nil).Once()
+	_, _ = s.apiKeyService.Authenticate(testCtx, issued.Key)
+
+	dbErr := errors.New("db down")
+	s.apiKeyDBMock.On("UpdateAPIKeysLastUsed", mock.Anything, mock.Anything).Return(dbErr).Once()
+	s.apiKeyDBMock.On("UpdateAPIKeysLastUsed", mock.Anything, mock.Anything).Return(nil).Once()
+
+	assert.ErrorIs(s.T(), s.apiKeyService.FlushLastUsed(testCtx), dbErr)
+	assert.Nil(s.T(), s.apiKeyService.FlushLastUsed(testCtx))
+	s.assertAllExpectations()
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"go-service-template/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+const DeadLettersLimit = 100
+
+// DeadLetterService keeps the messages the event handlers could not process and redrives them to their original
+// topic. Redriven messages are published through the outbox, in the same transaction that marks the dead letter.
+type DeadLetterService struct {
+	logger    monitor.AppLogger
+	dbFactory repositories.DatabaseFactory
+}
+
+func NewDeadLetterService(dbFactory repositories.DatabaseFactory) *DeadLetterService {
+	return &DeadLetterService{
+		logger:    monitor.GetStdLogger("DeadLetterService"),
+		dbFactory: dbFactory,
+	}
+}
+
+// StoreDeadLetter keeps a dead letter consumed from a poison topic, the ones already stored are ignored
+func (s *DeadLetterService) StoreDeadLetter(ctx monitor.ApplicationContext, deadLetter domain.DeadLetter) error {
+	fnName := "DeadLetterService.StoreDeadLetter"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("dead_letter_id", deadLetter.ID)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetDeadLetterDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if err = db.CreateDeadLetter(ctx, deadLetter); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to store dead letter", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *DeadLetterService) GetDeadLetters(ctx monitor.ApplicationContext, filters domain.DeadLetterFilters) ([]domain.DeadLetter, error) {
+	fnName := "DeadLetterService.GetDeadLetters"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	db, err := s.dbFactory.GetDeadLetterDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	filters.Limit = DeadLettersLimit
+
+	return db.GetDeadLetters(ctx, filters)
+}
+
+func (s *DeadLetterService) GetDeadLetterByID(ctx monitor.ApplicationContext, id string) (domain.DeadLetter, error) {
+	fnName := "DeadLetterService.GetDeadLetterByID"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("dead_letter_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetDeadLetterDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.DeadLetter{}, err
+	}
+
+	deadLetter, err := db.GetDeadLetterByID(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to get dead letter", err)
+		return domain.DeadLetter{}, err
+	}
+	if deadLetter == nil {
+		return domain.DeadLetter{}, domain.NotFoundErr{
+			Msg:      fmt.Sprintf("dead letter with ID %v does not exist", id),
+			Resource: domain.ResourceDeadLetter,
+		}
+	}
+
+	return *deadLetter, nil
+}
+
+// RedriveDeadLetter publishes the message of the dead letter to its original topic again. A dead letter is only
+// redriven once, a message that fails again produces a new one.
+func (s *DeadLetterService) RedriveDeadLetter(ctx monitor.ApplicationContext, id string) (domain.DeadLetter, error) {
+	fnName := "DeadLetterService.RedriveDeadLetter"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("dead_letter_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetDeadLetterDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.DeadLetter{}, err
+	}
+
+	var deadLetter *domain.DeadLetter
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		var txErr error
+
+		deadLetter, txErr = db.GetDeadLetterByID(ctx, id)
+		if txErr != nil {
+			return fmt.Errorf("error finding dead letter with ID %v: %w", id, txErr)
+		}
+		if deadLetter == nil {
+			return domain.NotFoundErr{Msg: fmt.Sprintf("dead letter with ID %v does not exist", id), Resource: domain.ResourceDeadLetter}
+		}
+
+		redrivenAt := time.Now().UTC()
+		marked, txErr := db.MarkDeadLetterAsRedriven(ctx, id, redrivenAt)
+		if txErr != nil {
+			return fmt.Errorf("error marking dead letter as redriven: %w", txErr)
+		}
+		if !marked {
+			return domain.BusinessErr{Msg: fmt.Sprintf("dead letter with ID %v was already redriven", id), Resource: domain.ResourceDeadLetter}
+		}
+		deadLetter.RedrivenAt = &redrivenAt
+
+		return db.CreateOutboxMessage(ctx, pubsub.CreateRedriveOutboxMessage(*deadLetter))
+	}); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
+		return domain.DeadLetter{}, err
+	}
+
+	return *deadLetter, nil
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/mocks"
+	"go-service-template/pubsub"
+	"go-service-template/services"
+	"go-service-template/utils"
+	"testing"
+	"time"
+)
+
+var testDeadLetter = domain.DeadLetter{
+	ID:            "5d0f1a8e-3c52-4d55-9f1e-2b7f3c9a6e21",
+	MessageID:     "b6a3c1d2-8f4e-4e1a-9c7b-1d2e3f4a5b6c",
+	Handler:       "NewLocationWebhookHandler",
+	OriginalTopic: domain.LocationsNewTopic,
+	PoisonTopic:   domain.LocationsNewTopic + ".poison.NewLocationWebhookHandler",
+	Error:         "webhook delivery failed",
+	Attempts:      6,
+	CorrelationID: "correlation-id",
+	Payload:       []byte(`{"id":"location1"}`),
+	Metadata:      map[string]string{pubsub.MessageKey: "location1"},
+	FailedAt:      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+}
+
+type DeadLetterServiceSuite struct {
+	suite.Suite
+	dbFactoryMock     *mocks.DatabaseFactory
+	deadLetterDBMock  *mocks.DeadLetterDB
+	deadLetterService *services.DeadLetterService
+}
+
+func (s *DeadLetterServiceSuite) SetupTest() {
+	s.dbFactoryMock = new(mocks.DatabaseFactory)
+	s.deadLetterDBMock = new(mocks.DeadLetterDB)
+	s.dbFactoryMock.On("GetDeadLetterDB").Return(s.deadLetterDBMock, nil)
+
+	s.deadLetterService = services.NewDeadLetterService(s.dbFactoryMock)
+}
+
+func TestDeadLetterServiceSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterServiceSuite))
+}
+
+func (s *DeadLetterServiceSuite) Test_GetDeadLetters_AppliesLimit() {
+	s.deadLetterDBMock.On("GetDeadLetters", mock.Anything, domain.DeadLetterFilters{
+		Handler: utils.ToPointer(testDeadLetter.Handler),
+		Limit:   services.DeadLettersLimit,
+	}).Return([]domain.DeadLetter{testDeadLetter}, nil).Once()
+
+	deadLetters, err := s.deadLetterService.GetDeadLetters(testCtx, domain.DeadLetterFilters{Handler: utils.ToPointer(testDeadLetter.Handler)})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []domain.DeadLetter{testDeadLetter}, deadLetters)
+	s.deadLetterDBMock.AssertExpectations(s.T())
+}
+
+func (s *DeadLetterServiceSuite) Test_GetDeadLetterByID_NotFound() {
+	s.deadLetterDBMock.On("GetDeadLetterByID", mock.Anything, testDeadLetter.ID).Return(nil, nil).Once()
+
+	_, err := s.deadLetterService.GetDeadLetterByID(testCtx, testDeadLetter.ID)
+
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
+	s.deadLetterDBMock.AssertExpectations(s.T())
+}
+
+func (s *DeadLetterServiceSuite) Test_RedriveDeadLetter_PublishesToOriginalTopic() {
+	deadLetter := testDeadLetter
+	s.deadLetterDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.deadLetterDBMock.On("CommitTx").Return(nil).Once()
+	s.deadLetterDBMock.On("GetDeadLetterByID", mock.Anything, deadLetter.ID).Return(&deadLetter, nil).Once()
+	s.deadLetterDBMock.On("MarkDeadLetterAsRedriven", mock.Anything, deadLetter.ID, mock.Anything).Return(true, nil).Once()
+	s.deadLetterDBMock.On("CreateOutboxMessage", mock.Anything, mock.MatchedBy(func(message domain.OutboxMessage) bool {
+		return message.ID != testDeadLetter.MessageID &&
+			message.AggregateID == "location1" &&
+			message.Topic == testDeadLetter.OriginalTopic &&
+			string(message.Payload) == string(testDeadLetter.Payload) &&
+			message.Metadata[pubsub.RedrivenFromMetadataKey] == testDeadLetter.ID
+	})).Return(nil).Once()
+
+	redriven, err := s.deadLetterService.RedriveDeadLetter(testCtx, deadLetter.ID)
+
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), redriven.RedrivenAt)
+	s.deadLetterDBMock.AssertExpectations(s.T())
+}
+
+func (s *DeadLetterServiceSuite) Test_RedriveDeadLetter_AlreadyRedriven() {
+	deadLetter := testDeadLetter
+	s.deadLetterDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.deadLetterDBMock.On("RollbackTx").Return(nil).Once()
+	s.deadLetterDBMock.On("GetDeadLetterByID", mock.Anything, deadLetter.ID).Return(&deadLetter, nil).Once()
+	s.deadLetterDBMock.On("MarkDeadLetterAsRedriven", mock.Anything, deadLetter.ID, mock.Anything).Return(false, nil).Once()
+
+	_, err := s.deadLetterService.RedriveDeadLetter(testCtx, deadLetter.ID)
+
+	assert.IsType(s.T(), domain.BusinessErr{}, err)
+	s.deadLetterDBMock.AssertNotCalled(s.T(), "CreateOutboxMessage", mock.Anything, mock.Anything)
+	s.deadLetterDBMock.AssertExpectations(s.T())
+}
+
+func (s *DeadLetterServiceSuite) Test_RedriveDeadLetter_NotFound() {
+	s.deadLetterDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.deadLetterDBMock.On("RollbackTx").Return(nil).Once()
+	s.deadLetterDBMock.On("GetDeadLetterByID", mock.Anything, testDeadLetter.ID).Return(nil, nil).Once()
+
+	_, err := s.deadLetterService.RedriveDeadLetter(testCtx, testDeadLetter.ID)
+
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
+	s.deadLetterDBMock.AssertExpectations(s.T())
+}
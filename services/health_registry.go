@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultHealthCheckTimeoutMs = 2000
+	DefaultHealthCacheTTLMs     = 5000
+
+	// ShutdownHealthCheck is the check reported by the readiness once the shutdown began
+	ShutdownHealthCheck = "shutdown"
+)
+
+var (
+	ErrHealthCheckTimeout = errors.New("the check did not finish in time")
+	ErrShuttingDown       = errors.New("the service is shutting down")
+)
+
+// HealthChecker checks a dependency of the service, it returns an error when the dependency cannot be used
+type HealthChecker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// HealthRegistry runs the checks behind the liveness and readiness endpoints. Each check runs with a timeout and its
+// result is cached, concurrent probes wait for the running check instead of starting another one.
+type HealthRegistry struct {
+	logger       monitor.AppLogger
+	timeout      time.Duration
+	cacheTTL     time.Duration
+	mu           sync.RWMutex
+	checks       map[string]*cachedHealthCheck
+	liveness     []string
+	readiness    []string
+	shuttingDown atomic.Bool
+}
+
+type cachedHealthCheck struct {
+	checker   HealthChecker
+	mu        sync.Mutex
+	result    domain.HealthCheckResult
+	expiresAt time.Time
+}
+
+func NewHealthRegistry(cfg config.HealthConfig) *HealthRegistry {
+	timeoutMs := config.GetIntValueOrDefault(cfg.CheckTimeoutMs, DefaultHealthCheckTimeoutMs)
+	cacheTTLMs := config.GetIntValueOrDefault(cfg.CacheTTLMs, DefaultHealthCacheTTLMs)
+
+	return &HealthRegistry{
+		logger:   monitor.GetStdLogger("HealthRegistry"),
+		timeout:  time.Duration(timeoutMs) * time.Millisecond,
+		cacheTTL: time.Duration(cacheTTLMs) * time.Millisecond,
+		checks:   map[string]*cachedHealthCheck{},
+	}
+}
+
+// RegisterLivenessCheck adds a check that fails when the process cannot recover by itself and has to be restarted
+func (r *HealthRegistry) RegisterLivenessCheck(checker HealthChecker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.liveness = append(r.liveness, r.addCheck(checker))
+}
+
+// RegisterReadinessCheck adds a check that fails when the service cannot handle requests for now
+func (r *HealthRegistry) RegisterReadinessCheck(checker HealthChecker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.readiness = append(r.readiness, r.addCheck(checker))
+}
+
+// MarkShuttingDown makes the readiness fail from now on, so no new traffic is routed to the instance while it drains
+func (r *HealthRegistry) MarkShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+func (r *HealthRegistry) Live(ctx monitor.ApplicationContext) domain.HealthReport {
+	r.mu.RLock()
+	names := r.liveness
+	r.mu.RUnlock()
+
+	return r.runChecks(ctx, names)
+}
+
+func (r *HealthRegistry) Ready(ctx monitor.ApplicationContext) domain.HealthReport {
+	if r.shuttingDown.Load() {
+		return domain.HealthReport{
+			Status: domain.HealthStatusDown,
+			Checks: map[string]domain.HealthCheckResult{
+				ShutdownHealthCheck: {Status: domain.HealthStatusDown, Error: ErrShuttingDown.Error(), CheckedAt: time.Now().UTC()},
+			},
+		}
+	}
+
+	r.mu.RLock()
+	names := r.readiness
+	r.mu.RUnlock()
+
+	return r.runChecks(ctx, names)
+}
+
+// addCheck returns the name of the check, a checker registered for both probes shares its cached result
+func (r *HealthRegistry) addCheck(checker HealthChecker) string {
+	if _, ok := r.checks[checker.Name()]; !ok {
+		r.checks[checker.Name()] = &cachedHealthCheck{checker: checker}
+	}
+
+	return checker.Name()
+}
+
+func (r *HealthRegistry) runChecks(ctx monitor.ApplicationContext, names []string) domain.HealthReport {
+	report := domain.HealthReport{Status: domain.HealthStatusUp, Checks: make(map[string]domain.HealthCheckResult, len(names))}
+
+	results := make([]domain.HealthCheckResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		r.mu.RLock()
+		check := r.checks[name]
+		r.mu.RUnlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		report.Checks[name] = results[i]
+		if results[i].Status == domain.HealthStatusDown {
+			report.Status = domain.HealthStatusDown
+		}
+	}
+
+	return report
+}
+
+// runCheck returns the cached result while it is fresh. Checks that ignore the context are abandoned when the timeout
+// expires, their result is discarded once they return.
+func (r *HealthRegistry) runCheck(ctx monitor.ApplicationContext, check *cachedHealthCheck) domain.HealthCheckResult {
+	fnName := "HealthRegistry.runCheck"
+
+	check.mu.Lock()
+	defer check.mu.Unlock()
+
+	if time.Now().Before(check.expiresAt) {
+		return check.result
+	}
+
+	// The check is not cancelled with the probe request, its result is shared with the next probes
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.checker.Check(checkCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-checkCtx.Done():
+		err = ErrHealthCheckTimeout
+	}
+
+	check.result = domain.HealthCheckResult{
+		Status:     domain.HealthStatusUp,
+		DurationMs: time.Since(start).Milliseconds(),
+		CheckedAt:  start.UTC(),
+	}
+	if err != nil {
+		check.result.Status = domain.HealthStatusDown
+		check.result.Error = err.Error()
+		r.logger.WarnCtx(ctx, fnName, "health check failed",
+			monitor.LoggingParam{Name: "check", Value: check.checker.Name()},
+			monitor.LoggingParam{Name: "error", Value: err.Error()},
+		)
+	}
+	check.expiresAt = time.Now().Add(r.cacheTTL)
+
+	return check.result
+}
+
+// DBHealthCheck pings the locations DB
+type DBHealthCheck struct {
+	dbFactory repositories.DatabaseFactory
+}
+
+func NewDBHealthCheck(dbFactory repositories.DatabaseFactory) *DBHealthCheck {
+	return &DBHealthCheck{dbFactory: dbFactory}
+}
+
+func (c *DBHealthCheck) Name() string {
+	return "postgres"
+}
+
+func (c *DBHealthCheck) Check(ctx context.Context) error {
+	db, err := c.dbFactory.GetLocationsDB()
+	if err != nil {
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
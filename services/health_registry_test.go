@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/mocks"
+	"go-service-template/services"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingHealthCheck counts its runs and blocks for the delay, ignoring the context like a check without timeout
+// support would
+type countingHealthCheck struct {
+	name  string
+	delay time.Duration
+	err   error
+	runs  atomic.Int32
+}
+
+func (c *countingHealthCheck) Name() string {
+	return c.name
+}
+
+func (c *countingHealthCheck) Check(_ context.Context) error {
+	c.runs.Add(1)
+	time.Sleep(c.delay)
+
+	return c.err
+}
+
+type HealthRegistrySuite struct {
+	suite.Suite
+	registry *services.HealthRegistry
+}
+
+func (s *HealthRegistrySuite) SetupTest() {
+	s.registry = services.NewHealthRegistry(config.HealthConfig{CheckTimeoutMs: 50, CacheTTLMs: 60000})
+}
+
+func TestHealthRegistrySuite(t *testing.T) {
+	suite.Run(t, new(HealthRegistrySuite))
+}
+
+func (s *HealthRegistrySuite) Test_Ready_CachesResults() {
+	check := &countingHealthCheck{name: "postgres"}
+	s.registry.RegisterReadinessCheck(check)
+	s.registry.RegisterLivenessCheck(check)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(s.T(), domain.HealthStatusUp, s.registry.Ready(testCtx).Status)
+	}
+	assert.Equal(s.T(), domain.HealthStatusUp, s.registry.Live(testCtx).Status)
+
+	assert.Equal(s.T(), int32(1), check.runs.Load())
+}
+
+func (s *HealthRegistrySuite) Test_Ready_ReportsSlowCheckAsDown() {
+	s.registry.RegisterReadinessCheck(&countingHealthCheck{name: "kafka", delay: time.Second})
+	s.registry.RegisterReadinessCheck(&countingHealthCheck{name: "postgres"})
+
+	start := time.Now()
+	report := s.registry.Ready(testCtx)
+
+	assert.Less(s.T(), time.Since(start), time.Second)
+	assert.Equal(s.T(), domain.HealthStatusDown, report.Status)
+	assert.Equal(s.T(), services.ErrHealthCheckTimeout.Error(), report.Checks["kafka"].Error)
+	assert.Equal(s.T(), domain.HealthStatusUp, report.Checks["postgres"].Status)
+}
+
+func (s *HealthRegistrySuite) Test_Ready_SkipsChecksOnceShuttingDown() {
+	check := &countingHealthCheck{name: "postgres"}
+	s.registry.RegisterReadinessCheck(check)
+	s.registry.MarkShuttingDown()
+
+	report := s.registry.Ready(testCtx)
+
+	assert.Equal(s.T(), domain.HealthStatusDown, report.Status)
+	assert.Equal(s.T(), services.ErrShuttingDown.Error(), report.Checks[services.ShutdownHealthCheck].Error)
+	assert.Equal(s.T(), int32(0), check.runs.Load())
+}
+
+func (s *HealthRegistrySuite) Test_DBHealthCheck_PingsLocationsDB() {
+	dbFactoryMock := new(mocks.DatabaseFactory)
+	locationsDBMock := new(mocks.LocationsDB)
+	dbFactoryMock.On("GetLocationsDB").Return(locationsDBMock, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	locationsDBMock.On("PingContext", ctx).Return(errors.New("connection refused")).Once()
+
+	err := services.NewDBHealthCheck(dbFactoryMock).Check(ctx)
+
+	assert.EqualError(s.T(), err, "connection refused")
+	locationsDBMock.AssertExpectations(s.T())
+}
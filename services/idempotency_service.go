@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+const (
+	DefaultIdempotencyKeyTTLHours            = 24
+	DefaultIdempotencyCleanupIntervalMinutes = 60
+)
+
+// IdempotencyService keeps the responses of the requests sent with an idempotency key, so a retried request gets the
+// original response instead of being processed again. Keys expire after the configured TTL.
+type IdempotencyService struct {
+	logger          monitor.AppLogger
+	dbFactory       repositories.DatabaseFactory
+	ttl             time.Duration
+	cleanupInterval time.Duration
+}
+
+func NewIdempotencyService(dbFactory repositories.DatabaseFactory, cfg config.IdempotencyConfig) *IdempotencyService {
+	ttlHours := config.GetIntValueOrDefault(cfg.KeyTTLHours, DefaultIdempotencyKeyTTLHours)
+	cleanupIntervalMinutes := config.GetIntValueOrDefault(cfg.CleanupIntervalMinutes, DefaultIdempotencyCleanupIntervalMinutes)
+
+	return &IdempotencyService{
+		logger:          monitor.GetStdLogger("IdempotencyService"),
+		dbFactory:       dbFactory,
+		ttl:             time.Duration(ttlHours) * time.Hour,
+		cleanupInterval: time.Duration(cleanupIntervalMinutes) * time.Minute,
+	}
+}
+
+// BeginRequest reserves the key for a new request. When the key was already used for the same request the stored
+// response is returned and the request must not be processed again. Using the key for another request returns an
+// IdempotencyKeyMismatchErr, and retrying while the first request is still being processed an
+// IdempotencyKeyInProgressErr.
+func (s *IdempotencyService) BeginRequest(
+	ctx monitor.ApplicationContext,
+	scope, key, requestHash string,
+) (*domain.IdempotentResponse, error) {
+	fnName := "IdempotencyService.BeginRequest"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("scope", scope)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetIdempotencyDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	reserved, err := db.ReserveIdempotencyKey(ctx, domain.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(s.ttl),
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to reserve idempotency key", err)
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	existingKey, err := db.GetIdempotencyKey(ctx, scope, key)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to get idempotency key", err)
+		return nil, err
+	}
+
+	switch {
+	// The first request failed and released the key after the reservation was attempted
+	case existingKey == nil:
+		return nil, domain.IdempotencyKeyInProgressErr{Msg: fmt.Sprintf("the idempotency key '%v' is being released, retry the request", key)}
+	case existingKey.RequestHash != requestHash:
+		return nil, domain.IdempotencyKeyMismatchErr{Msg: fmt.Sprintf("the idempotency key '%v' was already used for a different request", key)}
+	case existingKey.Response == nil:
+		return nil, domain.IdempotencyKeyInProgressErr{Msg: fmt.Sprintf("a request with the idempotency key '%v' is still being processed", key)}
+	}
+
+	span.SetAttributes(attribute.Bool("replayed", true))
+
+	return existingKey.Response, nil
+}
+
+// CompleteRequest stores the response of the request that reserved the key
+func (s *IdempotencyService) CompleteRequest(
+	ctx monitor.ApplicationContext,
+	scope, key string,
+	response domain.IdempotentResponse,
+) error {
+	fnName := "IdempotencyService.CompleteRequest"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("scope", scope)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetIdempotencyDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if err = db.CompleteIdempotencyKey(ctx, scope, key, response); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to store idempotent response", err)
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseKey deletes the key of a request that could not be processed, so it can be retried with the same key
+func (s *IdempotencyService) ReleaseKey(ctx monitor.ApplicationContext, scope, key string) error {
+	fnName := "IdempotencyService.ReleaseKey"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("scope", scope)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetIdempotencyDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if err = db.DeleteIdempotencyKey(ctx, scope, key); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to release idempotency key", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpiredKeys removes the keys past their TTL
+func (s *IdempotencyService) DeleteExpiredKeys(ctx monitor.ApplicationContext) (int64, error) {
+	ctx, span := ctx.StartSpan("IdempotencyService.DeleteExpiredKeys")
+	defer span.End()
+
+	db, err := s.dbFactory.GetIdempotencyDB()
+	if err != nil {
+		return 0, err
+	}
+
+	return db.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+}
+
+// RunCleanup deletes the expired keys periodically until the context is cancelled
+func (s *IdempotencyService) RunCleanup(ctx context.Context) {
+	fnName := "IdempotencyService.RunCleanup"
+
+	cleanupTicker := time.NewTicker(s.cleanupInterval)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info(fnName, "", "stopping idempotency keys cleanup")
+			return
+		case <-cleanupTicker.C:
+			appCtx := monitor.CreateAppContextFromContext(ctx, "")
+			if _, err := s.DeleteExpiredKeys(appCtx); err != nil {
+				s.logger.ErrorCtx(appCtx, fnName, "failed to delete expired idempotency keys", err)
+			}
+		}
+	}
+}
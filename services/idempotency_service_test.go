@@ -0,0 +1,142 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/mocks"
+	"go-service-template/services"
+	"testing"
+	"time"
+)
+
+const (
+	testIdempotencyScope = "POST /v1/locations"
+	testIdempotencyKey   = "key"
+)
+
+type IdempotencyServiceSuite struct {
+	suite.Suite
+	dbFactoryMock      *mocks.DatabaseFactory
+	idempotencyDBMock  *mocks.IdempotencyDB
+	idempotencyService *services.IdempotencyService
+}
+
+func (s *IdempotencyServiceSuite) SetupSuite() {
+	s.dbFactoryMock = new(mocks.DatabaseFactory)
+	s.idempotencyDBMock = new(mocks.IdempotencyDB)
+	s.idempotencyService = services.NewIdempotencyService(s.dbFactoryMock, config.IdempotencyConfig{KeyTTLHours: 2})
+}
+
+func (s *IdempotencyServiceSuite) SetupTest() {
+	s.dbFactoryMock.ExpectedCalls = nil
+	s.idempotencyDBMock.ExpectedCalls = nil
+	s.dbFactoryMock.On("GetIdempotencyDB").Return(s.idempotencyDBMock, nil)
+}
+
+func (s *IdempotencyServiceSuite) assertAllExpectations() {
+	s.dbFactoryMock.AssertExpectations(s.T())
+	s.idempotencyDBMock.AssertExpectations(s.T())
+}
+
+func (s *IdempotencyServiceSuite) expectExistingKey(requestHash string, response *domain.IdempotentResponse) {
+	s.idempotencyDBMock.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(false, nil).Once()
+	s.idempotencyDBMock.On("GetIdempotencyKey", mock.Anything, testIdempotencyScope, testIdempotencyKey).Return(&domain.IdempotencyKey{
+		Scope:       testIdempotencyScope,
+		Key:         testIdempotencyKey,
+		RequestHash: requestHash,
+		Response:    response,
+	}, nil).Once()
+}
+
+func TestIdempotencyServiceSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyServiceSuite))
+}
+
+func (s *IdempotencyServiceSuite) Test_BeginRequest_ReservesNewKeyWithTTL() {
+	s.idempotencyDBMock.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		key := args.Get(1).(domain.IdempotencyKey)
+		assert.Equal(s.T(), testIdempotencyScope, key.Scope)
+		assert.Equal(s.T(), "hash", key.RequestHash)
+		assert.WithinDuration(s.T(), time.Now().Add(2*time.Hour), key.ExpiresAt, time.Minute)
+	}).Return(true, nil).Once()
+
+	response, err := s.idempotencyService.BeginRequest(testCtx, testIdempotencyScope, testIdempotencyKey, "hash")
+
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), response)
+	s.assertAllExpectations()
+}
+
+func (s *IdempotencyServiceSuite) Test_BeginRequest_ReturnsStoredResponseForSameRequest() {
+	stored := &domain.IdempotentResponse{StatusCode: 200, Body: []byte(`{}`)}
+	s.expectExistingKey("hash", stored)
+
+	response, err := s.idempotencyService.BeginRequest(testCtx, testIdempotencyScope, testIdempotencyKey, "hash")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), stored, response)
+	s.assertAllExpectations()
+}
+
+func (s *IdempotencyServiceSuite) Test_BeginRequest_FailsWhenKeyWasUsedForAnotherRequest() {
+	s.expectExistingKey("other hash", &domain.IdempotentResponse{StatusCode: 200})
+
+	_, err := s.idempotencyService.BeginRequest(testCtx, testIdempotencyScope, testIdempotencyKey, "hash")
+
+	assert.ErrorAs(s.T(), err, &domain.IdempotencyKeyMismatchErr{})
+	s.assertAllExpectations()
+}
+
+func (s *IdempotencyServiceSuite) Test_BeginRequest_FailsWhileFirstRequestIsInProgress() {
+	s.expectExistingKey("hash", nil)
+
+	_, err := s.idempotencyService.BeginRequest(testCtx, testIdempotencyScope, testIdempotencyKey, "hash")
+
+	assert.ErrorAs(s.T(), err, &domain.IdempotencyKeyInProgressErr{})
+	s.assertAllExpectations()
+}
+
+func (s *IdempotencyServiceSuite) Test_BeginRequest_ReturnsDBError() {
+	dbErr := errors.New("db down")
+	s.idempotencyDBMock.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(false, dbErr).Once()
+
+	_, err := s.idempotencyService.BeginRequest(testCtx, testIdempotencyScope, testIdempotencyKey, "hash")
+
+	assert.ErrorIs(s.T(), err, dbErr)
+	s.assertAllExpectations()
+}
+
+func (s *IdempotencyServiceSuite) Test_CompleteRequest_StoresResponse() {
+	response := domain.IdempotentResponse{StatusCode: 200, Body: []byte(`{}`)}
+	s.idempotencyDBMock.On("CompleteIdempotencyKey", mock.Anything, testIdempotencyScope, testIdempotencyKey, response).Return(nil).Once()
+
+	err := s.idempotencyService.CompleteRequest(testCtx, testIdempotencyScope, testIdempotencyKey, response)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *IdempotencyServiceSuite) Test_ReleaseKey_DeletesKey() {
+	s.idempotencyDBMock.On("DeleteIdempotencyKey", mock.Anything, testIdempotencyScope, testIdempotencyKey).Return(nil).Once()
+
+	err := s.idempotencyService.ReleaseKey(testCtx, testIdempotencyScope, testIdempotencyKey)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *IdempotencyServiceSuite) Test_DeleteExpiredKeys_DeletesKeysPastNow() {
+	s.idempotencyDBMock.On("DeleteExpiredIdempotencyKeys", mock.Anything, mock.MatchedBy(func(expiredBefore time.Time) bool {
+		return time.Since(expiredBefore) < time.Minute
+	})).Return(int64(3), nil).Once()
+
+	deleted, err := s.idempotencyService.DeleteExpiredKeys(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(3), deleted)
+	s.assertAllExpectations()
+}
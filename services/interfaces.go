@@ -8,8 +8,86 @@ import (
 
 type ILocationService interface {
 	CreateLocationMock(ctx monitor.ApplicationContext) error
-	GetLocationByID(ctx monitor.ApplicationContext, id string) (*domain.Location, error)
+	GetLocationByID(ctx monitor.ApplicationContext, id string, includeDeleted bool) (*domain.Location, error)
 	CreateLocation(ctx monitor.ApplicationContext, newLocationData dto.CreateLocationRequest) (domain.Location, error)
-	UpdateLocation(ctx monitor.ApplicationContext, updatedLocationData dto.UpdateLocationRequest) (domain.Location, error)
+	ImportLocations(ctx monitor.ApplicationContext, rows []dto.ImportLocationRow, dryRun bool) (domain.LocationImportReport, error)
+	UpdateLocation(ctx monitor.ApplicationContext, updatedLocationData dto.UpdateLocationRequest, expectedVersion int) (domain.Location, error)
+	PatchLocation(ctx monitor.ApplicationContext, id string, patch dto.PatchLocationRequest, expectedVersion int) (domain.Location, error)
+	DeleteLocation(ctx monitor.ApplicationContext, id string) error
+	RestoreLocation(ctx monitor.ApplicationContext, id string) (domain.Location, error)
 	GetPaginatedLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters) (domain.CursorPage[domain.Location], error)
+	GetNearbyLocations(ctx monitor.ApplicationContext, filters domain.NearbyLocationsFilters) ([]domain.NearbyLocation, error)
+	ExportLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters, fn func(location domain.Location) error) error
+	GetLocationHistory(ctx monitor.ApplicationContext, filters domain.LocationHistoryFilters) (domain.CursorPage[domain.LocationHistoryEntry], error)
+	CreateSubLocation(ctx monitor.ApplicationContext, locationID string, newSubLocationData dto.CreateSubLocationRequest) (domain.SubLocation, error)
+	RenameSubLocation(ctx monitor.ApplicationContext, locationID, subLocationID string, renameData dto.RenameSubLocationRequest) (domain.SubLocation, error)
+	DeactivateSubLocation(ctx monitor.ApplicationContext, locationID, subLocationID string) (domain.SubLocation, error)
+	GetSubLocationByID(ctx monitor.ApplicationContext, locationID, subLocationID string) (*domain.SubLocation, error)
+	GetPaginatedSubLocations(ctx monitor.ApplicationContext, filters domain.SubLocationsFilters) (domain.CursorPage[domain.SubLocation], error)
+}
+
+type IReferenceDataService interface {
+	GetSuppliers(ctx monitor.ApplicationContext) ([]domain.Supplier, error)
+	GetSupplierByID(ctx monitor.ApplicationContext, id int) (domain.Supplier, error)
+	CreateSupplier(ctx monitor.ApplicationContext, data dto.SupplierRequest) (domain.Supplier, error)
+	UpdateSupplier(ctx monitor.ApplicationContext, id int, data dto.SupplierRequest) (domain.Supplier, error)
+	DeleteSupplier(ctx monitor.ApplicationContext, id int) error
+	GetLocationTypes(ctx monitor.ApplicationContext) ([]domain.LocationType, error)
+	GetLocationTypeByID(ctx monitor.ApplicationContext, id int) (domain.LocationType, error)
+	CreateLocationType(ctx monitor.ApplicationContext, data dto.LocationTypeRequest) (domain.LocationType, error)
+	UpdateLocationType(ctx monitor.ApplicationContext, id int, data dto.LocationTypeRequest) (domain.LocationType, error)
+	DeleteLocationType(ctx monitor.ApplicationContext, id int) error
+	GetSubLocationTypes(ctx monitor.ApplicationContext) ([]domain.SubLocationType, error)
+	GetSubLocationTypeByID(ctx monitor.ApplicationContext, id int) (domain.SubLocationType, error)
+	CreateSubLocationType(ctx monitor.ApplicationContext, data dto.SubLocationTypeRequest) (domain.SubLocationType, error)
+	UpdateSubLocationType(ctx monitor.ApplicationContext, id int, data dto.SubLocationTypeRequest) (domain.SubLocationType, error)
+	DeleteSubLocationType(ctx monitor.ApplicationContext, id int) error
+}
+
+type IIdempotencyService interface {
+	BeginRequest(ctx monitor.ApplicationContext, scope, key, requestHash string) (*domain.IdempotentResponse, error)
+	CompleteRequest(ctx monitor.ApplicationContext, scope, key string, response domain.IdempotentResponse) error
+	ReleaseKey(ctx monitor.ApplicationContext, scope, key string) error
+}
+
+type IAPIKeyService interface {
+	CreateAPIKey(ctx monitor.ApplicationContext, data dto.CreateAPIKeyRequest) (domain.IssuedAPIKey, error)
+	GetAPIKeys(ctx monitor.ApplicationContext) ([]domain.APIKey, error)
+	RotateAPIKey(ctx monitor.ApplicationContext, id string) (domain.IssuedAPIKey, error)
+	RevokeAPIKey(ctx monitor.ApplicationContext, id string) error
+	Authenticate(ctx monitor.ApplicationContext, key string) (*domain.APIKey, error)
+}
+
+type ILocationChangeStream interface {
+	Publish(change domain.LocationChange)
+	Subscribe(ctx monitor.ApplicationContext, lastEventID string, filters domain.LocationChangeFilters) (*LocationChangeSubscription, error)
+}
+
+type IWebhookService interface {
+	CreateWebhook(ctx monitor.ApplicationContext, data dto.CreateWebhookRequest) (domain.WebhookSubscription, error)
+	GetWebhooks(ctx monitor.ApplicationContext) ([]domain.WebhookSubscription, error)
+	GetWebhookByID(ctx monitor.ApplicationContext, id string) (domain.WebhookSubscription, error)
+	UpdateWebhook(ctx monitor.ApplicationContext, id string, data dto.UpdateWebhookRequest) (domain.WebhookSubscription, error)
+	DeleteWebhook(ctx monitor.ApplicationContext, id string) error
+	GetWebhookDeliveries(ctx monitor.ApplicationContext, subscriptionID string, status *string) ([]domain.WebhookDelivery, error)
+	RedeliverWebhook(ctx monitor.ApplicationContext, subscriptionID, deliveryID string) (domain.WebhookDelivery, error)
+	DeliverLocationEvent(ctx monitor.ApplicationContext, eventID, eventType string, location domain.Location) error
+}
+
+type IHealthRegistry interface {
+	Live(ctx monitor.ApplicationContext) domain.HealthReport
+	Ready(ctx monitor.ApplicationContext) domain.HealthReport
+}
+
+type IDeadLetterService interface {
+	StoreDeadLetter(ctx monitor.ApplicationContext, deadLetter domain.DeadLetter) error
+	GetDeadLetters(ctx monitor.ApplicationContext, filters domain.DeadLetterFilters) ([]domain.DeadLetter, error)
+	GetDeadLetterByID(ctx monitor.ApplicationContext, id string) (domain.DeadLetter, error)
+	RedriveDeadLetter(ctx monitor.ApplicationContext, id string) (domain.DeadLetter, error)
+}
+
+type IOutboxService interface {
+	GetParkedMessages(ctx monitor.ApplicationContext) ([]domain.OutboxMessage, error)
+	RedriveParkedMessage(ctx monitor.ApplicationContext, id string) error
+	DiscardParkedMessage(ctx monitor.ApplicationContext, id string) error
 }
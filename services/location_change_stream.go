@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"sync"
+)
+
+const (
+	DefaultLocationStreamBufferSize       = 1000
+	DefaultLocationStreamClientBufferSize = 64
+)
+
+var ErrLocationStreamClosed = errors.New("the location changes stream is closed")
+
+// LocationChangeStream fans the location changes received from the broker out to the connected clients. The last
+// changes are kept in a bounded buffer so a reconnecting client can resume after the last change it received.
+// Clients that do not keep up are disconnected instead of slowing down the rest, they resume from the buffer when
+// they reconnect.
+type LocationChangeStream struct {
+	mu               sync.Mutex
+	buffer           []domain.LocationChange
+	start            int
+	clientBufferSize int
+	subscriptions    map[*LocationChangeSubscription]struct{}
+	closed           bool
+}
+
+// LocationChangeSubscription receives the changes published after it was created. Backlog holds the buffered changes
+// after the last event ID sent by the client, Missed is true when that change is not buffered anymore and the
+// client has to reload the locations since changes may have been lost.
+type LocationChangeSubscription struct {
+	Backlog []domain.LocationChange
+	Missed  bool
+
+	stream         *LocationChangeStream
+	filters        domain.LocationChangeFilters
+	tenantSupplier *int
+	changes        chan domain.LocationChange
+}
+
+func NewLocationChangeStream(cfg config.LocationStreamConfig) *LocationChangeStream {
+	return &LocationChangeStream{
+		buffer:           make([]domain.LocationChange, 0, config.GetIntValueOrDefault(cfg.BufferSize, DefaultLocationStreamBufferSize)),
+		clientBufferSize: config.GetIntValueOrDefault(cfg.ClientBufferSize, DefaultLocationStreamClientBufferSize),
+		subscriptions:    map[*LocationChangeSubscription]struct{}{},
+	}
+}
+
+// Publish buffers a change and sends it to every subscription matching it
+func (s *LocationChangeStream) Publish(change domain.LocationChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	if len(s.buffer) < cap(s.buffer) {
+		s.buffer = append(s.buffer, change)
+	} else {
+		s.buffer[s.start] = change
+		s.start = (s.start + 1) % len(s.buffer)
+	}
+
+	for subscription := range s.subscriptions {
+		if !subscription.matches(change) {
+			continue
+		}
+
+		select {
+		case subscription.changes <- change:
+		default:
+			s.remove(subscription)
+		}
+	}
+}
+
+// Subscribe creates a subscription to the changes matching the filters. Callers restricted to a supplier only
+// receive the changes of its locations. The subscription must be closed once the client disconnects.
+func (s *LocationChangeStream) Subscribe(
+	ctx monitor.ApplicationContext,
+	lastEventID string,
+	filters domain.LocationChangeFilters,
+) (*LocationChangeSubscription, error) {
+	subscription := &LocationChangeSubscription{
+		stream:  s,
+		filters: filters,
+		changes: make(chan domain.LocationChange, s.clientBufferSize),
+	}
+	if supplierID, ok := ctx.GetTenantSupplierID(); ok {
+		subscription.tenantSupplier = &supplierID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrLocationStreamClosed
+	}
+
+	// The backlog is read under the same lock that registers the subscription, so no change is skipped or repeated
+	// between the backlog and the live changes
+	if lastEventID != "" {
+		subscription.Missed = true
+		for i := range s.buffer {
+			change := s.buffer[(s.start+i)%len(s.buffer)]
+			if subscription.Missed {
+				subscription.Missed = change.ID != lastEventID
+				continue
+			}
+			if subscription.matches(change) {
+				subscription.Backlog = append(subscription.Backlog, change)
+			}
+		}
+	}
+
+	s.subscriptions[subscription] = struct{}{}
+
+	return subscription, nil
+}
+
+// Close ends every subscription and rejects the new ones, so the open connections can finish on shutdown
+func (s *LocationChangeStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for subscription := range s.subscriptions {
+		s.remove(subscription)
+	}
+}
+
+func (s *LocationChangeStream) remove(subscription *LocationChangeSubscription) {
+	if _, ok := s.subscriptions[subscription]; ok {
+		delete(s.subscriptions, subscription)
+		close(subscription.changes)
+	}
+}
+
+// Changes returns the live changes, the channel is closed when the client fell behind or the stream was closed
+func (sub *LocationChangeSubscription) Changes() <-chan domain.LocationChange {
+	return sub.changes
+}
+
+func (sub *LocationChangeSubscription) Close() {
+	sub.stream.mu.Lock()
+	defer sub.stream.mu.Unlock()
+
+	sub.stream.remove(sub)
+}
+
+func (sub *LocationChangeSubscription) matches(change domain.LocationChange) bool {
+	if sub.tenantSupplier != nil && change.Location.Supplier.ID != *sub.tenantSupplier {
+		return false
+	}
+
+	return sub.filters.Matches(change)
+}
@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"go-service-template/utils"
+	"testing"
+)
+
+type LocationChangeStreamSuite struct {
+	suite.Suite
+	stream *services.LocationChangeStream
+}
+
+func (s *LocationChangeStreamSuite) SetupTest() {
+	s.stream = services.NewLocationChangeStream(config.LocationStreamConfig{BufferSize: 3, ClientBufferSize: 2})
+}
+
+func TestLocationChangeStreamSuite(t *testing.T) {
+	suite.Run(t, new(LocationChangeStreamSuite))
+}
+
+func locationChange(id string, supplierID, locationTypeID int) domain.LocationChange {
+	return domain.LocationChange{
+		ID:        id,
+		Operation: domain.LocationUpdatedOperation,
+		Location: domain.Location{
+			ID:           "location-" + id,
+			Supplier:     domain.Supplier{ID: supplierID},
+			LocationType: domain.LocationType{ID: locationTypeID},
+		},
+	}
+}
+
+func changeIDs(changes []domain.LocationChange) []string {
+	ids := make([]string, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+
+	return ids
+}
+
+func (s *LocationChangeStreamSuite) Test_Subscribe_SendsMatchingChanges() {
+	subscription, err := s.stream.Subscribe(testCtx, "", domain.LocationChangeFilters{SupplierIDs: []int{1}, LocationTypeIDs: []int{2}})
+	s.Require().NoError(err)
+	defer subscription.Close()
+
+	s.stream.Publish(locationChange("1", 1, 2))
+	s.stream.Publish(locationChange("2", 3, 2))
+	s.stream.Publish(locationChange("3", 1, 4))
+
+	assert.Equal(s.T(), "1", (<-subscription.Changes()).ID)
+	assert.Empty(s.T(), subscription.Changes())
+	assert.False(s.T(), subscription.Missed)
+	assert.Empty(s.T(), subscription.Backlog)
+}
+
+func (s *LocationChangeStreamSuite) Test_Subscribe_OnlySendsChangesOfTenantSupplier() {
+	supplierCtx := testCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", SupplierID: utils.ToPointer(7)})
+	subscription, err := s.stream.Subscribe(supplierCtx, "", domain.LocationChangeFilters{})
+	s.Require().NoError(err)
+	defer subscription.Close()
+
+	s.stream.Publish(locationChange("1", 1, 2))
+	s.stream.Publish(locationChange("2", 7, 2))
+
+	assert.Equal(s.T(), "2", (<-subscription.Changes()).ID)
+	assert.Empty(s.T(), subscription.Changes())
+}
+
+func (s *LocationChangeStreamSuite) Test_Subscribe_ResumesAfterLastEventID() {
+	for _, id := range []string{"1", "2", "3", "4"} {
+		s.stream.Publish(locationChange(id, 1, 2))
+	}
+
+	subscription, err := s.stream.Subscribe(testCtx, "2", domain.LocationChangeFilters{})
+	s.Require().NoError(err)
+	defer subscription.Close()
+
+	assert.False(s.T(), subscription.Missed)
+	assert.Equal(s.T(), []string{"3", "4"}, changeIDs(subscription.Backlog))
+}
+
+func (s *LocationChangeStreamSuite) Test_Subscribe_ReportsMissedChangesWhenLastEventIDIsNotBuffered() {
+	// The buffer holds three changes, the first one is dropped
+	for _, id := range []string{"1", "2", "3", "4"} {
+		s.stream.Publish(locationChange(id, 1, 2))
+	}
+
+	subscription, err := s.stream.Subscribe(testCtx, "1", domain.LocationChangeFilters{})
+	s.Require().NoError(err)
+	defer subscription.Close()
+
+	assert.True(s.T(), subscription.Missed)
+	assert.Empty(s.T(), subscription.Backlog)
+}
+
+func (s *LocationChangeStreamSuite) Test_Publish_DisconnectsSubscriptionsThatFallBehind() {
+	subscription, err := s.stream.Subscribe(testCtx, "", domain.LocationChangeFilters{})
+	s.Require().NoError(err)
+
+	for _, id := range []string{"1", "2", "3"} {
+		s.stream.Publish(locationChange(id, 1, 2))
+	}
+
+	var ids []string
+	for change := range subscription.Changes() {
+		ids = append(ids, change.ID)
+	}
+	assert.Equal(s.T(), []string{"1", "2"}, ids)
+
+	// Closing an ended subscription is a no-op
+	subscription.Close()
+}
+
+func (s *LocationChangeStreamSuite) Test_Close_EndsSubscriptionsAndRejectsNewOnes() {
+	subscription, err := s.stream.Subscribe(testCtx, "", domain.LocationChangeFilters{})
+	s.Require().NoError(err)
+
+	s.stream.Close()
+
+	_, open := <-subscription.Changes()
+	assert.False(s.T(), open)
+
+	_, err = s.stream.Subscribe(testCtx, "", domain.LocationChangeFilters{})
+	assert.ErrorIs(s.T(), err, services.ErrLocationStreamClosed)
+}
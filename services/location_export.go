@@ -0,0 +1,43 @@
+package services
+
+import (
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExportLocations calls fn with every location matching the filters, streamed from the database so exports of any
+// size use constant memory. An error returned by fn, like a closed connection, stops the export.
+func (s *LocationService) ExportLocations(
+	ctx monitor.ApplicationContext,
+	filters domain.LocationsFilters,
+	fn func(location domain.Location) error,
+) error {
+	fnName := "LocationService.ExportLocations"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("filters", utils.ToJSON(filters))))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	exported := 0
+	err = db.StreamLocations(ctx, filters, func(location domain.Location) error {
+		exported++
+		return fn(location)
+	})
+	span.SetAttributes(attribute.Int("exported", exported))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to export locations", err)
+		return err
+	}
+
+	return nil
+}
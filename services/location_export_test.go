@@ -0,0 +1,42 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-service-template/domain"
+	"go-service-template/utils"
+)
+
+func (s *LocationServiceSuite) Test_ExportLocations_StreamsEveryLocation() {
+	filters := domain.LocationsFilters{City: utils.ToPointer("Miami")}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StreamLocations", mock.Anything, filters, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(domain.Location) error)
+		assert.Nil(s.T(), fn(domain.Location{ID: "first"}))
+		assert.Nil(s.T(), fn(domain.Location{ID: "second"}))
+	}).Return(nil).Once()
+
+	var ids []string
+	err := s.locationService.ExportLocations(testCtx, filters, func(location domain.Location) error {
+		ids = append(ids, location.ID)
+		return nil
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"first", "second"}, ids)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_ExportLocations_ReturnsStreamError() {
+	streamErr := errors.New("connection reset")
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StreamLocations", mock.Anything, mock.Anything, mock.Anything).Return(streamErr).Once()
+
+	err := s.locationService.ExportLocations(testCtx, domain.LocationsFilters{}, func(domain.Location) error { return nil })
+
+	assert.ErrorIs(s.T(), err, streamErr)
+	s.assertAllExpectations()
+}
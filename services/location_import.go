@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"sync"
+)
+
+const (
+	ImportGeocodingConcurrency = 8
+	ImportBatchSize            = 100
+)
+
+// importedLocation is a location built from the row at index of the import
+type importedLocation struct {
+	index    int
+	location domain.Location
+}
+
+// ImportLocations creates the valid rows of an import file. Rows are geocoded concurrently and stored in batches, each
+// batch in its own transaction. When a batch fails its rows are retried one by one, so a bad row does not fail the rest
+// of its batch. Nothing is stored on dry runs.
+func (s *LocationService) ImportLocations(
+	ctx monitor.ApplicationContext,
+	rows []dto.ImportLocationRow,
+	dryRun bool,
+) (report domain.LocationImportReport, err error) {
+	fnName := "LocationService.ImportLocations"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.Int("rows", len(rows)), attribute.Bool("dry_run", dryRun)))
+	defer span.End()
+
+	report = domain.LocationImportReport{DryRun: dryRun, Total: len(rows), Rows: make([]domain.LocationImportRowResult, len(rows))}
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return report, err
+	}
+
+	pending, err := s.checkImportRows(ctx, db, rows, report.Rows)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to check import rows", err)
+		span.SetStatus(codes.Error, err.Error())
+		return report, err
+	}
+
+	locations := s.buildImportedLocations(ctx, rows, pending, report.Rows)
+
+	if dryRun {
+		for _, imported := range locations {
+			report.Rows[imported.index].Status = domain.ImportRowValid
+		}
+	} else {
+		s.storeImportedLocations(ctx, db, locations, report.Rows)
+	}
+
+	for _, result := range report.Rows {
+		if result.Status == domain.ImportRowFailed {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+	}
+
+	return report, nil
+}
+
+// checkImportRows fails the rows that are not valid or whose name is repeated or already in use, and returns the
+// indexes of the remaining ones.
+func (s *LocationService) checkImportRows(
+	ctx monitor.ApplicationContext,
+	db repositories.LocationsDB,
+	rows []dto.ImportLocationRow,
+	results []domain.LocationImportRowResult,
+) ([]int, error) {
+	var pending []int
+	rowsByName := map[string]int{}
+
+	for i, row := range rows {
+		results[i] = domain.LocationImportRowResult{Row: row.Row, Name: row.Request.Name}
+
+		if row.Err != nil {
+			failImportRow(&results[i], row.Err)
+			continue
+		}
+
+		// Location names are case insensitive
+		name := strings.ToLower(row.Request.Name)
+		if previousRow, ok := rowsByName[name]; ok {
+			failImportRow(&results[i], domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("location name '%v' is repeated, it is already used in row %v", row.Request.Name, previousRow), Resource: domain.ResourceLocation})
+			continue
+		}
+		rowsByName[name] = row.Row
+
+		nameInUse, err := db.CheckLocationNameExistence(ctx, row.Request.Name)
+		if err != nil {
+			return nil, err
+		}
+		if nameInUse {
+			failImportRow(&results[i], domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("location name '%v' is already in use", row.Request.Name), Resource: domain.ResourceLocation})
+			continue
+		}
+
+		pending = append(pending, i)
+	}
+
+	return pending, nil
+}
+
+// buildImportedLocations validates the references and geocodes the address of the pending rows, with at most
+// ImportGeocodingConcurrency requests at a time. The locations are returned in the order of the rows.
+func (s *LocationService) buildImportedLocations(
+	ctx monitor.ApplicationContext,
+	rows []dto.ImportLocationRow,
+	pending []int,
+	results []domain.LocationImportRowResult,
+) []importedLocation {
+	built := make([]*domain.Location, len(pending))
+	semaphore := make(chan struct{}, ImportGeocodingConcurrency)
+	var wg sync.WaitGroup
+
+	for i, index := range pending {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i, index int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			location, err := s.buildNewLocation(ctx, rows[index].Request)
+			if err != nil {
+				failImportRow(&results[index], err)
+				return
+			}
+			built[i] = &location
+		}(i, index)
+	}
+
+	wg.Wait()
+
+	locations := make([]importedLocation, 0, len(pending))
+	for i, location := range built {
+		if location != nil {
+			locations = append(locations, importedLocation{index: pending[i], location: *location})
+		}
+	}
+
+	return locations
+}
+
+func (s *LocationService) storeImportedLocations(
+	ctx monitor.ApplicationContext,
+	db repositories.LocationsDB,
+	locations []importedLocation,
+	results []domain.LocationImportRowResult,
+) {
+	fnName := "LocationService.storeImportedLocations"
+
+	for start := 0; start < len(locations); start += ImportBatchSize {
+		batch := locations[start:min(start+ImportBatchSize, len(locations))]
+
+		err := s.storeImportBatch(ctx, db, batch)
+		if err == nil {
+			for _, imported := range batch {
+				markImportRowCreated(&results[imported.index], imported.location)
+			}
+			continue
+		}
+
+		s.logger.WarnCtx(ctx, fnName, "import batch failed, retrying its rows one by one", monitor.LoggingParam{Name: "error", Value: err.Error()})
+
+		for _, imported := range batch {
+			if err = s.storeImportBatch(ctx, db, []importedLocation{imported}); err != nil {
+				failImportRow(&results[imported.index], err)
+			} else {
+				markImportRowCreated(&results[imported.index], imported.location)
+			}
+		}
+	}
+}
+
+func (s *LocationService) storeImportBatch(ctx monitor.ApplicationContext, db repositories.LocationsDB, batch []importedLocation) error {
+	return db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		for _, imported := range batch {
+			if err := s.storeNewLocation(ctx, db, imported.location); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func markImportRowCreated(result *domain.LocationImportRowResult, location domain.Location) {
+	result.Status = domain.ImportRowCreated
+	result.LocationID = location.ID
+}
+
+// failImportRow reports every error of a joined error on its own
+func failImportRow(result *domain.LocationImportRowResult, err error) {
+	result.Status = domain.ImportRowFailed
+
+	if joinedErr, ok := err.(interface{ Unwrap() []error }); ok { //nolint
+		for _, e := range joinedErr.Unwrap() {
+			result.Errors = append(result.Errors, e.Error())
+		}
+		return
+	}
+
+	result.Errors = append(result.Errors, err.Error())
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/domain/googlemaps"
+)
+
+func importRow(row int, name string) dto.ImportLocationRow {
+	request := createLocData
+	request.Name = name
+
+	return dto.ImportLocationRow{Row: row, Request: request}
+}
+
+func (s *LocationServiceSuite) expectImportRowBuilt(name string) {
+	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, name).Return(false, nil).Once()
+	s.expectReferenceDataLookups(createLocData.SupplierID, createLocData.LocationTypeID)
+	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Return(&googlemaps.AddressValidateMatch{}, nil).Once()
+}
+
+func (s *LocationServiceSuite) Test_ImportLocations_CreatesValidRowsAndReportsFailedOnes() {
+	rows := []dto.ImportLocationRow{
+		importRow(1, "First"),
+		{Row: 2, Err: errors.Join(errors.New("name is required"), errors.New("city is required"))},
+		importRow(3, "Second"),
+		importRow(4, "FIRST"),
+	}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.expectImportRowBuilt("First")
+	s.expectImportRowBuilt("Second")
+
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("CreateLocation", mock.Anything, mock.Anything).Return(nil).Twice()
+	s.locationsDBMock.On("CreateSubLocation", mock.Anything, mock.Anything).Return(nil).Twice()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.Equal(s.T(), domain.LocationsNewTopic, args.Get(1).(domain.OutboxMessage).Topic)
+	}).Return(nil).Twice()
+
+	report, err := s.locationService.ImportLocations(testCtx, rows, false)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 4, report.Total)
+	assert.Equal(s.T(), 2, report.Succeeded)
+	assert.Equal(s.T(), 2, report.Failed)
+	assert.Equal(s.T(), domain.ImportRowCreated, report.Rows[0].Status)
+	assert.NotEmpty(s.T(), report.Rows[0].LocationID)
+	assert.Equal(s.T(), []string{"name is required", "city is required"}, report.Rows[1].Errors)
+	assert.Equal(s.T(), domain.ImportRowCreated, report.Rows[2].Status)
+	assert.Equal(s.T(), domain.ImportRowFailed, report.Rows[3].Status)
+	assert.Contains(s.T(), report.Rows[3].Errors[0], "already used in row 1")
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_ImportLocations_DryRunDoesNotStoreRows() {
+	rows := []dto.ImportLocationRow{importRow(1, "First"), importRow(2, "Taken")}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.expectImportRowBuilt("First")
+	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, "Taken").Return(true, nil).Once()
+
+	report, err := s.locationService.ImportLocations(testCtx, rows, true)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), report.DryRun)
+	assert.Equal(s.T(), domain.ImportRowValid, report.Rows[0].Status)
+	assert.Empty(s.T(), report.Rows[0].LocationID)
+	assert.Equal(s.T(), domain.ImportRowFailed, report.Rows[1].Status)
+	assert.Equal(s.T(), 1, report.Succeeded)
+	assert.Equal(s.T(), 1, report.Failed)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_ImportLocations_RetriesRowsOneByOneWhenBatchFails() {
+	rows := []dto.ImportLocationRow{importRow(1, "Good"), importRow(2, "Bad")}
+	isLocation := func(name string) any {
+		return mock.MatchedBy(func(location domain.Location) bool { return location.Name == name })
+	}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.expectImportRowBuilt("Good")
+	s.expectImportRowBuilt("Bad")
+
+	// The batch fails on the second row, then each row is stored on its own
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Times(3)
+	s.locationsDBMock.On("RollbackTx").Return(nil).Twice()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("CreateLocation", mock.Anything, isLocation("Good")).Return(nil).Twice()
+	s.locationsDBMock.On("CreateLocation", mock.Anything, isLocation("Bad")).Return(errors.New("constraint violated")).Twice()
+	s.locationsDBMock.On("CreateSubLocation", mock.Anything, mock.Anything).Return(nil).Twice()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	report, err := s.locationService.ImportLocations(testCtx, rows, false)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), domain.ImportRowCreated, report.Rows[0].Status)
+	assert.Equal(s.T(), domain.ImportRowFailed, report.Rows[1].Status)
+	assert.Contains(s.T(), report.Rows[1].Errors[0], "constraint violated")
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_ImportLocations_FailsWhenNamesCannotBeChecked() {
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, "First").Return(false, errors.New("db down")).Once()
+
+	_, err := s.locationService.ImportLocations(testCtx, []dto.ImportLocationRow{importRow(1, "First")}, false)
+
+	assert.NotNil(s.T(), err)
+	s.assertAllExpectations()
+}
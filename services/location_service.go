@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"strings"
+	"time"
 )
 
 const (
@@ -25,18 +26,21 @@ type LocationService struct {
 	logger        monitor.AppLogger
 	dbFactory     repositories.DatabaseFactory
 	googleMapsAPI repositories.GoogleMapsAPI
+	referenceData IReferenceDataService
 	publisher     message.Publisher
 }
 
 func NewLocationService(
 	dbFactory repositories.DatabaseFactory,
 	googleMapsAPI repositories.GoogleMapsAPI,
+	referenceData IReferenceDataService,
 	publisher message.Publisher,
 ) *LocationService {
 	return &LocationService{
 		logger:        monitor.GetStdLogger("LocationService"),
 		dbFactory:     dbFactory,
 		googleMapsAPI: googleMapsAPI,
+		referenceData: referenceData,
 		publisher:     publisher,
 	}
 }
@@ -50,9 +54,12 @@ func (s *LocationService) CreateLocationMock(ctx monitor.ApplicationContext) (er
 	s.logger.Info(fnName,"corrid","Test Log")
 
 	// Create and publish kafka message
-	kafkaMsg, txErr := pubsub.CreateJSONMessage(ctx, ctx.GetCorrelationID(), domain.Location{
-		ID:   "mockID",
-		Name: "mockLocation",
+	kafkaMsg, txErr := pubsub.CreateCloudEventMessage(ctx, ctx.GetCorrelationID(), domain.LocationCreatedEventType, domain.LocationEventSchemaVersion, domain.LocationEventV2{
+		Location: domain.Location{
+			ID:   "mockID",
+			Name: "mockLocation",
+		},
+		Actor: ctx.GetActor(),
 	})
 	if txErr != nil {
 		return txErr
@@ -72,45 +79,26 @@ func (s *LocationService) CreateLocation(ctx monitor.ApplicationContext, newLoca
 		return location, err
 	}
 
-	newDefaultSubLocation := s.buildDefaultSubLocationForLocation(newLocation)
-
 	db, err := s.dbFactory.GetLocationsDB()
 	if err != nil {
 		return location, err
 	}
 
-	// Check if location name is already in use
+	// Check if location name is already in use, a concurrent create that wins the race is caught by the unique index
+	// and reported with the same error by the repository
 	nameInUse, err := db.CheckLocationNameExistence(ctx, newLocationData.Name)
 	if err != nil {
 		s.logger.ErrorCtx(ctx, fnName, "failed to check location name existence", err)
 		return location, err
 	}
 	if nameInUse {
-		errVal := domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("location name '%v' is already in use", newLocationData.Name)}
+		errVal := domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("location name '%v' is already in use", newLocationData.Name), Resource: domain.ResourceLocation}
 		s.logger.WarnCtx(ctx, fnName, errVal.Msg)
 		return location, errVal
 	}
 
 	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
-		var txErr error
-
-		// Create the location
-		if txErr = db.CreateLocation(ctx, newLocation); txErr != nil {
-			return fmt.Errorf("error creating new location: %w", txErr)
-		}
-
-		// Create it's default sub location
-		if txErr = db.CreateSubLocation(ctx, newDefaultSubLocation); txErr != nil {
-			return fmt.Errorf("error creating new sub location: %w", txErr)
-		}
-
-		// Create and publish kafka message
-		kafkaMsg, txErr := pubsub.CreateJSONMessage(ctx, ctx.GetCorrelationID(), newLocation)
-		if txErr != nil {
-			return txErr
-		}
-
-		return s.publisher.Publish(domain.LocationsNewTopic, kafkaMsg)
+		return s.storeNewLocation(ctx, db, newLocation)
 	}); err != nil {
 		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
 		return location, err
@@ -119,7 +107,46 @@ func (s *LocationService) CreateLocation(ctx monitor.ApplicationContext, newLoca
 	return newLocation, nil
 }
 
-func (s *LocationService) UpdateLocation(ctx monitor.ApplicationContext, updatedLocationData dto.UpdateLocationRequest) (location domain.Location, err error) {
+// storeNewLocation creates the location with its default sub location and the new location event. It must run inside
+// a transaction.
+func (s *LocationService) storeNewLocation(ctx monitor.ApplicationContext, db repositories.LocationsDB, newLocation domain.Location) error {
+	// Create the location
+	if err := db.CreateLocation(ctx, newLocation); err != nil {
+		return fmt.Errorf("error creating new location: %w", err)
+	}
+
+	// Create it's default sub location
+	if err := db.CreateSubLocation(ctx, s.buildDefaultSubLocationForLocation(newLocation)); err != nil {
+		return fmt.Errorf("error creating new sub location: %w", err)
+	}
+
+	// Store the event in the outbox, it will be published once the transaction commits
+	outboxMsg, err := createLocationOutboxMessage(ctx, domain.LocationsNewTopic, newLocation)
+	if err != nil {
+		return err
+	}
+
+	return db.CreateOutboxMessage(ctx, outboxMsg)
+}
+
+// createLocationOutboxMessage builds the CloudEvent of the location published to the location topic, with the
+// data in the latest schema version
+func createLocationOutboxMessage(ctx monitor.ApplicationContext, topic string, location domain.Location) (domain.OutboxMessage, error) {
+	return pubsub.CreateCloudEventOutboxMessage(
+		ctx,
+		topic,
+		location.ID,
+		domain.LocationEventTypes[topic],
+		domain.LocationEventSchemaVersion,
+		domain.LocationEventV2{Location: location, Actor: ctx.GetActor()},
+	)
+}
+
+func (s *LocationService) UpdateLocation(
+	ctx monitor.ApplicationContext,
+	updatedLocationData dto.UpdateLocationRequest,
+	expectedVersion int,
+) (location domain.Location, err error) {
 	fnName := "LocationService.UpdateLocation"
 
 	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("updated_location_data", utils.ToJSON(updatedLocationData))))
@@ -140,8 +167,11 @@ func (s *LocationService) UpdateLocation(ctx monitor.ApplicationContext, updated
 		if txErr != nil {
 			return fmt.Errorf("error finding location with ID %v: %w", updatedLocationData.ID, txErr)
 		}
-		if existingLocation == nil {
-			return domain.BusinessErr{Msg: fmt.Sprintf("location with ID %v does not exist", updatedLocationData.ID)}
+		if existingLocation == nil || existingLocation.IsDeleted() {
+			return domain.NotFoundErr{Msg: fmt.Sprintf("location with ID %v does not exist", updatedLocationData.ID), Resource: domain.ResourceLocation}
+		}
+		if txErr = checkLocationVersion(existingLocation, expectedVersion); txErr != nil {
+			return txErr
 		}
 
 		// Check if location name changed. If so, validate the new name is not in use
@@ -152,7 +182,7 @@ func (s *LocationService) UpdateLocation(ctx monitor.ApplicationContext, updated
 				return txErr
 			}
 			if nameInUse {
-				return domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("location name '%v' is already in use", updatedLocationData.Name)}
+				return domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("location name '%v' is already in use", updatedLocationData.Name), Resource: domain.ResourceLocation}
 			}
 		}
 
@@ -161,13 +191,13 @@ func (s *LocationService) UpdateLocation(ctx monitor.ApplicationContext, updated
 			return txErr
 		}
 
-		// Create and publish kafka message
-		kafkaMsg, txErr := pubsub.CreateJSONMessage(ctx, ctx.GetCorrelationID(), existingLocation)
+		// Store the event in the outbox, it will be published once the transaction commits
+		outboxMsg, txErr := createLocationOutboxMessage(ctx, domain.LocationsUpdatedTopic, *existingLocation)
 		if txErr != nil {
 			return txErr
 		}
 
-		return s.publisher.Publish(domain.LocationsUpdatedTopic, kafkaMsg)
+		return db.CreateOutboxMessage(ctx, outboxMsg)
 	}); err != nil {
 		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
 		return location, err
@@ -176,18 +206,183 @@ func (s *LocationService) UpdateLocation(ctx monitor.ApplicationContext, updated
 	return *existingLocation, nil
 }
 
-func (s *LocationService) GetLocationByID(ctx monitor.ApplicationContext, id string) (*domain.Location, error) {
-	fnName := "LocationService.GetLocationByID"
+func (s *LocationService) PatchLocation(
+	ctx monitor.ApplicationContext,
+	id string,
+	patch dto.PatchLocationRequest,
+	expectedVersion int,
+) (location domain.Location, err error) {
+	fnName := "LocationService.PatchLocation"
 
 	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("location_id", id)))
 	defer span.End()
 
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return location, err
+	}
+
+	var existingLocation *domain.Location
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		var txErr error
+
+		existingLocation, txErr = db.GetLocationByID(ctx, id)
+		if txErr != nil {
+			return fmt.Errorf("error finding location with ID %v: %w", id, txErr)
+		}
+		if existingLocation == nil || existingLocation.IsDeleted() {
+			return domain.NotFoundErr{Msg: fmt.Sprintf("location with ID %v does not exist", id), Resource: domain.ResourceLocation}
+		}
+		if txErr = checkLocationVersion(existingLocation, expectedVersion); txErr != nil {
+			return txErr
+		}
+
+		// An empty patch document leaves the location as it is
+		if patch.IsEmpty() {
+			return nil
+		}
+
+		if txErr = s.applyLocationPatch(ctx, db, existingLocation, patch); txErr != nil {
+			return txErr
+		}
+
+		if txErr = db.UpdateLocation(ctx, *existingLocation); txErr != nil {
+			return txErr
+		}
+		existingLocation.Version++
+
+		// Store the event in the outbox, it will be published once the transaction commits
+		outboxMsg, txErr := createLocationOutboxMessage(ctx, domain.LocationsUpdatedTopic, *existingLocation)
+		if txErr != nil {
+			return txErr
+		}
+
+		return db.CreateOutboxMessage(ctx, outboxMsg)
+	}); err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
+		return location, err
+	}
+
+	return *existingLocation, nil
+}
+
+func (s *LocationService) DeleteLocation(ctx monitor.ApplicationContext, id string) error {
+	fnName := "LocationService.DeleteLocation"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("location_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return err
+	}
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		existingLocation, txErr := db.GetLocationByID(ctx, id)
+		if txErr != nil {
+			return fmt.Errorf("error finding location with ID %v: %w", id, txErr)
+		}
+		if existingLocation == nil || existingLocation.IsDeleted() {
+			return domain.NotFoundErr{Msg: fmt.Sprintf("location with ID %v does not exist", id), Resource: domain.ResourceLocation}
+		}
+
+		deletedAt := time.Now().UTC()
+		if txErr = db.SoftDeleteLocation(ctx, id, deletedAt); txErr != nil {
+			return fmt.Errorf("error deleting location: %w", txErr)
+		}
+		existingLocation.DeletedAt = &deletedAt
+		existingLocation.Version++
+
+		// Store the event in the outbox, it will be published once the transaction commits
+		outboxMsg, txErr := createLocationOutboxMessage(ctx, domain.LocationsDeletedTopic, *existingLocation)
+		if txErr != nil {
+			return txErr
+		}
+
+		return db.CreateOutboxMessage(ctx, outboxMsg)
+	}); err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *LocationService) RestoreLocation(ctx monitor.ApplicationContext, id string) (location domain.Location, err error) {
+	fnName := "LocationService.RestoreLocation"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("location_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return location, err
+	}
+
+	var existingLocation *domain.Location
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		var txErr error
+
+		existingLocation, txErr = db.GetLocationByID(ctx, id)
+		if txErr != nil {
+			return fmt.Errorf("error finding location with ID %v: %w", id, txErr)
+		}
+		if existingLocation == nil {
+			return domain.NotFoundErr{Msg: fmt.Sprintf("location with ID %v does not exist", id), Resource: domain.ResourceLocation}
+		}
+
+		// Restoring a location that is not deleted changes nothing
+		if !existingLocation.IsDeleted() {
+			return nil
+		}
+
+		if txErr = db.RestoreLocation(ctx, id); txErr != nil {
+			return fmt.Errorf("error restoring location: %w", txErr)
+		}
+		existingLocation.DeletedAt = nil
+		existingLocation.Version++
+
+		// Store the event in the outbox, it will be published once the transaction commits
+		outboxMsg, txErr := createLocationOutboxMessage(ctx, domain.LocationsRestoredTopic, *existingLocation)
+		if txErr != nil {
+			return txErr
+		}
+
+		return db.CreateOutboxMessage(ctx, outboxMsg)
+	}); err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
+		return location, err
+	}
+
+	return *existingLocation, nil
+}
+
+func (s *LocationService) GetLocationByID(ctx monitor.ApplicationContext, id string, includeDeleted bool) (*domain.Location, error) {
+	fnName := "LocationService.GetLocationByID"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(
+		attribute.String("location_id", id),
+		attribute.Bool("include_deleted", includeDeleted),
+	))
+	defer span.End()
+
 	db, err := s.dbFactory.GetLocationsDB()
 	if err != nil {
 		return nil, err
 	}
 
-	return db.GetLocationByID(ctx, id)
+	location, err := db.GetLocationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if location != nil && location.IsDeleted() && !includeDeleted {
+		return nil, nil
+	}
+
+	return location, nil
 }
 
 func (s *LocationService) GetPaginatedLocations(ctx monitor.ApplicationContext, filters domain.LocationsFilters) (page domain.CursorPage[domain.Location], err error) {
@@ -212,9 +407,74 @@ func (s *LocationService) GetPaginatedLocations(ctx monitor.ApplicationContext,
 	return page, nil
 }
 
+func (s *LocationService) GetNearbyLocations(
+	ctx monitor.ApplicationContext,
+	filters domain.NearbyLocationsFilters,
+) ([]domain.NearbyLocation, error) {
+	fnName := "LocationService.GetNearbyLocations"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("filters", utils.ToJSON(filters))))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	locations, err := db.GetNearbyLocations(ctx, filters)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to retrieve nearby locations", err)
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (s *LocationService) GetLocationHistory(
+	ctx monitor.ApplicationContext,
+	filters domain.LocationHistoryFilters,
+) (page domain.CursorPage[domain.LocationHistoryEntry], err error) {
+	fnName := "LocationService.GetLocationHistory"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("filters", utils.ToJSON(filters))))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return page, err
+	}
+
+	// Deleted locations keep their history, so they are not filtered out here
+	location, err := db.GetLocationByID(ctx, filters.LocationID)
+	if err != nil {
+		return page, fmt.Errorf("error finding location with ID %v: %w", filters.LocationID, err)
+	}
+	if location == nil {
+		return page, domain.NotFoundErr{Msg: fmt.Sprintf("location with ID %v does not exist", filters.LocationID), Resource: domain.ResourceLocation}
+	}
+
+	page, err = db.GetLocationHistory(ctx, filters)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to retrieve location history", err)
+		return page, err
+	}
+
+	return page, nil
+}
+
 func (s *LocationService) buildNewLocation(ctx monitor.ApplicationContext, data dto.CreateLocationRequest) (domain.Location, error) {
-	supplierName := SupplierMap[data.SupplierID]
-	locationTypeName := LocationTypeMap[data.LocationTypeID]
+	supplier, err := getTenantSupplier(ctx, s.referenceData, data.SupplierID)
+	if err != nil {
+		return domain.Location{}, err
+	}
+
+	locationType, err := s.referenceData.GetLocationTypeByID(ctx, data.LocationTypeID)
+	if err != nil {
+		return domain.Location{}, err
+	}
 
 	// Use Google Maps API to retrieve the address and fill latitude and longitude values
 	validatedAddress, err := s.googleMapsAPI.ValidateAddress(ctx, googlemaps.AddressValidationRequest{
@@ -250,9 +510,11 @@ func (s *LocationService) buildNewLocation(ctx monitor.ApplicationContext, data
 				Email:         data.Email,
 			},
 		},
-		LocationType: domain.LocationType{ID: data.LocationTypeID, Type: locationTypeName},
-		Supplier:     domain.Supplier{ID: data.SupplierID, Name: supplierName},
+		LocationType: locationType,
+		Supplier:     supplier,
 		Active:       true,
+		Version:      1,
+		CreatedAt:    time.Now().UTC(),
 	}, nil
 }
 
@@ -272,6 +534,16 @@ func (s *LocationService) updateLocation(
 	location *domain.Location,
 	updateData dto.UpdateLocationRequest,
 ) error {
+	supplier, err := getTenantSupplier(ctx, s.referenceData, updateData.SupplierID)
+	if err != nil {
+		return err
+	}
+
+	locationType, err := s.referenceData.GetLocationTypeByID(ctx, updateData.LocationTypeID)
+	if err != nil {
+		return err
+	}
+
 	// Use Google Maps API to retrieve the address and fill latitude and longitude values
 	validatedAddress, err := s.googleMapsAPI.ValidateAddress(ctx, googlemaps.AddressValidationRequest{
 		City:         updateData.City,
@@ -290,10 +562,8 @@ func (s *LocationService) updateLocation(
 	}
 
 	location.Name = updateData.Name
-	location.Supplier.ID = updateData.SupplierID
-	location.Supplier.Name = SupplierMap[updateData.SupplierID]
-	location.LocationType.ID = updateData.LocationTypeID
-	location.LocationType.Type = LocationTypeMap[updateData.LocationTypeID]
+	location.Supplier = supplier
+	location.LocationType = locationType
 	location.Active = updateData.Active
 
 	location.Information.Address = updateData.Address
@@ -307,5 +577,116 @@ func (s *LocationService) updateLocation(
 	location.Information.ContactInformation.PhoneNumber = updateData.PhoneNumber
 	location.Information.ContactInformation.Email = updateData.Email
 
-	return db.UpdateLocation(ctx, *location)
+	if err = db.UpdateLocation(ctx, *location); err != nil {
+		return err
+	}
+	location.Version++
+
+	return nil
+}
+
+// applyLocationPatch merges the patch into the location. The address is only validated again when one of its
+// fields actually changes.
+func (s *LocationService) applyLocationPatch(
+	ctx monitor.ApplicationContext,
+	db repositories.LocationsDB,
+	location *domain.Location,
+	patch dto.PatchLocationRequest,
+) error {
+	if patch.Name.HasValue() && !strings.EqualFold(location.Name, patch.Name.Value) {
+		nameInUse, err := db.CheckLocationNameExistence(ctx, patch.Name.Value)
+		if err != nil {
+			return err
+		}
+		if nameInUse {
+			return domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("location name '%v' is already in use", patch.Name.Value), Resource: domain.ResourceLocation}
+		}
+	}
+	if patch.Name.HasValue() {
+		location.Name = patch.Name.Value
+	}
+
+	if patch.SupplierID.HasValue() {
+		supplier, err := getTenantSupplier(ctx, s.referenceData, patch.SupplierID.Value)
+		if err != nil {
+			return err
+		}
+		location.Supplier = supplier
+	}
+
+	if patch.LocationTypeID.HasValue() {
+		locationType, err := s.referenceData.GetLocationTypeByID(ctx, patch.LocationTypeID.Value)
+		if err != nil {
+			return err
+		}
+		location.LocationType = locationType
+	}
+
+	if patch.Active.HasValue() {
+		location.Active = patch.Active.Value
+	}
+
+	information := location.Information
+	patchString(&information.Address, patch.Address)
+	patchString(&information.City, patch.City)
+	patchString(&information.State, patch.State)
+	patchString(&information.Zipcode, patch.Zipcode)
+
+	if information.Address != location.Information.Address || information.City != location.Information.City ||
+		information.State != location.Information.State || information.Zipcode != location.Information.Zipcode {
+		validatedAddress, err := s.googleMapsAPI.ValidateAddress(ctx, googlemaps.AddressValidationRequest{
+			City:         information.City,
+			AddressLine1: information.Address,
+			State:        information.State,
+			LongForm:     true,
+			Zipcode:      information.Zipcode,
+		})
+		if err != nil {
+			return err
+		}
+
+		if validatedAddress == nil {
+			s.logger.WarnCtx(ctx, "applyLocationPatch", "failed to validate address", monitor.LoggingParam{Name: "address_data", Value: information})
+			return domain.AddressNotValidErr{Msg: "the address information does not correspond to a valid address"}
+		}
+
+		information.Latitude = validatedAddress.Latitude
+		information.Longitude = validatedAddress.Longitude
+	}
+
+	patchNullableString(&information.ContactInformation.ContactPerson, patch.ContactPerson)
+	patchNullableString(&information.ContactInformation.PhoneNumber, patch.PhoneNumber)
+	patchNullableString(&information.ContactInformation.Email, patch.Email)
+
+	location.Information = information
+
+	return nil
+}
+
+// checkLocationVersion rejects writes based on a stale copy of the location. It must be called on a location read
+// inside the same transaction, the row lock taken by GetLocationByID keeps the version from changing afterwards.
+func checkLocationVersion(location *domain.Location, expectedVersion int) error {
+	if location.Version != expectedVersion {
+		return domain.PreconditionFailedErr{
+			Msg:      fmt.Sprintf("location with ID %v was modified, the current version is %v", location.ID, location.Version),
+			Resource: domain.ResourceLocation,
+		}
+	}
+
+	return nil
+}
+
+func patchString(target *string, value dto.Optional[string]) {
+	if value.HasValue() {
+		*target = value.Value
+	}
+}
+
+func patchNullableString(target **string, value dto.Optional[string]) {
+	switch {
+	case value.Null:
+		*target = nil
+	case value.Set:
+		*target = utils.ToPointer(value.Value)
+	}
 }
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -12,7 +13,9 @@ import (
 	"go-service-template/monitor"
 	"go-service-template/services"
 	"go-service-template/utils"
+	"strconv"
 	"testing"
+	"time"
 )
 
 var (
@@ -50,6 +53,7 @@ type LocationServiceSuite struct {
 	dbFactoryMock     *mocks.DatabaseFactory
 	googleMapsAPIMock *mocks.GoogleMapsAPI
 	locationsDBMock   *mocks.LocationsDB
+	referenceDataMock *mocks.IReferenceDataService
 	publisherMock     *mocks.MockPublisher
 	locationService   *services.LocationService
 }
@@ -58,12 +62,14 @@ func (s *LocationServiceSuite) SetupSuite() {
 	dbFactoryMock := new(mocks.DatabaseFactory)
 	locationsDBMock := new(mocks.LocationsDB)
 	googleMapsMock := new(mocks.GoogleMapsAPI)
+	referenceDataMock := new(mocks.IReferenceDataService)
 	publisherMock := new(mocks.MockPublisher)
 
-	s.locationService = services.NewLocationService(dbFactoryMock, googleMapsMock, publisherMock)
+	s.locationService = services.NewLocationService(dbFactoryMock, googleMapsMock, referenceDataMock, publisherMock)
 	s.dbFactoryMock = dbFactoryMock
 	s.locationsDBMock = locationsDBMock
 	s.googleMapsAPIMock = googleMapsMock
+	s.referenceDataMock = referenceDataMock
 	s.publisherMock = publisherMock
 }
 
@@ -71,6 +77,7 @@ func (s *LocationServiceSuite) SetupTest() {
 	s.dbFactoryMock.ExpectedCalls = nil
 	s.locationsDBMock.ExpectedCalls = nil
 	s.googleMapsAPIMock.ExpectedCalls = nil
+	s.referenceDataMock.ExpectedCalls = nil
 	s.publisherMock.ExpectedCalls = nil
 }
 
@@ -78,14 +85,21 @@ func (s *LocationServiceSuite) assertAllExpectations() {
 	s.dbFactoryMock.AssertExpectations(s.T())
 	s.locationsDBMock.AssertExpectations(s.T())
 	s.googleMapsAPIMock.AssertExpectations(s.T())
+	s.referenceDataMock.AssertExpectations(s.T())
 	s.publisherMock.AssertExpectations(s.T())
 }
 
+func (s *LocationServiceSuite) expectReferenceDataLookups(supplierID, locationTypeID int) {
+	s.referenceDataMock.On("GetSupplierByID", mock.Anything, supplierID).Return(domain.Supplier{ID: supplierID, Name: "Supplier"}, nil).Once()
+	s.referenceDataMock.On("GetLocationTypeByID", mock.Anything, locationTypeID).Return(domain.LocationType{ID: locationTypeID, Type: "Type"}, nil).Once()
+}
+
 func TestLocationServiceSuite(t *testing.T) {
 	suite.Run(t, new(LocationServiceSuite))
 }
 
 func (s *LocationServiceSuite) Test_CreateLocation_Success() {
+	s.expectReferenceDataLookups(createLocData.SupplierID, createLocData.LocationTypeID)
 	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Return(&googlemaps.AddressValidateMatch{}, nil)
 	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
 	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, createLocData.Name).Return(false, nil).Once()
@@ -99,16 +113,66 @@ func (s *LocationServiceSuite) Test_CreateLocation_Success() {
 		assert.Equal(s.T(), services.DefaultSubLocationName, subLocation.Name)
 	}).Return(nil).Once()
 
-	s.publisherMock.On("Publish", domain.LocationsNewTopic, mock.Anything).Return(nil)
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		outboxMsg := args.Get(1).(domain.OutboxMessage)
+		assert.Equal(s.T(), domain.LocationsNewTopic, outboxMsg.Topic)
+		assert.Equal(s.T(), domain.LocationCreatedEventType, outboxMsg.Metadata["ce_type"])
+		assert.Equal(s.T(), strconv.Itoa(domain.LocationEventSchemaVersion), outboxMsg.Metadata["ce_schemaversion"])
+	}).Return(nil).Once()
 
 	location, err := s.locationService.CreateLocation(testCtx, createLocData)
 
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), location.Name, createLocData.Name)
+	assert.Equal(s.T(), "Supplier", location.Supplier.Name)
+	assert.Equal(s.T(), "Type", location.LocationType.Type)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_CreateLocation_FailsIfSupplierDoesNotExist() {
+	s.referenceDataMock.On("GetSupplierByID", mock.Anything, createLocData.SupplierID).
+		Return(domain.Supplier{}, domain.UnknownReferenceErr{Msg: "unknown supplier"}).Once()
+
+	_, err := s.locationService.CreateLocation(testCtx, createLocData)
+
+	assert.IsType(s.T(), domain.UnknownReferenceErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_CreateLocation_FailsIfSupplierIsNotTheTenantOne() {
+	supplierCtx := testCtx.WithPrincipal(monitor.Principal{
+		Subject:    "supplier-portal",
+		SupplierID: utils.ToPointer(createLocData.SupplierID + 1),
+	})
+
+	_, err := s.locationService.CreateLocation(supplierCtx, createLocData)
+
+	assert.IsType(s.T(), domain.UnknownReferenceErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_CreateLocation_RollsBackIfOutboxMessageCannotBeStored() {
+	s.expectReferenceDataLookups(createLocData.SupplierID, createLocData.LocationTypeID)
+	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Return(&googlemaps.AddressValidateMatch{}, nil)
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, createLocData.Name).Return(false, nil).Once()
+
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+
+	s.locationsDBMock.On("CreateLocation", mock.Anything, mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CreateSubLocation", mock.Anything, mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Return(errors.New("outbox error")).Once()
+
+	_, err := s.locationService.CreateLocation(testCtx, createLocData)
+
+	assert.NotNil(s.T(), err)
+	s.publisherMock.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
 	s.assertAllExpectations()
 }
 
 func (s *LocationServiceSuite) Test_CreateLocation_FailsIfNewLocationNameIsAlreadyInUse() {
+	s.expectReferenceDataLookups(createLocData.SupplierID, createLocData.LocationTypeID)
 	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Return(&googlemaps.AddressValidateMatch{}, nil)
 	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
 	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, createLocData.Name).Return(true, nil).Once()
@@ -121,6 +185,7 @@ func (s *LocationServiceSuite) Test_CreateLocation_FailsIfNewLocationNameIsAlrea
 }
 
 func (s *LocationServiceSuite) Test_CreateLocation_FailsIfAddressValidationCannotFindAddress() {
+	s.expectReferenceDataLookups(createLocData.SupplierID, createLocData.LocationTypeID)
 	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Return(nil, nil)
 
 	_, err := s.locationService.CreateLocation(testCtx, createLocData)
@@ -151,6 +216,7 @@ func (s *LocationServiceSuite) Test_UpdateLocation_Success() {
 		LocationType: domain.LocationType{ID: 1, Type: "Type"},
 		Supplier:     domain.Supplier{ID: 2, Name: "Supplier"},
 		Active:       true,
+		Version:      3,
 	}
 
 	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
@@ -159,6 +225,7 @@ func (s *LocationServiceSuite) Test_UpdateLocation_Success() {
 
 	s.locationsDBMock.On("GetLocationByID", mock.Anything, updateLocData.ID).Return(&existingLocation, nil).Once()
 	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, updateLocData.Name).Return(false, nil).Once()
+	s.expectReferenceDataLookups(updateLocData.SupplierID, updateLocData.LocationTypeID)
 
 	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Return(&googlemaps.AddressValidateMatch{}, nil)
 	s.locationsDBMock.On("UpdateLocation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
@@ -166,12 +233,17 @@ func (s *LocationServiceSuite) Test_UpdateLocation_Success() {
 		assert.Equal(s.T(), updateLocData.Name, updatedLocation.Name)
 	}).Return(nil).Once()
 
-	s.publisherMock.On("Publish", domain.LocationsUpdatedTopic, mock.Anything).Return(nil)
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		outboxMsg := args.Get(1).(domain.OutboxMessage)
+		assert.Equal(s.T(), domain.LocationsUpdatedTopic, outboxMsg.Topic)
+		assert.Equal(s.T(), updateLocData.ID, outboxMsg.AggregateID)
+	}).Return(nil).Once()
 
-	updatedLocation, err := s.locationService.UpdateLocation(testCtx, updateLocData)
+	updatedLocation, err := s.locationService.UpdateLocation(testCtx, updateLocData, 3)
 
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), updateLocData.Name, updatedLocation.Name)
+	assert.Equal(s.T(), 4, updatedLocation.Version)
 	s.assertAllExpectations()
 }
 
@@ -182,9 +254,10 @@ func (s *LocationServiceSuite) Test_UpdateLocation_FailsIfAddressValidationCanno
 
 	s.locationsDBMock.On("GetLocationByID", mock.Anything, updateLocData.ID).Return(&domain.Location{Name: "OldName"}, nil).Once()
 	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, updateLocData.Name).Return(false, nil).Once()
+	s.expectReferenceDataLookups(updateLocData.SupplierID, updateLocData.LocationTypeID)
 	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Return(nil, nil)
 
-	_, err := s.locationService.UpdateLocation(testCtx, updateLocData)
+	_, err := s.locationService.UpdateLocation(testCtx, updateLocData, 0)
 
 	assert.NotNil(s.T(), err)
 	assert.IsType(s.T(), domain.AddressNotValidErr{}, err)
@@ -199,7 +272,7 @@ func (s *LocationServiceSuite) Test_UpdateLocation_FailsIfUpdatedLocationNameIsA
 	s.locationsDBMock.On("GetLocationByID", mock.Anything, updateLocData.ID).Return(&domain.Location{Name: "OldName"}, nil).Once()
 	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, updateLocData.Name).Return(true, nil).Once()
 
-	_, err := s.locationService.UpdateLocation(testCtx, updateLocData)
+	_, err := s.locationService.UpdateLocation(testCtx, updateLocData, 0)
 
 	assert.NotNil(s.T(), err)
 	assert.IsType(s.T(), domain.NameAlreadyInUseErr{}, err)
@@ -213,10 +286,10 @@ func (s *LocationServiceSuite) Test_UpdateLocation_FailsIfLocationCannotBeFound(
 
 	s.locationsDBMock.On("GetLocationByID", mock.Anything, updateLocData.ID).Return(nil, nil).Once()
 
-	_, err := s.locationService.UpdateLocation(testCtx, updateLocData)
+	_, err := s.locationService.UpdateLocation(testCtx, updateLocData, 0)
 
 	assert.NotNil(s.T(), err)
-	assert.IsType(s.T(), domain.BusinessErr{}, err)
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
 	s.assertAllExpectations()
 }
 
@@ -238,9 +311,280 @@ func (s *LocationServiceSuite) Test_GetLocationByID_Success() {
 	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
 	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID}, nil).Once()
 
-	location, err := s.locationService.GetLocationByID(testCtx, locationID)
+	location, err := s.locationService.GetLocationByID(testCtx, locationID, false)
 
 	assert.Nil(s.T(), err)
 	assert.NotNil(s.T(), location)
 	s.assertAllExpectations()
 }
+
+func (s *LocationServiceSuite) Test_GetLocationByID_HidesDeletedLocationsUnlessRequested() {
+	locationID := uuid.New().String()
+	deletedLocation := &domain.Location{ID: locationID, DeletedAt: utils.ToPointer(time.Now())}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(deletedLocation, nil).Twice()
+
+	location, err := s.locationService.GetLocationByID(testCtx, locationID, false)
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), location)
+
+	location, err = s.locationService.GetLocationByID(testCtx, locationID, true)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), deletedLocation, location)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_DeleteLocation_Success() {
+	locationID := uuid.New().String()
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID}, nil).Once()
+	s.locationsDBMock.On("SoftDeleteLocation", mock.Anything, locationID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		outboxMsg := args.Get(1).(domain.OutboxMessage)
+		assert.Equal(s.T(), domain.LocationsDeletedTopic, outboxMsg.Topic)
+	}).Return(nil).Once()
+
+	err := s.locationService.DeleteLocation(testCtx, locationID)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_DeleteLocation_FailsIfLocationIsAlreadyDeleted() {
+	locationID := uuid.New().String()
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).
+		Return(&domain.Location{ID: locationID, DeletedAt: utils.ToPointer(time.Now())}, nil).Once()
+
+	err := s.locationService.DeleteLocation(testCtx, locationID)
+
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_RestoreLocation_Success() {
+	locationID := uuid.New().String()
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).
+		Return(&domain.Location{ID: locationID, DeletedAt: utils.ToPointer(time.Now())}, nil).Once()
+	s.locationsDBMock.On("RestoreLocation", mock.Anything, locationID).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		outboxMsg := args.Get(1).(domain.OutboxMessage)
+		assert.Equal(s.T(), domain.LocationsRestoredTopic, outboxMsg.Topic)
+	}).Return(nil).Once()
+
+	location, err := s.locationService.RestoreLocation(testCtx, locationID)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), location.IsDeleted())
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_RestoreLocation_DoesNothingIfLocationIsNotDeleted() {
+	locationID := uuid.New().String()
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID}, nil).Once()
+
+	location, err := s.locationService.RestoreLocation(testCtx, locationID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), locationID, location.ID)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_RestoreLocation_FailsIfLocationCannotBeFound() {
+	locationID := uuid.New().String()
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(nil, nil).Once()
+
+	_, err := s.locationService.RestoreLocation(testCtx, locationID)
+
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) buildExistingLocationForPatch() *domain.Location {
+	return &domain.Location{
+		ID:   uuid.New().String(),
+		Name: "SomeName",
+		Information: domain.LocationInformation{
+			Address:   "Address",
+			City:      "City",
+			State:     "State",
+			Zipcode:   "Zipcode",
+			Latitude:  123.4,
+			Longitude: 567.8,
+			ContactInformation: domain.ContactInformation{
+				ContactPerson: utils.ToPointer[string]("ContactPerson"),
+				Email:         utils.ToPointer[string]("Email"),
+			},
+		},
+		Active:  true,
+		Version: 2,
+	}
+}
+
+func (s *LocationServiceSuite) Test_PatchLocation_DoesNotValidateAddressIfItDidNotChange() {
+	existingLocation := s.buildExistingLocationForPatch()
+	patch := dto.PatchLocationRequest{
+		City:          dto.Optional[string]{Value: "City", Set: true},
+		ContactPerson: dto.Optional[string]{Set: true, Null: true},
+		Active:        dto.Optional[bool]{Value: false, Set: true},
+	}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existingLocation.ID).Return(existingLocation, nil).Once()
+	s.locationsDBMock.On("UpdateLocation", mock.Anything, mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Return(nil).Once()
+
+	location, err := s.locationService.PatchLocation(testCtx, existingLocation.ID, patch, 2)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), location.Active)
+	assert.Nil(s.T(), location.Information.ContactInformation.ContactPerson)
+	assert.Equal(s.T(), "Email", *location.Information.ContactInformation.Email)
+	assert.Equal(s.T(), 123.4, location.Information.Latitude)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_PatchLocation_ValidatesAddressIfItChanged() {
+	existingLocation := s.buildExistingLocationForPatch()
+	patch := dto.PatchLocationRequest{Zipcode: dto.Optional[string]{Value: "99999", Set: true}}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existingLocation.ID).Return(existingLocation, nil).Once()
+	s.googleMapsAPIMock.On("ValidateAddress", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(1).(googlemaps.AddressValidationRequest)
+		assert.Equal(s.T(), "99999", request.Zipcode)
+		assert.Equal(s.T(), "Address", request.AddressLine1)
+	}).Return(&googlemaps.AddressValidateMatch{Latitude: 1, Longitude: 2}, nil).Once()
+	s.locationsDBMock.On("UpdateLocation", mock.Anything, mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Return(nil).Once()
+
+	location, err := s.locationService.PatchLocation(testCtx, existingLocation.ID, patch, 2)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "99999", location.Information.Zipcode)
+	assert.Equal(s.T(), float64(1), location.Information.Latitude)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_PatchLocation_FailsIfNewNameIsAlreadyInUse() {
+	existingLocation := s.buildExistingLocationForPatch()
+	patch := dto.PatchLocationRequest{Name: dto.Optional[string]{Value: "Taken", Set: true}}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existingLocation.ID).Return(existingLocation, nil).Once()
+	s.locationsDBMock.On("CheckLocationNameExistence", mock.Anything, "Taken").Return(true, nil).Once()
+
+	_, err := s.locationService.PatchLocation(testCtx, existingLocation.ID, patch, 2)
+
+	assert.IsType(s.T(), domain.NameAlreadyInUseErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_PatchLocation_DoesNothingOnEmptyPatch() {
+	existingLocation := s.buildExistingLocationForPatch()
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existingLocation.ID).Return(existingLocation, nil).Once()
+
+	location, err := s.locationService.PatchLocation(testCtx, existingLocation.ID, dto.PatchLocationRequest{}, 2)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), *existingLocation, location)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_UpdateLocation_FailsIfVersionDoesNotMatch() {
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, updateLocData.ID).Return(&domain.Location{ID: updateLocData.ID, Version: 5}, nil).Once()
+
+	_, err := s.locationService.UpdateLocation(testCtx, updateLocData, 4)
+
+	assert.IsType(s.T(), domain.PreconditionFailedErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_PatchLocation_FailsIfVersionDoesNotMatch() {
+	existingLocation := s.buildExistingLocationForPatch()
+	patch := dto.PatchLocationRequest{Name: dto.Optional[string]{Value: "New name", Set: true}}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existingLocation.ID).Return(existingLocation, nil).Once()
+
+	_, err := s.locationService.PatchLocation(testCtx, existingLocation.ID, patch, 1)
+
+	assert.IsType(s.T(), domain.PreconditionFailedErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_GetLocationHistory_IncludesDeletedLocations() {
+	filters := domain.LocationHistoryFilters{LocationID: uuid.New().String()}
+	history := domain.CursorPage[domain.LocationHistoryEntry]{Data: []domain.LocationHistoryEntry{{ID: 1, LocationID: filters.LocationID}}}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, filters.LocationID).
+		Return(&domain.Location{ID: filters.LocationID, DeletedAt: utils.ToPointer(time.Now())}, nil).Once()
+	s.locationsDBMock.On("GetLocationHistory", mock.Anything, filters).Return(history, nil).Once()
+
+	page, err := s.locationService.GetLocationHistory(testCtx, filters)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), history, page)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_GetLocationHistory_FailsIfLocationDoesNotExist() {
+	filters := domain.LocationHistoryFilters{LocationID: uuid.New().String()}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, filters.LocationID).Return(nil, nil).Once()
+
+	_, err := s.locationService.GetLocationHistory(testCtx, filters)
+
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_GetNearbyLocations_Success() {
+	filters := domain.NearbyLocationsFilters{Latitude: 10, Longitude: 20, RadiusMeters: 500}
+	nearby := []domain.NearbyLocation{{Location: domain.Location{ID: uuid.New().String()}, DistanceMeters: 120}}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("GetNearbyLocations", mock.Anything, filters).Return(nearby, nil).Once()
+
+	locations, err := s.locationService.GetNearbyLocations(testCtx, filters)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), nearby, locations)
+	s.assertAllExpectations()
+}
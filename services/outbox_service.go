@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"go-service-template/domain"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const ParkedOutboxMessagesLimit = 100
+
+// OutboxService manages the outbox messages parked after failing every publish attempt. A parked message holds back
+// the later messages of its aggregate, so the relay does not publish them until it is redriven or discarded.
+type OutboxService struct {
+	logger    monitor.AppLogger
+	dbFactory repositories.DatabaseFactory
+}
+
+func NewOutboxService(dbFactory repositories.DatabaseFactory) *OutboxService {
+	return &OutboxService{
+		logger:    monitor.GetStdLogger("OutboxService"),
+		dbFactory: dbFactory,
+	}
+}
+
+func (s *OutboxService) GetParkedMessages(ctx monitor.ApplicationContext) ([]domain.OutboxMessage, error) {
+	fnName := "OutboxService.GetParkedMessages"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return db.GetParkedOutboxMessages(ctx, ParkedOutboxMessagesLimit)
+}
+
+// RedriveParkedMessage makes the message pending again with its attempts reset, the relay publishes it before the
+// messages of its aggregate it held back
+func (s *OutboxService) RedriveParkedMessage(ctx monitor.ApplicationContext, id string) error {
+	fnName := "OutboxService.RedriveParkedMessage"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("outbox_message_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	redriven, err := db.RedriveParkedOutboxMessage(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to redrive parked outbox message", err)
+		return err
+	}
+	if !redriven {
+		return domain.NotFoundErr{
+			Msg:      fmt.Sprintf("parked outbox message with ID %v does not exist", id),
+			Resource: domain.ResourceOutboxMessage,
+		}
+	}
+
+	return nil
+}
+
+// DiscardParkedMessage deletes the message without publishing it, the messages of its aggregate it held back are
+// published after it
+func (s *OutboxService) DiscardParkedMessage(ctx monitor.ApplicationContext, id string) error {
+	fnName := "OutboxService.DiscardParkedMessage"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("outbox_message_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	discarded, err := db.DeleteParkedOutboxMessage(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to discard parked outbox message", err)
+		return err
+	}
+	if !discarded {
+		return domain.NotFoundErr{
+			Msg:      fmt.Sprintf("parked outbox message with ID %v does not exist", id),
+			Resource: domain.ResourceOutboxMessage,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/domain"
+	"go-service-template/mocks"
+	"go-service-template/services"
+	"testing"
+)
+
+const testOutboxMessageID = "8c6f2b1e-4d3a-4f5b-9e8d-7a6b5c4d3e2f"
+
+type OutboxServiceSuite struct {
+	suite.Suite
+	dbFactoryMock   *mocks.DatabaseFactory
+	locationsDBMock *mocks.LocationsDB
+	outboxService   *services.OutboxService
+}
+
+func (s *OutboxServiceSuite) SetupTest() {
+	s.dbFactoryMock = new(mocks.DatabaseFactory)
+	s.locationsDBMock = new(mocks.LocationsDB)
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+
+	s.outboxService = services.NewOutboxService(s.dbFactoryMock)
+}
+
+func TestOutboxServiceSuite(t *testing.T) {
+	suite.Run(t, new(OutboxServiceSuite))
+}
+
+func (s *OutboxServiceSuite) Test_GetParkedMessages_AppliesLimit() {
+	parkedMessages := []domain.OutboxMessage{{ID: testOutboxMessageID}}
+	s.locationsDBMock.On("GetParkedOutboxMessages", mock.Anything, services.ParkedOutboxMessagesLimit).Return(parkedMessages, nil).Once()
+
+	messages, err := s.outboxService.GetParkedMessages(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), parkedMessages, messages)
+	s.locationsDBMock.AssertExpectations(s.T())
+}
+
+func (s *OutboxServiceSuite) Test_RedriveParkedMessage_Success() {
+	s.locationsDBMock.On("RedriveParkedOutboxMessage", mock.Anything, testOutboxMessageID).Return(true, nil).Once()
+
+	err := s.outboxService.RedriveParkedMessage(testCtx, testOutboxMessageID)
+
+	assert.Nil(s.T(), err)
+	s.locationsDBMock.AssertExpectations(s.T())
+}
+
+func (s *OutboxServiceSuite) Test_RedriveParkedMessage_NotFoundWhenNotParked() {
+	s.locationsDBMock.On("RedriveParkedOutboxMessage", mock.Anything, testOutboxMessageID).Return(false, nil).Once()
+
+	err := s.outboxService.RedriveParkedMessage(testCtx, testOutboxMessageID)
+
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
+	s.locationsDBMock.AssertExpectations(s.T())
+}
+
+func (s *OutboxServiceSuite) Test_DiscardParkedMessage_NotFoundWhenNotParked() {
+	s.locationsDBMock.On("DeleteParkedOutboxMessage", mock.Anything, testOutboxMessageID).Return(false, nil).Once()
+
+	err := s.outboxService.DiscardParkedMessage(testCtx, testOutboxMessageID)
+
+	assert.IsType(s.T(), domain.NotFoundErr{}, err)
+	s.locationsDBMock.AssertExpectations(s.T())
+}
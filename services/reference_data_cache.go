@@ -0,0 +1,151 @@
+package services
+
+import (
+	"go-service-template/monitor"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReferenceDataMissReloadInterval is how long a miss is answered from a copy before it is loaded again, so requests
+// with unknown IDs cannot reload it on every request
+const ReferenceDataMissReloadInterval = time.Second
+
+// referenceDataCache keeps a full copy of a reference data table in memory. Changes made through this instance
+// invalidate it right away, changes made by other instances are picked up once the TTL expires, or on the first
+// lookup of an ID the copy does not have.
+type referenceDataCache[T any] struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	items     []T
+	byID      map[int]T
+	loadedAt  time.Time
+	expiresAt time.Time
+	idOf      func(T) int
+	nameOf    func(T) string
+	fetch     func(ctx monitor.ApplicationContext) ([]T, error)
+}
+
+func newReferenceDataCache[T any](
+	ttl time.Duration,
+	idOf func(T) int,
+	nameOf func(T) string,
+	fetch func(ctx monitor.ApplicationContext) ([]T, error),
+) *referenceDataCache[T] {
+	return &referenceDataCache[T]{
+		ttl:    ttl,
+		idOf:   idOf,
+		nameOf: nameOf,
+		fetch:  fetch,
+	}
+}
+
+// all returns every cached item. The returned slice is shared and must not be modified.
+func (c *referenceDataCache[T]) all(ctx monitor.ApplicationContext) ([]T, error) {
+	items, _, err := c.load(ctx)
+
+	return items, err
+}
+
+// find looks up the item with the ID. A miss loads the copy again once, the item may have been created by another
+// instance since it was loaded.
+func (c *referenceDataCache[T]) find(ctx monitor.ApplicationContext, id int) (item T, found bool, err error) {
+	_, byID, err := c.load(ctx)
+	if err != nil {
+		return item, false, err
+	}
+
+	if item, found = byID[id]; found {
+		return item, true, nil
+	}
+
+	if byID, err = c.reloadOnMiss(ctx); err != nil {
+		return item, false, err
+	}
+
+	item, found = byID[id]
+
+	return item, found, nil
+}
+
+// nameInUse checks case-insensitively whether an item other than excludedID already uses the name
+func (c *referenceDataCache[T]) nameInUse(ctx monitor.ApplicationContext, name string, excludedID int) (bool, error) {
+	items, _, err := c.load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if c.idOf(item) != excludedID && strings.EqualFold(c.nameOf(item), name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (c *referenceDataCache[T]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items, c.byID = nil, nil
+}
+
+func (c *referenceDataCache[T]) load(ctx monitor.ApplicationContext) ([]T, map[int]T, error) {
+	c.mu.RLock()
+	if c.byID != nil && time.Now().Before(c.expiresAt) {
+		items, byID := c.items, c.byID
+		c.mu.RUnlock()
+		return items, byID, nil
+	}
+	c.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine may have loaded it while waiting for the lock
+	if c.byID != nil && time.Now().Before(c.expiresAt) {
+		return c.items, c.byID, nil
+	}
+
+	if err := c.fetchLocked(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return c.items, c.byID, nil
+}
+
+// reloadOnMiss loads the copy again unless it was loaded within the miss reload interval, concurrent misses wait for
+// the lock and reuse the copy the first one loaded
+func (c *referenceDataCache[T]) reloadOnMiss(ctx monitor.ApplicationContext) (map[int]T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.byID != nil && time.Since(c.loadedAt) < ReferenceDataMissReloadInterval {
+		return c.byID, nil
+	}
+
+	if err := c.fetchLocked(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.byID, nil
+}
+
+// fetchLocked replaces the copy, the caller must hold the write lock
+func (c *referenceDataCache[T]) fetchLocked(ctx monitor.ApplicationContext) error {
+	items, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int]T, len(items))
+	for _, item := range items {
+		byID[c.idOf(item)] = item
+	}
+
+	now := time.Now()
+	c.items, c.byID, c.loadedAt, c.expiresAt = items, byID, now, now.Add(c.ttl)
+
+	return nil
+}
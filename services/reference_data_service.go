@@ -0,0 +1,464 @@
+package services
+
+import (
+	"fmt"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+const (
+	DefaultReferenceDataCacheTTLSeconds = 300
+)
+
+type ReferenceDataService struct {
+	logger           monitor.AppLogger
+	dbFactory        repositories.DatabaseFactory
+	suppliers        *referenceDataCache[domain.Supplier]
+	locationTypes    *referenceDataCache[domain.LocationType]
+	subLocationTypes *referenceDataCache[domain.SubLocationType]
+}
+
+func NewReferenceDataService(dbFactory repositories.DatabaseFactory, cfg config.ReferenceDataConfig) *ReferenceDataService {
+	s := &ReferenceDataService{
+		logger:    monitor.GetStdLogger("ReferenceDataService"),
+		dbFactory: dbFactory,
+	}
+
+	ttl := time.Duration(config.GetIntValueOrDefault(cfg.CacheTTLSeconds, DefaultReferenceDataCacheTTLSeconds)) * time.Second
+
+	s.suppliers = newReferenceDataCache(
+		ttl,
+		func(supplier domain.Supplier) int { return supplier.ID },
+		func(supplier domain.Supplier) string { return supplier.Name },
+		func(ctx monitor.ApplicationContext) ([]domain.Supplier, error) {
+			db, err := s.dbFactory.GetReferenceDataDB()
+			if err != nil {
+				return nil, err
+			}
+			return db.GetSuppliers(ctx)
+		},
+	)
+	s.locationTypes = newReferenceDataCache(
+		ttl,
+		func(locationType domain.LocationType) int { return locationType.ID },
+		func(locationType domain.LocationType) string { return locationType.Type },
+		func(ctx monitor.ApplicationContext) ([]domain.LocationType, error) {
+			db, err := s.dbFactory.GetReferenceDataDB()
+			if err != nil {
+				return nil, err
+			}
+			return db.GetLocationTypes(ctx)
+		},
+	)
+	s.subLocationTypes = newReferenceDataCache(
+		ttl,
+		func(subLocationType domain.SubLocationType) int { return subLocationType.ID },
+		func(subLocationType domain.SubLocationType) string { return subLocationType.Type },
+		func(ctx monitor.ApplicationContext) ([]domain.SubLocationType, error) {
+			db, err := s.dbFactory.GetReferenceDataDB()
+			if err != nil {
+				return nil, err
+			}
+			return db.GetSubLocationTypes(ctx)
+		},
+	)
+
+	return s
+}
+
+func (s *ReferenceDataService) GetSuppliers(ctx monitor.ApplicationContext) ([]domain.Supplier, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataService.GetSuppliers")
+	defer span.End()
+
+	return s.suppliers.all(ctx)
+}
+
+func (s *ReferenceDataService) GetSupplierByID(ctx monitor.ApplicationContext, id int) (domain.Supplier, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataService.GetSupplierByID", trace.WithAttributes(attribute.Int("supplier_id", id)))
+	defer span.End()
+
+	supplier, found, err := s.suppliers.find(ctx, id)
+	if err != nil {
+		return supplier, err
+	}
+	if !found {
+		return supplier, domain.UnknownReferenceErr{Msg: fmt.Sprintf("supplier with ID %v does not exist", id), Resource: domain.ResourceSupplier}
+	}
+
+	return supplier, nil
+}
+
+func (s *ReferenceDataService) CreateSupplier(ctx monitor.ApplicationContext, data dto.SupplierRequest) (domain.Supplier, error) {
+	fnName := "ReferenceDataService.CreateSupplier"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	supplier := domain.Supplier{Name: data.Name}
+
+	if err := checkReferenceNameInUse(ctx, s.suppliers, domain.ResourceSupplier, data.Name, 0); err != nil {
+		return supplier, err
+	}
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return supplier, err
+	}
+
+	supplier.ID, err = db.CreateSupplier(ctx, supplier)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to create supplier", err)
+		return supplier, err
+	}
+
+	s.suppliers.invalidate()
+
+	return supplier, nil
+}
+
+func (s *ReferenceDataService) UpdateSupplier(ctx monitor.ApplicationContext, id int, data dto.SupplierRequest) (domain.Supplier, error) {
+	fnName := "ReferenceDataService.UpdateSupplier"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.Int("supplier_id", id)))
+	defer span.End()
+
+	supplier := domain.Supplier{ID: id, Name: data.Name}
+
+	if err := checkReferenceNameInUse(ctx, s.suppliers, domain.ResourceSupplier, data.Name, id); err != nil {
+		return supplier, err
+	}
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return supplier, err
+	}
+
+	updated, err := db.UpdateSupplier(ctx, supplier)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to update supplier", err)
+		return supplier, err
+	}
+	if !updated {
+		return supplier, domain.UnknownReferenceErr{Msg: fmt.Sprintf("supplier with ID %v does not exist", id), Resource: domain.ResourceSupplier}
+	}
+
+	s.suppliers.invalidate()
+
+	return supplier, nil
+}
+
+func (s *ReferenceDataService) DeleteSupplier(ctx monitor.ApplicationContext, id int) error {
+	fnName := "ReferenceDataService.DeleteSupplier"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.Int("supplier_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return err
+	}
+
+	deleted, err := db.DeleteSupplier(ctx, id)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to delete supplier", err)
+		return err
+	}
+
+	s.suppliers.invalidate()
+
+	if !deleted {
+		return explainNotDeletedReference(ctx, s.suppliers, domain.ResourceSupplier, id)
+	}
+
+	return nil
+}
+
+func (s *ReferenceDataService) GetLocationTypes(ctx monitor.ApplicationContext) ([]domain.LocationType, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataService.GetLocationTypes")
+	defer span.End()
+
+	return s.locationTypes.all(ctx)
+}
+
+func (s *ReferenceDataService) GetLocationTypeByID(ctx monitor.ApplicationContext, id int) (domain.LocationType, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataService.GetLocationTypeByID", trace.WithAttributes(attribute.Int("location_type_id", id)))
+	defer span.End()
+
+	locationType, found, err := s.locationTypes.find(ctx, id)
+	if err != nil {
+		return locationType, err
+	}
+	if !found {
+		return locationType, domain.UnknownReferenceErr{Msg: fmt.Sprintf("location type with ID %v does not exist", id), Resource: domain.ResourceLocationType}
+	}
+
+	return locationType, nil
+}
+
+func (s *ReferenceDataService) CreateLocationType(ctx monitor.ApplicationContext, data dto.LocationTypeRequest) (domain.LocationType, error) {
+	fnName := "ReferenceDataService.CreateLocationType"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	locationType := domain.LocationType{Type: data.Type}
+
+	if err := checkReferenceNameInUse(ctx, s.locationTypes, domain.ResourceLocationType, data.Type, 0); err != nil {
+		return locationType, err
+	}
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return locationType, err
+	}
+
+	locationType.ID, err = db.CreateLocationType(ctx, locationType)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to create location type", err)
+		return locationType, err
+	}
+
+	s.locationTypes.invalidate()
+
+	return locationType, nil
+}
+
+func (s *ReferenceDataService) UpdateLocationType(ctx monitor.ApplicationContext, id int, data dto.LocationTypeRequest) (domain.LocationType, error) {
+	fnName := "ReferenceDataService.UpdateLocationType"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.Int("location_type_id", id)))
+	defer span.End()
+
+	locationType := domain.LocationType{ID: id, Type: data.Type}
+
+	if err := checkReferenceNameInUse(ctx, s.locationTypes, domain.ResourceLocationType, data.Type, id); err != nil {
+		return locationType, err
+	}
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return locationType, err
+	}
+
+	updated, err := db.UpdateLocationType(ctx, locationType)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to update location type", err)
+		return locationType, err
+	}
+	if !updated {
+		return locationType, domain.UnknownReferenceErr{Msg: fmt.Sprintf("location type with ID %v does not exist", id), Resource: domain.ResourceLocationType}
+	}
+
+	s.locationTypes.invalidate()
+
+	return locationType, nil
+}
+
+func (s *ReferenceDataService) DeleteLocationType(ctx monitor.ApplicationContext, id int) error {
+	fnName := "ReferenceDataService.DeleteLocationType"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.Int("location_type_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return err
+	}
+
+	deleted, err := db.DeleteLocationType(ctx, id)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to delete location type", err)
+		return err
+	}
+
+	s.locationTypes.invalidate()
+
+	if !deleted {
+		return explainNotDeletedReference(ctx, s.locationTypes, domain.ResourceLocationType, id)
+	}
+
+	return nil
+}
+
+func (s *ReferenceDataService) GetSubLocationTypes(ctx monitor.ApplicationContext) ([]domain.SubLocationType, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataService.GetSubLocationTypes")
+	defer span.End()
+
+	return s.subLocationTypes.all(ctx)
+}
+
+func (s *ReferenceDataService) GetSubLocationTypeByID(ctx monitor.ApplicationContext, id int) (domain.SubLocationType, error) {
+	ctx, span := ctx.StartSpan("ReferenceDataService.GetSubLocationTypeByID", trace.WithAttributes(attribute.Int("sub_location_type_id", id)))
+	defer span.End()
+
+	subLocationType, found, err := s.subLocationTypes.find(ctx, id)
+	if err != nil {
+		return subLocationType, err
+	}
+	if !found {
+		return subLocationType, domain.UnknownReferenceErr{Msg: fmt.Sprintf("sub location type with ID %v does not exist", id), Resource: domain.ResourceSubLocationType}
+	}
+
+	return subLocationType, nil
+}
+
+func (s *ReferenceDataService) CreateSubLocationType(ctx monitor.ApplicationContext, data dto.SubLocationTypeRequest) (domain.SubLocationType, error) {
+	fnName := "ReferenceDataService.CreateSubLocationType"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	subLocationType := domain.SubLocationType{Type: data.Type}
+
+	if err := checkReferenceNameInUse(ctx, s.subLocationTypes, domain.ResourceSubLocationType, data.Type, 0); err != nil {
+		return subLocationType, err
+	}
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return subLocationType, err
+	}
+
+	subLocationType.ID, err = db.CreateSubLocationType(ctx, subLocationType)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to create sub location type", err)
+		return subLocationType, err
+	}
+
+	s.subLocationTypes.invalidate()
+
+	return subLocationType, nil
+}
+
+func (s *ReferenceDataService) UpdateSubLocationType(
+	ctx monitor.ApplicationContext,
+	id int,
+	data dto.SubLocationTypeRequest,
+) (domain.SubLocationType, error) {
+	fnName := "ReferenceDataService.UpdateSubLocationType"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.Int("sub_location_type_id", id)))
+	defer span.End()
+
+	subLocationType := domain.SubLocationType{ID: id, Type: data.Type}
+
+	if err := checkReferenceNameInUse(ctx, s.subLocationTypes, domain.ResourceSubLocationType, data.Type, id); err != nil {
+		return subLocationType, err
+	}
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return subLocationType, err
+	}
+
+	updated, err := db.UpdateSubLocationType(ctx, subLocationType)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to update sub location type", err)
+		return subLocationType, err
+	}
+	if !updated {
+		return subLocationType, domain.UnknownReferenceErr{Msg: fmt.Sprintf("sub location type with ID %v does not exist", id), Resource: domain.ResourceSubLocationType}
+	}
+
+	s.subLocationTypes.invalidate()
+
+	return subLocationType, nil
+}
+
+func (s *ReferenceDataService) DeleteSubLocationType(ctx monitor.ApplicationContext, id int) error {
+	fnName := "ReferenceDataService.DeleteSubLocationType"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.Int("sub_location_type_id", id)))
+	defer span.End()
+
+	// Every new location gets a default sub location of this type
+	if id == domain.DefaultSubLocationTypeID {
+		return domain.BusinessErr{Msg: "the default sub location type cannot be deleted", Resource: domain.ResourceSubLocationType}
+	}
+
+	db, err := s.dbFactory.GetReferenceDataDB()
+	if err != nil {
+		return err
+	}
+
+	deleted, err := db.DeleteSubLocationType(ctx, id)
+	if err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "failed to delete sub location type", err)
+		return err
+	}
+
+	s.subLocationTypes.invalidate()
+
+	if !deleted {
+		return explainNotDeletedReference(ctx, s.subLocationTypes, domain.ResourceSubLocationType, id)
+	}
+
+	return nil
+}
+
+func checkReferenceNameInUse[T any](ctx monitor.ApplicationContext, cache *referenceDataCache[T], resource, name string, excludedID int) error {
+	nameInUse, err := cache.nameInUse(ctx, name, excludedID)
+	if err != nil {
+		return err
+	}
+	if nameInUse {
+		return domain.NameAlreadyInUseErr{
+			Msg:      fmt.Sprintf("%v '%v' is already in use", domain.DescribeResource(resource), name),
+			Resource: resource,
+		}
+	}
+
+	return nil
+}
+
+// explainNotDeletedReference tells apart a missing item from one that is still referenced, the delete statements
+// skip both without distinction
+func explainNotDeletedReference[T any](ctx monitor.ApplicationContext, cache *referenceDataCache[T], resource string, id int) error {
+	_, found, err := cache.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return domain.UnknownReferenceErr{
+			Msg:      fmt.Sprintf("%v with ID %v does not exist", domain.DescribeResource(resource), id),
+			Resource: resource,
+		}
+	}
+
+	return domain.BusinessErr{
+		Msg:      fmt.Sprintf("%v with ID %v is still in use and cannot be deleted", domain.DescribeResource(resource), id),
+		Resource: resource,
+	}
+}
+
+// getTenantSupplier returns the supplier with the ID. Callers restricted to a supplier can only reference their own,
+// the rest are reported as unknown so their existence is not disclosed.
+func getTenantSupplier(ctx monitor.ApplicationContext, referenceData IReferenceDataService, id int) (domain.Supplier, error) {
+	if tenantSupplierID, ok := ctx.GetTenantSupplierID(); ok && tenantSupplierID != id {
+		return domain.Supplier{}, domain.UnknownReferenceErr{Msg: fmt.Sprintf("supplier with ID %v does not exist", id), Resource: domain.ResourceSupplier}
+	}
+
+	return referenceData.GetSupplierByID(ctx, id)
+}
+
+// getTenantSupplierID returns the supplier a resource is restricted to, nil when it applies to every supplier. Callers
+// restricted to a supplier always get their own, the rest of the suppliers are checked by getTenantSupplier.
+func getTenantSupplierID(ctx monitor.ApplicationContext, referenceData IReferenceDataService, supplierID *int) (*int, error) {
+	if tenantSupplierID, ok := ctx.GetTenantSupplierID(); ok && (supplierID == nil || *supplierID == tenantSupplierID) {
+		return &tenantSupplierID, nil
+	}
+
+	if supplierID != nil {
+		if _, err := getTenantSupplier(ctx, referenceData, *supplierID); err != nil {
+			return nil, err
+		}
+	}
+
+	return supplierID, nil
+}
@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/mocks"
+	"go-service-template/services"
+	"testing"
+	"time"
+)
+
+var testSuppliers = []domain.Supplier{{ID: 1, Name: "Supplier 1"}, {ID: 2, Name: "Supplier 2"}}
+
+type ReferenceDataServiceSuite struct {
+	suite.Suite
+	dbFactoryMock       *mocks.DatabaseFactory
+	referenceDataDBMock *mocks.ReferenceDataDB
+	service             *services.ReferenceDataService
+}
+
+func (s *ReferenceDataServiceSuite) SetupTest() {
+	s.dbFactoryMock = new(mocks.DatabaseFactory)
+	s.referenceDataDBMock = new(mocks.ReferenceDataDB)
+
+	// A new service on each test starts with an empty cache
+	s.service = services.NewReferenceDataService(s.dbFactoryMock, config.ReferenceDataConfig{})
+
+	s.dbFactoryMock.On("GetReferenceDataDB").Return(s.referenceDataDBMock, nil)
+}
+
+func (s *ReferenceDataServiceSuite) assertAllExpectations() {
+	s.dbFactoryMock.AssertExpectations(s.T())
+	s.referenceDataDBMock.AssertExpectations(s.T())
+}
+
+func TestReferenceDataServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceDataServiceSuite))
+}
+
+func (s *ReferenceDataServiceSuite) Test_GetSupplierByID_LoadsTheCacheOnlyOnce() {
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+
+	first, err := s.service.GetSupplierByID(testCtx, 1)
+	assert.Nil(s.T(), err)
+	second, err := s.service.GetSupplierByID(testCtx, 2)
+	assert.Nil(s.T(), err)
+
+	assert.Equal(s.T(), "Supplier 1", first.Name)
+	assert.Equal(s.T(), "Supplier 2", second.Name)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_GetSupplierByID_FailsIfSupplierDoesNotExist() {
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+
+	_, err := s.service.GetSupplierByID(testCtx, 99)
+
+	assert.IsType(s.T(), domain.UnknownReferenceErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_GetSupplierByID_ReloadsTheCacheOnMiss() {
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(append(testSuppliers, domain.Supplier{ID: 3, Name: "Supplier 3"}), nil).Once()
+
+	_, err := s.service.GetSupplierByID(testCtx, 1)
+	assert.Nil(s.T(), err)
+
+	// The supplier is created by another instance once the copy can be loaded again
+	time.Sleep(services.ReferenceDataMissReloadInterval)
+	supplier, err := s.service.GetSupplierByID(testCtx, 3)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "Supplier 3", supplier.Name)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_CreateSupplier_InvalidatesTheCache() {
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+	s.referenceDataDBMock.On("CreateSupplier", mock.Anything, domain.Supplier{Name: "Supplier 3"}).Return(3, nil).Once()
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(append(testSuppliers, domain.Supplier{ID: 3, Name: "Supplier 3"}), nil).Once()
+
+	created, err := s.service.CreateSupplier(testCtx, dto.SupplierRequest{Name: "Supplier 3"})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 3, created.ID)
+
+	supplier, err := s.service.GetSupplierByID(testCtx, 3)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "Supplier 3", supplier.Name)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_CreateSupplier_FailsIfNameIsAlreadyInUse() {
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+
+	_, err := s.service.CreateSupplier(testCtx, dto.SupplierRequest{Name: "supplier 1"})
+
+	assert.IsType(s.T(), domain.NameAlreadyInUseErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_UpdateSupplier_FailsIfSupplierDoesNotExist() {
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+	s.referenceDataDBMock.On("UpdateSupplier", mock.Anything, domain.Supplier{ID: 99, Name: "New name"}).Return(false, nil).Once()
+
+	_, err := s.service.UpdateSupplier(testCtx, 99, dto.SupplierRequest{Name: "New name"})
+
+	assert.IsType(s.T(), domain.UnknownReferenceErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_DeleteSupplier_FailsIfSupplierIsInUse() {
+	s.referenceDataDBMock.On("DeleteSupplier", mock.Anything, 1).Return(false, nil).Once()
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+
+	err := s.service.DeleteSupplier(testCtx, 1)
+
+	assert.IsType(s.T(), domain.BusinessErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_DeleteSupplier_FailsIfSupplierDoesNotExist() {
+	s.referenceDataDBMock.On("DeleteSupplier", mock.Anything, 99).Return(false, nil).Once()
+	s.referenceDataDBMock.On("GetSuppliers", mock.Anything).Return(testSuppliers, nil).Once()
+
+	err := s.service.DeleteSupplier(testCtx, 99)
+
+	assert.IsType(s.T(), domain.UnknownReferenceErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_GetLocationTypeByID_Success() {
+	s.referenceDataDBMock.On("GetLocationTypes", mock.Anything).Return([]domain.LocationType{{ID: 1, Type: "Recon"}}, nil).Once()
+
+	locationType, err := s.service.GetLocationTypeByID(testCtx, 1)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "Recon", locationType.Type)
+	s.assertAllExpectations()
+}
+
+func (s *ReferenceDataServiceSuite) Test_DeleteSubLocationType_FailsForDefaultType() {
+	err := s.service.DeleteSubLocationType(testCtx, domain.DefaultSubLocationTypeID)
+
+	assert.IsType(s.T(), domain.BusinessErr{}, err)
+	s.referenceDataDBMock.AssertExpectations(s.T())
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/monitor"
+	"go-service-template/pubsub"
+	"go-service-template/repositories"
+	"go-service-template/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+func (s *LocationService) CreateSubLocation(
+	ctx monitor.ApplicationContext,
+	locationID string,
+	newSubLocationData dto.CreateSubLocationRequest,
+) (subLocation domain.SubLocation, err error) {
+	fnName := "LocationService.CreateSubLocation"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(
+		attribute.String("location_id", locationID),
+		attribute.String("new_sub_location_data", utils.ToJSON(newSubLocationData)),
+	))
+	defer span.End()
+
+	subLocationType, err := s.referenceData.GetSubLocationTypeByID(ctx, newSubLocationData.SubLocationTypeID)
+	if err != nil {
+		return subLocation, err
+	}
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return subLocation, err
+	}
+
+	var createdSubLocation domain.SubLocation
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		// Retrieve the parent location, this also locks it until the sub location is created
+		if txErr := s.checkParentLocation(ctx, db, locationID); txErr != nil {
+			return txErr
+		}
+
+		if txErr := s.validateSubLocationName(ctx, db, locationID, newSubLocationData.Name); txErr != nil {
+			return txErr
+		}
+
+		newSubLocation := domain.SubLocation{
+			ID:              uuid.New().String(),
+			Name:            newSubLocationData.Name,
+			SubLocationType: subLocationType,
+			Active:          true,
+			LocationID:      locationID,
+		}
+
+		if txErr := db.CreateSubLocation(ctx, newSubLocation); txErr != nil {
+			return fmt.Errorf("error creating new sub location: %w", txErr)
+		}
+
+		createdSubLocation = newSubLocation
+
+		return s.storeSubLocationEvent(ctx, db, domain.SubLocationsNewTopic, createdSubLocation)
+	}); err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
+		return subLocation, err
+	}
+
+	return createdSubLocation, nil
+}
+
+func (s *LocationService) RenameSubLocation(
+	ctx monitor.ApplicationContext,
+	locationID, subLocationID string,
+	renameData dto.RenameSubLocationRequest,
+) (subLocation domain.SubLocation, err error) {
+	fnName := "LocationService.RenameSubLocation"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(
+		attribute.String("location_id", locationID),
+		attribute.String("sub_location_id", subLocationID),
+		attribute.String("rename_data", utils.ToJSON(renameData)),
+	))
+	defer span.End()
+
+	return s.modifySubLocation(ctx, fnName, locationID, subLocationID, func(ctx monitor.ApplicationContext, db repositories.LocationsDB, existing *domain.SubLocation) (bool, error) {
+		if existing.Name == renameData.Name {
+			return false, nil
+		}
+
+		// A case change keeps the same name for the unique index
+		if !strings.EqualFold(existing.Name, renameData.Name) {
+			if err := s.validateSubLocationName(ctx, db, locationID, renameData.Name); err != nil {
+				return false, err
+			}
+		}
+
+		existing.Name = renameData.Name
+
+		return true, nil
+	})
+}
+
+func (s *LocationService) DeactivateSubLocation(ctx monitor.ApplicationContext, locationID, subLocationID string) (subLocation domain.SubLocation, err error) {
+	fnName := "LocationService.DeactivateSubLocation"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(
+		attribute.String("location_id", locationID),
+		attribute.String("sub_location_id", subLocationID),
+	))
+	defer span.End()
+
+	return s.modifySubLocation(ctx, fnName, locationID, subLocationID, func(_ monitor.ApplicationContext, _ repositories.LocationsDB, existing *domain.SubLocation) (bool, error) {
+		if !existing.Active {
+			return false, nil
+		}
+
+		existing.Active = false
+
+		return true, nil
+	})
+}
+
+func (s *LocationService) GetSubLocationByID(ctx monitor.ApplicationContext, locationID, subLocationID string) (*domain.SubLocation, error) {
+	fnName := "LocationService.GetSubLocationByID"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(
+		attribute.String("location_id", locationID),
+		attribute.String("sub_location_id", subLocationID),
+	))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkParentLocation(ctx, db, locationID); err != nil {
+		return nil, err
+	}
+
+	return db.GetSubLocationByID(ctx, locationID, subLocationID)
+}
+
+func (s *LocationService) GetPaginatedSubLocations(
+	ctx monitor.ApplicationContext,
+	filters domain.SubLocationsFilters,
+) (page domain.CursorPage[domain.SubLocation], err error) {
+	fnName := "LocationService.GetPaginatedSubLocations"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("filters", utils.ToJSON(filters))))
+	defer span.End()
+
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return page, err
+	}
+
+	if err = s.checkParentLocation(ctx, db, filters.LocationID); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return page, err
+	}
+
+	page, err = db.GetPaginatedSubLocations(ctx, filters)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to retrieve paginated sub locations", err)
+		return page, err
+	}
+
+	return page, nil
+}
+
+// modifySubLocation runs the modification inside a transaction. The modifier returns false when the sub location
+// already has the requested state, in which case nothing is stored and no event is published.
+func (s *LocationService) modifySubLocation(
+	ctx monitor.ApplicationContext,
+	fnName, locationID, subLocationID string,
+	modifier func(ctx monitor.ApplicationContext, db repositories.LocationsDB, existing *domain.SubLocation) (bool, error),
+) (subLocation domain.SubLocation, err error) {
+	db, err := s.dbFactory.GetLocationsDB()
+	if err != nil {
+		return subLocation, err
+	}
+
+	var existingSubLocation *domain.SubLocation
+
+	if err = db.WithTx(ctx, func(ctx monitor.ApplicationContext) error {
+		// The parent location stays locked until the change is stored, so it cannot be deleted meanwhile
+		txErr := s.checkParentLocation(ctx, db, locationID)
+		if txErr != nil {
+			return txErr
+		}
+
+		existingSubLocation, txErr = db.GetSubLocationByID(ctx, locationID, subLocationID)
+		if txErr != nil {
+			return fmt.Errorf("error finding sub location with ID %v: %w", subLocationID, txErr)
+		}
+		if existingSubLocation == nil {
+			return domain.NotFoundErr{Msg: fmt.Sprintf("sub location with ID %v does not exist in location %v", subLocationID, locationID), Resource: domain.ResourceSubLocation}
+		}
+		if existingSubLocation.Name == DefaultSubLocationName {
+			return domain.BusinessErr{Msg: "the default sub location cannot be modified", Resource: domain.ResourceSubLocation}
+		}
+
+		changed, txErr := modifier(ctx, db, existingSubLocation)
+		if txErr != nil || !changed {
+			return txErr
+		}
+
+		if txErr = db.UpdateSubLocation(ctx, *existingSubLocation); txErr != nil {
+			return fmt.Errorf("error updating sub location: %w", txErr)
+		}
+
+		return s.storeSubLocationEvent(ctx, db, domain.SubLocationsUpdatedTopic, *existingSubLocation)
+	}); err != nil {
+		s.logger.ErrorCtx(ctx, fnName, "tx failed", err)
+		return subLocation, err
+	}
+
+	return *existingSubLocation, nil
+}
+
+// checkParentLocation returns a NotFoundErr when the location does not exist or is deleted, the sub locations of a
+// deleted location cannot be read nor changed
+func (s *LocationService) checkParentLocation(ctx monitor.ApplicationContext, db repositories.LocationsDB, locationID string) error {
+	location, err := db.GetLocationByID(ctx, locationID)
+	if err != nil {
+		return fmt.Errorf("error finding location with ID %v: %w", locationID, err)
+	}
+	if location == nil || location.IsDeleted() {
+		return domain.NotFoundErr{Msg: fmt.Sprintf("location with ID %v does not exist", locationID), Resource: domain.ResourceLocation}
+	}
+
+	return nil
+}
+
+func (s *LocationService) validateSubLocationName(ctx monitor.ApplicationContext, db repositories.LocationsDB, locationID, name string) error {
+	if strings.EqualFold(name, DefaultSubLocationName) {
+		return domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("sub location name '%v' is reserved", name), Resource: domain.ResourceSubLocation}
+	}
+
+	nameInUse, err := db.CheckSubLocationNameExistence(ctx, locationID, name)
+	if err != nil {
+		return err
+	}
+	if nameInUse {
+		return domain.NameAlreadyInUseErr{Msg: fmt.Sprintf("sub location name '%v' is already in use", name), Resource: domain.ResourceSubLocation}
+	}
+
+	return nil
+}
+
+// storeSubLocationEvent keys sub location events by their location, so they keep their order relative to the
+// events of the location itself
+func (s *LocationService) storeSubLocationEvent(ctx monitor.ApplicationContext, db repositories.LocationsDB, topic string, subLocation domain.SubLocation) error {
+	outboxMsg, err := pubsub.CreateJSONOutboxMessage(ctx, topic, subLocation.LocationID, subLocation)
+	if err != nil {
+		return err
+	}
+
+	return db.CreateOutboxMessage(ctx, outboxMsg)
+}
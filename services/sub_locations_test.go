@@ -0,0 +1,219 @@
+package services_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	"go-service-template/services"
+	"time"
+)
+
+func (s *LocationServiceSuite) Test_CreateSubLocation_Success() {
+	locationID := uuid.New().String()
+	request := dto.CreateSubLocationRequest{Name: "Storage", SubLocationTypeID: 1}
+
+	s.referenceDataMock.On("GetSubLocationTypeByID", mock.Anything, 1).Return(domain.SubLocationType{ID: 1, Type: "Zone"}, nil).Once()
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID}, nil).Once()
+	s.locationsDBMock.On("CheckSubLocationNameExistence", mock.Anything, locationID, request.Name).Return(false, nil).Once()
+
+	var newSubLocationID string
+	s.locationsDBMock.On("CreateSubLocation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		subLocation := args.Get(1).(domain.SubLocation)
+		assert.Equal(s.T(), request.Name, subLocation.Name)
+		assert.Equal(s.T(), locationID, subLocation.LocationID)
+		assert.True(s.T(), subLocation.Active)
+		newSubLocationID = subLocation.ID
+	}).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		outboxMsg := args.Get(1).(domain.OutboxMessage)
+		assert.Equal(s.T(), domain.SubLocationsNewTopic, outboxMsg.Topic)
+		assert.Equal(s.T(), locationID, outboxMsg.AggregateID)
+	}).Return(nil).Once()
+
+	subLocation, err := s.locationService.CreateSubLocation(testCtx, locationID, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), newSubLocationID, subLocation.ID)
+	assert.Equal(s.T(), request.Name, subLocation.Name)
+	assert.Equal(s.T(), "Zone", subLocation.SubLocationType.Type)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_CreateSubLocation_FailsIfSubLocationTypeDoesNotExist() {
+	s.referenceDataMock.On("GetSubLocationTypeByID", mock.Anything, 99).
+		Return(domain.SubLocationType{}, domain.UnknownReferenceErr{Msg: "unknown sub location type"}).Once()
+
+	_, err := s.locationService.CreateSubLocation(testCtx, uuid.New().String(), dto.CreateSubLocationRequest{Name: "Storage", SubLocationTypeID: 99})
+
+	assert.IsType(s.T(), domain.UnknownReferenceErr{}, err)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_CreateSubLocation_FailsIfLocationDoesNotExist() {
+	locationID := uuid.New().String()
+
+	s.referenceDataMock.On("GetSubLocationTypeByID", mock.Anything, 1).Return(domain.SubLocationType{ID: 1, Type: "Zone"}, nil).Once()
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(nil, nil).Once()
+
+	_, err := s.locationService.CreateSubLocation(testCtx, locationID, dto.CreateSubLocationRequest{Name: "Storage", SubLocationTypeID: 1})
+
+	assert.ErrorAs(s.T(), err, &domain.NotFoundErr{})
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_CreateSubLocation_FailsIfNameIsAlreadyInUse() {
+	locationID := uuid.New().String()
+
+	s.referenceDataMock.On("GetSubLocationTypeByID", mock.Anything, 1).Return(domain.SubLocationType{ID: 1, Type: "Zone"}, nil).Once()
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID}, nil).Once()
+	s.locationsDBMock.On("CheckSubLocationNameExistence", mock.Anything, locationID, "Storage").Return(true, nil).Once()
+
+	_, err := s.locationService.CreateSubLocation(testCtx, locationID, dto.CreateSubLocationRequest{Name: "Storage", SubLocationTypeID: 1})
+
+	assert.ErrorAs(s.T(), err, &domain.NameAlreadyInUseErr{})
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_CreateSubLocation_FailsIfNameIsReserved() {
+	locationID := uuid.New().String()
+
+	s.referenceDataMock.On("GetSubLocationTypeByID", mock.Anything, 1).Return(domain.SubLocationType{ID: 1, Type: "Zone"}, nil).Once()
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID}, nil).Once()
+
+	_, err := s.locationService.CreateSubLocation(testCtx, locationID, dto.CreateSubLocationRequest{Name: "default", SubLocationTypeID: 1})
+
+	assert.ErrorAs(s.T(), err, &domain.NameAlreadyInUseErr{})
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_RenameSubLocation_Success() {
+	existing := domain.SubLocation{ID: uuid.New().String(), Name: "Storage", LocationID: uuid.New().String(), Active: true}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existing.LocationID).Return(&domain.Location{ID: existing.LocationID}, nil).Once()
+	s.locationsDBMock.On("GetSubLocationByID", mock.Anything, existing.LocationID, existing.ID).Return(&existing, nil).Once()
+	s.locationsDBMock.On("CheckSubLocationNameExistence", mock.Anything, existing.LocationID, "Backroom").Return(false, nil).Once()
+	s.locationsDBMock.On("UpdateSubLocation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		subLocation := args.Get(1).(domain.SubLocation)
+		assert.Equal(s.T(), "Backroom", subLocation.Name)
+	}).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		outboxMsg := args.Get(1).(domain.OutboxMessage)
+		assert.Equal(s.T(), domain.SubLocationsUpdatedTopic, outboxMsg.Topic)
+	}).Return(nil).Once()
+
+	subLocation, err := s.locationService.RenameSubLocation(testCtx, existing.LocationID, existing.ID, dto.RenameSubLocationRequest{Name: "Backroom"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "Backroom", subLocation.Name)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_RenameSubLocation_FailsIfSubLocationIsDefault() {
+	existing := domain.SubLocation{ID: uuid.New().String(), Name: services.DefaultSubLocationName, LocationID: uuid.New().String(), Active: true}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existing.LocationID).Return(&domain.Location{ID: existing.LocationID}, nil).Once()
+	s.locationsDBMock.On("GetSubLocationByID", mock.Anything, existing.LocationID, existing.ID).Return(&existing, nil).Once()
+
+	_, err := s.locationService.RenameSubLocation(testCtx, existing.LocationID, existing.ID, dto.RenameSubLocationRequest{Name: "Backroom"})
+
+	assert.ErrorAs(s.T(), err, &domain.BusinessErr{})
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_RenameSubLocation_FailsIfLocationIsDeleted() {
+	deletedAt := time.Now()
+	existing := domain.SubLocation{ID: uuid.New().String(), Name: "Storage", LocationID: uuid.New().String(), Active: true}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("RollbackTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existing.LocationID).Return(&domain.Location{ID: existing.LocationID, DeletedAt: &deletedAt}, nil).Once()
+
+	_, err := s.locationService.RenameSubLocation(testCtx, existing.LocationID, existing.ID, dto.RenameSubLocationRequest{Name: "Backroom"})
+
+	assert.ErrorAs(s.T(), err, &domain.NotFoundErr{})
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_DeactivateSubLocation_Success() {
+	existing := domain.SubLocation{ID: uuid.New().String(), Name: "Storage", LocationID: uuid.New().String(), Active: true}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existing.LocationID).Return(&domain.Location{ID: existing.LocationID}, nil).Once()
+	s.locationsDBMock.On("GetSubLocationByID", mock.Anything, existing.LocationID, existing.ID).Return(&existing, nil).Once()
+	s.locationsDBMock.On("UpdateSubLocation", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		subLocation := args.Get(1).(domain.SubLocation)
+		assert.False(s.T(), subLocation.Active)
+	}).Return(nil).Once()
+	s.locationsDBMock.On("CreateOutboxMessage", mock.Anything, mock.Anything).Return(nil).Once()
+
+	subLocation, err := s.locationService.DeactivateSubLocation(testCtx, existing.LocationID, existing.ID)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), subLocation.Active)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_DeactivateSubLocation_DoesNothingIfAlreadyInactive() {
+	existing := domain.SubLocation{ID: uuid.New().String(), Name: "Storage", LocationID: uuid.New().String(), Active: false}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("StartTx", mock.Anything).Return(nil).Once()
+	s.locationsDBMock.On("CommitTx").Return(nil).Once()
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, existing.LocationID).Return(&domain.Location{ID: existing.LocationID}, nil).Once()
+	s.locationsDBMock.On("GetSubLocationByID", mock.Anything, existing.LocationID, existing.ID).Return(&existing, nil).Once()
+
+	subLocation, err := s.locationService.DeactivateSubLocation(testCtx, existing.LocationID, existing.ID)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), subLocation.Active)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_GetSubLocationByID_FailsIfLocationIsDeleted() {
+	deletedAt := time.Now()
+	locationID := uuid.New().String()
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, locationID).Return(&domain.Location{ID: locationID, DeletedAt: &deletedAt}, nil).Once()
+
+	subLocation, err := s.locationService.GetSubLocationByID(testCtx, locationID, uuid.New().String())
+
+	assert.ErrorAs(s.T(), err, &domain.NotFoundErr{})
+	assert.Nil(s.T(), subLocation)
+	s.assertAllExpectations()
+}
+
+func (s *LocationServiceSuite) Test_GetPaginatedSubLocations_FailsIfLocationDoesNotExist() {
+	filters := domain.SubLocationsFilters{LocationID: uuid.New().String()}
+
+	s.dbFactoryMock.On("GetLocationsDB").Return(s.locationsDBMock, nil)
+	s.locationsDBMock.On("GetLocationByID", mock.Anything, filters.LocationID).Return(nil, nil).Once()
+
+	_, err := s.locationService.GetPaginatedSubLocations(testCtx, filters)
+
+	assert.ErrorAs(s.T(), err, &domain.NotFoundErr{})
+	s.assertAllExpectations()
+}
@@ -0,0 +1,607 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/monitor"
+	"go-service-template/repositories"
+	"go-service-template/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultWebhookMaxAttempts      = 5
+	DefaultWebhookInitialBackoffMs = 1000
+	DefaultWebhookMaxBackoffMs     = 30000
+	DefaultWebhookRetryIntervalMs  = 5000
+	WebhookDeliveriesLimit         = 100
+	WebhookRetriesBatchSize        = 50
+
+	// webhookRetryClaimTimeout is how long a claimed retry is skipped by the other instances, it outlasts the sending
+	// of a batch so a retry is only sent again when the instance that claimed it stopped
+	webhookRetryClaimTimeout = 5 * time.Minute
+
+	// Headers of the webhook requests, the signature is computed over "<timestamp>.<body>" so a captured request
+	// cannot be replayed with another timestamp
+	WebhookIDHeader        = "X-Webhook-Id"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	webhookSignaturePrefix = "sha256="
+)
+
+// WebhookService manages the webhook subscriptions and delivers the location events to them. A delivery is attempted
+// once when the event is handled, the failed ones are written to the delivery log and retried in the background with
+// exponential backoff until they run out of attempts, then they can be redelivered on request.
+type WebhookService struct {
+	logger            monitor.AppLogger
+	dbFactory         repositories.DatabaseFactory
+	referenceData     IReferenceDataService
+	httpClient        customHTTP.CustomHTTPClient
+	maxAttempts       int
+	initialBackoff    time.Duration
+	maxBackoff        time.Duration
+	retryInterval     time.Duration
+	allowLocalTargets bool
+}
+
+// webhookAttempt is the outcome of sending an event once, the status is nil when no response was received
+type webhookAttempt struct {
+	responseStatus *int
+	err            error
+}
+
+func NewWebhookService(
+	dbFactory repositories.DatabaseFactory,
+	referenceData IReferenceDataService,
+	httpClient customHTTP.CustomHTTPClient,
+	cfg config.WebhookConfig,
+) *WebhookService {
+	initialBackoffMs := config.GetIntValueOrDefault(cfg.InitialBackoffMs, DefaultWebhookInitialBackoffMs)
+	maxBackoffMs := config.GetIntValueOrDefault(cfg.MaxBackoffMs, DefaultWebhookMaxBackoffMs)
+	retryIntervalMs := config.GetIntValueOrDefault(cfg.RetryIntervalMs, DefaultWebhookRetryIntervalMs)
+
+	return &WebhookService{
+		logger:            monitor.GetStdLogger("WebhookService"),
+		dbFactory:         dbFactory,
+		referenceData:     referenceData,
+		httpClient:        httpClient,
+		maxAttempts:       config.GetIntValueOrDefault(cfg.MaxAttempts, DefaultWebhookMaxAttempts),
+		initialBackoff:    time.Duration(initialBackoffMs) * time.Millisecond,
+		maxBackoff:        time.Duration(maxBackoffMs) * time.Millisecond,
+		retryInterval:     time.Duration(retryIntervalMs) * time.Millisecond,
+		allowLocalTargets: cfg.AllowLocalTargets,
+	}
+}
+
+// SignWebhookPayload returns the signature header of a webhook request, receivers compute it with their secret to
+// check the request was sent by this service
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) CreateWebhook(ctx monitor.ApplicationContext, data dto.CreateWebhookRequest) (domain.WebhookSubscription, error) {
+	fnName := "WebhookService.CreateWebhook"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	if err := s.checkWebhookURL(data.URL); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	supplierID, err := getTenantSupplierID(ctx, s.referenceData, data.SupplierID)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	now := time.Now().UTC()
+	subscription := domain.WebhookSubscription{
+		ID:         uuid.New().String(),
+		URL:        data.URL,
+		EventTypes: data.EventTypes,
+		Secret:     data.Secret,
+		SupplierID: supplierID,
+		Status:     data.Status,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if subscription.Status == "" {
+		subscription.Status = domain.WebhookStatusActive
+	}
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.WebhookSubscription{}, err
+	}
+
+	if err = db.CreateWebhookSubscription(ctx, subscription); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to create webhook subscription", err)
+		return domain.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (s *WebhookService) GetWebhooks(ctx monitor.ApplicationContext) ([]domain.WebhookSubscription, error) {
+	ctx, span := ctx.StartSpan("WebhookService.GetWebhooks")
+	defer span.End()
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetWebhookSubscriptions(ctx)
+}
+
+func (s *WebhookService) GetWebhookByID(ctx monitor.ApplicationContext, id string) (domain.WebhookSubscription, error) {
+	ctx, span := ctx.StartSpan("WebhookService.GetWebhookByID", trace.WithAttributes(attribute.String("webhook_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	subscription, err := db.GetWebhookSubscriptionByID(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.WebhookSubscription{}, err
+	}
+	if subscription == nil {
+		return domain.WebhookSubscription{}, domain.NotFoundErr{Msg: fmt.Sprintf("webhook with ID %v does not exist", id), Resource: domain.ResourceWebhook}
+	}
+
+	return *subscription, nil
+}
+
+// UpdateWebhook replaces the subscription, the secret is kept when no new one is sent
+func (s *WebhookService) UpdateWebhook(
+	ctx monitor.ApplicationContext,
+	id string,
+	data dto.UpdateWebhookRequest,
+) (domain.WebhookSubscription, error) {
+	fnName := "WebhookService.UpdateWebhook"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("webhook_id", id)))
+	defer span.End()
+
+	subscription, err := s.GetWebhookByID(ctx, id)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	if err = s.checkWebhookURL(data.URL); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	if subscription.SupplierID, err = getTenantSupplierID(ctx, s.referenceData, data.SupplierID); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	subscription.URL = data.URL
+	subscription.EventTypes = data.EventTypes
+	subscription.Status = data.Status
+	subscription.UpdatedAt = time.Now().UTC()
+	if data.Secret != "" {
+		subscription.Secret = data.Secret
+	}
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.WebhookSubscription{}, err
+	}
+
+	updated, err := db.UpdateWebhookSubscription(ctx, subscription)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to update webhook subscription", err)
+		return domain.WebhookSubscription{}, err
+	}
+	if !updated {
+		return domain.WebhookSubscription{}, domain.NotFoundErr{Msg: fmt.Sprintf("webhook with ID %v does not exist", id), Resource: domain.ResourceWebhook}
+	}
+
+	return subscription, nil
+}
+
+// DeleteWebhook deletes the subscription along with its delivery log
+func (s *WebhookService) DeleteWebhook(ctx monitor.ApplicationContext, id string) error {
+	fnName := "WebhookService.DeleteWebhook"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("webhook_id", id)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	deleted, err := db.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to delete webhook subscription", err)
+		return err
+	}
+	if !deleted {
+		return domain.NotFoundErr{Msg: fmt.Sprintf("webhook with ID %v does not exist", id), Resource: domain.ResourceWebhook}
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries returns the newest entries of the delivery log of the subscription, optionally only the ones
+// with the status
+func (s *WebhookService) GetWebhookDeliveries(
+	ctx monitor.ApplicationContext,
+	subscriptionID string,
+	status *string,
+) ([]domain.WebhookDelivery, error) {
+	ctx, span := ctx.StartSpan("WebhookService.GetWebhookDeliveries", trace.WithAttributes(attribute.String("webhook_id", subscriptionID)))
+	defer span.End()
+
+	// The subscription is read first so the log of another supplier is reported as not found
+	if _, err := s.GetWebhookByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetWebhookDeliveries(ctx, subscriptionID, status, WebhookDeliveriesLimit)
+}
+
+// RedeliverWebhook sends a logged delivery again, once and with a new signature, and stores its outcome. It is sent
+// even if the subscription is paused since it is requested explicitly, a pending retry of the delivery is cancelled.
+func (s *WebhookService) RedeliverWebhook(ctx monitor.ApplicationContext, subscriptionID, deliveryID string) (domain.WebhookDelivery, error) {
+	fnName := "WebhookService.RedeliverWebhook"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(
+		attribute.String("webhook_id", subscriptionID),
+		attribute.String("webhook_delivery_id", deliveryID),
+	))
+	defer span.End()
+
+	subscription, err := s.GetWebhookByID(ctx, subscriptionID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.WebhookDelivery{}, err
+	}
+
+	delivery, err := db.GetWebhookDeliveryByID(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return domain.WebhookDelivery{}, err
+	}
+	if delivery == nil {
+		return domain.WebhookDelivery{}, domain.NotFoundErr{
+			Msg:      fmt.Sprintf("webhook delivery with ID %v does not exist", deliveryID),
+			Resource: domain.ResourceWebhookDelivery,
+		}
+	}
+
+	attempt := s.send(ctx, subscription, delivery.EventID, delivery.EventType, delivery.Payload)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+	delivery.ResponseStatus = attempt.responseStatus
+	delivery.NextAttemptAt = nil
+	if attempt.err != nil {
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.LastError = utils.ToPointer(attempt.err.Error())
+	} else {
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+	}
+
+	if err = db.UpdateWebhookDelivery(ctx, *delivery); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to update webhook delivery", err)
+		return domain.WebhookDelivery{}, err
+	}
+
+	return *delivery, nil
+}
+
+// DeliverLocationEvent sends the event to every active subscription to its type that receives the events of the
+// location supplier. Subscriptions are served concurrently so a slow endpoint does not delay the rest. An error is
+// only returned when a failed delivery could not be written to the log, the broker then redelivers the event and
+// receivers discard the copies they already got by its ID.
+func (s *WebhookService) DeliverLocationEvent(ctx monitor.ApplicationContext, eventID, eventType string, location domain.Location) error {
+	fnName := "WebhookService.DeliverLocationEvent"
+
+	ctx, span := ctx.StartSpan(fnName, trace.WithAttributes(attribute.String("event_id", eventID)))
+	defer span.End()
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	subscriptions, err := db.GetActiveWebhookSubscriptions(ctx, eventType, location.Supplier.ID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to get webhook subscriptions", err)
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(domain.WebhookEvent{ID: eventID, Type: eventType, Data: location})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	errs := make([]error, len(subscriptions))
+	var wg sync.WaitGroup
+	for i, subscription := range subscriptions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.deliver(ctx, db, subscription, eventID, eventType, payload)
+		}()
+	}
+	wg.Wait()
+
+	if err = errors.Join(errs...); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to log webhook deliveries", err)
+		return err
+	}
+
+	return nil
+}
+
+// deliver sends the event once, an event that could not be delivered is written to the delivery log to be retried in
+// the background. Retrying here would hold the broker message for the whole backoff.
+func (s *WebhookService) deliver(
+	ctx monitor.ApplicationContext,
+	db repositories.WebhookDB,
+	subscription domain.WebhookSubscription,
+	eventID, eventType string,
+	payload []byte,
+) error {
+	fnName := "WebhookService.deliver"
+
+	attempt := s.send(ctx, subscription, eventID, eventType, payload)
+	if attempt.err == nil {
+		return nil
+	}
+
+	s.logger.WarnCtx(ctx, fnName, "webhook delivery attempt failed",
+		monitor.LoggingParam{Name: "webhook_id", Value: subscription.ID},
+		monitor.LoggingParam{Name: "error", Value: attempt.err.Error()},
+	)
+
+	now := time.Now().UTC()
+	delivery := domain.WebhookDelivery{
+		ID:             uuid.New().String(),
+		SubscriptionID: subscription.ID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Attempts:       1,
+		ResponseStatus: attempt.responseStatus,
+		LastError:      utils.ToPointer(attempt.err.Error()),
+		CreatedAt:      now,
+		LastAttemptAt:  now,
+	}
+	s.scheduleRetry(&delivery, attempt.err)
+
+	return db.UpsertWebhookDelivery(ctx, delivery)
+}
+
+// RetryWebhookDeliveries sends the logged deliveries whose next attempt is due and stores their outcome, it returns
+// how many were sent. Deliveries of paused subscriptions are marked failed without being sent.
+func (s *WebhookService) RetryWebhookDeliveries(ctx monitor.ApplicationContext) (int, error) {
+	fnName := "WebhookService.RetryWebhookDeliveries"
+
+	ctx, span := ctx.StartSpan(fnName)
+	defer span.End()
+
+	db, err := s.dbFactory.GetWebhookDB()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	deliveries, err := db.ClaimDueWebhookDeliveries(ctx, time.Now().UTC().Add(webhookRetryClaimTimeout), WebhookRetriesBatchSize)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to claim webhook deliveries", err)
+		return 0, err
+	}
+
+	errs := make([]error, len(deliveries))
+	var wg sync.WaitGroup
+	for i, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.retry(ctx, db, delivery)
+		}()
+	}
+	wg.Wait()
+
+	if err = errors.Join(errs...); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.ErrorCtx(ctx, fnName, "failed to retry webhook deliveries", err)
+		return len(deliveries), err
+	}
+
+	return len(deliveries), nil
+}
+
+// RunDeliveryRetries retries the due webhook deliveries periodically until the context is cancelled
+func (s *WebhookService) RunDeliveryRetries(ctx context.Context) {
+	fnName := "WebhookService.RunDeliveryRetries"
+
+	retryTicker := time.NewTicker(s.retryInterval)
+	defer retryTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info(fnName, "", "stopping webhook delivery retries")
+			return
+		case <-retryTicker.C:
+			// The error is logged by RetryWebhookDeliveries, the failed retries are claimed again once their claim ends
+			_, _ = s.RetryWebhookDeliveries(monitor.CreateAppContextFromContext(ctx, ""))
+		}
+	}
+}
+
+// retry sends a logged delivery once and stores its outcome
+func (s *WebhookService) retry(ctx monitor.ApplicationContext, db repositories.WebhookDB, delivery domain.WebhookDelivery) error {
+	subscription, err := db.GetWebhookSubscriptionByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		// The subscription was deleted along with its delivery log
+		return nil
+	}
+
+	now := time.Now().UTC()
+	if subscription.Status == domain.WebhookStatusPaused {
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return db.UpdateWebhookDelivery(ctx, delivery)
+	}
+
+	attempt := s.send(ctx, *subscription, delivery.EventID, delivery.EventType, delivery.Payload)
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+	delivery.ResponseStatus = attempt.responseStatus
+	if attempt.err != nil {
+		delivery.LastError = utils.ToPointer(attempt.err.Error())
+		s.scheduleRetry(&delivery, attempt.err)
+	} else {
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	}
+
+	return db.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// scheduleRetry sets when a failed delivery is attempted again, the wait starts at the initial backoff and doubles
+// after each attempt. A delivery that ran out of attempts or was rejected by the URL checks is marked failed.
+func (s *WebhookService) scheduleRetry(delivery *domain.WebhookDelivery, err error) {
+	var businessErr domain.BusinessErr
+	if delivery.Attempts >= s.maxAttempts || errors.As(err, &businessErr) {
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	backoff := s.initialBackoff
+	for i := 1; i < delivery.Attempts && backoff < s.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	delivery.Status = domain.WebhookDeliveryRetrying
+	delivery.NextAttemptAt = utils.ToPointer(delivery.LastAttemptAt.Add(min(backoff, s.maxBackoff)))
+}
+
+// send posts the event once, any status outside 2xx is a failure
+func (s *WebhookService) send(
+	ctx monitor.ApplicationContext,
+	subscription domain.WebhookSubscription,
+	eventID, eventType string,
+	payload []byte,
+) webhookAttempt {
+	// Subscriptions created before the URLs were restricted are checked again
+	if err := s.checkWebhookURL(subscription.URL); err != nil {
+		return webhookAttempt{err: err}
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	headers.Set(WebhookIDHeader, eventID)
+	headers.Set(WebhookEventHeader, eventType)
+	headers.Set(WebhookTimestampHeader, timestamp)
+	headers.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, timestamp, payload))
+
+	// The payload is sent as raw JSON so the body is exactly the signed bytes
+	response, err := s.httpClient.Do(ctx, customHTTP.RequestValues{
+		URL:     subscription.URL,
+		Method:  http.MethodPost,
+		Headers: headers,
+		Body:    json.RawMessage(payload),
+	})
+	if err != nil {
+		return webhookAttempt{err: err}
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return webhookAttempt{
+			responseStatus: &response.StatusCode,
+			err:            fmt.Errorf("the webhook endpoint answered with status %d", response.StatusCode),
+		}
+	}
+
+	return webhookAttempt{responseStatus: &response.StatusCode}
+}
+
+// checkWebhookURL rejects the URLs that are not https or point to an address that is not public. Host names are
+// checked by the HTTP client when it connects, once they are resolved.
+func (s *WebhookService) checkWebhookURL(rawURL string) error {
+	if s.allowLocalTargets {
+		return nil
+	}
+
+	webhookURL, err := url.Parse(rawURL)
+	if err != nil || webhookURL.Scheme != "https" {
+		return domain.BusinessErr{Msg: "the webhook URL must be an https URL", Resource: domain.ResourceWebhook}
+	}
+
+	if addr, err := netip.ParseAddr(webhookURL.Hostname()); err == nil && !customHTTP.IsPublicAddress(addr) {
+		return domain.BusinessErr{Msg: "the webhook URL must point to a public address", Resource: domain.ResourceWebhook}
+	}
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go-service-template/config"
+	"go-service-template/domain"
+	"go-service-template/domain/dto"
+	customHTTP "go-service-template/http"
+	"go-service-template/mocks"
+	"go-service-template/monitor"
+	"go-service-template/services"
+	"go-service-template/utils"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var testWebhook = domain.WebhookSubscription{
+	ID:         "0f6b1d9e-7b1c-4c55-9a0e-2a9a3cbb6f10",
+	URL:        "https://partner.example.com/hooks",
+	EventTypes: []string{domain.WebhookEventLocationCreated},
+	Secret:     "0123456789abcdef",
+	Status:     domain.WebhookStatusActive,
+}
+
+type WebhookServiceSuite struct {
+	suite.Suite
+	dbFactoryMock     *mocks.DatabaseFactory
+	webhookDBMock     *mocks.WebhookDB
+	referenceDataMock *mocks.IReferenceDataService
+	httpClientMock    *mocks.CustomHTTPClient
+	webhookService    *services.WebhookService
+}
+
+func (s *WebhookServiceSuite) SetupTest() {
+	s.dbFactoryMock = new(mocks.DatabaseFactory)
+	s.webhookDBMock = new(mocks.WebhookDB)
+	s.referenceDataMock = new(mocks.IReferenceDataService)
+	s.httpClientMock = new(mocks.CustomHTTPClient)
+	s.dbFactoryMock.On("GetWebhookDB").Return(s.webhookDBMock, nil)
+
+	s.webhookService = services.NewWebhookService(s.dbFactoryMock, s.referenceDataMock, s.httpClientMock, config.WebhookConfig{
+		MaxAttempts:      3,
+		InitialBackoffMs: 1,
+		MaxBackoffMs:     2,
+	})
+}
+
+func (s *WebhookServiceSuite) assertAllExpectations() {
+	s.webhookDBMock.AssertExpectations(s.T())
+	s.referenceDataMock.AssertExpectations(s.T())
+	s.httpClientMock.AssertExpectations(s.T())
+}
+
+func TestWebhookServiceSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceSuite))
+}
+
+func (s *WebhookServiceSuite) Test_CreateWebhook_DefaultsToActive() {
+	s.webhookDBMock.On("CreateWebhookSubscription", mock.Anything, mock.MatchedBy(func(webhook domain.WebhookSubscription) bool {
+		return webhook.Status == domain.WebhookStatusActive && webhook.Secret == testWebhook.Secret && webhook.SupplierID == nil
+	})).Return(nil).Once()
+
+	webhook, err := s.webhookService.CreateWebhook(testCtx, dto.CreateWebhookRequest{
+		URL:        testWebhook.URL,
+		EventTypes: testWebhook.EventTypes,
+		Secret:     testWebhook.Secret,
+	})
+
+	assert.Nil(s.T(), err)
+	assert.NotEmpty(s.T(), webhook.ID)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_CreateWebhook_RestrictsToTenantSupplier() {
+	supplierCtx := testCtx.WithPrincipal(monitor.Principal{Subject: "supplier-portal", SupplierID: utils.ToPointer(7)})
+
+	_, err := s.webhookService.CreateWebhook(supplierCtx, dto.CreateWebhookRequest{
+		URL:        testWebhook.URL,
+		EventTypes: testWebhook.EventTypes,
+		Secret:     testWebhook.Secret,
+		SupplierID: utils.ToPointer(8),
+	})
+
+	assert.ErrorAs(s.T(), err, &domain.UnknownReferenceErr{})
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_CreateWebhook_RejectsURLsThatAreNotHTTPS() {
+	_, err := s.webhookService.CreateWebhook(testCtx, dto.CreateWebhookRequest{
+		URL:        "http://partner.example.com/hooks",
+		EventTypes: testWebhook.EventTypes,
+		Secret:     testWebhook.Secret,
+	})
+
+	assert.ErrorAs(s.T(), err, &domain.BusinessErr{})
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_CreateWebhook_RejectsNonPublicAddresses() {
+	for _, url := range []string{"https://127.0.0.1/hooks", "https://169.254.169.254/latest", "https://10.0.0.5/hooks", "https://[::1]/hooks"} {
+		_, err := s.webhookService.CreateWebhook(testCtx, dto.CreateWebhookRequest{
+			URL:        url,
+			EventTypes: testWebhook.EventTypes,
+			Secret:     testWebhook.Secret,
+		})
+
+		assert.ErrorAs(s.T(), err, &domain.BusinessErr{}, url)
+	}
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_UpdateWebhook_KeepsSecretWhenNotSent() {
+	s.webhookDBMock.On("GetWebhookSubscriptionByID", mock.Anything, testWebhook.ID).Return(&testWebhook, nil).Once()
+	s.webhookDBMock.On("UpdateWebhookSubscription", mock.Anything, mock.MatchedBy(func(webhook domain.WebhookSubscription) bool {
+		return webhook.Secret == testWebhook.Secret && webhook.Status == domain.WebhookStatusPaused
+	})).Return(true, nil).Once()
+
+	webhook, err := s.webhookService.UpdateWebhook(testCtx, testWebhook.ID, dto.UpdateWebhookRequest{
+		URL:        testWebhook.URL,
+		EventTypes: testWebhook.EventTypes,
+		Status:     domain.WebhookStatusPaused,
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), domain.WebhookStatusPaused, webhook.Status)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_DeleteWebhook_ReturnsNotFound() {
+	s.webhookDBMock.On("DeleteWebhookSubscription", mock.Anything, testWebhook.ID).Return(false, nil).Once()
+
+	err := s.webhookService.DeleteWebhook(testCtx, testWebhook.ID)
+
+	assert.ErrorAs(s.T(), err, &domain.NotFoundErr{})
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_DeliverLocationEvent_SendsSignedEvent() {
+	location := domain.Location{ID: "location", Supplier: domain.Supplier{ID: 2}}
+	s.webhookDBMock.On("GetActiveWebhookSubscriptions", mock.Anything, domain.WebhookEventLocationCreated, 2).
+		Return([]domain.WebhookSubscription{testWebhook}, nil).Once()
+
+	var request customHTTP.RequestValues
+	s.httpClientMock.On("Do", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request = args.Get(1).(customHTTP.RequestValues)
+	}).Return(customHTTP.CustomHTTPResponse{StatusCode: http.StatusNoContent}, nil).Once()
+
+	err := s.webhookService.DeliverLocationEvent(testCtx, "event", domain.WebhookEventLocationCreated, location)
+
+	assert.Nil(s.T(), err)
+	body := request.Body.(json.RawMessage)
+	timestamp := request.Headers.Get(services.WebhookTimestampHeader)
+	assert.Equal(s.T(), services.SignWebhookPayload(testWebhook.Secret, timestamp, body), request.Headers.Get(services.WebhookSignatureHeader))
+	assert.Equal(s.T(), "event", request.Headers.Get(services.WebhookIDHeader))
+
+	var event domain.WebhookEvent
+	s.Require().NoError(json.Unmarshal(body, &event))
+	assert.Equal(s.T(), domain.WebhookEvent{ID: "event", Type: domain.WebhookEventLocationCreated, Data: location}, event)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_DeliverLocationEvent_LogsFailedDeliveryForRetry() {
+	location := domain.Location{ID: "location", Supplier: domain.Supplier{ID: 2}}
+	s.webhookDBMock.On("GetActiveWebhookSubscriptions", mock.Anything, domain.WebhookEventLocationCreated, 2).
+		Return([]domain.WebhookSubscription{testWebhook}, nil).Once()
+	s.httpClientMock.On("Do", mock.Anything, mock.Anything).
+		Return(customHTTP.CustomHTTPResponse{StatusCode: http.StatusServiceUnavailable}, nil).Once()
+	s.webhookDBMock.On("UpsertWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery domain.WebhookDelivery) bool {
+		return delivery.SubscriptionID == testWebhook.ID && delivery.EventID == "event" &&
+			delivery.Status == domain.WebhookDeliveryRetrying && delivery.Attempts == 1 &&
+			*delivery.ResponseStatus == http.StatusServiceUnavailable &&
+			delivery.NextAttemptAt.Equal(delivery.LastAttemptAt.Add(time.Millisecond))
+	})).Return(nil).Once()
+
+	err := s.webhookService.DeliverLocationEvent(testCtx, "event", domain.WebhookEventLocationCreated, location)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_DeliverLocationEvent_DoesNotCallURLsThatAreNotHTTPS() {
+	location := domain.Location{ID: "location", Supplier: domain.Supplier{ID: 2}}
+	insecureWebhook := testWebhook
+	insecureWebhook.URL = "http://partner.example.com/hooks"
+	s.webhookDBMock.On("GetActiveWebhookSubscriptions", mock.Anything, domain.WebhookEventLocationCreated, 2).
+		Return([]domain.WebhookSubscription{insecureWebhook}, nil).Once()
+	s.webhookDBMock.On("UpsertWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery domain.WebhookDelivery) bool {
+		return delivery.Status == domain.WebhookDeliveryFailed && delivery.ResponseStatus == nil
+	})).Return(nil).Once()
+
+	err := s.webhookService.DeliverLocationEvent(testCtx, "event", domain.WebhookEventLocationCreated, location)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_DeliverLocationEvent_FailsWhenDeliveryCannotBeLogged() {
+	location := domain.Location{ID: "location", Supplier: domain.Supplier{ID: 2}}
+	s.webhookDBMock.On("GetActiveWebhookSubscriptions", mock.Anything, domain.WebhookEventLocationCreated, 2).
+		Return([]domain.WebhookSubscription{testWebhook}, nil).Once()
+	s.httpClientMock.On("Do", mock.Anything, mock.Anything).
+		Return(customHTTP.CustomHTTPResponse{}, errors.New("connection refused")).Once()
+	s.webhookDBMock.On("UpsertWebhookDelivery", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+
+	err := s.webhookService.DeliverLocationEvent(testCtx, "event", domain.WebhookEventLocationCreated, location)
+
+	assert.NotNil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_RedeliverWebhook_MarksDeliverySucceeded() {
+	delivery := domain.WebhookDelivery{
+		ID:             "delivery",
+		SubscriptionID: testWebhook.ID,
+		EventID:        "event",
+		EventType:      domain.WebhookEventLocationCreated,
+		Payload:        json.RawMessage(`{"id":"event"}`),
+		Status:         domain.WebhookDeliveryFailed,
+		Attempts:       3,
+		LastError:      utils.ToPointer("connection refused"),
+	}
+	s.webhookDBMock.On("GetWebhookSubscriptionByID", mock.Anything, testWebhook.ID).Return(&testWebhook, nil).Once()
+	s.webhookDBMock.On("GetWebhookDeliveryByID", mock.Anything, testWebhook.ID, delivery.ID).Return(&delivery, nil).Once()
+	s.httpClientMock.On("Do", mock.Anything, mock.Anything).Return(customHTTP.CustomHTTPResponse{StatusCode: http.StatusOK}, nil).Once()
+	s.webhookDBMock.On("UpdateWebhookDelivery", mock.Anything, mock.Anything).Return(nil).Once()
+
+	redelivered, err := s.webhookService.RedeliverWebhook(testCtx, testWebhook.ID, delivery.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), domain.WebhookDeliverySucceeded, redelivered.Status)
+	assert.Equal(s.T(), 4, redelivered.Attempts)
+	assert.Nil(s.T(), redelivered.LastError)
+	assert.NotNil(s.T(), redelivered.DeliveredAt)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_RedeliverWebhook_ReturnsNotFoundForUnknownDelivery() {
+	s.webhookDBMock.On("GetWebhookSubscriptionByID", mock.Anything, testWebhook.ID).Return(&testWebhook, nil).Once()
+	s.webhookDBMock.On("GetWebhookDeliveryByID", mock.Anything, testWebhook.ID, "unknown").
+		Return((*domain.WebhookDelivery)(nil), nil).Once()
+
+	_, err := s.webhookService.RedeliverWebhook(testCtx, testWebhook.ID, "unknown")
+
+	assert.ErrorAs(s.T(), err, &domain.NotFoundErr{})
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_RetryWebhookDeliveries_MarksDeliverySucceeded() {
+	delivery := domain.WebhookDelivery{
+		ID:             "delivery",
+		SubscriptionID: testWebhook.ID,
+		EventID:        "event",
+		EventType:      domain.WebhookEventLocationCreated,
+		Payload:        json.RawMessage(`{"id":"event"}`),
+		Status:         domain.WebhookDeliveryRetrying,
+		Attempts:       1,
+		LastError:      utils.ToPointer("connection refused"),
+		NextAttemptAt:  utils.ToPointer(time.Now()),
+	}
+	s.webhookDBMock.On("ClaimDueWebhookDeliveries", mock.Anything, mock.Anything, services.WebhookRetriesBatchSize).
+		Return([]domain.WebhookDelivery{delivery}, nil).Once()
+	s.webhookDBMock.On("GetWebhookSubscriptionByID", mock.Anything, testWebhook.ID).Return(&testWebhook, nil).Once()
+	s.httpClientMock.On("Do", mock.Anything, mock.Anything).Return(customHTTP.CustomHTTPResponse{StatusCode: http.StatusOK}, nil).Once()
+	s.webhookDBMock.On("UpdateWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery domain.WebhookDelivery) bool {
+		return delivery.Status == domain.WebhookDeliverySucceeded && delivery.Attempts == 2 &&
+			delivery.LastError == nil && delivery.DeliveredAt != nil && delivery.NextAttemptAt == nil
+	})).Return(nil).Once()
+
+	retried, err := s.webhookService.RetryWebhookDeliveries(testCtx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, retried)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_RetryWebhookDeliveries_DoublesBackoffAfterEachAttempt() {
+	delivery := domain.WebhookDelivery{
+		ID:             "delivery",
+		SubscriptionID: testWebhook.ID,
+		EventID:        "event",
+		EventType:      domain.WebhookEventLocationCreated,
+		Status:         domain.WebhookDeliveryRetrying,
+		Attempts:       1,
+	}
+	s.webhookDBMock.On("ClaimDueWebhookDeliveries", mock.Anything, mock.Anything, services.WebhookRetriesBatchSize).
+		Return([]domain.WebhookDelivery{delivery}, nil).Once()
+	s.webhookDBMock.On("GetWebhookSubscriptionByID", mock.Anything, testWebhook.ID).Return(&testWebhook, nil).Once()
+	s.httpClientMock.On("Do", mock.Anything, mock.Anything).Return(customHTTP.CustomHTTPResponse{}, errors.New("connection refused")).Once()
+	s.webhookDBMock.On("UpdateWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery domain.WebhookDelivery) bool {
+		return delivery.Status == domain.WebhookDeliveryRetrying && delivery.Attempts == 2 &&
+			*delivery.LastError == "connection refused" &&
+			delivery.NextAttemptAt.Equal(delivery.LastAttemptAt.Add(2*time.Millisecond))
+	})).Return(nil).Once()
+
+	_, err := s.webhookService.RetryWebhookDeliveries(testCtx)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_RetryWebhookDeliveries_MarksDeliveryFailedAfterLastAttempt() {
+	delivery := domain.WebhookDelivery{
+		ID:             "delivery",
+		SubscriptionID: testWebhook.ID,
+		EventID:        "event",
+		EventType:      domain.WebhookEventLocationCreated,
+		Status:         domain.WebhookDeliveryRetrying,
+		Attempts:       2,
+	}
+	s.webhookDBMock.On("ClaimDueWebhookDeliveries", mock.Anything, mock.Anything, services.WebhookRetriesBatchSize).
+		Return([]domain.WebhookDelivery{delivery}, nil).Once()
+	s.webhookDBMock.On("GetWebhookSubscriptionByID", mock.Anything, testWebhook.ID).Return(&testWebhook, nil).Once()
+	s.httpClientMock.On("Do", mock.Anything, mock.Anything).
+		Return(customHTTP.CustomHTTPResponse{StatusCode: http.StatusServiceUnavailable}, nil).Once()
+	s.webhookDBMock.On("UpdateWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery domain.WebhookDelivery) bool {
+		return delivery.Status == domain.WebhookDeliveryFailed && delivery.Attempts == 3 && delivery.NextAttemptAt == nil
+	})).Return(nil).Once()
+
+	_, err := s.webhookService.RetryWebhookDeliveries(testCtx)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}
+
+func (s *WebhookServiceSuite) Test_RetryWebhookDeliveries_DoesNotSendToPausedWebhooks() {
+	pausedWebhook := testWebhook
+	pausedWebhook.Status = domain.WebhookStatusPaused
+	delivery := domain.WebhookDelivery{
+		ID:             "delivery",
+		SubscriptionID: testWebhook.ID,
+		Status:         domain.WebhookDeliveryRetrying,
+		Attempts:       1,
+	}
+	s.webhookDBMock.On("ClaimDueWebhookDeliveries", mock.Anything, mock.Anything, services.WebhookRetriesBatchSize).
+		Return([]domain.WebhookDelivery{delivery}, nil).Once()
+	s.webhookDBMock.On("GetWebhookSubscriptionByID", mock.Anything, testWebhook.ID).Return(&pausedWebhook, nil).Once()
+	s.webhookDBMock.On("UpdateWebhookDelivery", mock.Anything, mock.MatchedBy(func(delivery domain.WebhookDelivery) bool {
+		return delivery.Status == domain.WebhookDeliveryFailed && delivery.Attempts == 1 && delivery.NextAttemptAt == nil
+	})).Return(nil).Once()
+
+	_, err := s.webhookService.RetryWebhookDeliveries(testCtx)
+
+	assert.Nil(s.T(), err)
+	s.assertAllExpectations()
+}